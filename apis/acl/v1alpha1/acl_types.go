@@ -23,37 +23,165 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
 )
 
-// AccessControlListParameters are the configurable fields of a AccessControlList.
-type AccessControlListParameters struct {
-	// ResourceName is the name of the resource.
-	ResourceName string `json:"resourceName"`
+// An AccessControlListBinding is a single ACL of an AccessControlList in bulk
+// mode. Its fields have the same meaning as those of a single ACL.
+// +kubebuilder:validation:XValidation:rule="self.resourceType != 'TransactionalID' || self.resourceOperation in ['All', 'Write', 'Describe']",message="TransactionalID ACLs only support the operations All, Write and Describe"
+type AccessControlListBinding struct {
+	// ResourceName is the name of the resource. The Cluster resource type
+	// only has a single resource, named kafka-cluster, so it may be omitted.
+	// +optional
+	ResourceName string `json:"resourceName,omitempty"`
 	// ResourceType is the type of resource.
-	// Valid values are Unknown, Any, Topic, Group, Cluster, TransactionalID
-	// +kubebuilder:validation:Enum=Unknown;Any;Topic;Group;Cluster;TransactionalID
+	// +kubebuilder:validation:Enum=Topic;Group;Cluster;TransactionalID
 	ResourceType string `json:"resourceType"`
 	// ResourcePrincipal is the Principal that is being allowed or denied.
 	ResourcePrincipal string `json:"resourcePrincipal"`
-	// ResourceHost is the Host from which principal listed in ResourcePrinciple will have access.
-	ResourceHost string `json:"resourceHost"`
+	// ResourceHost is the Host from which the principal is allowed or
+	// denied access. Defaults to *, which matches all hosts.
+	// +optional
+	ResourceHost string `json:"resourceHost,omitempty"`
 	// ResourceOperation is the Operation that is being allowed or denied.
-	// Valid values are Unknown, Any, All, Read, Write, Create, Delete, Alter, Describe, ClusterAction, DescribeConfigs, AlterConfigs, IdempotentWrite.
-	// +kubebuilder:validation:Enum=Unknown;Any;All;Read;Write;Create;Delete;Alter;Describe;ClusterAction;DescribeConfigs;AlterConfigs;IdempotentWrite
+	// +kubebuilder:validation:Enum=All;Read;Write;Create;Delete;Alter;Describe;ClusterAction;DescribeConfigs;AlterConfigs;IdempotentWrite
 	ResourceOperation string `json:"resourceOperation"`
 	// ResourcePermissionType is the Type of permission.
-	// Valid values are Unknown, Any, Allow, Deny.
-	// +kubebuilder:validation:Enum=Unknown;Any;Allow;Deny
+	// +kubebuilder:validation:Enum=Allow;Deny
 	ResourcePermissionType string `json:"resourcePermissionType"`
-	// ResourcePatternTypeFilter is the pattern filter.
-	// Valid values are Prefixed, Any, Match, Literal.
-	// +kubebuilder:validation:Enum=Prefixed;Any;Match;Literal
+	// ResourcePatternTypeFilter is the pattern of the resource name.
+	// +kubebuilder:validation:Enum=Prefixed;Literal
 	ResourcePatternTypeFilter string `json:"resourcePatternTypeFilter"`
 }
 
+// A TransactionalIDAccessControl grants a principal the operations on a
+// transactional ID that transactional, e.g. exactly-once, producers need.
+type TransactionalIDAccessControl struct {
+	// ID is the transactional ID, or the prefix of the transactional IDs
+	// if Prefixed is set.
+	// +kubebuilder:validation:MinLength=1
+	ID string `json:"id"`
+	// Prefixed grants access to every transactional ID starting with ID,
+	// e.g. those of producers that suffix it with their instance.
+	// +optional
+	Prefixed bool `json:"prefixed,omitempty"`
+	// Principal is the Principal that is being allowed access.
+	Principal string `json:"principal"`
+	// Host is the Host from which the principal is allowed access. Defaults
+	// to *, which matches all hosts.
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Operations are the operations allowed on the transactional IDs.
+	// Defaults to Write and Describe, both of which transactional producers
+	// require.
+	// +optional
+	// +listType=set
+	Operations []TransactionalIDOperation `json:"operations,omitempty"`
+}
+
+// A TransactionalIDOperation is an operation on a transactional ID.
+// +kubebuilder:validation:Enum=Write;Describe
+type TransactionalIDOperation string
+
+// Operations on transactional IDs.
+const (
+	TransactionalIDOperationWrite    TransactionalIDOperation = "Write"
+	TransactionalIDOperationDescribe TransactionalIDOperation = "Describe"
+)
+
+// A ServiceAccountReference references a Kubernetes ServiceAccount.
+type ServiceAccountReference struct {
+	// Name of the ServiceAccount.
+	Name string `json:"name"`
+	// Namespace of the ServiceAccount.
+	Namespace string `json:"namespace"`
+}
+
+// AccessControlListParameters are the configurable fields of a AccessControlList.
+// +kubebuilder:validation:XValidation:rule="has(self.bindings) || has(self.transactionalID) || has(self.resourceName) || has(self.topicRef) || has(self.topicSelector)",message="one of resourceName, topicRef, topicSelector, bindings or transactionalID is required"
+// +kubebuilder:validation:XValidation:rule="!has(self.transactionalID) || !(has(self.bindings) || has(self.resourceName) || has(self.topicRef) || has(self.topicSelector) || has(self.resourceType) || has(self.resourcePrincipal) || has(self.serviceAccountRef) || has(self.resourceHost) || has(self.resourceOperation) || has(self.resourcePermissionType) || has(self.resourcePatternTypeFilter))",message="transactionalID cannot be combined with bindings or the fields of a single ACL"
+// +kubebuilder:validation:XValidation:rule="!has(self.resourceType) || self.resourceType != 'TransactionalID' || !has(self.resourceOperation) || self.resourceOperation in ['All', 'Write', 'Describe']",message="TransactionalID ACLs only support the operations All, Write and Describe"
+// +kubebuilder:validation:XValidation:rule="has(self.bindings) || has(self.transactionalID) || (has(self.resourceType) && (has(self.resourcePrincipal) || has(self.serviceAccountRef)) && has(self.resourceOperation) && has(self.resourcePermissionType) && has(self.resourcePatternTypeFilter))",message="resourceType, resourcePrincipal or serviceAccountRef, resourceOperation, resourcePermissionType and resourcePatternTypeFilter are required unless bindings or transactionalID are set"
+// +kubebuilder:validation:XValidation:rule="!has(self.bindings) || !(has(self.resourceName) || has(self.topicRef) || has(self.topicSelector) || has(self.resourceType) || has(self.resourcePrincipal) || has(self.serviceAccountRef) || has(self.resourceHost) || has(self.resourceOperation) || has(self.resourcePermissionType) || has(self.resourcePatternTypeFilter))",message="bindings cannot be combined with the fields of a single ACL"
+// +kubebuilder:validation:XValidation:rule="!(has(self.topicRef) || has(self.topicSelector)) || self.resourceType == 'Topic'",message="topicRef and topicSelector require resourceType Topic"
+type AccessControlListParameters struct {
+	// ResourceName is the name of the resource. It is resolved from TopicRef
+	// or TopicSelector when either is set.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1.Topic
+	// +crossplane:generate:reference:refFieldName=TopicRef
+	// +crossplane:generate:reference:selectorFieldName=TopicSelector
+	ResourceName string `json:"resourceName,omitempty"`
+	// TopicRef references the Topic managed resource whose topic is the
+	// resource of this ACL. The ACL is not created until the Topic is ready.
+	// +optional
+	TopicRef *xpv1.Reference `json:"topicRef,omitempty"`
+	// TopicSelector selects the Topic managed resource whose topic is the
+	// resource of this ACL.
+	// +optional
+	TopicSelector *xpv1.Selector `json:"topicSelector,omitempty"`
+	// ResourceType is the type of resource.
+	// Valid values are Topic, Group, Cluster, TransactionalID.
+	// +kubebuilder:validation:Enum=Topic;Group;Cluster;TransactionalID
+	// +optional
+	ResourceType string `json:"resourceType,omitempty"`
+	// ResourcePrincipal is the Principal that is being allowed or denied.
+	// It is derived from ServiceAccountRef when set.
+	// +optional
+	ResourcePrincipal string `json:"resourcePrincipal,omitempty"`
+	// ServiceAccountRef references the Kubernetes ServiceAccount whose
+	// workloads are being allowed or denied. Their principal is rendered from
+	// the principalTemplate of the ProviderConfig.
+	// +optional
+	ServiceAccountRef *ServiceAccountReference `json:"serviceAccountRef,omitempty"`
+	// ResourceHost is the Host from which principal listed in ResourcePrinciple will be allowed or denied access.
+	// Use * to match all hosts, which is also the default when empty.
+	// +optional
+	ResourceHost string `json:"resourceHost,omitempty"`
+	// ResourceOperation is the Operation that is being allowed or denied.
+	// Valid values are All, Read, Write, Create, Delete, Alter, Describe, ClusterAction, DescribeConfigs, AlterConfigs, IdempotentWrite.
+	// +kubebuilder:validation:Enum=All;Read;Write;Create;Delete;Alter;Describe;ClusterAction;DescribeConfigs;AlterConfigs;IdempotentWrite
+	// +optional
+	ResourceOperation string `json:"resourceOperation,omitempty"`
+	// ResourcePermissionType is the Type of permission.
+	// Valid values are Allow, Deny. Deny ACLs take precedence over Allow
+	// ACLs.
+	// +kubebuilder:validation:Enum=Allow;Deny
+	// +optional
+	ResourcePermissionType string `json:"resourcePermissionType,omitempty"`
+	// ResourcePatternTypeFilter is the pattern filter.
+	// Valid values are Prefixed, Literal.
+	// +kubebuilder:validation:Enum=Prefixed;Literal
+	// +optional
+	ResourcePatternTypeFilter string `json:"resourcePatternTypeFilter,omitempty"`
+	// Bindings puts the AccessControlList in bulk mode, in which it manages
+	// every listed ACL instead of a single one. Missing ACLs are created and
+	// ACLs removed from the list are deleted, each in a single request. Only
+	// ACLs recorded in status.atProvider.bindings are ever deleted.
+	// Bindings cannot reference Topics; use resourceName instead.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	Bindings []AccessControlListBinding `json:"bindings,omitempty"`
+	// TransactionalID puts the AccessControlList in bulk mode, managing the
+	// Allow ACLs a transactional producer needs on its transactional IDs.
+	// +optional
+	TransactionalID *TransactionalIDAccessControl `json:"transactionalID,omitempty"`
+}
+
 // AccessControlListObservation are the observable fields of an AccessControlList
 type AccessControlListObservation struct {
 	ID string `json:"id,omitempty"`
+	// Bindings are the ACLs of an AccessControlList in bulk mode that existed
+	// when it was last observed. Only these are deleted when they are
+	// removed from spec.forProvider.bindings.
+	// +optional
+	Bindings []AccessControlListBinding `json:"bindings,omitempty"`
+	// UnreachableBrokers are the seed brokers that could not be used, and
+	// why, when the AccessControlList could last not be observed.
+	// +optional
+	UnreachableBrokers []apisv1alpha1.BrokerError `json:"unreachableBrokers,omitempty"`
 }
 
 // An AccessControlListSpec defines the desired state of an AccessControlList
@@ -68,12 +196,49 @@ type AccessControlListStatus struct {
 	AtProvider          AccessControlListObservation `json:"atProvider,omitempty"`
 }
 
+// TypeAuthorizerMissing indicates whether the Kafka cluster of an
+// AccessControlList lacks an authorizer, without which ACLs cannot be
+// described or created.
+const TypeAuthorizerMissing xpv1.ConditionType = "AuthorizerMissing"
+
+// Reasons the Kafka cluster of an AccessControlList does or does not lack an
+// authorizer.
+const (
+	ReasonSecurityDisabled xpv1.ConditionReason = "SecurityDisabled"
+	ReasonAuthorizerFound  xpv1.ConditionReason = "AuthorizerFound"
+)
+
+// AuthorizerMissing returns a condition that indicates the Kafka cluster
+// refuses ACL requests because it runs no authorizer, with the supplied
+// message.
+func AuthorizerMissing(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAuthorizerMissing,
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSecurityDisabled,
+		Message:            msg,
+	}
+}
+
+// AuthorizerFound returns a condition that indicates the Kafka cluster runs
+// an authorizer.
+func AuthorizerFound() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAuthorizerMissing,
+		Status:             "False",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAuthorizerFound,
+	}
+}
+
 // +kubebuilder:object:root=true
 
 // A AccessControlList is an example API type.
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.providerConfigRef.name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kafka}
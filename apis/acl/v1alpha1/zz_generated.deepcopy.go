@@ -21,6 +21,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -51,6 +53,21 @@ func (in *AccessControlList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessControlListBinding) DeepCopyInto(out *AccessControlListBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlListBinding.
+func (in *AccessControlListBinding) DeepCopy() *AccessControlListBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessControlListBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessControlListList) DeepCopyInto(out *AccessControlListList) {
 	*out = *in
@@ -86,6 +103,16 @@ func (in *AccessControlListList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessControlListObservation) DeepCopyInto(out *AccessControlListObservation) {
 	*out = *in
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]AccessControlListBinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnreachableBrokers != nil {
+		in, out := &in.UnreachableBrokers, &out.UnreachableBrokers
+		*out = make([]apisv1alpha1.BrokerError, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlListObservation.
@@ -101,6 +128,31 @@ func (in *AccessControlListObservation) DeepCopy() *AccessControlListObservation
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessControlListParameters) DeepCopyInto(out *AccessControlListParameters) {
 	*out = *in
+	if in.TopicRef != nil {
+		in, out := &in.TopicRef, &out.TopicRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopicSelector != nil {
+		in, out := &in.TopicSelector, &out.TopicSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountRef != nil {
+		in, out := &in.ServiceAccountRef, &out.ServiceAccountRef
+		*out = new(ServiceAccountReference)
+		**out = **in
+	}
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]AccessControlListBinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.TransactionalID != nil {
+		in, out := &in.TransactionalID, &out.TransactionalID
+		*out = new(TransactionalIDAccessControl)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlListParameters.
@@ -117,7 +169,7 @@ func (in *AccessControlListParameters) DeepCopy() *AccessControlListParameters {
 func (in *AccessControlListSpec) DeepCopyInto(out *AccessControlListSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	out.ForProvider = in.ForProvider
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlListSpec.
@@ -134,7 +186,7 @@ func (in *AccessControlListSpec) DeepCopy() *AccessControlListSpec {
 func (in *AccessControlListStatus) DeepCopyInto(out *AccessControlListStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessControlListStatus.
@@ -146,3 +198,38 @@ func (in *AccessControlListStatus) DeepCopy() *AccessControlListStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransactionalIDAccessControl) DeepCopyInto(out *TransactionalIDAccessControl) {
+	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]TransactionalIDOperation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransactionalIDAccessControl.
+func (in *TransactionalIDAccessControl) DeepCopy() *TransactionalIDAccessControl {
+	if in == nil {
+		return nil
+	}
+	out := new(TransactionalIDAccessControl)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A PatternType determines how the name of a ResourcePattern matches
+// resources.
+type PatternType string
+
+// Pattern types.
+const (
+	// PatternTypeLiteral matches the resource of the pattern's name, or
+	// every resource of its type if the name is "*".
+	PatternTypeLiteral PatternType = "LITERAL"
+	// PatternTypePrefixed matches the resources whose names start with the
+	// pattern's name.
+	PatternTypePrefixed PatternType = "PREFIXED"
+)
+
+// A ResourcePattern is a set of resources a role is bound for.
+type ResourcePattern struct {
+	// ResourceType of the resources, e.g. Topic, Group, Subject, Connector
+	// or TransactionalId.
+	// +kubebuilder:validation:MinLength=1
+	ResourceType string `json:"resourceType"`
+	// Name of the resources, or their prefix for PREFIXED patterns.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// PatternType determines how the name matches resources.
+	// +kubebuilder:validation:Enum=LITERAL;PREFIXED
+	// +kubebuilder:default=LITERAL
+	// +optional
+	PatternType PatternType `json:"patternType,omitempty"`
+}
+
+// A RoleBindingScope is the cluster, or cluster of a Confluent Platform
+// component, a role is bound in.
+type RoleBindingScope struct {
+	// KafkaCluster is the ID of the Kafka cluster. Defaults to the ID of the
+	// cluster of the ProviderConfig, as reported in its status.
+	// +optional
+	KafkaCluster string `json:"kafkaCluster,omitempty"`
+	// SchemaRegistryCluster is the ID of the Schema Registry cluster, for
+	// roles bound in it.
+	// +optional
+	SchemaRegistryCluster string `json:"schemaRegistryCluster,omitempty"`
+	// ConnectCluster is the ID of the Connect cluster, for roles bound in it.
+	// +optional
+	ConnectCluster string `json:"connectCluster,omitempty"`
+	// KSQLCluster is the ID of the ksqlDB cluster, for roles bound in it.
+	// +optional
+	KSQLCluster string `json:"ksqlCluster,omitempty"`
+}
+
+// RoleBindingParameters are the configurable fields of a RoleBinding.
+// +kubebuilder:validation:XValidation:rule="(has(self.resourcePatterns) && size(self.resourcePatterns) > 0) == (has(oldSelf.resourcePatterns) && size(oldSelf.resourcePatterns) > 0)",message="a RoleBinding cannot switch between binding its role for the whole scope and for resource patterns"
+type RoleBindingParameters struct {
+	// Principal the role is bound to, e.g. User:alice or Group:developers.
+	// +kubebuilder:validation:Pattern=`^(User|Group):.+`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="principal is immutable"
+	Principal string `json:"principal"`
+	// Role bound, e.g. DeveloperRead, ResourceOwner or ClusterAdmin.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="role is immutable"
+	Role string `json:"role"`
+	// Scope the role is bound in.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="scope is immutable"
+	// +optional
+	Scope RoleBindingScope `json:"scope,omitempty"`
+	// ResourcePatterns are the resources the role is bound for. The role is
+	// bound for the whole scope if there are none, as cluster roles like
+	// SystemAdmin or ClusterAdmin are.
+	// +optional
+	ResourcePatterns []ResourcePattern `json:"resourcePatterns,omitempty"`
+}
+
+// RoleBindingObservation are the observable fields of a RoleBinding.
+type RoleBindingObservation struct {
+	// KafkaCluster is the ID of the Kafka cluster the role is bound in.
+	KafkaCluster string `json:"kafkaCluster,omitempty"`
+	// ResourcePatterns are the desired resource patterns the role was
+	// observed to be bound for, so that patterns removed from the
+	// RoleBinding can be unbound.
+	// +optional
+	ResourcePatterns []ResourcePattern `json:"resourcePatterns,omitempty"`
+}
+
+// A RoleBindingSpec defines the desired state of a RoleBinding.
+type RoleBindingSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RoleBindingParameters `json:"forProvider"`
+}
+
+// A RoleBindingStatus represents the observed state of a RoleBinding.
+type RoleBindingStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RoleBindingObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RoleBinding binds a Confluent Platform RBAC role to a principal, through
+// the Metadata Service (MDS) configured in the credentials of its
+// ProviderConfig. Its ProviderConfig must use ConfluentRBAC authorization.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PRINCIPAL",type="string",JSONPath=".spec.forProvider.principal"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.forProvider.role"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kafka}
+type RoleBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RoleBindingSpec   `json:"spec"`
+	Status RoleBindingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RoleBindingList contains a list of RoleBinding
+type RoleBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RoleBinding `json:"items"`
+}
+
+// RoleBinding type metadata.
+var (
+	RoleBindingKind             = reflect.TypeOf(RoleBinding{}).Name()
+	RoleBindingGroupKind        = schema.GroupKind{Group: Group, Kind: RoleBindingKind}.String()
+	RoleBindingKindAPIVersion   = RoleBindingKind + "." + SchemeGroupVersion.String()
+	RoleBindingGroupVersionKind = SchemeGroupVersion.WithKind(RoleBindingKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RoleBinding{}, &RoleBindingList{})
+}
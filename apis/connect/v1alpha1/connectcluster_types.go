@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ConnectClusterParameters are the configurable fields of a ConnectCluster.
+type ConnectClusterParameters struct {
+	// URL of the Kafka Connect REST API, e.g. https://connect:8083.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// Username used to authenticate to the REST API with basic auth.
+	// +optional
+	Username string `json:"username,omitempty"`
+	// PasswordSecretRef references the password used to authenticate to the
+	// REST API with basic auth.
+	// +optional
+	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// TLS configures how the REST API's server certificate is verified.
+	// +optional
+	TLS *ConnectClusterTLS `json:"tls,omitempty"`
+}
+
+// ConnectClusterTLS configures TLS for a Kafka Connect REST API.
+type ConnectClusterTLS struct {
+	// InsecureSkipVerify disables verification of the server certificate.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// CASecretRef references a PEM encoded CA certificate used to verify the
+	// server certificate, instead of the system roots.
+	// +optional
+	CASecretRef *xpv1.SecretKeySelector `json:"caSecretRef,omitempty"`
+}
+
+// ConnectClusterObservation are the observable fields of a ConnectCluster.
+type ConnectClusterObservation struct {
+	// Version of Kafka Connect.
+	Version string `json:"version,omitempty"`
+	// Commit of Kafka Connect.
+	Commit string `json:"commit,omitempty"`
+	// KafkaClusterID is the ID of the Kafka cluster Connect is backed by.
+	KafkaClusterID string `json:"kafkaClusterID,omitempty"`
+}
+
+// A ConnectClusterSpec defines the desired state of a ConnectCluster.
+type ConnectClusterSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ConnectClusterParameters `json:"forProvider"`
+}
+
+// A ConnectClusterStatus represents the observed state of a ConnectCluster.
+type ConnectClusterStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ConnectClusterObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ConnectCluster is a Kafka Connect REST endpoint that Connectors are
+// managed through. It is Ready while the endpoint is reachable.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.forProvider.url"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.atProvider.version"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kafka}
+type ConnectCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConnectClusterSpec   `json:"spec"`
+	Status ConnectClusterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ConnectClusterList contains a list of ConnectCluster
+type ConnectClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConnectCluster `json:"items"`
+}
+
+// ConnectCluster type metadata.
+var (
+	ConnectClusterKind             = reflect.TypeOf(ConnectCluster{}).Name()
+	ConnectClusterGroupKind        = schema.GroupKind{Group: Group, Kind: ConnectClusterKind}.String()
+	ConnectClusterKindAPIVersion   = ConnectClusterKind + "." + SchemeGroupVersion.String()
+	ConnectClusterGroupVersionKind = SchemeGroupVersion.WithKind(ConnectClusterKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ConnectCluster{}, &ConnectClusterList{})
+}
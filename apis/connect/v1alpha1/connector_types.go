@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ConnectorParameters are the configurable fields of a Connector.
+type ConnectorParameters struct {
+	// ConnectClusterRef references the ConnectCluster the connector runs on.
+	// The connector is not created until the ConnectCluster is ready.
+	ConnectClusterRef xpv1.Reference `json:"connectClusterRef"`
+	// TopicRefs reference the Topics the connector reads from or writes to.
+	// The connector is not created until they are ready. They do not
+	// configure the connector; name their topics in config.
+	// +optional
+	TopicRefs []xpv1.Reference `json:"topicRefs,omitempty"`
+	// Class of the connector plugin, e.g.
+	// org.apache.kafka.connect.file.FileStreamSinkConnector.
+	Class string `json:"class"`
+	// Config of the connector, excluding connector.class and name.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// ConnectorObservation are the observable fields of a Connector.
+type ConnectorObservation struct {
+	// State of the connector, e.g. RUNNING, PAUSED or FAILED.
+	State string `json:"state,omitempty"`
+	// WorkerID of the worker running the connector.
+	WorkerID string `json:"workerID,omitempty"`
+}
+
+// A ConnectorSpec defines the desired state of a Connector.
+type ConnectorSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ConnectorParameters `json:"forProvider"`
+}
+
+// A ConnectorStatus represents the observed state of a Connector.
+type ConnectorStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ConnectorObservation `json:"atProvider,omitempty"`
+}
+
+// TypeConfigValid indicates whether the connector plugin accepted a
+// Connector's config.
+const TypeConfigValid xpv1.ConditionType = "ConfigValid"
+
+// Reasons a Connector's config is or is not valid.
+const (
+	ReasonValidConfig   xpv1.ConditionReason = "ValidConfig"
+	ReasonInvalidConfig xpv1.ConditionReason = "InvalidConfig"
+)
+
+// ConfigValid returns a condition that indicates the connector plugin
+// accepted a Connector's config.
+func ConfigValid() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConfigValid,
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonValidConfig,
+	}
+}
+
+// ConfigInvalid returns a condition that indicates the connector plugin
+// rejected a Connector's config, with the supplied field errors as message.
+func ConfigInvalid(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConfigValid,
+		Status:             "False",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalidConfig,
+		Message:            msg,
+	}
+}
+
+// +kubebuilder:object:root=true
+
+// A Connector is a Kafka Connect connector. Its config is validated by the
+// connector plugin before the connector is created or updated.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="CONFIG-VALID",type="string",JSONPath=".status.conditions[?(@.type=='ConfigValid')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kafka}
+type Connector struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConnectorSpec   `json:"spec"`
+	Status ConnectorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ConnectorList contains a list of Connector
+type ConnectorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Connector `json:"items"`
+}
+
+// Connector type metadata.
+var (
+	ConnectorKind             = reflect.TypeOf(Connector{}).Name()
+	ConnectorGroupKind        = schema.GroupKind{Group: Group, Kind: ConnectorKind}.String()
+	ConnectorKindAPIVersion   = ConnectorKind + "." + SchemeGroupVersion.String()
+	ConnectorGroupVersionKind = SchemeGroupVersion.WithKind(ConnectorKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Connector{}, &ConnectorList{})
+}
@@ -26,6 +26,12 @@ limitations under the License.
 // Generate deepcopy methodsets and CRD manifests
 //go:generate go run -tags generate sigs.k8s.io/controller-tools/cmd/controller-gen object:headerFile=../hack/boilerplate.go.txt paths=./... crd:crdVersions=v1 output:artifacts:config=../package/crds
 
+// Generate the admission webhook configurations
+//go:generate go run -tags generate sigs.k8s.io/controller-tools/cmd/controller-gen webhook paths=../internal/webhook/... output:webhook:artifacts:config=../package/webhookconfigurations
+
+// Generate the example XRD and Composition exposing a claimable Topic
+//go:generate go run ../hack/generate-xrd --crd ../package/crds/topic.kafka.crossplane.io_topics.yaml --output ../examples/composition
+
 // Generate crossplane-runtime methodsets (resource.Claim, etc)
 //go:generate go run -tags generate github.com/crossplane/crossplane-tools/cmd/angryjet generate-methodsets --header-file=../hack/boilerplate.go.txt ./...
 
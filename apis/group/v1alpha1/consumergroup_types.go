@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// ConsumerGroupParameters are the configurable fields of a ConsumerGroup.
+type ConsumerGroupParameters struct {
+	// Force deletes the group even if it has active members. Deleting a
+	// group discards its committed offsets, so its consumers restart from
+	// their auto.offset.reset position.
+	// +optional
+	Force bool `json:"force,omitempty"`
+}
+
+// ConsumerGroupObservation are the observable fields of a ConsumerGroup.
+type ConsumerGroupObservation struct {
+	// State of the group, e.g. Stable, Empty or PreparingRebalance.
+	State string `json:"state,omitempty"`
+	// Members is the number of active members of the group.
+	Members int `json:"members,omitempty"`
+	// UnreachableBrokers are the seed brokers that could not be used, and
+	// why, when the ConsumerGroup could last not be observed.
+	// +optional
+	UnreachableBrokers []apisv1alpha1.BrokerError `json:"unreachableBrokers,omitempty"`
+}
+
+// A ConsumerGroupSpec defines the desired state of a ConsumerGroup.
+type ConsumerGroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ConsumerGroupParameters `json:"forProvider,omitempty"`
+}
+
+// A ConsumerGroupStatus represents the observed state of a ConsumerGroup.
+type ConsumerGroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ConsumerGroupObservation `json:"atProvider,omitempty"`
+}
+
+// TypeDeletionBlocked indicates whether the deletion of a ConsumerGroup is
+// blocked by its active members.
+const TypeDeletionBlocked xpv1.ConditionType = "DeletionBlocked"
+
+// Reasons the deletion of a ConsumerGroup is blocked.
+const (
+	ReasonActiveMembers xpv1.ConditionReason = "ActiveMembers"
+)
+
+// DeletionBlocked returns a condition that indicates a ConsumerGroup is not
+// deleted because it has active members, with the supplied message.
+func DeletionBlocked(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionBlocked,
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonActiveMembers,
+		Message:            msg,
+	}
+}
+
+// +kubebuilder:object:root=true
+
+// A ConsumerGroup is a Kafka consumer group. Groups are created by their
+// consumers, so a ConsumerGroup only becomes ready once they joined it. A
+// group with active members is not deleted unless forced.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="MEMBERS",type="integer",JSONPath=".status.atProvider.members"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.providerConfigRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kafka}
+type ConsumerGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConsumerGroupSpec   `json:"spec"`
+	Status ConsumerGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ConsumerGroupList contains a list of ConsumerGroup
+type ConsumerGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConsumerGroup `json:"items"`
+}
+
+// ConsumerGroup type metadata.
+var (
+	ConsumerGroupKind             = reflect.TypeOf(ConsumerGroup{}).Name()
+	ConsumerGroupGroupKind        = schema.GroupKind{Group: Group, Kind: ConsumerGroupKind}.String()
+	ConsumerGroupKindAPIVersion   = ConsumerGroupKind + "." + SchemeGroupVersion.String()
+	ConsumerGroupGroupVersionKind = SchemeGroupVersion.WithKind(ConsumerGroupKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ConsumerGroup{}, &ConsumerGroupList{})
+}
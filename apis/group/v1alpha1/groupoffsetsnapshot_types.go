@@ -0,0 +1,147 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// GroupOffsetSnapshotParameters are the configurable fields of a
+// GroupOffsetSnapshot.
+type GroupOffsetSnapshotParameters struct {
+	// Group is the consumer group whose committed offsets are captured.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="group is immutable"
+	Group string `json:"group"`
+
+	// Interval between two snapshots. Snapshots are taken when the resource
+	// is reconciled, so the poll interval bounds how precisely it is kept.
+	// +optional
+	// +kubebuilder:default="1h"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// ConfigMap additionally keeps a history of snapshots in a ConfigMap,
+	// which outlives the GroupOffsetSnapshot.
+	// +optional
+	ConfigMap *SnapshotConfigMap `json:"configMap,omitempty"`
+}
+
+// A SnapshotConfigMap is a ConfigMap snapshots are written to. Each snapshot
+// is written under its own key, named after the time it was taken, in the CSV
+// format read by kafka-consumer-groups --reset-offsets --from-file.
+type SnapshotConfigMap struct {
+	// Name of the ConfigMap. It is created if it does not exist.
+	Name string `json:"name"`
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+	// History is the number of snapshots kept in the ConfigMap; older ones
+	// are removed.
+	// +optional
+	// +kubebuilder:default=24
+	// +kubebuilder:validation:Minimum=1
+	History int `json:"history,omitempty"`
+}
+
+// A PartitionOffset is the offset a consumer group committed for a partition.
+type PartitionOffset struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	Offset    int64  `json:"offset"`
+}
+
+// GroupOffsetSnapshotObservation are the observable fields of a
+// GroupOffsetSnapshot.
+type GroupOffsetSnapshotObservation struct {
+	// CapturedAt is the time the last snapshot was taken.
+	// +optional
+	CapturedAt *metav1.Time `json:"capturedAt,omitempty"`
+	// Offsets are the committed offsets of the group at the time of the last
+	// snapshot.
+	// +optional
+	Offsets []PartitionOffset `json:"offsets,omitempty"`
+	// ConfigMapKey is the key of the ConfigMap the last snapshot was
+	// written to.
+	// +optional
+	ConfigMapKey string `json:"configMapKey,omitempty"`
+	// UnreachableBrokers are the seed brokers that could not be used, and
+	// why, when the last snapshot could not be taken.
+	// +optional
+	UnreachableBrokers []apisv1alpha1.BrokerError `json:"unreachableBrokers,omitempty"`
+}
+
+// A GroupOffsetSnapshotSpec defines the desired state of a
+// GroupOffsetSnapshot.
+type GroupOffsetSnapshotSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       GroupOffsetSnapshotParameters `json:"forProvider"`
+}
+
+// A GroupOffsetSnapshotStatus represents the observed state of a
+// GroupOffsetSnapshot.
+type GroupOffsetSnapshotStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          GroupOffsetSnapshotObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A GroupOffsetSnapshot periodically captures the committed offsets of a
+// consumer group, so that they can be restored after an accidental reset.
+// Deleting it deletes nothing in Kafka, nor the ConfigMap it wrote to.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="GROUP",type="string",JSONPath=".spec.forProvider.group"
+// +kubebuilder:printcolumn:name="CAPTURED",type="date",JSONPath=".status.atProvider.capturedAt"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.providerConfigRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kafka}
+type GroupOffsetSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GroupOffsetSnapshotSpec   `json:"spec"`
+	Status GroupOffsetSnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GroupOffsetSnapshotList contains a list of GroupOffsetSnapshot
+type GroupOffsetSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GroupOffsetSnapshot `json:"items"`
+}
+
+// GroupOffsetSnapshot type metadata.
+var (
+	GroupOffsetSnapshotKind             = reflect.TypeOf(GroupOffsetSnapshot{}).Name()
+	GroupOffsetSnapshotGroupKind        = schema.GroupKind{Group: Group, Kind: GroupOffsetSnapshotKind}.String()
+	GroupOffsetSnapshotKindAPIVersion   = GroupOffsetSnapshotKind + "." + SchemeGroupVersion.String()
+	GroupOffsetSnapshotGroupVersionKind = SchemeGroupVersion.WithKind(GroupOffsetSnapshotKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&GroupOffsetSnapshot{}, &GroupOffsetSnapshotList{})
+}
@@ -0,0 +1,332 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroup) DeepCopyInto(out *ConsumerGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroup.
+func (in *ConsumerGroup) DeepCopy() *ConsumerGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsumerGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupList) DeepCopyInto(out *ConsumerGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConsumerGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupList.
+func (in *ConsumerGroupList) DeepCopy() *ConsumerGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsumerGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupObservation) DeepCopyInto(out *ConsumerGroupObservation) {
+	*out = *in
+	if in.UnreachableBrokers != nil {
+		in, out := &in.UnreachableBrokers, &out.UnreachableBrokers
+		*out = make([]apisv1alpha1.BrokerError, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupObservation.
+func (in *ConsumerGroupObservation) DeepCopy() *ConsumerGroupObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupParameters) DeepCopyInto(out *ConsumerGroupParameters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupParameters.
+func (in *ConsumerGroupParameters) DeepCopy() *ConsumerGroupParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupSpec) DeepCopyInto(out *ConsumerGroupSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	out.ForProvider = in.ForProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupSpec.
+func (in *ConsumerGroupSpec) DeepCopy() *ConsumerGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupStatus) DeepCopyInto(out *ConsumerGroupStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupStatus.
+func (in *ConsumerGroupStatus) DeepCopy() *ConsumerGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupOffsetSnapshot) DeepCopyInto(out *GroupOffsetSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupOffsetSnapshot.
+func (in *GroupOffsetSnapshot) DeepCopy() *GroupOffsetSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupOffsetSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupOffsetSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupOffsetSnapshotList) DeepCopyInto(out *GroupOffsetSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GroupOffsetSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupOffsetSnapshotList.
+func (in *GroupOffsetSnapshotList) DeepCopy() *GroupOffsetSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupOffsetSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupOffsetSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupOffsetSnapshotObservation) DeepCopyInto(out *GroupOffsetSnapshotObservation) {
+	*out = *in
+	if in.CapturedAt != nil {
+		in, out := &in.CapturedAt, &out.CapturedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Offsets != nil {
+		in, out := &in.Offsets, &out.Offsets
+		*out = make([]PartitionOffset, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnreachableBrokers != nil {
+		in, out := &in.UnreachableBrokers, &out.UnreachableBrokers
+		*out = make([]apisv1alpha1.BrokerError, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupOffsetSnapshotObservation.
+func (in *GroupOffsetSnapshotObservation) DeepCopy() *GroupOffsetSnapshotObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupOffsetSnapshotObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupOffsetSnapshotParameters) DeepCopyInto(out *GroupOffsetSnapshotParameters) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(SnapshotConfigMap)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupOffsetSnapshotParameters.
+func (in *GroupOffsetSnapshotParameters) DeepCopy() *GroupOffsetSnapshotParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupOffsetSnapshotParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupOffsetSnapshotSpec) DeepCopyInto(out *GroupOffsetSnapshotSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupOffsetSnapshotSpec.
+func (in *GroupOffsetSnapshotSpec) DeepCopy() *GroupOffsetSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupOffsetSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupOffsetSnapshotStatus) DeepCopyInto(out *GroupOffsetSnapshotStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupOffsetSnapshotStatus.
+func (in *GroupOffsetSnapshotStatus) DeepCopy() *GroupOffsetSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupOffsetSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionOffset) DeepCopyInto(out *PartitionOffset) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionOffset.
+func (in *PartitionOffset) DeepCopy() *PartitionOffset {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionOffset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotConfigMap) DeepCopyInto(out *SnapshotConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotConfigMap.
+func (in *SnapshotConfigMap) DeepCopy() *SnapshotConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -21,7 +21,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	aclv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+	confluentv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/confluent/v1alpha1"
+	connectv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/connect/v1alpha1"
+	groupv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/group/v1alpha1"
+	schemaregistryv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/schemaregistry/v1alpha1"
 	topicv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	topicv1beta1 "github.com/crossplane-contrib/provider-kafka/apis/topic/v1beta1"
 	kafkav1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
 )
 
@@ -30,7 +35,12 @@ func init() {
 	AddToSchemes = append(AddToSchemes,
 		kafkav1alpha1.SchemeBuilder.AddToScheme,
 		topicv1alpha1.SchemeBuilder.AddToScheme,
+		topicv1beta1.SchemeBuilder.AddToScheme,
 		aclv1alpha1.SchemeBuilder.AddToScheme,
+		connectv1alpha1.SchemeBuilder.AddToScheme,
+		groupv1alpha1.SchemeBuilder.AddToScheme,
+		schemaregistryv1alpha1.SchemeBuilder.AddToScheme,
+		confluentv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A ContextType determines the schema context subjects are exported to.
+type ContextType string
+
+// Context types.
+const (
+	// ContextTypeAuto exports subjects to a context named after the
+	// source registry's cluster.
+	ContextTypeAuto ContextType = "AUTO"
+	// ContextTypeCustom exports subjects to the context named by context.
+	ContextTypeCustom ContextType = "CUSTOM"
+	// ContextTypeNone exports subjects to the default context.
+	ContextTypeNone ContextType = "NONE"
+)
+
+// SchemaExporterParameters are the configurable fields of a SchemaExporter.
+// +kubebuilder:validation:XValidation:rule="!has(self.context) || self.contextType == 'CUSTOM'",message="context can only be set with contextType CUSTOM"
+type SchemaExporterParameters struct {
+	// Subjects to export, e.g. orders-value. A subject of "*" exports every
+	// subject of the default context, and ":*:" every subject of every
+	// context.
+	// +kubebuilder:validation:MinItems=1
+	Subjects []string `json:"subjects"`
+	// SubjectRenameFormat renames the subjects in the destination registry,
+	// e.g. dc_${subject}. Subjects keep their name if it is unset.
+	// +optional
+	SubjectRenameFormat string `json:"subjectRenameFormat,omitempty"`
+	// ContextType determines the schema context subjects are exported to.
+	// +kubebuilder:validation:Enum=AUTO;CUSTOM;NONE
+	// +kubebuilder:default=AUTO
+	// +optional
+	ContextType ContextType `json:"contextType,omitempty"`
+	// Context subjects are exported to when contextType is CUSTOM.
+	// +optional
+	Context string `json:"context,omitempty"`
+	// Destination is the Schema Registry subjects are exported to.
+	Destination SchemaExporterDestination `json:"destination"`
+}
+
+// A SchemaExporterDestination is the Schema Registry a SchemaExporter exports
+// subjects to.
+type SchemaExporterDestination struct {
+	// URL of the destination Schema Registry.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// Username used to authenticate to the destination with basic auth.
+	// +optional
+	Username string `json:"username,omitempty"`
+	// PasswordSecretRef references the password used to authenticate to the
+	// destination with basic auth.
+	// +optional
+	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// Config is additional exporter config, e.g. TLS settings of the
+	// client connecting to the destination.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// SchemaExporterObservation are the observable fields of a SchemaExporter.
+type SchemaExporterObservation struct {
+	// State of the exporter, e.g. STARTING, RUNNING or PAUSED.
+	State string `json:"state,omitempty"`
+	// Offset of the last schema exported.
+	Offset int64 `json:"offset,omitempty"`
+	// Trace of the error that stopped the exporter, if any.
+	Trace string `json:"trace,omitempty"`
+}
+
+// A SchemaExporterSpec defines the desired state of a SchemaExporter.
+type SchemaExporterSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SchemaExporterParameters `json:"forProvider"`
+}
+
+// A SchemaExporterStatus represents the observed state of a SchemaExporter.
+type SchemaExporterStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SchemaExporterObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SchemaExporter is a Confluent Schema Registry exporter, which links the
+// Schema Registry configured in its ProviderConfig to a destination registry
+// by continuously exporting subjects to it, e.g. to keep a disaster recovery
+// registry in sync. It is Ready while the exporter is running.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="DESTINATION",type="string",JSONPath=".spec.forProvider.destination.url"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kafka}
+type SchemaExporter struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SchemaExporterSpec   `json:"spec"`
+	Status SchemaExporterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SchemaExporterList contains a list of SchemaExporter
+type SchemaExporterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SchemaExporter `json:"items"`
+}
+
+// SchemaExporter type metadata.
+var (
+	SchemaExporterKind             = reflect.TypeOf(SchemaExporter{}).Name()
+	SchemaExporterGroupKind        = schema.GroupKind{Group: Group, Kind: SchemaExporterKind}.String()
+	SchemaExporterKindAPIVersion   = SchemaExporterKind + "." + SchemeGroupVersion.String()
+	SchemaExporterGroupVersionKind = SchemeGroupVersion.WithKind(SchemaExporterKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&SchemaExporter{}, &SchemaExporterList{})
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// RecordsTruncationParameters are the configurable fields of a
+// RecordsTruncation. Exactly one of beforeOffset, beforeTime and all must be
+// set.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="a RecordsTruncation is immutable"
+// +kubebuilder:validation:XValidation:rule="[has(self.beforeOffset), has(self.beforeTime), has(self.all) && self.all].filter(x, x).size() == 1",message="exactly one of beforeOffset, beforeTime and all must be set"
+type RecordsTruncationParameters struct {
+	// Topic is the name of the topic whose records are deleted.
+	// +kubebuilder:validation:MinLength=1
+	Topic string `json:"topic"`
+
+	// Partitions are the partitions whose records are deleted. Defaults to
+	// all partitions of the topic.
+	// +optional
+	Partitions []int32 `json:"partitions,omitempty"`
+
+	// BeforeOffset deletes the records of each partition before this
+	// offset, or all of them if the partition ends before it.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	BeforeOffset *int64 `json:"beforeOffset,omitempty"`
+
+	// BeforeTime deletes the records of each partition produced before this
+	// time.
+	// +optional
+	BeforeTime *metav1.Time `json:"beforeTime,omitempty"`
+
+	// All deletes all records of each partition.
+	// +optional
+	All bool `json:"all,omitempty"`
+}
+
+// A PartitionTruncation is the outcome of deleting the records of a
+// partition.
+type PartitionTruncation struct {
+	// Partition whose records were deleted.
+	Partition int32 `json:"partition"`
+	// LowWatermark is the offset of the first record left in the partition.
+	LowWatermark int64 `json:"lowWatermark"`
+}
+
+// RecordsTruncationObservation are the observable fields of a
+// RecordsTruncation.
+type RecordsTruncationObservation struct {
+	// CompletedAt is the time the records were deleted.
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+	// Partitions are the partitions whose records were deleted.
+	// +optional
+	Partitions []PartitionTruncation `json:"partitions,omitempty"`
+	// UnreachableBrokers are the seed brokers that could not be used, and
+	// why, when the records could last not be deleted.
+	// +optional
+	UnreachableBrokers []apisv1alpha1.BrokerError `json:"unreachableBrokers,omitempty"`
+}
+
+// A RecordsTruncationSpec defines the desired state of a RecordsTruncation.
+type RecordsTruncationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RecordsTruncationParameters `json:"forProvider"`
+}
+
+// A RecordsTruncationStatus represents the observed state of a
+// RecordsTruncation.
+type RecordsTruncationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RecordsTruncationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RecordsTruncation deletes the records of a topic before an offset or a
+// time, once. It is Ready once the records were deleted, and is not run
+// again. Deleting it deletes nothing.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TOPIC",type="string",JSONPath=".spec.forProvider.topic"
+// +kubebuilder:printcolumn:name="COMPLETED",type="date",JSONPath=".status.atProvider.completedAt"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.providerConfigRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kafka}
+type RecordsTruncation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RecordsTruncationSpec   `json:"spec"`
+	Status RecordsTruncationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RecordsTruncationList contains a list of RecordsTruncation
+type RecordsTruncationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RecordsTruncation `json:"items"`
+}
+
+// RecordsTruncation type metadata.
+var (
+	RecordsTruncationKind             = reflect.TypeOf(RecordsTruncation{}).Name()
+	RecordsTruncationGroupKind        = schema.GroupKind{Group: Group, Kind: RecordsTruncationKind}.String()
+	RecordsTruncationKindAPIVersion   = RecordsTruncationKind + "." + SchemeGroupVersion.String()
+	RecordsTruncationGroupVersionKind = SchemeGroupVersion.WithKind(RecordsTruncationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RecordsTruncation{}, &RecordsTruncationList{})
+}
@@ -23,24 +23,326 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
 )
 
 // TopicParameters are the configurable fields of a Topic.
+// +kubebuilder:validation:XValidation:rule="!has(self.replicaAssignment) || (!has(self.partitions) && !has(self.replicationFactor))",message="partitions and replicationFactor must not be set together with replicaAssignment"
+// +kubebuilder:validation:XValidation:rule="has(self.replicaAssignment) || (has(self.partitions) && has(self.replicationFactor))",message="partitions and replicationFactor are required unless replicaAssignment is set"
+// +kubebuilder:validation:XValidation:rule="!has(self.partitionsAutoScale) || !has(self.partitions) || self.partitionsAutoScale.maxPartitions >= self.partitions",message="partitionsAutoScale.maxPartitions must not be less than partitions"
+// +kubebuilder:validation:XValidation:rule="!has(self.partitionsAutoScale) || !has(self.replicaAssignment)",message="partitionsAutoScale cannot be combined with replicaAssignment"
 type TopicParameters struct {
 	// ReplicationFactor defines the number of replicas the topic should have.
+	// Required unless ReplicaAssignment is set, and must not be set with it.
 	// +kubebuilder:validation:Minimum:=1
-	ReplicationFactor int `json:"replicationFactor"`
+	// +optional
+	ReplicationFactor int `json:"replicationFactor,omitempty"`
 	// Partitions defines the number of partitions the topic should have.
+	// Required unless ReplicaAssignment is set, and must not be set with it.
 	// +kubebuilder:validation:Minimum:=1
-	Partitions int `json:"partitions"`
+	// +optional
+	Partitions int `json:"partitions,omitempty"`
+	// ReplicaAssignment explicitly places the replicas of every partition on
+	// brokers when the topic is created, instead of partitions and
+	// replicationFactor, which must not be set with it. The topic has as many
+	// partitions as the assignment, with as many replicas as the assignment
+	// places for each partition. Partitions added to the assignment after the
+	// topic was created are added to the topic, but placed by Kafka.
+	// +listType=map
+	// +listMapKey=partition
+	// +kubebuilder:validation:MinItems:=1
+	// +optional
+	ReplicaAssignment []ReplicaAssignment `json:"replicaAssignment,omitempty"`
+	// PartitionsAutoScale grows the partitions of the topic as the rate at
+	// which records are produced to it rises. Partitions are only ever
+	// added, never removed, and the topic keeps the partitions it was grown
+	// to even if partitions is less.
+	// +optional
+	PartitionsAutoScale *PartitionsAutoScale `json:"partitionsAutoScale,omitempty"`
 	// Config is an optional map of string key/ value pairs.
 	// +optional
 	Config map[string]*string `json:"config,omitempty"`
+	// ConfigFrom references ConfigMaps whose data are config key/value
+	// pairs merged into config, e.g. config profiles maintained centrally
+	// and shared by many Topics. Keys of later ConfigMaps take precedence
+	// over those of earlier ones, and keys of config over all of them.
+	// +optional
+	ConfigFrom []ConfigMapReference `json:"configFrom,omitempty"`
+	// IgnoreConfigKeys are config keys the Topic leaves to other
+	// controllers, e.g. Cruise Control or a tiered storage operator. They
+	// are neither late initialized, checked for drift nor updated, so the
+	// provider never reverts changes others make to them. Keys of config
+	// and configFrom listed here are only set when the topic is created.
+	// +listType=set
+	// +optional
+	IgnoreConfigKeys []string `json:"ignoreConfigKeys,omitempty"`
+	// RollbackConfigOnFailure restores the previous values of the config
+	// keys an update applied when other keys of the same update failed, so
+	// that the topic config is either entirely old or entirely new.
+	// +optional
+	RollbackConfigOnFailure *bool `json:"rollbackConfigOnFailure,omitempty"`
+	// AllowDataLoss allows the topic to be deleted even though it still holds
+	// records or has active consumers. It only has an effect when the
+	// provider runs with topic deletion protection enabled.
+	// +optional
+	AllowDataLoss *bool `json:"allowDataLoss,omitempty"`
+	// AdoptExisting allows the Topic to manage a topic that already exists
+	// in Kafka but was not created by it, for example one created by
+	// another tool. Without it such a topic is left untouched, and the
+	// Topic reports a TopicExistsUnmanaged condition. Once adopted, the
+	// topic stays managed even if this is unset again.
+	// +optional
+	AdoptExisting *bool `json:"adoptExisting,omitempty"`
+	// Internal allows the Topic to manage a topic reserved for internal use
+	// by Kafka: one the brokers mark internal, __consumer_offsets,
+	// __transaction_state, __cluster_metadata, _schemas, or one whose name
+	// begins with _confluent-, or any of these with "." in place of "_"
+	// since Kafka considers such names colliding. Without it such a
+	// topic is neither created, altered nor deleted. Topics of Kafka
+	// tooling that are not reserved, such as connect-offsets, only need
+	// adoptExisting to be managed once they exist.
+	// +optional
+	Internal *bool `json:"internal,omitempty"`
+	// DeletionPropagation controls what happens to the AccessControlLists
+	// referencing this Topic through topicRef when it is deleted. Orphan
+	// leaves them in place. Delete deletes them, and deletes the topic only
+	// once they are gone, so that no ACL outlives its topic.
+	// +kubebuilder:validation:Enum=Orphan;Delete
+	// +optional
+	DeletionPropagation DeletionPropagation `json:"deletionPropagation,omitempty"`
+	// DeletionStrategy controls what happens to the topic when the Topic is
+	// deleted. Delete deletes it. Archive keeps it with time and size based
+	// retention disabled, so that its records survive the Topic, e.g. to
+	// retain them for compliance. Kafka cannot rename topics, so an archived
+	// topic keeps its name; a new Topic can adopt it with adoptExisting.
+	// Defaults to Delete.
+	// +kubebuilder:validation:Enum=Delete;Archive
+	// +optional
+	DeletionStrategy DeletionStrategy `json:"deletionStrategy,omitempty"`
+	// KeySchemaRef links the topic to the schema of its keys, registered in
+	// the Schema Registry configured in the provider credentials. A compacted
+	// topic linked to a key schema is only created, or a linked topic only
+	// made compacted, once the schema is registered. Topics not linked to a
+	// key schema are not checked.
+	// +optional
+	KeySchemaRef *KeySchemaReference `json:"keySchemaRef,omitempty"`
+	// WaitForReadyReplicas only marks the Topic Available once every
+	// partition of the topic has at least min.insync.replicas in-sync
+	// replicas, i.e. once producers requiring acknowledgement by all
+	// in-sync replicas can write to it, rather than as soon as it exists.
+	// +optional
+	WaitForReadyReplicas *bool `json:"waitForReadyReplicas,omitempty"`
+}
+
+// A ConfigMapReference references a ConfigMap.
+type ConfigMapReference struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+}
+
+// DeletionPropagation is what happens to the resources depending on a Topic
+// when it is deleted.
+type DeletionPropagation string
+
+// Deletion propagation policies.
+const (
+	DeletionPropagationOrphan DeletionPropagation = "Orphan"
+	DeletionPropagationDelete DeletionPropagation = "Delete"
+)
+
+// DeletionStrategy is what happens to the topic of a Topic when the Topic is
+// deleted.
+type DeletionStrategy string
+
+// Deletion strategies.
+const (
+	DeletionStrategyDelete  DeletionStrategy = "Delete"
+	DeletionStrategyArchive DeletionStrategy = "Archive"
+)
+
+// A ReplicaAssignment places the replicas of a partition on brokers.
+type ReplicaAssignment struct {
+	// Partition whose replicas are placed.
+	// +kubebuilder:validation:Minimum:=0
+	Partition int `json:"partition"`
+	// Brokers are the IDs of the brokers to place the replicas on. The
+	// first broker is the preferred leader.
+	// +kubebuilder:validation:MinItems:=1
+	Brokers []int `json:"brokers"`
 }
 
-// TopicObservation are the observable fields of a Topic.
+// PartitionsAutoScale grows the partitions of a topic within bounds.
+type PartitionsAutoScale struct {
+	// MaxPartitions is the number of partitions the topic is never grown
+	// beyond.
+	// +kubebuilder:validation:Minimum:=1
+	MaxPartitions int `json:"maxPartitions"`
+	// TargetBytesInPerPartition is the rate, in bytes per second, at which
+	// records should be produced to each partition at most. Once records
+	// were produced at a higher rate over a whole window, partitions are
+	// added to bring the rate per partition back to the target.
+	// +kubebuilder:validation:Minimum:=1
+	TargetBytesInPerPartition int64 `json:"targetBytesInPerPartition"`
+	// Window is how long the rate at which records are produced is measured
+	// over before partitions are added. Defaults to 5m.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// PartitionsAutoScaleObservation is the state of the autoscaling of the
+// partitions of a topic.
+type PartitionsAutoScaleObservation struct {
+	// Partitions is the number of partitions the topic was last grown to.
+	// +optional
+	Partitions int `json:"partitions,omitempty"`
+	// BytesInPerSecond is the rate at which records were produced to the
+	// topic over the last window.
+	// +optional
+	BytesInPerSecond int64 `json:"bytesInPerSecond,omitempty"`
+	// WindowStartTime is when the current window started.
+	// +optional
+	WindowStartTime *metav1.Time `json:"windowStartTime,omitempty"`
+	// WindowStartOffset is the sum of the end offsets of the partitions of
+	// the topic when the current window started.
+	// +optional
+	WindowStartOffset int64 `json:"windowStartOffset,omitempty"`
+	// ScaleEvents are the last times partitions were added, oldest first.
+	// +optional
+	ScaleEvents []PartitionsScaleEvent `json:"scaleEvents,omitempty"`
+}
+
+// A PartitionsScaleEvent records partitions being added to a topic.
+type PartitionsScaleEvent struct {
+	// Time is when the partitions were added.
+	Time metav1.Time `json:"time"`
+	// From is the number of partitions before they were added.
+	From int `json:"from"`
+	// To is the number of partitions after they were added.
+	To int `json:"to"`
+	// BytesInPerSecond is the rate at which records were produced to the
+	// topic that the partitions were added for.
+	BytesInPerSecond int64 `json:"bytesInPerSecond"`
+}
+
+// A ReplicationFactorPlan is the plan to increase the replication factor of a
+// topic by copying each of its partitions to more brokers.
+type ReplicationFactorPlan struct {
+	// ReplicationFactor the topic is increased to.
+	ReplicationFactor int `json:"replicationFactor"`
+	// Brokers is the number of brokers of the cluster.
+	Brokers int `json:"brokers"`
+	// Racks is the number of racks of the brokers, if they have racks.
+	// +optional
+	Racks int `json:"racks,omitempty"`
+	// Feasible is whether the brokers can hold the new replicas.
+	Feasible bool `json:"feasible"`
+	// Reason the replication factor cannot be increased, if it cannot.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Records is the approximate number of records copied to the new
+	// replicas.
+	// +optional
+	Records int64 `json:"records,omitempty"`
+	// Bytes is the approximate size of the data copied to the new replicas.
+	// +optional
+	Bytes int64 `json:"bytes,omitempty"`
+	// ApprovalRequired is whether the plan copies more data than the
+	// provider copies unless the Topic is annotated with
+	// topic.kafka.crossplane.io/approve-replication-factor set to the
+	// ReplicationFactor.
+	// +optional
+	ApprovalRequired bool `json:"approvalRequired,omitempty"`
+	// PlannedTime is when the plan was made.
+	PlannedTime metav1.Time `json:"plannedTime"`
+}
+
+// A KeySchemaReference references the schema of the keys of a topic in a
+// Schema Registry.
+type KeySchemaReference struct {
+	// Subject under which the key schema is registered. Defaults to
+	// "<topic>-key", the subject of the default TopicNameStrategy.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+}
+
+// TopicObservation are the observable fields of a Topic. Apart from ID, the
+// fields are intended to be patched into composite resources, and are kept
+// stable across releases.
 type TopicObservation struct {
+	// TopicName is the name of the topic in Kafka managed by the Topic. Once
+	// recorded, changes to the external name of the Topic are ignored unless
+	// it is annotated to migrate to its new external name.
+	TopicName string `json:"topicName,omitempty"`
+	// ID is the topic ID assigned by Kafka.
+	// Deprecated: Use TopicID.
 	ID string `json:"id,omitempty"`
+	// TopicID is the topic ID assigned by Kafka.
+	TopicID string `json:"topicID,omitempty"`
+	// PartitionCount is the number of partitions the topic has.
+	PartitionCount int `json:"partitionCount,omitempty"`
+	// ReplicationFactor is the number of replicas of the topic's first
+	// partition.
+	ReplicationFactor int `json:"replicationFactor,omitempty"`
+	// ReadyReplicasPerPartition is the number of in-sync replicas of each
+	// partition, indexed by partition.
+	ReadyReplicasPerPartition []int `json:"readyReplicasPerPartition,omitempty"`
+	// MinInSyncReplicas is the min.insync.replicas in effect for the topic,
+	// whether set on the topic or defaulted by the brokers.
+	// +optional
+	MinInSyncReplicas int `json:"minInSyncReplicas,omitempty"`
+	// UnreachableBrokers are the seed brokers that could not be used, and
+	// why, when the Topic could last not be observed.
+	// +optional
+	UnreachableBrokers []apisv1alpha1.BrokerError `json:"unreachableBrokers,omitempty"`
+	// Records is the approximate number of records retained in the topic.
+	// It is only observed if the provider runs with --topic-size-in-status.
+	// +optional
+	Records *int64 `json:"records,omitempty"`
+	// SizeBytes is the size on disk of all replicas of the topic. It is only
+	// observed if the provider runs with --topic-size-in-status.
+	// +optional
+	SizeBytes *int64 `json:"sizeBytes,omitempty"`
+
+	// PartitionsAutoScale is the state of the autoscaling of the partitions
+	// of the topic, if it was ever autoscaled.
+	// +optional
+	PartitionsAutoScale *PartitionsAutoScaleObservation `json:"partitionsAutoScale,omitempty"`
+
+	// ReplicationFactorPlan is the plan to increase the replication factor
+	// of the topic, until it was increased.
+	// +optional
+	ReplicationFactorPlan *ReplicationFactorPlan `json:"replicationFactorPlan,omitempty"`
+
+	// ObservedGeneration is the generation of the Topic whose config was
+	// last verified to be up to date in Kafka.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// ConfigHash is a hash of the config that was last verified to be up to
+	// date in Kafka.
+	ConfigHash string `json:"configHash,omitempty"`
+	// ConfigVerifiedTime is when the config was last verified to be up to
+	// date in Kafka. Without a config verify grace period, it is when the
+	// current config was first verified.
+	ConfigVerifiedTime *metav1.Time `json:"configVerifiedTime,omitempty"`
+
+	// DriftCount is the number of times the topic was found to differ from
+	// the Topic while its spec did not change since the topic was last up
+	// to date, e.g. as another tool altered its config. A count growing
+	// steadily means the Topic fights with another tool over the topic.
+	// +optional
+	DriftCount int64 `json:"driftCount,omitempty"`
+	// LastDriftTime is when the topic was last found to have drifted.
+	// +optional
+	LastDriftTime *metav1.Time `json:"lastDriftTime,omitempty"`
+
+	// PolicyViolationGeneration is the generation of the Topic that a create
+	// topic policy of the brokers last rejected.
+	PolicyViolationGeneration int64 `json:"policyViolationGeneration,omitempty"`
+	// PolicyViolationTime is when a create topic policy of the brokers last
+	// rejected the Topic.
+	PolicyViolationTime *metav1.Time `json:"policyViolationTime,omitempty"`
 }
 
 // A TopicSpec defines the desired state of a Topic.
@@ -55,13 +357,262 @@ type TopicStatus struct {
 	AtProvider          TopicObservation `json:"atProvider,omitempty"`
 }
 
+// TypeTopicExistsUnmanaged indicates whether a topic with the Topic's
+// external name exists in Kafka without being managed by the Topic.
+const TypeTopicExistsUnmanaged xpv1.ConditionType = "TopicExistsUnmanaged"
+
+// Reasons a topic is or is not managed by its Topic.
+const (
+	ReasonNotCreatedByProvider xpv1.ConditionReason = "NotCreatedByProvider"
+	ReasonManaged              xpv1.ConditionReason = "Managed"
+)
+
+// TopicExistsUnmanaged returns a condition that indicates the topic exists
+// in Kafka but was not created or adopted by the Topic, which therefore
+// leaves it untouched.
+func TopicExistsUnmanaged(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTopicExistsUnmanaged,
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotCreatedByProvider,
+		Message:            msg,
+	}
+}
+
+// TopicManaged returns a condition that indicates the topic is managed by
+// the Topic.
+func TopicManaged() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTopicExistsUnmanaged,
+		Status:             "False",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonManaged,
+	}
+}
+
+// TypeAuthorized indicates whether the principal of the provider is allowed
+// to observe the topic of a Topic.
+const TypeAuthorized xpv1.ConditionType = "Authorized"
+
+// Reasons the principal of the provider is or is not allowed to observe the
+// topic of a Topic.
+const (
+	ReasonTopicAuthorizationFailed xpv1.ConditionReason = "TopicAuthorizationFailed"
+	ReasonTopicAuthorized          xpv1.ConditionReason = "TopicAuthorized"
+)
+
+// TopicUnauthorized returns a condition that indicates the brokers refused to
+// describe the topic to the principal of the provider, with a message naming
+// the operation an ACL must allow.
+func TopicUnauthorized(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAuthorized,
+		Status:             "False",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTopicAuthorizationFailed,
+		Message:            msg,
+	}
+}
+
+// TopicAuthorized returns a condition that indicates the principal of the
+// provider is allowed to observe the topic.
+func TopicAuthorized() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAuthorized,
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTopicAuthorized,
+	}
+}
+
+// TypeExternalNameChanged indicates whether the external name of a Topic
+// differs from the name of the topic it manages.
+const TypeExternalNameChanged xpv1.ConditionType = "ExternalNameChanged"
+
+// Reasons the external name of a Topic does or does not match its topic.
+const (
+	ReasonExternalNameIgnored xpv1.ConditionReason = "ExternalNameIgnored"
+	ReasonExternalNameMatches xpv1.ConditionReason = "ExternalNameMatches"
+)
+
+// ExternalNameIgnored returns a condition that indicates the external name of
+// the Topic was changed after its topic was created, and that the change is
+// ignored.
+func ExternalNameIgnored(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeExternalNameChanged,
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonExternalNameIgnored,
+		Message:            msg,
+	}
+}
+
+// ExternalNameMatches returns a condition that indicates the external name of
+// the Topic is the name of the topic it manages.
+func ExternalNameMatches() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeExternalNameChanged,
+		Status:             "False",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonExternalNameMatches,
+	}
+}
+
+// AnnotationKeyMigrateExternalName makes a Topic whose external name was
+// changed manage the topic of its new external name, when set to "true". The
+// topic of its old external name is left in Kafka. It is removed once the
+// Topic was migrated.
+const AnnotationKeyMigrateExternalName = "kafka.crossplane.io/migrate-external-name"
+
+// AnnotationKeyRefresh forces the next reconcile of a Topic to describe the
+// topic in full, rather than trusting a recent verification of its config,
+// when set to "true". It is removed once the Topic was refreshed.
+const AnnotationKeyRefresh = "kafka.crossplane.io/refresh"
+
+// AnnotationKeyPolicyRetryAfter overrides how long a Topic rejected by a
+// create topic policy of the brokers waits before it is created again, as a
+// duration such as "10m". Without it, creation is only retried once the Topic
+// changes.
+const AnnotationKeyPolicyRetryAfter = "topic.kafka.crossplane.io/policy-retry-after"
+
+// AnnotationKeyPolicyViolation records that a create topic policy of the
+// brokers rejected a Topic, as JSON holding the generation of the Topic, when
+// it was rejected and the reason given by the policy. The status of a Topic is
+// not persisted when creating its topic fails, so the rejection is recorded
+// here and reported in its status when it is next observed. It is removed once
+// the brokers accept the Topic.
+const AnnotationKeyPolicyViolation = "topic.kafka.crossplane.io/policy-violation"
+
+// AnnotationKeyCreateInterrupted records when a request to create the topic of
+// a Topic was cancelled or timed out before the brokers answered, as an
+// RFC3339 time, so that the topic may have been created by it. The Topic
+// adopts such a topic if it observes it shortly after. It is removed once the
+// Topic observed its topic.
+const AnnotationKeyCreateInterrupted = "topic.kafka.crossplane.io/create-interrupted"
+
+// AnnotationKeyApproveReplicationFactor approves increasing the replication
+// factor of a Topic to the one it is set to, such as "3", when the plan to do
+// so copies more data than the provider copies without approval.
+const AnnotationKeyApproveReplicationFactor = "topic.kafka.crossplane.io/approve-replication-factor"
+
+// TypeBrokerPolicyCompliant indicates whether the create topic policy of the
+// brokers accepted a Topic.
+const TypeBrokerPolicyCompliant xpv1.ConditionType = "BrokerPolicyCompliant"
+
+// Reasons a Topic was or was not accepted by a create topic policy.
+const (
+	ReasonPolicyViolation xpv1.ConditionReason = "PolicyViolation"
+	ReasonPolicyAccepted  xpv1.ConditionReason = "PolicyAccepted"
+)
+
+// BrokerPolicyViolated returns a condition that indicates a create topic
+// policy of the brokers rejected the Topic, for the supplied reason.
+func BrokerPolicyViolated(reason string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBrokerPolicyCompliant,
+		Status:             "False",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPolicyViolation,
+		Message:            reason,
+	}
+}
+
+// BrokerPolicyCompliant returns a condition that indicates the create topic
+// policies of the brokers accepted the Topic.
+func BrokerPolicyCompliant() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBrokerPolicyCompliant,
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPolicyAccepted,
+	}
+}
+
+// TypeDeletionInProgress indicates whether the brokers are still deleting a
+// previous topic of the same name, which keeps the Topic's topic from being
+// created.
+const TypeDeletionInProgress xpv1.ConditionType = "DeletionInProgress"
+
+// Reasons a previous topic of the same name is or is not being deleted.
+const (
+	ReasonMarkedForDeletion xpv1.ConditionReason = "MarkedForDeletion"
+	ReasonDeletionConfirmed xpv1.ConditionReason = "DeletionConfirmed"
+)
+
+// DeletionInProgress returns a condition that indicates the brokers are
+// still deleting a previous topic of the same name.
+func DeletionInProgress(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionInProgress,
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMarkedForDeletion,
+		Message:            msg,
+	}
+}
+
+// DeletionConfirmed returns a condition that indicates the brokers confirmed
+// the removal of a previous topic of the same name.
+func DeletionConfirmed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionInProgress,
+		Status:             "False",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletionConfirmed,
+	}
+}
+
+// TypeArchived indicates whether the topic of a deleted Topic was archived
+// rather than deleted.
+const TypeArchived xpv1.ConditionType = "Archived"
+
+// ReasonRetentionDisabled is why the topic of a deleted Topic is archived.
+const ReasonRetentionDisabled xpv1.ConditionReason = "RetentionDisabled"
+
+// Archived returns a condition that indicates the topic was archived: it is
+// kept, with retention disabled, and no longer managed by the Topic.
+func Archived(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeArchived,
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRetentionDisabled,
+		Message:            msg,
+	}
+}
+
+// TypeInUse indicates whether the topic of a deleted Topic is kept because
+// it still holds records or has active consumers.
+const TypeInUse xpv1.ConditionType = "InUse"
+
+// ReasonDataLossRefused is why the topic of a deleted Topic is kept.
+const ReasonDataLossRefused xpv1.ConditionReason = "DataLossRefused"
+
+// InUse returns a condition that indicates the topic of a deleted Topic is
+// kept, because deleting it would lose its records or disrupt its consumers.
+func InUse(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInUse,
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDataLossRefused,
+		Message:            msg,
+	}
+}
+
 // +kubebuilder:object:root=true
 
 // A Topic is an example API type.
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="PARTITIONS",type="integer",JSONPath=".status.atProvider.partitionCount"
+// +kubebuilder:printcolumn:name="REPLICATION",type="integer",JSONPath=".status.atProvider.replicationFactor"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.providerConfigRef.name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kafka}
 type Topic struct {
@@ -21,9 +21,305 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeySchemaReference) DeepCopyInto(out *KeySchemaReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeySchemaReference.
+func (in *KeySchemaReference) DeepCopy() *KeySchemaReference {
+	if in == nil {
+		return nil
+	}
+	out := new(KeySchemaReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionTruncation) DeepCopyInto(out *PartitionTruncation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionTruncation.
+func (in *PartitionTruncation) DeepCopy() *PartitionTruncation {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionTruncation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionsAutoScale) DeepCopyInto(out *PartitionsAutoScale) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionsAutoScale.
+func (in *PartitionsAutoScale) DeepCopy() *PartitionsAutoScale {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionsAutoScale)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionsAutoScaleObservation) DeepCopyInto(out *PartitionsAutoScaleObservation) {
+	*out = *in
+	if in.WindowStartTime != nil {
+		in, out := &in.WindowStartTime, &out.WindowStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ScaleEvents != nil {
+		in, out := &in.ScaleEvents, &out.ScaleEvents
+		*out = make([]PartitionsScaleEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionsAutoScaleObservation.
+func (in *PartitionsAutoScaleObservation) DeepCopy() *PartitionsAutoScaleObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionsAutoScaleObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionsScaleEvent) DeepCopyInto(out *PartitionsScaleEvent) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionsScaleEvent.
+func (in *PartitionsScaleEvent) DeepCopy() *PartitionsScaleEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionsScaleEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordsTruncation) DeepCopyInto(out *RecordsTruncation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordsTruncation.
+func (in *RecordsTruncation) DeepCopy() *RecordsTruncation {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordsTruncation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RecordsTruncation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordsTruncationList) DeepCopyInto(out *RecordsTruncationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RecordsTruncation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordsTruncationList.
+func (in *RecordsTruncationList) DeepCopy() *RecordsTruncationList {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordsTruncationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RecordsTruncationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordsTruncationObservation) DeepCopyInto(out *RecordsTruncationObservation) {
+	*out = *in
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]PartitionTruncation, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnreachableBrokers != nil {
+		in, out := &in.UnreachableBrokers, &out.UnreachableBrokers
+		*out = make([]apisv1alpha1.BrokerError, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordsTruncationObservation.
+func (in *RecordsTruncationObservation) DeepCopy() *RecordsTruncationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordsTruncationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordsTruncationParameters) DeepCopyInto(out *RecordsTruncationParameters) {
+	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.BeforeOffset != nil {
+		in, out := &in.BeforeOffset, &out.BeforeOffset
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BeforeTime != nil {
+		in, out := &in.BeforeTime, &out.BeforeTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordsTruncationParameters.
+func (in *RecordsTruncationParameters) DeepCopy() *RecordsTruncationParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordsTruncationParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordsTruncationSpec) DeepCopyInto(out *RecordsTruncationSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordsTruncationSpec.
+func (in *RecordsTruncationSpec) DeepCopy() *RecordsTruncationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordsTruncationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordsTruncationStatus) DeepCopyInto(out *RecordsTruncationStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordsTruncationStatus.
+func (in *RecordsTruncationStatus) DeepCopy() *RecordsTruncationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordsTruncationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaAssignment) DeepCopyInto(out *ReplicaAssignment) {
+	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaAssignment.
+func (in *ReplicaAssignment) DeepCopy() *ReplicaAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationFactorPlan) DeepCopyInto(out *ReplicationFactorPlan) {
+	*out = *in
+	in.PlannedTime.DeepCopyInto(&out.PlannedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationFactorPlan.
+func (in *ReplicationFactorPlan) DeepCopy() *ReplicationFactorPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationFactorPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Topic) DeepCopyInto(out *Topic) {
 	*out = *in
@@ -86,6 +382,48 @@ func (in *TopicList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopicObservation) DeepCopyInto(out *TopicObservation) {
 	*out = *in
+	if in.ReadyReplicasPerPartition != nil {
+		in, out := &in.ReadyReplicasPerPartition, &out.ReadyReplicasPerPartition
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnreachableBrokers != nil {
+		in, out := &in.UnreachableBrokers, &out.UnreachableBrokers
+		*out = make([]apisv1alpha1.BrokerError, len(*in))
+		copy(*out, *in)
+	}
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SizeBytes != nil {
+		in, out := &in.SizeBytes, &out.SizeBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PartitionsAutoScale != nil {
+		in, out := &in.PartitionsAutoScale, &out.PartitionsAutoScale
+		*out = new(PartitionsAutoScaleObservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicationFactorPlan != nil {
+		in, out := &in.ReplicationFactorPlan, &out.ReplicationFactorPlan
+		*out = new(ReplicationFactorPlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigVerifiedTime != nil {
+		in, out := &in.ConfigVerifiedTime, &out.ConfigVerifiedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastDriftTime != nil {
+		in, out := &in.LastDriftTime, &out.LastDriftTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PolicyViolationTime != nil {
+		in, out := &in.PolicyViolationTime, &out.PolicyViolationTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicObservation.
@@ -101,6 +439,18 @@ func (in *TopicObservation) DeepCopy() *TopicObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopicParameters) DeepCopyInto(out *TopicParameters) {
 	*out = *in
+	if in.ReplicaAssignment != nil {
+		in, out := &in.ReplicaAssignment, &out.ReplicaAssignment
+		*out = make([]ReplicaAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PartitionsAutoScale != nil {
+		in, out := &in.PartitionsAutoScale, &out.PartitionsAutoScale
+		*out = new(PartitionsAutoScale)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = make(map[string]*string, len(*in))
@@ -117,6 +467,46 @@ func (in *TopicParameters) DeepCopyInto(out *TopicParameters) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.ConfigFrom != nil {
+		in, out := &in.ConfigFrom, &out.ConfigFrom
+		*out = make([]ConfigMapReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreConfigKeys != nil {
+		in, out := &in.IgnoreConfigKeys, &out.IgnoreConfigKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RollbackConfigOnFailure != nil {
+		in, out := &in.RollbackConfigOnFailure, &out.RollbackConfigOnFailure
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowDataLoss != nil {
+		in, out := &in.AllowDataLoss, &out.AllowDataLoss
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdoptExisting != nil {
+		in, out := &in.AdoptExisting, &out.AdoptExisting
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Internal != nil {
+		in, out := &in.Internal, &out.Internal
+		*out = new(bool)
+		**out = **in
+	}
+	if in.KeySchemaRef != nil {
+		in, out := &in.KeySchemaRef, &out.KeySchemaRef
+		*out = new(KeySchemaReference)
+		**out = **in
+	}
+	if in.WaitForReadyReplicas != nil {
+		in, out := &in.WaitForReadyReplicas, &out.WaitForReadyReplicas
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicParameters.
@@ -150,7 +540,7 @@ func (in *TopicSpec) DeepCopy() *TopicSpec {
 func (in *TopicStatus) DeepCopyInto(out *TopicStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicStatus.
@@ -19,6 +19,15 @@ package v1alpha1
 
 import resource "github.com/crossplane/crossplane-runtime/pkg/resource"
 
+// GetItems of this RecordsTruncationList.
+func (l *RecordsTruncationList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this TopicList.
 func (l *TopicList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
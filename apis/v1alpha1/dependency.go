@@ -0,0 +1,57 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeDependenciesReady indicates whether the managed resources a managed
+// resource depends on, such as the Topic an AccessControlList references, are
+// ready. A managed resource is neither observed nor created until they are.
+const TypeDependenciesReady xpv1.ConditionType = "DependenciesReady"
+
+// Reasons the dependencies of a managed resource are or are not ready.
+const (
+	ReasonDependenciesReady      xpv1.ConditionReason = "DependenciesReady"
+	ReasonWaitingForDependencies xpv1.ConditionReason = "WaitingForDependencies"
+)
+
+// DependenciesReady returns a condition that indicates the dependencies of a
+// managed resource are ready.
+func DependenciesReady() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDependenciesReady,
+		Status:             "True",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDependenciesReady,
+	}
+}
+
+// WaitingForDependencies returns a condition that indicates a managed resource
+// waits for its dependencies to be ready, with the supplied message.
+func WaitingForDependencies(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDependenciesReady,
+		Status:             "False",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaitingForDependencies,
+		Message:            msg,
+	}
+}
@@ -29,6 +29,74 @@ import (
 type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// BrokersConfigMapRef references a ConfigMap listing the brokers to
+	// connect to, separated by commas or whitespace. It takes precedence over
+	// the brokers of the credentials, which then only need to hold secrets.
+	// +optional
+	BrokersConfigMapRef *ConfigMapKeySelector `json:"brokersConfigMapRef,omitempty"`
+
+	// ClientBuilder selects how clients connecting to the cluster are built
+	// from the credentials: Standard connects as the credentials describe,
+	// MSKIAM connects to Amazon MSK with IAM authentication, ConfluentCloud
+	// and EventHubs connect to those services with an API key or connection
+	// string as SASL password. Defaults to Standard.
+	// +optional
+	ClientBuilder string `json:"clientBuilder,omitempty"`
+
+	// PrincipalTemplate is the Go template the principal of
+	// AccessControlLists referencing a ServiceAccount is rendered from. The
+	// ServiceAccount's .Namespace and .Name are available to it. Defaults to
+	// User:CN={{ .Namespace }}/{{ .Name }}.
+	// +optional
+	PrincipalTemplate string `json:"principalTemplate,omitempty"`
+
+	// Authorization selects how access to the cluster is authorized: ACL
+	// manages AccessControlLists on the brokers, ConfluentRBAC manages
+	// RoleBindings through the Confluent Metadata Service configured in the
+	// credentials. Managed resources of the other model are rejected.
+	// Defaults to ACL.
+	// +kubebuilder:validation:Enum=ACL;ConfluentRBAC
+	// +optional
+	Authorization Authorization `json:"authorization,omitempty"`
+
+	// ClientCertificateIdentity selects the client certificate the provider
+	// connects to the cluster with among several held by the Secret the
+	// tls.clientCertificateSecretRef of the credentials references: the
+	// identity <name> is the key pair under <name>.crt and <name>.key. The
+	// fields of the credentials are used if it is unset.
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +optional
+	ClientCertificateIdentity string `json:"clientCertificateIdentity,omitempty"`
+
+	// ReadOnlyClientCertificateIdentity is the client certificate identity
+	// managed resources annotated with kafka.crossplane.io/read-only-identity
+	// connect with, e.g. one only allowed to describe the cluster. Such
+	// resources only observe their external resources.
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +optional
+	ReadOnlyClientCertificateIdentity string `json:"readOnlyClientCertificateIdentity,omitempty"`
+}
+
+// Authorization is how access to a Kafka cluster is authorized.
+type Authorization string
+
+// Authorization models.
+const (
+	AuthorizationACL           Authorization = "ACL"
+	AuthorizationConfluentRBAC Authorization = "ConfluentRBAC"
+)
+
+// A ConfigMapKeySelector is a reference to a key of a ConfigMap in an
+// arbitrary namespace.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+	// Key of the ConfigMap. Defaults to brokers.
+	// +optional
+	Key string `json:"key,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -40,9 +108,117 @@ type ProviderCredentials struct {
 	xpv1.CommonCredentialSelectors `json:",inline"`
 }
 
+// A BrokerError is why a seed broker could not be used, as recorded in the
+// status of managed resources whose brokers could not be reached.
+type BrokerError struct {
+	// Broker is the address of the seed broker.
+	Broker string `json:"broker"`
+	// Stage at which using the broker failed: DNS, Dial, TLS, Auth or
+	// Request.
+	Stage string `json:"stage"`
+	// Message is the error using the broker returned.
+	Message string `json:"message"`
+}
+
+// ClusterStatus describes the Kafka cluster of a ProviderConfig.
+type ClusterStatus struct {
+	// ID of the cluster.
+	ID string `json:"id,omitempty"`
+	// ControllerID is the ID of the active controller.
+	ControllerID int32 `json:"controllerID"`
+	// Brokers are the IDs of the brokers of the cluster.
+	Brokers []int32 `json:"brokers,omitempty"`
+	// BrokerDetails are the brokers of the cluster, sorted by ID, e.g. for
+	// compositions to derive replication factors from.
+	// +optional
+	BrokerDetails []BrokerStatus `json:"brokerDetails,omitempty"`
+	// Racks are the distinct racks of the brokers, sorted. It is empty if
+	// no broker sets broker.rack.
+	// +optional
+	Racks []string `json:"racks,omitempty"`
+	// Version is the Kafka version the brokers most likely run, guessed from
+	// the API versions they support.
+	Version string `json:"version,omitempty"`
+	// MetadataMode is how the cluster stores its metadata: KRaft, or
+	// ZooKeeper for clusters whose brokers cannot describe a KRaft quorum.
+	MetadataMode string `json:"metadataMode,omitempty"`
+	// Quorum is the KRaft metadata quorum. It is only reported for KRaft
+	// clusters, if the provider may describe the cluster.
+	// +optional
+	Quorum *MetadataQuorum `json:"quorum,omitempty"`
+	// LastObservedTime is when the cluster was last described.
+	LastObservedTime metav1.Time `json:"lastObservedTime,omitempty"`
+}
+
+// A BrokerStatus describes a broker of a Kafka cluster.
+type BrokerStatus struct {
+	// ID of the broker.
+	ID int32 `json:"id"`
+	// Host the broker advertises.
+	Host string `json:"host"`
+	// Port the broker advertises.
+	Port int32 `json:"port"`
+	// Rack of the broker, i.e. its broker.rack, if set.
+	// +optional
+	Rack string `json:"rack,omitempty"`
+	// Controller is true if the broker is the active controller.
+	// +optional
+	Controller bool `json:"controller,omitempty"`
+}
+
+// A MetadataQuorum is the KRaft quorum of controllers replicating the
+// metadata of a Kafka cluster.
+type MetadataQuorum struct {
+	// LeaderID is the ID of the controller leading the quorum.
+	LeaderID int32 `json:"leaderID"`
+	// LeaderEpoch is the epoch of the leader.
+	LeaderEpoch int32 `json:"leaderEpoch"`
+	// HighWatermark of the metadata log.
+	HighWatermark int64 `json:"highWatermark"`
+	// Voters are the IDs of the controllers voting in the quorum.
+	Voters []int32 `json:"voters,omitempty"`
+	// Observers are the IDs of the brokers replicating the metadata log.
+	Observers []int32 `json:"observers,omitempty"`
+}
+
+// Conditions reporting the capabilities of the Kafka cluster of a
+// ProviderConfig. Each is True if all brokers support the capability.
+const (
+	TypeSupportsTopicIDs                xpv1.ConditionType = "SupportsTopicIDs"
+	TypeSupportsIncrementalAlterConfigs xpv1.ConditionType = "SupportsIncrementalAlterConfigs"
+	TypeSupportsDelegationTokens        xpv1.ConditionType = "SupportsDelegationTokens"
+	TypeACLsEnabled                     xpv1.ConditionType = "ACLsEnabled"
+)
+
+// Reasons a capability condition is True or False.
+const (
+	ReasonSupported   xpv1.ConditionReason = "Supported"
+	ReasonUnsupported xpv1.ConditionReason = "Unsupported"
+)
+
+// Capability returns a capability condition of the supplied type, which is
+// True if the capability is supported.
+func Capability(t xpv1.ConditionType, supported bool) xpv1.Condition {
+	c := xpv1.Condition{
+		Type:               t,
+		Status:             "False",
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnsupported,
+	}
+	if supported {
+		c.Status, c.Reason = "True", ReasonSupported
+	}
+	return c
+}
+
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// Cluster describes the Kafka cluster of the ProviderConfig, as last
+	// observed.
+	// +optional
+	Cluster *ClusterStatus `json:"cluster,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -50,6 +226,7 @@ type ProviderConfigStatus struct {
 // A ProviderConfig configures a Template provider.
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="METADATA-MODE",type="string",JSONPath=".status.cluster.metadataMode"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Cluster
 type ProviderConfig struct {
@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A TopicPolicySpec defines the guardrails enforced on Topics.
+type TopicPolicySpec struct {
+	// ProviderConfigRef selects the Topics the policy applies to, namely
+	// those using the referenced ProviderConfig.
+	ProviderConfigRef xpv1.Reference `json:"providerConfigRef"`
+	// MinPartitions is the least number of partitions a topic may have.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinPartitions *int `json:"minPartitions,omitempty"`
+	// MaxPartitions is the greatest number of partitions a topic may have.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxPartitions *int `json:"maxPartitions,omitempty"`
+	// RequiredConfigs must be set on every topic.
+	// +optional
+	RequiredConfigs []RequiredTopicConfig `json:"requiredConfigs,omitempty"`
+	// ForbiddenConfigs must not be set on any topic.
+	// +optional
+	ForbiddenConfigs []string `json:"forbiddenConfigs,omitempty"`
+	// NamePattern is a regular expression every topic name must match.
+	// +optional
+	NamePattern string `json:"namePattern,omitempty"`
+}
+
+// A RequiredTopicConfig is a config that must be set on every topic. If
+// neither Value nor Minimum is set, the config may have any value.
+type RequiredTopicConfig struct {
+	// Key of the config, e.g. min.insync.replicas.
+	Key string `json:"key"`
+	// Value the config must be set to.
+	// +optional
+	Value *string `json:"value,omitempty"`
+	// Minimum numeric value of the config.
+	// +optional
+	Minimum *int64 `json:"minimum,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TopicPolicy constrains the Topics using a ProviderConfig. Topics that
+// violate any policy are neither created nor updated.
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="CONFIG-NAME",type="string",JSONPath=".spec.providerConfigRef.name"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,kafka}
+type TopicPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TopicPolicySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// TopicPolicyList contains a list of TopicPolicy.
+type TopicPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TopicPolicy `json:"items"`
+}
+
+// TopicPolicy type metadata.
+var (
+	TopicPolicyKind             = reflect.TypeOf(TopicPolicy{}).Name()
+	TopicPolicyGroupKind        = schema.GroupKind{Group: Group, Kind: TopicPolicyKind}.String()
+	TopicPolicyKindAPIVersion   = TopicPolicyKind + "." + SchemeGroupVersion.String()
+	TopicPolicyGroupVersionKind = SchemeGroupVersion.WithKind(TopicPolicyKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&TopicPolicy{}, &TopicPolicyList{})
+}
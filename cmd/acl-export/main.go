@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// acl-export exports the ACLs of the cluster of a ProviderConfig as
+// AccessControlLists that adopt them, e.g.
+//
+//	acl-export --provider-config main --principal User:payments | kubectl apply -f -
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/aclexport"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka/acl"
+)
+
+func main() {
+	var (
+		app            = kingpin.New(filepath.Base(os.Args[0]), "Export the ACLs of a Kafka cluster as AccessControlLists adopting them.").DefaultEnvars()
+		providerConfig = app.Flag("provider-config", "ProviderConfig whose cluster to export the ACLs of, and that every AccessControlList references.").Required().String()
+		principals     = app.Flag("principal", "Only export the ACLs of this principal, e.g. User:payments. May be repeated.").Strings()
+		resourcePrefix = app.Flag("resource-prefix", "Only export the ACLs of resources whose name starts with this prefix.").String()
+		namePrefix     = app.Flag("name-prefix", "Prefix of the names of AccessControlLists.").String()
+		timeout        = app.Flag("timeout", "How long describing the ACLs of the cluster may take.").Default("1m").Duration()
+	)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	s := runtime.NewScheme()
+	kingpin.FatalIfError(clientgoscheme.AddToScheme(s), "Cannot add Kubernetes APIs to scheme")
+	kingpin.FatalIfError(apisv1alpha1.SchemeBuilder.AddToScheme(s), "Cannot add Kafka APIs to scheme")
+	cfg, err := ctrl.GetConfig()
+	kingpin.FatalIfError(err, "Cannot get API server rest config")
+	kube, err := client.New(cfg, client.Options{Scheme: s})
+	kingpin.FatalIfError(err, "Cannot create Kubernetes client")
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	kingpin.FatalIfError(kube.Get(ctx, types.NamespacedName{Name: *providerConfig}, pc), "Cannot get ProviderConfig %s", *providerConfig)
+	cd := pc.Spec.Credentials
+	data, err := kafka.ExtractCredentials(ctx, cd.Source, kube, cd.CommonCredentialSelectors)
+	kingpin.FatalIfError(err, "Cannot get credentials")
+	if ref := pc.Spec.BrokersConfigMapRef; ref != nil {
+		data, err = kafka.ReplaceBrokers(ctx, kube, data, ref.Namespace, ref.Name, ref.Key)
+		kingpin.FatalIfError(err, "Cannot get brokers")
+	}
+	clients := kafka.NewClientCache(kafka.DefaultTimeouts)
+	defer clients.Close()
+	kc, err := clients.Get(ctx, pc.Spec.ClientBuilder, data, kube)
+	kingpin.FatalIfError(err, "Cannot create Kafka client")
+
+	acls, skipped, err := acl.DescribeAll(ctx, kc, *principals...)
+	kingpin.FatalIfError(err, "Cannot describe ACLs")
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d ACLs that AccessControlLists cannot manage, such as those of delegation tokens\n", skipped)
+	}
+
+	out, err := aclexport.ExportAll(acls, aclexport.Options{ProviderConfig: *providerConfig, NamePrefix: *namePrefix, ResourcePrefix: *resourcePrefix})
+	kingpin.FatalIfError(err, "Cannot export ACLs")
+	_, err = os.Stdout.Write(out)
+	kingpin.FatalIfError(err, "Cannot write AccessControlLists")
+}
@@ -17,23 +17,55 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	uzap "go.uber.org/zap"
 	"gopkg.in/alecthomas/kingpin.v2"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-kafka/apis"
+	topicv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/audit"
+	"github.com/crossplane-contrib/provider-kafka/internal/cancellation"
+	"github.com/crossplane-contrib/provider-kafka/internal/concurrency"
 	kafkacontroller "github.com/crossplane-contrib/provider-kafka/internal/controller"
+	debugserver "github.com/crossplane-contrib/provider-kafka/internal/debug"
+	"github.com/crossplane-contrib/provider-kafka/internal/deletion"
+	"github.com/crossplane-contrib/provider-kafka/internal/devcluster"
+	"github.com/crossplane-contrib/provider-kafka/internal/features"
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+	"github.com/crossplane-contrib/provider-kafka/internal/options"
+	"github.com/crossplane-contrib/provider-kafka/internal/secrets"
+	"github.com/crossplane-contrib/provider-kafka/internal/shard"
+	"github.com/crossplane-contrib/provider-kafka/internal/shutdown"
+	"github.com/crossplane-contrib/provider-kafka/internal/watch"
+	kafkawebhook "github.com/crossplane-contrib/provider-kafka/internal/webhook"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/pkg/version"
 )
 
 func main() {
@@ -44,11 +76,67 @@ func main() {
 
 		syncPeriod       = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
 		pollInterval     = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
+		pollJitter       = app.Flag("poll-jitter", "Spread the polls of individual resources by up to this much around the poll interval, so that resources created at once do not poll in lockstep.").Default("10s").Duration()
 		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
+
+		metadataTimeout = app.Flag("kafka-metadata-timeout", "How long a single read-only Kafka admin operation, such as describing a topic, may take.").Default("10s").Duration()
+		mutationTimeout = app.Flag("kafka-mutation-timeout", "How long a single mutating Kafka admin operation, such as creating or altering a topic, may take.").Default("30s").Duration()
+		createTimeout   = app.Flag("kafka-create-timeout", "How long the brokers may take to create a topic once it passed validation.").Default("30s").Duration()
+		clusterTimeout  = app.Flag("kafka-cluster-metadata-timeout", "How long reading the metadata of all topics of a cluster may take. Raise it on clusters with tens of thousands of partitions.").Default("1m").Duration()
+		maxReadBytes    = app.Flag("kafka-max-read-bytes", "Size of the largest response accepted from a broker, which must fit the metadata of all topics of a cluster.").Default("104857600").Int32()
+
+		configVerifyGracePeriod = app.Flag("topic-config-verify-grace-period", "How long a topic config verified to be up to date is trusted without describing it again, unless the Topic changes. Zero describes it on every poll.").Default("0s").Duration()
+
+		auditSink         = app.Flag("audit-sink", "Where to record every Create, Update and Delete issued by the provider: none, log (JSON lines), or kafka.").Default("none").Enum("none", "log", "kafka")
+		auditLogFile      = app.Flag("audit-log-file", "File to append audit JSON lines to when --audit-sink=log. Defaults to stdout.").String()
+		auditKafkaBrokers = app.Flag("audit-kafka-brokers", "Brokers to produce audit events to when --audit-sink=kafka.").Strings()
+		auditKafkaTopic   = app.Flag("audit-kafka-topic", "Topic to produce audit events to when --audit-sink=kafka.").Default("provider-kafka-audit").String()
+
+		shutdownDrainTimeout = app.Flag("shutdown-drain-timeout", "How long to wait at shutdown for calls to Kafka and other external systems in flight, such as altering the config of a topic, to complete before cancelling them.").Default("30s").Envar("SHUTDOWN_DRAIN_TIMEOUT").Duration()
+
+		cacheCredentialSecrets = app.Flag("cache-credential-secrets", "Cache only the Secrets credentials are read from, each with an informer watching that Secret alone, stopped once it is no longer read, rather than every Secret of the cluster. Other Secrets, such as connection secrets, are then read from the API server.").Default("true").Envar("CACHE_CREDENTIAL_SECRETS").Bool()
+
+		watchNamespaces    = app.Flag("watch-namespace", "Only watch namespaced resources, such as the ConfigMaps topic configs and brokers are read from, in this namespace. Repeat to watch several namespaces. Secrets, if cached, are watched in every namespace. Every namespace is watched if unset.").Envar("WATCH_NAMESPACES").Strings()
+		watchLabelSelector = app.Flag("watch-label-selector", "Only watch and reconcile managed resources and ConfigMaps whose labels match this selector, such as shard=a. ProviderConfigs, ProviderConfigUsages, StoreConfigs, TopicPolicies, Namespaces and Secrets, if cached, are watched whatever their labels.").Envar("WATCH_LABEL_SELECTOR").String()
+
+		shardName = app.Flag("shard", "Only reconcile the ProviderConfigs labeled "+shard.LabelKey+" with this shard, and the managed resources using them, so that several deployments of the provider can split a fleet. Only unlabeled ProviderConfigs are reconciled if unset.").Envar("SHARD").String()
+
+		devFakeKafka = app.Flag("dev-fake-kafka", "Run an in-process fake Kafka cluster, for local development and CI only. Its brokers are exported as KAFKA_BROKERS to ProviderConfigs using the Environment credentials source.").Bool()
+
+		disableUsageTracking = app.Flag("disable-provider-config-usage-tracking", "Do not track which ProviderConfig each managed resource uses, to keep ProviderConfigUsages from bloating etcd at scale. ProviderConfigs can then be deleted while still in use.").Default("false").Envar("DISABLE_PROVIDER_CONFIG_USAGE_TRACKING").Bool()
+
+		deletionTimeout = app.Flag("deletion-timeout", "How long the deletion of a managed resource may be blocked, e.g. by unreachable brokers, before DeletionBlocked events are emitted. Overridden by the "+deletion.AnnotationKeyTimeout+" annotation. Zero disables the timeout.").Default("0").Envar("DELETION_TIMEOUT").Duration()
+		orphanOnTimeout = app.Flag("orphan-on-deletion-timeout", "Orphan the external resource of a managed resource whose deletion timed out, instead of only emitting events. Overridden by the "+deletion.AnnotationKeyOrphanOnTimeout+" annotation.").Default("false").Envar("ORPHAN_ON_DELETION_TIMEOUT").Bool()
+
+		topicDeletionRate      = app.Flag("topic-deletion-rate", "Delete at most this many topics per second, in batches, so that deleting many Topics at once does not spike the load of the brokers. Zero deletes each topic as soon as its Topic is.").Default("0").Envar("TOPIC_DELETION_RATE").Float64()
+		topicDeletionBatchSize = app.Flag("topic-deletion-batch-size", "How many topics may be deleted with a single request when --topic-deletion-rate is set.").Default("50").Envar("TOPIC_DELETION_BATCH_SIZE").Int()
+
+		maxConcurrentOperations = app.Flag("max-concurrent-operations-per-provider-config", "How many external resources, such as topics, may be created, updated or deleted at once against the cluster of each ProviderConfig. Zero does not limit them.").Default("5").Envar("MAX_CONCURRENT_OPERATIONS_PER_PROVIDER_CONFIG").Int()
+
+		replicationFactorApprovalBytes = app.Flag("replication-factor-approval-bytes", "How many bytes increasing the replication factor of a topic may copy to new replicas before the Topic must be annotated "+topicv1alpha1.AnnotationKeyApproveReplicationFactor+" to approve it.").Default("10737418240").Envar("REPLICATION_FACTOR_APPROVAL_BYTES").Int64()
+
+		topicSizeInStatus = app.Flag("topic-size-in-status", "Record the approximate number of records and size on disk of each topic in the status of its Topic. Adds ListOffsets and DescribeLogDirs requests to every poll.").Default("false").Envar("TOPIC_SIZE_IN_STATUS").Bool()
+
+		namespaceProviderConfig = app.Flag("namespace-provider-config", "Set the ProviderConfig of managed resources claimed from a namespace annotated with kafka.crossplane.io/provider-config to the one it names, rejecting resources referencing another. Requires permission to get namespaces.").Default("false").Envar("NAMESPACE_PROVIDER_CONFIG").Bool()
+
+		debugAddress = app.Flag("debug-address", "Serve the /debug/loglevel endpoint, which changes the log level at runtime, on this loopback address, such as 127.0.0.1:8081. The endpoint is not authenticated, so it is only served on loopback addresses, and not at all if unset.").Envar("DEBUG_ADDRESS").String()
+
+		webhookTLSCertDir = app.Flag("webhook-tls-cert-dir", "The directory of the TLS certificate and key the admission webhook server serves with. Webhooks are only served if it is set, which Crossplane does for packages that ship webhook configurations.").Envar("WEBHOOK_TLS_CERT_DIR").String()
+
+		enableExternalSecretStores    = app.Flag("enable-external-secret-stores", "Publish connection details to the External Secret Stores configured by StoreConfigs, in addition to Kubernetes Secrets.").Default("false").Envar("ENABLE_EXTERNAL_SECRET_STORES").Bool()
+		namespace                     = app.Flag("namespace", "Namespace used as the default scope of the default StoreConfig.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
+		enableTopicDeletionProtection = app.Flag("enable-topic-deletion-protection", "Refuse to delete topics that hold records or have active consumers unless the Topic allows data loss.").Default("false").Envar("ENABLE_TOPIC_DELETION_PROTECTION").Bool()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
-	zl := zap.New(zap.UseDevMode(*debug))
+	// The level can be changed at runtime through the /debug/loglevel endpoint
+	// of the debug server, without a restart changing the timing of whatever
+	// is being debugged.
+	level := uzap.NewAtomicLevelAt(uzap.InfoLevel)
+	if *debug {
+		level.SetLevel(uzap.DebugLevel)
+	}
+	zl := zap.New(zap.UseDevMode(*debug), zap.Level(level))
 	log := logging.NewLogrLogger(zl.WithName("provider-kafka"))
 	if *debug {
 		// The controller-runtime runs with a no-op logger by default. It is
@@ -57,37 +145,180 @@ func main() {
 		ctrl.SetLogger(zl)
 	}
 
+	// The build is reported by the provider_kafka_build_info metric and the
+	// /version endpoint of the metrics server.
+	served := runtime.NewScheme()
+	kingpin.FatalIfError(apis.AddToScheme(served), "Cannot add Kafka APIs to scheme")
+	build := version.Get(version.APIVersions(served, "kafka.crossplane.io"))
+	metrics.RecordBuildInfo(build)
+
 	log.Debug(
 		"Starting",
+		"version", build.Version,
+		"git-commit", build.GitCommit,
 		"sync-period", syncPeriod.String(),
 		"poll-interval", pollInterval.String(),
+		"poll-jitter", pollJitter.String(),
 		"max-reconcile-rate", maxReconcileRate,
+		"kafka-metadata-timeout", metadataTimeout.String(),
+		"kafka-mutation-timeout", mutationTimeout.String(),
+		"kafka-create-timeout", createTimeout.String(),
+		"kafka-cluster-metadata-timeout", clusterTimeout.String(),
+		"kafka-max-read-bytes", *maxReadBytes,
 	)
 
+	if *devFakeKafka {
+		fc, err := devcluster.Start()
+		kingpin.FatalIfError(err, "Cannot start fake Kafka cluster")
+		defer fc.Close()
+		kingpin.FatalIfError(os.Setenv(kafka.EnvBrokers, strings.Join(fc.ListenAddrs(), ",")), "Cannot export fake Kafka brokers")
+		log.Info("Running against an in-process fake Kafka cluster", "brokers", fc.ListenAddrs())
+	}
+
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
+	// Credential Secrets are cached on their own, so the client of the
+	// manager must not cache every Secret of the cluster. Other Secrets, such
+	// as connection secrets, are read from the API server.
+	clientOptions := client.Options{}
+	if *cacheCredentialSecrets {
+		clientOptions.Cache = &client.CacheOptions{DisableFor: []client.Object{&corev1.Secret{}}}
+	}
+
+	selector, err := labels.Parse(*watchLabelSelector)
+	kingpin.FatalIfError(err, "Cannot parse watch label selector")
+	scope := watch.Options{Namespaces: *watchNamespaces, Selector: selector}
+
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		LeaderElection:             *leaderElection,
-		LeaderElectionID:           "crossplane-leader-election-provider-kafka",
+		LeaderElectionID:           leaderElectionID(*shardName),
 		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
 		LeaseDuration:              func() *time.Duration { d := 60 * time.Second; return &d }(),
 		RenewDeadline:              func() *time.Duration { d := 50 * time.Second; return &d }(),
-		Cache: cache.Options{
+		// Leave the drainer time to close clients once the drain timed out.
+		GracefulShutdownTimeout: func() *time.Duration { d := *shutdownDrainTimeout + 10*time.Second; return &d }(),
+		Cache: scope.Cache(cache.Options{
 			SyncPeriod: syncPeriod,
+		}, &apisv1alpha1.ProviderConfig{}, &apisv1alpha1.ProviderConfigUsage{}, &apisv1alpha1.StoreConfig{}, &apisv1alpha1.TopicPolicy{}, &corev1.Namespace{}),
+		Client: clientOptions,
+		Metrics: metricsserver.Options{
+			ExtraHandlers: map[string]http.Handler{"/version": build},
 		},
+		WebhookServer: webhook.NewServer(webhook.Options{
+			CertDir: *webhookTLSCertDir,
+		}),
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Kafka APIs to scheme")
 
-	o := controller.Options{
-		Logger:                  log,
-		MaxConcurrentReconciles: *maxReconcileRate,
-		PollInterval:            *pollInterval,
-		GlobalRateLimiter:       ratelimiter.NewGlobal(*maxReconcileRate),
-		Features:                &feature.Flags{},
+	if *debugAddress != "" {
+		srv, err := debugserver.NewServer(*debugAddress, map[string]http.Handler{"/debug/loglevel": level})
+		kingpin.FatalIfError(err, "Cannot create debug server")
+		kingpin.FatalIfError(mgr.Add(srv), "Cannot add debug server")
+	}
+
+	drainer := shutdown.NewDrainer(*shutdownDrainTimeout, log)
+	kingpin.FatalIfError(mgr.Add(drainer), "Cannot add shutdown drainer")
+
+	var secretCache *secrets.Cache
+	if *cacheCredentialSecrets {
+		secretCache = secrets.NewCache(mgr)
+		kingpin.FatalIfError(mgr.Add(secretCache), "Cannot add credential Secrets cache")
+	}
+
+	// One cache of admin clients is shared by all controllers, so that the
+	// clients of a ProviderConfig trip a single circuit breaker when its
+	// brokers are unreachable. Its clients are closed once the calls in
+	// flight were drained at shutdown.
+	timeouts := kafka.Timeouts{
+		Metadata:        *metadataTimeout,
+		Mutation:        *mutationTimeout,
+		Create:          *createTimeout,
+		ClusterMetadata: *clusterTimeout,
+	}
+	clients := kafka.NewClientCache(timeouts).WithMaxReadBytes(*maxReadBytes).WithThrottleObserver(metrics.RecordThrottle).WithLogger(log)
+	drainer.OnDrained(clients.Close)
+
+	o := options.Options{
+		Options: controller.Options{
+			Logger:                  log,
+			MaxConcurrentReconciles: *maxReconcileRate,
+			PollInterval:            *pollInterval,
+			GlobalRateLimiter:       ratelimiter.NewGlobal(*maxReconcileRate),
+			Features:                &feature.Flags{},
+		},
+		Timeouts:                       timeouts,
+		ClientCache:                    clients,
+		ConfigVerifyGracePeriod:        *configVerifyGracePeriod,
+		PollJitter:                     *pollJitter,
+		DisableUsageTracking:           *disableUsageTracking,
+		Deletion:                       deletion.Policy{Timeout: *deletionTimeout, OrphanOnTimeout: *orphanOnTimeout},
+		TopicDeletionRate:              *topicDeletionRate,
+		TopicDeletionBatchSize:         *topicDeletionBatchSize,
+		TopicSizeInStatus:              *topicSizeInStatus,
+		ReplicationFactorApprovalBytes: *replicationFactorApprovalBytes,
+		NamespaceProviderConfig:        *namespaceProviderConfig,
+		Shutdown:                       drainer,
+		Cancellation:                   cancellation.NewRegistry(),
+		Secrets:                        secretCache,
+	}
+	// A deployment without a shard is the shard of the unlabeled
+	// ProviderConfigs, so that it never reconciles those of other shards.
+	o.Shard = shard.NewFilter(mgr.GetClient(), *shardName)
+	if *maxConcurrentOperations > 0 {
+		o.Concurrency = concurrency.NewLimiter(*maxConcurrentOperations)
+	}
+
+	switch *auditSink {
+	case "log":
+		w := os.Stdout
+		if *auditLogFile != "" {
+			w, err = os.OpenFile(*auditLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+			kingpin.FatalIfError(err, "Cannot open audit log file")
+			defer w.Close() //nolint:errcheck
+		}
+		o.Audit = audit.NewJSONSink(w)
+	case "kafka":
+		s, err := audit.NewKafkaSink(*auditKafkaBrokers, *auditKafkaTopic)
+		kingpin.FatalIfError(err, "Cannot create Kafka audit sink")
+		defer s.Close()
+		o.Audit = s
+	}
+
+	if *enableTopicDeletionProtection {
+		o.Features.Enable(features.EnableAlphaTopicDeletionProtection)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaTopicDeletionProtection)
+	}
+
+	if *enableExternalSecretStores {
+		o.Features.Enable(features.EnableAlphaExternalSecretStores)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaExternalSecretStores)
+
+		// Ensure the default StoreConfig exists, storing connection details
+		// in Kubernetes Secrets.
+		kingpin.FatalIfError(resource.Ignore(kerrors.IsAlreadyExists, mgr.GetClient().Create(context.Background(), &apisv1alpha1.StoreConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: apisv1alpha1.StoreConfigSpec{
+				SecretStoreConfig: xpv1.SecretStoreConfig{DefaultScope: *namespace},
+			},
+		})), "Cannot create default StoreConfig")
 	}
 
 	kingpin.FatalIfError(kafkacontroller.Setup(mgr, o), "Cannot setup Kafka controllers")
+	if *webhookTLSCertDir != "" {
+		kingpin.FatalIfError(kafkawebhook.Setup(mgr), "Cannot setup Kafka webhooks")
+	}
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
+
+// leaderElectionID returns the ID of the leader election of the deployments of
+// the supplied shard, so that each shard elects a leader of its own. The
+// deployments without a shard only reconcile the unlabeled ProviderConfigs,
+// so their leader never reconciles the resources of a shard's leader.
+func leaderElectionID(shard string) string {
+	if shard == "" {
+		return "crossplane-leader-election-provider-kafka"
+	}
+	return "crossplane-leader-election-provider-kafka-" + shard
+}
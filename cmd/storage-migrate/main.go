@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// storage-migrate migrates Kafka provider resources to the storage version of
+// their CRD, e.g. after an upgrade changed it, so that the versions they were
+// stored as before can stop being served:
+//
+//	storage-migrate --crd topics.topic.kafka.crossplane.io
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/internal/migration"
+)
+
+func main() {
+	var (
+		app    = kingpin.New(filepath.Base(os.Args[0]), "Migrate Kafka provider resources to the storage version of their CRD.").DefaultEnvars()
+		crds   = app.Flag("crd", "Name of a CRD whose resources to migrate. May be repeated.").Default("topics.topic.kafka.crossplane.io").Strings()
+		dryRun = app.Flag("dry-run", "Only count the resources that would be migrated.").Bool()
+	)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	s := runtime.NewScheme()
+	kingpin.FatalIfError(extv1.AddToScheme(s), "Cannot add CRDs to scheme")
+	cfg, err := ctrl.GetConfig()
+	kingpin.FatalIfError(err, "Cannot get API server rest config")
+	kube, err := client.New(cfg, client.Options{Scheme: s})
+	kingpin.FatalIfError(err, "Cannot create Kubernetes client")
+
+	for _, name := range *crds {
+		r, err := migration.Migrate(context.Background(), kube, name, *dryRun)
+		kingpin.FatalIfError(err, "Cannot migrate %s", name)
+		verb := "Migrated"
+		if *dryRun {
+			verb = "Would migrate"
+		}
+		fmt.Printf("%s %d resources of %s from stored versions %v to %s\n", verb, r.Migrated, name, r.StoredVersions, r.StorageVersion)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// strimzi-convert converts the KafkaTopics of the Strimzi Topic Operator to
+// Topics of the Kafka provider, e.g.
+//
+//	kubectl get kafkatopics -n kafka -o yaml | strimzi-convert | kubectl apply -f -
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/crossplane-contrib/provider-kafka/internal/strimzi"
+)
+
+func main() {
+	var (
+		app            = kingpin.New(filepath.Base(os.Args[0]), "Convert Strimzi KafkaTopics to Kafka provider Topics.").DefaultEnvars()
+		providerConfig = app.Flag("provider-config", "ProviderConfig every Topic references. Defaults to the Strimzi cluster of each KafkaTopic, from its "+strimzi.LabelKeyCluster+" label.").String()
+		namePrefix     = app.Flag("name-prefix", "Prefix of the names of Topics, e.g. to tell apart KafkaTopics of the same name in different namespaces.").String()
+		files          = app.Arg("files", "Files holding KafkaTopics, or Lists of them. Read from stdin if none is supplied.").ExistingFiles()
+	)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	var kts []strimzi.KafkaTopic
+	read := func(data []byte) {
+		parsed, err := strimzi.Parse(data)
+		kingpin.FatalIfError(err, "Cannot parse KafkaTopics")
+		kts = append(kts, parsed...)
+	}
+	if len(*files) == 0 {
+		data, err := io.ReadAll(os.Stdin)
+		kingpin.FatalIfError(err, "Cannot read stdin")
+		read(data)
+	}
+	for _, f := range *files {
+		data, err := os.ReadFile(filepath.Clean(f))
+		kingpin.FatalIfError(err, "Cannot read %s", f)
+		read(data)
+	}
+
+	out, err := strimzi.ConvertAll(kts, strimzi.Options{ProviderConfig: *providerConfig, NamePrefix: *namePrefix})
+	kingpin.FatalIfError(err, "Cannot convert KafkaTopics")
+	_, err = os.Stdout.Write(out)
+	kingpin.FatalIfError(err, "Cannot write Topics")
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// generate-xrd emits a CompositeResourceDefinition and a Composition that
+// expose a simplified, claimable Topic. The schema of each claim parameter is
+// copied from the generated Topic CRD, so that running it after controller-gen
+// keeps both in sync with the Topic API types.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+const (
+	header = "# Code generated by hack/generate-xrd. DO NOT EDIT.\n"
+
+	// retentionKey is the topic config key the retention parameter sets.
+	retentionKey = "retention.ms"
+
+	errReadCRD      = "cannot read Topic CRD"
+	errParseCRD     = "cannot parse Topic CRD"
+	errNoVersion    = "Topic CRD does not serve version %s"
+	errNoField      = "Topic CRD has no field %s"
+	errMarshal      = "cannot marshal %s"
+	errWriteOutput  = "cannot write %s"
+	errInvalidGroup = "group %q must contain at least one dot"
+)
+
+// Options of a generated XRD and Composition.
+type Options struct {
+	// Group of the composite resource and its claim.
+	Group string
+	// ReplicationFactor of every topic composed.
+	ReplicationFactor int
+}
+
+func main() {
+	crd := flag.String("crd", "package/crds/"+v1alpha1.Group+"_topics.yaml", "Path of the generated Topic CRD.")
+	out := flag.String("output", "examples/composition", "Directory the XRD and Composition are written to.")
+	o := Options{}
+	flag.StringVar(&o.Group, "group", "platform.kafka.crossplane.io", "API group of the composite resource and its claim.")
+	flag.IntVar(&o.ReplicationFactor, "replication-factor", 3, "Replication factor of every topic composed.")
+	flag.Parse()
+
+	if err := run(*crd, *out, o); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(crdPath, out string, o Options) error {
+	b, err := os.ReadFile(crdPath) // nolint: gosec
+	if err != nil {
+		return errors.Wrap(err, errReadCRD)
+	}
+	crd := &extv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(b, crd); err != nil {
+		return errors.Wrap(err, errParseCRD)
+	}
+	files, err := Generate(crd, o)
+	if err != nil {
+		return err
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(out, name), data, 0o644); err != nil { // nolint: gosec
+			return errors.Wrapf(err, errWriteOutput, name)
+		}
+	}
+	return nil
+}
+
+// Generate returns the XRD and Composition exposing a claimable Topic built
+// from the supplied Topic CRD, keyed by the name of the file they are
+// written to.
+func Generate(crd *extv1.CustomResourceDefinition, o Options) (map[string][]byte, error) {
+	if !strings.Contains(o.Group, ".") {
+		return nil, errors.Errorf(errInvalidGroup, o.Group)
+	}
+	var schema *extv1.JSONSchemaProps
+	for i := range crd.Spec.Versions {
+		if v := crd.Spec.Versions[i]; v.Name == v1alpha1.Version && v.Schema != nil {
+			schema = v.Schema.OpenAPIV3Schema
+		}
+	}
+	if schema == nil {
+		return nil, errors.Errorf(errNoVersion, v1alpha1.Version)
+	}
+	partitions, err := field(schema, "spec", "forProvider", "partitions")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := field(schema, "spec", "forProvider", "config"); err != nil {
+		return nil, err
+	}
+	if _, err := field(schema, "spec", "forProvider", "replicationFactor"); err != nil {
+		return nil, err
+	}
+
+	xrd, err := marshal("CompositeResourceDefinition", definition(o, *partitions))
+	if err != nil {
+		return nil, err
+	}
+	comp, err := marshal("Composition", composition(o))
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{"definition.yaml": xrd, "composition.yaml": comp}, nil
+}
+
+// field returns the schema of the field at the supplied path.
+func field(s *extv1.JSONSchemaProps, path ...string) (*extv1.JSONSchemaProps, error) {
+	for _, p := range path {
+		f, ok := s.Properties[p]
+		if !ok {
+			return nil, errors.Errorf(errNoField, strings.Join(path, "."))
+		}
+		s = &f
+	}
+	return s, nil
+}
+
+// definition returns an XRD whose claim has a topic name, a number of
+// partitions and a retention.
+func definition(o Options, partitions extv1.JSONSchemaProps) map[string]any {
+	// Only the validation of the Topic's field applies; its description
+	// mentions fields the claim does not have.
+	partitions.Description = "Partitions of the topic."
+	minRetention := float64(-1)
+	params := extv1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"name", "partitions"},
+		Properties: map[string]extv1.JSONSchemaProps{
+			"name": {
+				Type:        "string",
+				Description: "Name of the topic in Kafka.",
+				MinLength:   ptr(int64(1)),
+				MaxLength:   ptr(int64(249)),
+				Pattern:     `^[a-zA-Z0-9._-]+$`,
+			},
+			"partitions": partitions,
+			"retentionMs": {
+				Type:        "integer",
+				Description: "RetentionMs is how long records are retained, in milliseconds. -1 retains them forever. Defaults to the broker's " + retentionKey + ".",
+				Minimum:     &minRetention,
+			},
+		},
+	}
+	return map[string]any{
+		"apiVersion": "apiextensions.crossplane.io/v1",
+		"kind":       "CompositeResourceDefinition",
+		"metadata":   map[string]any{"name": "xtopics." + o.Group},
+		"spec": map[string]any{
+			"group":                 o.Group,
+			"names":                 map[string]any{"kind": "XTopic", "plural": "xtopics"},
+			"claimNames":            map[string]any{"kind": v1alpha1.TopicKind, "plural": "topics"},
+			"defaultCompositionRef": map[string]any{"name": "xtopics." + o.Group},
+			"versions": []any{map[string]any{
+				"name":          "v1alpha1",
+				"served":        true,
+				"referenceable": true,
+				"schema": map[string]any{"openAPIV3Schema": extv1.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]extv1.JSONSchemaProps{
+						"spec": {
+							Type:       "object",
+							Required:   []string{"parameters"},
+							Properties: map[string]extv1.JSONSchemaProps{"parameters": params},
+						},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+// composition returns a Composition of a single Topic of this provider.
+func composition(o Options) map[string]any {
+	patch := func(from, to string, transforms ...any) map[string]any {
+		p := map[string]any{"type": "FromCompositeFieldPath", "fromFieldPath": from, "toFieldPath": to}
+		if len(transforms) > 0 {
+			p["transforms"] = transforms
+		}
+		return p
+	}
+	return map[string]any{
+		"apiVersion": "apiextensions.crossplane.io/v1",
+		"kind":       "Composition",
+		"metadata": map[string]any{
+			"name":   "xtopics." + o.Group,
+			"labels": map[string]any{"provider": "provider-kafka"},
+		},
+		"spec": map[string]any{
+			"compositeTypeRef": map[string]any{"apiVersion": o.Group + "/v1alpha1", "kind": "XTopic"},
+			"resources": []any{map[string]any{
+				"name": "topic",
+				"base": map[string]any{
+					"apiVersion": v1alpha1.SchemeGroupVersion.String(),
+					"kind":       v1alpha1.TopicKind,
+					"spec": map[string]any{
+						"forProvider": map[string]any{"replicationFactor": o.ReplicationFactor},
+					},
+				},
+				"patches": []any{
+					patch("spec.parameters.name", "metadata.annotations[crossplane.io/external-name]"),
+					patch("spec.parameters.partitions", "spec.forProvider.partitions"),
+					patch("spec.parameters.retentionMs", "spec.forProvider.config["+retentionKey+"]",
+						map[string]any{"type": "convert", "convert": map[string]any{"toType": "string"}}),
+				},
+			}},
+		},
+	}
+}
+
+func marshal(kind string, obj any) ([]byte, error) {
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, errMarshal, kind)
+	}
+	return append([]byte(header), append(bytes.TrimSpace(b), '\n')...), nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// TestGenerated fails when the examples are out of date, e.g. because the
+// Topic API changed without running go generate.
+func TestGenerated(t *testing.T) {
+	b, err := os.ReadFile("../../package/crds/topic.kafka.crossplane.io_topics.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	crd := &extv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(b, crd); err != nil {
+		t.Fatal(err)
+	}
+	files, err := Generate(crd, Options{Group: "platform.kafka.crossplane.io", ReplicationFactor: 3})
+	if err != nil {
+		t.Fatalf("Generate(...): %v", err)
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join("../../examples/composition", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(string(want), string(got)); diff != "" {
+			t.Errorf("%s is out of date, run go generate: -want, +got:\n%s", name, diff)
+		}
+	}
+}
+
+func TestGenerateMissingField(t *testing.T) {
+	crd := &extv1.CustomResourceDefinition{}
+	crd.Spec.Versions = []extv1.CustomResourceDefinitionVersion{{
+		Name:   "v1alpha1",
+		Schema: &extv1.CustomResourceValidation{OpenAPIV3Schema: &extv1.JSONSchemaProps{}},
+	}}
+	if _, err := Generate(crd, Options{Group: "platform.example.org"}); err == nil {
+		t.Error("Generate(...): want error for a CRD without spec.forProvider.partitions")
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package aclexport renders the ACLs of a cluster as AccessControlLists, to
+// adopt ACLs created outside of the Kafka provider.
+package aclexport
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka/acl"
+)
+
+const (
+	// maxNameLength is the longest name of an AccessControlList, which
+	// must be a valid label value.
+	maxNameLength = 63
+	// hashLength is the length of the suffix telling apart ACLs whose names
+	// are otherwise the same, e.g. as they only differ in host.
+	hashLength = 8
+
+	errMarshal = "cannot marshal AccessControlList %s"
+)
+
+// separators are runs of characters other than lowercase letters and digits,
+// which are replaced by a single dash in the name of an AccessControlList.
+var separators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Options of an export.
+type Options struct {
+	// ProviderConfig every AccessControlList references.
+	ProviderConfig string
+	// NamePrefix is prepended to the names of AccessControlLists.
+	NamePrefix string
+	// ResourcePrefix only exports ACLs whose resource name starts with it.
+	ResourcePrefix string
+}
+
+// Convert returns the AccessControlList managing the supplied ACL. Its
+// external name identifies the ACL, so that it observes the existing ACL
+// rather than creating it.
+func Convert(a acl.AccessControlList, o Options) (*v1alpha1.AccessControlList, error) {
+	extname, err := acl.ConvertToJSON(&a)
+	if err != nil {
+		return nil, err
+	}
+	cr := &v1alpha1.AccessControlList{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: v1alpha1.AccessControlListKind},
+		ObjectMeta: metav1.ObjectMeta{Name: name(a, extname, o.NamePrefix)},
+		Spec: v1alpha1.AccessControlListSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: o.ProviderConfig}},
+			ForProvider: v1alpha1.AccessControlListParameters{
+				ResourceName:              a.ResourceName,
+				ResourceType:              a.ResourceType,
+				ResourcePrincipal:         a.ResourcePrincipal,
+				ResourceHost:              a.ResourceHost,
+				ResourceOperation:         a.ResourceOperation,
+				ResourcePermissionType:    a.ResourcePermissionType,
+				ResourcePatternTypeFilter: a.ResourcePatternTypeFilter,
+			},
+		},
+	}
+	meta.SetExternalName(cr, extname)
+	return cr, nil
+}
+
+// name returns a readable name of the AccessControlList managing the supplied
+// ACL, made of its principal, resource and operation, and suffixed by a hash
+// of its external name to be unique.
+func name(a acl.AccessControlList, extname, prefix string) string {
+	principal := a.ResourcePrincipal
+	if i := strings.Index(principal, ":"); i >= 0 {
+		principal = principal[i+1:]
+	}
+	parts := []string{principal, a.ResourceName, a.ResourceOperation}
+	if a.ResourcePermissionType == "Deny" {
+		parts = append(parts, "deny")
+	}
+	n := prefix + separators.ReplaceAllString(strings.ToLower(strings.Join(parts, "-")), "-")
+
+	h := sha256.Sum256([]byte(extname))
+	suffix := "-" + hex.EncodeToString(h[:])[:hashLength]
+	if len(n) > maxNameLength-len(suffix) {
+		n = n[:maxNameLength-len(suffix)]
+	}
+	return strings.Trim(n, "-") + suffix
+}
+
+// ExportAll returns the YAML documents of the AccessControlLists managing the
+// supplied ACLs whose resource name starts with the ResourcePrefix, in the
+// order of the ACLs.
+func ExportAll(acls []acl.AccessControlList, o Options) ([]byte, error) {
+	out := &bytes.Buffer{}
+	for _, a := range acls {
+		if !strings.HasPrefix(a.ResourceName, o.ResourcePrefix) {
+			continue
+		}
+		cr, err := Convert(a, o)
+		if err != nil {
+			return nil, err
+		}
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cr)
+		if err != nil {
+			return nil, errors.Wrapf(err, errMarshal, cr.GetName())
+		}
+		// Neither is meaningful for an AccessControlList that was not
+		// created yet.
+		delete(u, "status")
+		delete(u["metadata"].(map[string]any), "creationTimestamp")
+		b, err := yaml.Marshal(u)
+		if err != nil {
+			return nil, errors.Wrapf(err, errMarshal, cr.GetName())
+		}
+		if out.Len() > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(b)
+	}
+	return out.Bytes(), nil
+}
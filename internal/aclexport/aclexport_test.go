@@ -0,0 +1,157 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aclexport
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka/acl"
+)
+
+func TestExportAll(t *testing.T) {
+	read := acl.AccessControlList{
+		ResourceName:              "payments.",
+		ResourceType:              "Topic",
+		ResourcePrincipal:         "User:payments",
+		ResourceOperation:         "Read",
+		ResourcePermissionType:    "Allow",
+		ResourcePatternTypeFilter: "Prefixed",
+	}
+	deny := acl.AccessControlList{
+		ResourceName:              "kafka-cluster",
+		ResourceType:              "Cluster",
+		ResourcePrincipal:         "User:payments",
+		ResourceHost:              "10.0.0.1",
+		ResourceOperation:         "IdempotentWrite",
+		ResourcePermissionType:    "Deny",
+		ResourcePatternTypeFilter: "Literal",
+	}
+
+	cases := map[string]struct {
+		reason string
+		acls   []acl.AccessControlList
+		o      Options
+		want   string
+	}{
+		"Adopt": {
+			reason: "ACLs should be exported as AccessControlLists whose external names identify them, named after their principal, resource and operation.",
+			acls:   []acl.AccessControlList{read, deny},
+			o:      Options{ProviderConfig: "example", NamePrefix: "main-"},
+			want: `apiVersion: acl.kafka.crossplane.io/v1alpha1
+kind: AccessControlList
+metadata:
+  annotations:
+    crossplane.io/external-name: '{"ResourceName":"payments.","ResourceType":"Topic","ResourcePrincipal":"User:payments","ResourceHost":"","ResourceOperation":"Read","ResourcePermissionType":"Allow","ResourcePatternTypeFilter":"Prefixed"}'
+  name: main-payments-payments-read-dd09cdea
+spec:
+  forProvider:
+    resourceName: payments.
+    resourceOperation: Read
+    resourcePatternTypeFilter: Prefixed
+    resourcePermissionType: Allow
+    resourcePrincipal: User:payments
+    resourceType: Topic
+  providerConfigRef:
+    name: example
+---
+apiVersion: acl.kafka.crossplane.io/v1alpha1
+kind: AccessControlList
+metadata:
+  annotations:
+    crossplane.io/external-name: '{"ResourceName":"kafka-cluster","ResourceType":"Cluster","ResourcePrincipal":"User:payments","ResourceHost":"10.0.0.1","ResourceOperation":"IdempotentWrite","ResourcePermissionType":"Deny","ResourcePatternTypeFilter":"Literal"}'
+  name: main-payments-kafka-cluster-idempotentwrite-deny-a292f3bd
+spec:
+  forProvider:
+    resourceHost: 10.0.0.1
+    resourceName: kafka-cluster
+    resourceOperation: IdempotentWrite
+    resourcePatternTypeFilter: Literal
+    resourcePermissionType: Deny
+    resourcePrincipal: User:payments
+    resourceType: Cluster
+  providerConfigRef:
+    name: example
+`,
+		},
+		"ResourcePrefix": {
+			reason: "Only ACLs of resources whose name starts with the resource prefix should be exported.",
+			acls:   []acl.AccessControlList{read, deny},
+			o:      Options{ProviderConfig: "example", ResourcePrefix: "kafka-"},
+			want: `apiVersion: acl.kafka.crossplane.io/v1alpha1
+kind: AccessControlList
+metadata:
+  annotations:
+    crossplane.io/external-name: '{"ResourceName":"kafka-cluster","ResourceType":"Cluster","ResourcePrincipal":"User:payments","ResourceHost":"10.0.0.1","ResourceOperation":"IdempotentWrite","ResourcePermissionType":"Deny","ResourcePatternTypeFilter":"Literal"}'
+  name: payments-kafka-cluster-idempotentwrite-deny-a292f3bd
+spec:
+  forProvider:
+    resourceHost: 10.0.0.1
+    resourceName: kafka-cluster
+    resourceOperation: IdempotentWrite
+    resourcePatternTypeFilter: Literal
+    resourcePermissionType: Deny
+    resourcePrincipal: User:payments
+    resourceType: Cluster
+  providerConfigRef:
+    name: example
+`,
+		},
+		"LongName": {
+			reason: "Names should be truncated to fit a label value, keeping their hash.",
+			acls: []acl.AccessControlList{{
+				ResourceName:              "a-topic-whose-name-is-far-too-long-to-fit-the-name-of-an-access-control-list",
+				ResourceType:              "Topic",
+				ResourcePrincipal:         "User:payments",
+				ResourceOperation:         "Write",
+				ResourcePermissionType:    "Allow",
+				ResourcePatternTypeFilter: "Literal",
+			}},
+			o: Options{ProviderConfig: "example"},
+			want: `apiVersion: acl.kafka.crossplane.io/v1alpha1
+kind: AccessControlList
+metadata:
+  annotations:
+    crossplane.io/external-name: '{"ResourceName":"a-topic-whose-name-is-far-too-long-to-fit-the-name-of-an-access-control-list","ResourceType":"Topic","ResourcePrincipal":"User:payments","ResourceHost":"","ResourceOperation":"Write","ResourcePermissionType":"Allow","ResourcePatternTypeFilter":"Literal"}'
+  name: payments-a-topic-whose-name-is-far-too-long-to-fit-the-0dc02e6c
+spec:
+  forProvider:
+    resourceName: a-topic-whose-name-is-far-too-long-to-fit-the-name-of-an-access-control-list
+    resourceOperation: Write
+    resourcePatternTypeFilter: Literal
+    resourcePermissionType: Allow
+    resourcePrincipal: User:payments
+    resourceType: Topic
+  providerConfigRef:
+    name: example
+`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ExportAll(tc.acls, tc.o)
+			if err != nil {
+				t.Fatalf("\n%s\nExportAll(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("\n%s\nExportAll(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records every change the Kafka provider makes to external
+// resources, so that who changed what on a cluster can be evidenced later.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const (
+	errMarshalEvent = "cannot marshal audit event"
+	errWriteEvent   = "cannot write audit event"
+	errProduceEvent = "cannot produce audit event"
+	errNewProducer  = "cannot create audit event producer"
+	errNoBrokers    = "at least one broker is required to produce audit events"
+)
+
+// An Operation is a change made to an external resource.
+type Operation string
+
+// Audited operations.
+const (
+	OperationCreate Operation = "Create"
+	OperationUpdate Operation = "Update"
+	OperationDelete Operation = "Delete"
+)
+
+// A Result is the outcome of an Operation.
+type Result string
+
+// Operation results.
+const (
+	ResultSuccess Result = "Success"
+	ResultFailure Result = "Failure"
+)
+
+// An Event records a single Operation issued by the provider.
+type Event struct {
+	Time           time.Time         `json:"time"`
+	Operation      Operation         `json:"operation"`
+	Kind           string            `json:"kind"`
+	Name           string            `json:"name"`
+	UID            string            `json:"uid"`
+	Generation     int64             `json:"generation"`
+	ExternalName   string            `json:"externalName,omitempty"`
+	ProviderConfig string            `json:"providerConfig,omitempty"`
+	Annotations    map[string]string `json:"annotations,omitempty"`
+	Result         Result            `json:"result"`
+	// Error is the error returned by the brokers, if the Operation failed.
+	Error string `json:"error,omitempty"`
+}
+
+// A Sink records audit events.
+type Sink interface {
+	Record(ctx context.Context, e Event) error
+}
+
+// A JSONSink writes audit events to a stream, one JSON object per line.
+type JSONSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONSink returns a JSONSink writing to the supplied writer.
+func NewJSONSink(w io.Writer) *JSONSink {
+	return &JSONSink{w: w}
+}
+
+// Record writes the supplied event.
+func (s *JSONSink) Record(_ context.Context, e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, errMarshalEvent)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(b, '\n'))
+	return errors.Wrap(err, errWriteEvent)
+}
+
+// A KafkaSink produces audit events to a Kafka topic, as JSON keyed by the
+// kind and name of the managed resource.
+type KafkaSink struct {
+	client *kgo.Client
+}
+
+// NewKafkaSink returns a KafkaSink producing to the supplied topic.
+func NewKafkaSink(brokers []string, topic string) (*KafkaSink, error) {
+	if len(brokers) == 0 {
+		return nil, errors.New(errNoBrokers)
+	}
+	cl, err := kgo.NewClient(kgo.SeedBrokers(brokers...), kgo.DefaultProduceTopic(topic))
+	if err != nil {
+		return nil, errors.Wrap(err, errNewProducer)
+	}
+	return &KafkaSink{client: cl}, nil
+}
+
+// Record produces the supplied event, waiting for it to be acknowledged.
+func (s *KafkaSink) Record(ctx context.Context, e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, errMarshalEvent)
+	}
+	r := &kgo.Record{Key: []byte(e.Kind + "/" + e.Name), Value: b}
+	return errors.Wrap(s.client.ProduceSync(ctx, r).FirstErr(), errProduceEvent)
+}
+
+// Close flushes and closes the underlying producer.
+func (s *KafkaSink) Close() {
+	s.client.Close()
+}
+
+// NewConnecter returns an ExternalConnecter whose clients record every
+// Create, Update and Delete of the supplied kind to the supplied sink. The
+// supplied connecter is returned unchanged if the sink is nil.
+func NewConnecter(c managed.ExternalConnecter, kind string, s Sink, log logging.Logger) managed.ExternalConnecter {
+	if s == nil {
+		return c
+	}
+	return &connecter{ExternalConnecter: c, kind: kind, sink: s, log: log}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	kind string
+	sink Sink
+	log  logging.Logger
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: ec, kind: c.kind, sink: c.sink, log: c.log}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	kind string
+	sink Sink
+	log  logging.Logger
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, err := e.ExternalClient.Create(ctx, mg)
+	e.record(ctx, OperationCreate, mg, err)
+	return cr, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := e.ExternalClient.Update(ctx, mg)
+	e.record(ctx, OperationUpdate, mg, err)
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	err := e.ExternalClient.Delete(ctx, mg)
+	e.record(ctx, OperationDelete, mg, err)
+	return err
+}
+
+// record records the supplied operation. The operation has already been
+// issued by now, so failing to record it is logged rather than returned.
+func (e *external) record(ctx context.Context, op Operation, mg resource.Managed, err error) {
+	ev := NewEvent(op, e.kind, mg, err)
+	if rerr := e.sink.Record(ctx, ev); rerr != nil {
+		e.log.Info("Cannot record audit event", "error", rerr, "operation", op, "kind", e.kind, "name", mg.GetName())
+	}
+}
+
+// NewEvent returns an event recording that the supplied operation was issued
+// for the supplied managed resource, and returned the supplied error.
+func NewEvent(op Operation, kind string, mg resource.Managed, err error) Event {
+	e := Event{
+		Time:         time.Now().UTC(),
+		Operation:    op,
+		Kind:         kind,
+		Name:         mg.GetName(),
+		UID:          string(mg.GetUID()),
+		Generation:   mg.GetGeneration(),
+		ExternalName: meta.GetExternalName(mg),
+		Annotations:  userAnnotations(mg.GetAnnotations()),
+		Result:       ResultSuccess,
+	}
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		e.ProviderConfig = ref.Name
+	}
+	if err != nil {
+		e.Result = ResultFailure
+		e.Error = err.Error()
+	}
+	return e
+}
+
+// userAnnotations returns the supplied annotations, except for those managed
+// by Crossplane and kubectl.
+func userAnnotations(a map[string]string) map[string]string {
+	out := map[string]string{}
+	for k, v := range a {
+		if strings.HasPrefix(k, "crossplane.io/") || strings.HasPrefix(k, "kubectl.kubernetes.io/") {
+			continue
+		}
+		out[k] = v
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
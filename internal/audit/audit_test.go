@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+type recordingSink struct {
+	events []Event
+}
+
+func (s *recordingSink) Record(_ context.Context, e Event) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+func topic() *v1alpha1.Topic {
+	t := &v1alpha1.Topic{}
+	t.SetName("orders")
+	t.SetUID("uid-1")
+	t.SetGeneration(2)
+	t.SetProviderConfigReference(&xpv1.Reference{Name: "default"})
+	t.SetAnnotations(map[string]string{
+		"team": "payments",
+		"kubectl.kubernetes.io/last-applied-configuration": "{}",
+	})
+	meta.SetExternalName(t, "orders.v1")
+	return t
+}
+
+func TestConnecter(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		client managed.ExternalClient
+		call   func(ec managed.ExternalClient, mg resource.Managed)
+		want   []Event
+	}{
+		"CreateSucceeded": {
+			reason: "A successful Create should be recorded with the acting resource and its user annotations.",
+			client: &managed.ExternalClientFns{
+				CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+					return managed.ExternalCreation{}, nil
+				},
+			},
+			call: func(ec managed.ExternalClient, mg resource.Managed) {
+				_, _ = ec.Create(context.Background(), mg)
+			},
+			want: []Event{{
+				Operation:      OperationCreate,
+				Kind:           v1alpha1.TopicKind,
+				Name:           "orders",
+				UID:            "uid-1",
+				Generation:     2,
+				ExternalName:   "orders.v1",
+				ProviderConfig: "default",
+				Annotations:    map[string]string{"team": "payments"},
+				Result:         ResultSuccess,
+			}},
+		},
+		"DeleteFailed": {
+			reason: "A failed Delete should be recorded with the error returned by the brokers.",
+			client: &managed.ExternalClientFns{
+				DeleteFn: func(_ context.Context, _ resource.Managed) error {
+					return errBoom
+				},
+			},
+			call: func(ec managed.ExternalClient, mg resource.Managed) {
+				_ = ec.Delete(context.Background(), mg)
+			},
+			want: []Event{{
+				Operation:      OperationDelete,
+				Kind:           v1alpha1.TopicKind,
+				Name:           "orders",
+				UID:            "uid-1",
+				Generation:     2,
+				ExternalName:   "orders.v1",
+				ProviderConfig: "default",
+				Annotations:    map[string]string{"team": "payments"},
+				Result:         ResultFailure,
+				Error:          "boom",
+			}},
+		},
+		"ObserveNotRecorded": {
+			reason: "Observe does not change anything, so it should not be recorded.",
+			client: &managed.ExternalClientFns{
+				ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+					return managed.ExternalObservation{}, nil
+				},
+			},
+			call: func(ec managed.ExternalClient, mg resource.Managed) {
+				_, _ = ec.Observe(context.Background(), mg)
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &recordingSink{}
+			c := NewConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return tc.client, nil
+			}), v1alpha1.TopicKind, s, logging.NewNopLogger())
+
+			mg := topic()
+			ec, err := c.Connect(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Connect(...): %v", err)
+			}
+			tc.call(ec, mg)
+
+			if diff := cmp.Diff(tc.want, s.events, cmpopts.IgnoreFields(Event{}, "Time")); diff != "" {
+				t.Errorf("\n%s\nNewConnecter(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestJSONSink(t *testing.T) {
+	b := &bytes.Buffer{}
+	s := NewJSONSink(b)
+	for _, op := range []Operation{OperationCreate, OperationUpdate} {
+		if err := s.Record(context.Background(), NewEvent(op, v1alpha1.TopicKind, topic(), nil)); err != nil {
+			t.Fatalf("Record(...): %v", err)
+		}
+	}
+
+	var got []Operation
+	d := json.NewDecoder(b)
+	for d.More() {
+		e := Event{}
+		if err := d.Decode(&e); err != nil {
+			t.Fatalf("Decode(...): %v", err)
+		}
+		got = append(got, e.Operation)
+	}
+	if diff := cmp.Diff([]Operation{OperationCreate, OperationUpdate}, got); diff != "" {
+		t.Errorf("JSONSink.Record(...): -want operations, +got operations:\n%s\n", diff)
+	}
+}
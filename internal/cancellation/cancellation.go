@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cancellation cancels the calls of controllers to external systems
+// for managed resources that are deleted while the calls are in flight, so
+// that slow brokers do not keep workers busy with resources that are gone.
+package cancellation
+
+import (
+	"context"
+	"sync"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const errWatch = "cannot watch %T for deletion"
+
+// A Registry tracks the calls in flight for each managed resource, and
+// cancels them once the resource is deleted.
+type Registry struct {
+	mu    sync.Mutex
+	next  uint64
+	calls map[types.UID]map[uint64]context.CancelFunc
+}
+
+// NewRegistry returns a Registry tracking no calls.
+func NewRegistry() *Registry {
+	return &Registry{calls: map[types.UID]map[uint64]context.CancelFunc{}}
+}
+
+// track returns a context derived from the supplied one that is cancelled once
+// the supplied managed resource is deleted, and a function to call once the
+// call using it returned. Calls for a resource that is already being deleted,
+// e.g. deleting its external resource, are not cancelled by its deletion.
+func (r *Registry) track(ctx context.Context, mg resource.Managed) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	if meta.WasDeleted(mg) {
+		return ctx, cancel
+	}
+
+	uid := mg.GetUID()
+	r.mu.Lock()
+	id := r.next
+	r.next++
+	if r.calls[uid] == nil {
+		r.calls[uid] = map[uint64]context.CancelFunc{}
+	}
+	r.calls[uid][id] = cancel
+	r.mu.Unlock()
+
+	return ctx, func() {
+		r.mu.Lock()
+		delete(r.calls[uid], id)
+		if len(r.calls[uid]) == 0 {
+			delete(r.calls, uid)
+		}
+		r.mu.Unlock()
+		cancel()
+	}
+}
+
+// Cancel the calls in flight for the managed resource of the supplied UID.
+func (r *Registry) Cancel(uid types.UID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, cancel := range r.calls[uid] {
+		cancel()
+	}
+}
+
+// Watch cancels the calls in flight for managed resources of the kind of the
+// supplied object once the supplied informers observe they were deleted, i.e.
+// got a deletion timestamp or are gone.
+func (r *Registry) Watch(ctx context.Context, i cache.Informers, obj client.Object) error {
+	inf, err := i.GetInformer(ctx, obj)
+	if err != nil {
+		return errors.Wrapf(err, errWatch, obj)
+	}
+	_, err = inf.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, obj interface{}) {
+			if o, ok := obj.(metav1.Object); ok && o.GetDeletionTimestamp() != nil {
+				r.Cancel(o.GetUID())
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if t, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = t.Obj
+			}
+			if o, ok := obj.(metav1.Object); ok {
+				r.Cancel(o.GetUID())
+			}
+		},
+	})
+	return errors.Wrapf(err, errWatch, obj)
+}
+
+// NewConnecter returns an ExternalConnecter whose calls are cancelled by the
+// supplied Registry once the managed resource they are for is deleted. A
+// cancelled call may have been applied partially, which the next reconcile
+// observes.
+func NewConnecter(c managed.ExternalConnecter, r *Registry) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, registry: r}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	registry *Registry
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ctx, done := c.registry.track(ctx, mg)
+	defer done()
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: ec, registry: c.registry}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	registry *Registry
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	ctx, done := e.registry.track(ctx, mg)
+	defer done()
+	return e.ExternalClient.Observe(ctx, mg)
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	ctx, done := e.registry.track(ctx, mg)
+	defer done()
+	return e.ExternalClient.Create(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	ctx, done := e.registry.track(ctx, mg)
+	defer done()
+	return e.ExternalClient.Update(ctx, mg)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cancellation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+type blocking struct {
+	managed.ExternalClient
+	started chan struct{}
+}
+
+func (b *blocking) Observe(ctx context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+	close(b.started)
+	select {
+	case <-ctx.Done():
+		return managed.ExternalObservation{}, ctx.Err()
+	case <-time.After(time.Second):
+		return managed.ExternalObservation{ResourceExists: true}, nil
+	}
+}
+
+func TestCancel(t *testing.T) {
+	deleted := metav1.Now()
+
+	cases := map[string]struct {
+		reason    string
+		deletedAt *metav1.Time
+		want      error
+	}{
+		"Cancelled": {
+			reason: "Calls in flight for a resource should be cancelled once it is deleted.",
+			want:   context.Canceled,
+		},
+		"AlreadyDeleted": {
+			reason:    "Calls for a resource that is already being deleted should not be cancelled by its deletion.",
+			deletedAt: &deleted,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewRegistry()
+			b := &blocking{started: make(chan struct{})}
+			e := &external{ExternalClient: b, registry: r}
+
+			mg := &v1alpha1.Topic{}
+			mg.SetUID("cool-uid")
+			mg.SetDeletionTimestamp(tc.deletedAt)
+
+			errs := make(chan error)
+			go func() {
+				_, err := e.Observe(context.Background(), mg)
+				errs <- err
+			}()
+			<-b.started
+			r.Cancel(mg.GetUID())
+
+			if diff := cmp.Diff(tc.want, <-errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if len(r.calls) != 0 {
+				t.Errorf("\n%s\nObserve(...): calls still tracked after returning: %d", tc.reason, len(r.calls))
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package connect
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/connect/v1alpha1"
+)
+
+const (
+	errCannotBuildRequest  = "cannot build Kafka Connect request"
+	errCannotSendRequest   = "cannot send Kafka Connect request"
+	errCannotDecode        = "cannot decode Kafka Connect response"
+	errCannotEncode        = "cannot encode Kafka Connect request"
+	errCannotReadSecret    = "cannot read secret %q in namespace %q"
+	errMissingSecretKey    = "secret %q in namespace %q has no key %q"
+	errCannotParseCA       = "cannot parse CA certificate"
+	errCannotGetServerInfo = "cannot get Kafka Connect server info"
+
+	requestTimeout = 30 * time.Second
+)
+
+// Client is a Kafka Connect REST API client
+type Client struct {
+	url      string
+	username string
+	password string
+	http     *http.Client
+}
+
+// ServerInfo is the Kafka Connect server information
+type ServerInfo struct {
+	Version        string `json:"version"`
+	Commit         string `json:"commit"`
+	KafkaClusterID string `json:"kafka_cluster_id"`
+}
+
+// An APIError is returned by the REST API for unsuccessful requests
+type APIError struct {
+	Code    int    `json:"error_code"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Kafka Connect returned %d: %s", e.Code, e.Message)
+}
+
+// IsNotFound returns true if the supplied error indicates that the requested
+// Kafka Connect object does not exist.
+func IsNotFound(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.Code == http.StatusNotFound
+}
+
+// NewClient returns a client for the Kafka Connect REST API described by the
+// supplied parameters, reading any referenced secrets.
+func NewClient(ctx context.Context, kube client.Client, p v1alpha1.ConnectClusterParameters) (*Client, error) {
+	c := &Client{
+		url:      strings.TrimSuffix(p.URL, "/"),
+		username: p.Username,
+	}
+
+	if p.PasswordSecretRef != nil {
+		pw, err := secretValue(ctx, kube, *p.PasswordSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		c.password = string(pw)
+	}
+
+	var insecure bool
+	var ca []byte
+	if p.TLS != nil {
+		insecure = p.TLS.InsecureSkipVerify
+		if p.TLS.CASecretRef != nil {
+			v, err := secretValue(ctx, kube, *p.TLS.CASecretRef)
+			if err != nil {
+				return nil, err
+			}
+			ca = v
+		}
+	}
+
+	t, err := transports.get(c.url, insecure, ca)
+	if err != nil {
+		return nil, err
+	}
+	c.http = &http.Client{Timeout: requestTimeout, Transport: t}
+	return c, nil
+}
+
+// transports are the transports of the REST APIs of all Kafka Connect
+// clusters, so that clients created for every reconcile reuse their
+// connections.
+var transports = &transportCache{entries: map[string]*transportEntry{}}
+
+// A transportCache caches a transport per REST API URL, created with the TLS
+// config the URL was last used with.
+type transportCache struct {
+	mu      sync.Mutex
+	entries map[string]*transportEntry
+}
+
+type transportEntry struct {
+	tls       [sha256.Size]byte
+	transport *http.Transport
+}
+
+// get returns the transport of the supplied URL, replacing it if the supplied
+// TLS config differs from the one it was created with. The connections of a
+// replaced transport are closed once they are idle.
+func (c *transportCache) get(url string, insecure bool, ca []byte) (*http.Transport, error) {
+	key := sha256.Sum256(append([]byte(strconv.FormatBool(insecure)+"\x00"), ca...))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[url]; ok && e.tls == key {
+		return e.transport, nil
+	}
+
+	tc := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: insecure}
+	if ca != nil {
+		tc.RootCAs = x509.NewCertPool()
+		if !tc.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.New(errCannotParseCA)
+		}
+	}
+	if e, ok := c.entries[url]; ok {
+		e.transport.CloseIdleConnections()
+	}
+	t := &http.Transport{TLSClientConfig: tc, Proxy: http.ProxyFromEnvironment}
+	c.entries[url] = &transportEntry{tls: key, transport: t}
+	return t, nil
+}
+
+// ServerInfo returns information about the Kafka Connect worker serving the
+// REST API.
+func (c *Client) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	si := &ServerInfo{}
+	if err := c.Do(ctx, http.MethodGet, "/", nil, si); err != nil {
+		return nil, errors.Wrap(err, errCannotGetServerInfo)
+	}
+	return si, nil
+}
+
+// Do sends a request with the supplied JSON body to the supplied path of the
+// REST API, and decodes the JSON response into out unless it is nil.
+func (c *Client) Do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return errors.Wrap(err, errCannotEncode)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, body)
+	if err != nil {
+		return errors.Wrap(err, errCannotBuildRequest)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.Wrap(err, errCannotSendRequest)
+	}
+	defer resp.Body.Close() //nolint:errcheck // Closing a read body can't fail meaningfully.
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		e := &APIError{}
+		if err := json.NewDecoder(resp.Body).Decode(e); err != nil || e.Message == "" {
+			e.Message = http.StatusText(resp.StatusCode)
+		}
+		e.Code = resp.StatusCode
+		return e
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return errors.Wrap(json.NewDecoder(resp.Body).Decode(out), errCannotDecode)
+}
+
+func secretValue(ctx context.Context, kube client.Client, ref xpv1.SecretKeySelector) ([]byte, error) {
+	s := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrapf(err, errCannotReadSecret, ref.Name, ref.Namespace)
+	}
+	v, ok := s.Data[ref.Key]
+	if !ok {
+		return nil, errors.Errorf(errMissingSecretKey, ref.Name, ref.Namespace, ref.Key)
+	}
+	return v, nil
+}
@@ -0,0 +1,82 @@
+package connect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/connect/v1alpha1"
+)
+
+func TestServerInfo(t *testing.T) {
+	cases := map[string]struct {
+		handler  http.HandlerFunc
+		want     *ServerInfo
+		notFound bool
+		wantErr  bool
+	}{
+		"Healthy": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"version":"3.6.0","commit":"abc","kafka_cluster_id":"xyz"}`))
+			},
+			want: &ServerInfo{Version: "3.6.0", Commit: "abc", KafkaClusterID: "xyz"},
+		},
+		"NotFound": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error_code":404,"message":"nope"}`))
+			},
+			notFound: true,
+			wantErr:  true,
+		},
+		"ServerError": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(tc.handler)
+			defer srv.Close()
+
+			c, err := NewClient(context.Background(), nil, v1alpha1.ConnectClusterParameters{URL: srv.URL})
+			if err != nil {
+				t.Fatalf("NewClient(...): %s", err)
+			}
+			got, err := c.ServerInfo(context.Background())
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ServerInfo(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if IsNotFound(err) != tc.notFound {
+				t.Errorf("IsNotFound(...): got %t, want %t", IsNotFound(err), tc.notFound)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ServerInfo(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewClientReusesTransport(t *testing.T) {
+	p := v1alpha1.ConnectClusterParameters{URL: "https://connect.example.org:8083"}
+	a, err := NewClient(context.Background(), nil, p)
+	if err != nil {
+		t.Fatalf("NewClient(...): %s", err)
+	}
+	b, _ := NewClient(context.Background(), nil, p)
+	if a.http.Transport != b.http.Transport {
+		t.Error("NewClient(...) twice: want the transport of the URL reused, got a new one")
+	}
+
+	p.TLS = &v1alpha1.ConnectClusterTLS{InsecureSkipVerify: true}
+	c, _ := NewClient(context.Background(), nil, p)
+	if c.http.Transport == a.http.Transport {
+		t.Error("NewClient(...) with another TLS config: want a new transport, got the cached one")
+	}
+}
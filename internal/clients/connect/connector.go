@@ -0,0 +1,127 @@
+package connect
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errCannotValidate  = "cannot validate connector config"
+	errCannotGetConfig = "cannot get connector config"
+	errCannotGetStatus = "cannot get connector status"
+	errCannotPutConfig = "cannot create or update connector"
+	errCannotDelete    = "cannot delete connector"
+)
+
+const (
+	configConnectorClass = "connector.class"
+	configConnectorName  = "name"
+
+	connectorsPath       = "/connectors/"
+	connectorPluginsPath = "/connector-plugins/"
+)
+
+// A Validation is the result of validating a connector config against its
+// connector plugin.
+type Validation struct {
+	ErrorCount int                `json:"error_count"`
+	Configs    []ConfigValidation `json:"configs"`
+}
+
+// A ConfigValidation is the validation result of a single config field.
+type ConfigValidation struct {
+	Value ConfigValue `json:"value"`
+}
+
+// A ConfigValue is a validated config field, and the errors found for it.
+type ConfigValue struct {
+	Name   string   `json:"name"`
+	Errors []string `json:"errors"`
+}
+
+// FieldErrors returns the validation errors of every invalid config field,
+// each prefixed by the name of the field, sorted by field name.
+func (v *Validation) FieldErrors() []string {
+	var errs []string
+	for _, c := range v.Configs {
+		for _, e := range c.Value.Errors {
+			errs = append(errs, fmt.Sprintf("%s: %s", c.Value.Name, e))
+		}
+	}
+	sort.Strings(errs)
+	return errs
+}
+
+// ConnectorStatus is the status of a connector.
+type ConnectorStatus struct {
+	Connector struct {
+		State    string `json:"state"`
+		WorkerID string `json:"worker_id"`
+		Trace    string `json:"trace,omitempty"`
+	} `json:"connector"`
+}
+
+// ConnectorConfig returns the full config of a connector with the supplied
+// class and name, as it is sent to the REST API.
+func ConnectorConfig(name, class string, config map[string]string) map[string]string {
+	cfg := make(map[string]string, len(config)+2)
+	for k, v := range config {
+		cfg[k] = v
+	}
+	cfg[configConnectorClass] = class
+	cfg[configConnectorName] = name
+	return cfg
+}
+
+// ValidateConfig validates the supplied connector config against the
+// connector plugin named by its connector.class.
+func (c *Client) ValidateConfig(ctx context.Context, config map[string]string) (*Validation, error) {
+	v := &Validation{}
+	path := connectorPluginsPath + url.PathEscape(pluginType(config[configConnectorClass])) + "/config/validate"
+	if err := c.Do(ctx, http.MethodPut, path, config, v); err != nil {
+		return nil, errors.Wrap(err, errCannotValidate)
+	}
+	return v, nil
+}
+
+// GetConnectorConfig returns the config of the named connector.
+func (c *Client) GetConnectorConfig(ctx context.Context, name string) (map[string]string, error) {
+	cfg := map[string]string{}
+	if err := c.Do(ctx, http.MethodGet, connectorsPath+url.PathEscape(name)+"/config", nil, &cfg); err != nil {
+		return nil, errors.Wrap(err, errCannotGetConfig)
+	}
+	return cfg, nil
+}
+
+// GetConnectorStatus returns the status of the named connector.
+func (c *Client) GetConnectorStatus(ctx context.Context, name string) (*ConnectorStatus, error) {
+	s := &ConnectorStatus{}
+	if err := c.Do(ctx, http.MethodGet, connectorsPath+url.PathEscape(name)+"/status", nil, s); err != nil {
+		return nil, errors.Wrap(err, errCannotGetStatus)
+	}
+	return s, nil
+}
+
+// PutConnectorConfig creates the named connector with the supplied config, or
+// updates its config if it already exists.
+func (c *Client) PutConnectorConfig(ctx context.Context, name string, config map[string]string) error {
+	return errors.Wrap(c.Do(ctx, http.MethodPut, connectorsPath+url.PathEscape(name)+"/config", config, nil), errCannotPutConfig)
+}
+
+// DeleteConnector deletes the named connector.
+func (c *Client) DeleteConnector(ctx context.Context, name string) error {
+	return errors.Wrap(c.Do(ctx, http.MethodDelete, connectorsPath+url.PathEscape(name), nil, nil), errCannotDelete)
+}
+
+// pluginType returns the plugin type of a connector class. Kafka Connect
+// accepts both fully qualified class names and simple names, but the former
+// contain dots that some proxies reject in path segments.
+func pluginType(class string) string {
+	return class[strings.LastIndex(class, ".")+1:]
+}
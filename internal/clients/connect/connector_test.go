@@ -0,0 +1,65 @@
+package connect
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/connect/v1alpha1"
+)
+
+func TestValidateConfig(t *testing.T) {
+	cases := map[string]struct {
+		config         map[string]string
+		response       string
+		wantPath       string
+		wantFieldError []string
+	}{
+		"Valid": {
+			config:   ConnectorConfig("sink", "org.apache.kafka.connect.file.FileStreamSinkConnector", map[string]string{"topics": "orders"}),
+			response: `{"name":"FileStreamSinkConnector","error_count":0,"configs":[{"value":{"name":"topics","value":"orders","errors":[]}}]}`,
+			wantPath: "/connector-plugins/FileStreamSinkConnector/config/validate",
+		},
+		"Invalid": {
+			config:         ConnectorConfig("sink", "FileStreamSinkConnector", nil),
+			response:       `{"name":"FileStreamSinkConnector","error_count":2,"configs":[{"value":{"name":"topics","errors":["Must configure one of topics or topics.regex"]}},{"value":{"name":"file","errors":["Missing required configuration \"file\" which has no default value."]}}]}`,
+			wantPath:       "/connector-plugins/FileStreamSinkConnector/config/validate",
+			wantFieldError: []string{`file: Missing required configuration "file" which has no default value.`, "topics: Must configure one of topics or topics.regex"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotPath string
+			var gotConfig map[string]string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				_ = json.NewDecoder(r.Body).Decode(&gotConfig)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer srv.Close()
+
+			c, err := NewClient(context.Background(), nil, v1alpha1.ConnectClusterParameters{URL: srv.URL})
+			if err != nil {
+				t.Fatalf("NewClient(...): %s", err)
+			}
+			v, err := c.ValidateConfig(context.Background(), tc.config)
+			if err != nil {
+				t.Fatalf("ValidateConfig(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.wantPath, gotPath); diff != "" {
+				t.Errorf("ValidateConfig(...): -want path, +got path:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.config, gotConfig); diff != "" {
+				t.Errorf("ValidateConfig(...): -want config, +got config:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantFieldError, v.FieldErrors()); diff != "" {
+				t.Errorf("FieldErrors(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
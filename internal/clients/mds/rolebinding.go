@@ -0,0 +1,143 @@
+package mds
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/confluent/v1alpha1"
+)
+
+const (
+	errCannotLookupRoles     = "cannot look up roles of principal %q"
+	errCannotLookupResources = "cannot look up resources principal %q is bound %q for"
+	errCannotBindRole        = "cannot bind role %q to principal %q"
+	errCannotUnbindRole      = "cannot unbind role %q from principal %q"
+
+	securityPath = "/security/1.0"
+)
+
+// A Scope is the cluster, or cluster of a Confluent Platform component, a
+// role is bound in.
+type Scope struct {
+	Clusters Clusters `json:"clusters"`
+}
+
+// Clusters identify the clusters of a Scope.
+type Clusters struct {
+	KafkaCluster          string `json:"kafka-cluster,omitempty"`
+	SchemaRegistryCluster string `json:"schema-registry-cluster,omitempty"`
+	ConnectCluster        string `json:"connect-cluster,omitempty"`
+	KSQLCluster           string `json:"ksql-cluster,omitempty"`
+}
+
+// A ResourcePattern is a set of resources a role is bound for.
+type ResourcePattern struct {
+	ResourceType string `json:"resourceType"`
+	Name         string `json:"name"`
+	PatternType  string `json:"patternType"`
+}
+
+// resourceBindings are the resource patterns a role is bound or unbound for.
+type resourceBindings struct {
+	Scope            Scope             `json:"scope"`
+	ResourcePatterns []ResourcePattern `json:"resourcePatterns"`
+}
+
+// GenerateScope returns the scope described by the supplied parameters, in
+// the Kafka cluster of the supplied ID.
+func GenerateScope(s v1alpha1.RoleBindingScope, kafkaCluster string) Scope {
+	return Scope{Clusters: Clusters{
+		KafkaCluster:          kafkaCluster,
+		SchemaRegistryCluster: s.SchemaRegistryCluster,
+		ConnectCluster:        s.ConnectCluster,
+		KSQLCluster:           s.KSQLCluster,
+	}}
+}
+
+// GeneratePatterns returns the resource patterns described by the supplied
+// parameters. Patterns without a pattern type are LITERAL.
+func GeneratePatterns(ps []v1alpha1.ResourcePattern) []ResourcePattern {
+	out := make([]ResourcePattern, 0, len(ps))
+	for _, p := range ps {
+		pt := p.PatternType
+		if pt == "" {
+			pt = v1alpha1.PatternTypeLiteral
+		}
+		out = append(out, ResourcePattern{ResourceType: p.ResourceType, Name: p.Name, PatternType: string(pt)})
+	}
+	return out
+}
+
+// Intersect returns the patterns of a that are also in b, in the order of a.
+func Intersect(a, b []ResourcePattern) []ResourcePattern {
+	var out []ResourcePattern
+	for _, p := range a {
+		if slices.Contains(b, p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Subtract returns the patterns of a that are not in b, in the order of a.
+func Subtract(a, b []ResourcePattern) []ResourcePattern {
+	var out []ResourcePattern
+	for _, p := range a {
+		if !slices.Contains(b, p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// HasRole returns true if the supplied role is bound to the supplied
+// principal in the supplied scope.
+func (c *Client) HasRole(ctx context.Context, principal, role string, s Scope) (bool, error) {
+	var roles []string
+	if err := c.do(ctx, http.MethodPost, securityPath+"/lookup/principals/"+url.PathEscape(principal)+"/roleNames", s, &roles); err != nil {
+		return false, errors.Wrapf(err, errCannotLookupRoles, principal)
+	}
+	return slices.Contains(roles, role), nil
+}
+
+// ResourcePatterns returns the resource patterns the supplied role is bound
+// to the supplied principal for in the supplied scope.
+func (c *Client) ResourcePatterns(ctx context.Context, principal, role string, s Scope) ([]ResourcePattern, error) {
+	var ps []ResourcePattern
+	err := c.do(ctx, http.MethodPost, rolePath(principal, role)+"/resources", s, &ps)
+	return ps, errors.Wrapf(err, errCannotLookupResources, principal, role)
+}
+
+// BindRole binds the supplied role to the supplied principal for the whole
+// supplied scope.
+func (c *Client) BindRole(ctx context.Context, principal, role string, s Scope) error {
+	return errors.Wrapf(c.do(ctx, http.MethodPost, rolePath(principal, role), s, nil), errCannotBindRole, role, principal)
+}
+
+// UnbindRole unbinds the supplied role bound to the supplied principal for
+// the whole supplied scope.
+func (c *Client) UnbindRole(ctx context.Context, principal, role string, s Scope) error {
+	return errors.Wrapf(c.do(ctx, http.MethodDelete, rolePath(principal, role), s, nil), errCannotUnbindRole, role, principal)
+}
+
+// BindResources binds the supplied role to the supplied principal for the
+// supplied resource patterns of the supplied scope.
+func (c *Client) BindResources(ctx context.Context, principal, role string, s Scope, ps []ResourcePattern) error {
+	b := resourceBindings{Scope: s, ResourcePatterns: ps}
+	return errors.Wrapf(c.do(ctx, http.MethodPost, rolePath(principal, role)+"/bindings", b, nil), errCannotBindRole, role, principal)
+}
+
+// UnbindResources unbinds the supplied role bound to the supplied principal
+// for the supplied resource patterns of the supplied scope.
+func (c *Client) UnbindResources(ctx context.Context, principal, role string, s Scope, ps []ResourcePattern) error {
+	b := resourceBindings{Scope: s, ResourcePatterns: ps}
+	return errors.Wrapf(c.do(ctx, http.MethodDelete, rolePath(principal, role)+"/bindings", b, nil), errCannotUnbindRole, role, principal)
+}
+
+func rolePath(principal, role string) string {
+	return securityPath + "/principals/" + url.PathEscape(principal) + "/roles/" + url.PathEscape(role)
+}
@@ -0,0 +1,103 @@
+package mds
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/confluent/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+func TestGeneratePatterns(t *testing.T) {
+	ps := []v1alpha1.ResourcePattern{
+		{ResourceType: "Topic", Name: "orders"},
+		{ResourceType: "Group", Name: "billing-", PatternType: v1alpha1.PatternTypePrefixed},
+	}
+	want := []ResourcePattern{
+		{ResourceType: "Topic", Name: "orders", PatternType: "LITERAL"},
+		{ResourceType: "Group", Name: "billing-", PatternType: "PREFIXED"},
+	}
+	if diff := cmp.Diff(want, GeneratePatterns(ps)); diff != "" {
+		t.Errorf("GeneratePatterns(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestBindResources(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got = append(got, r.Method+" "+r.URL.Path, string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := NewClient(&kafka.MDS{URL: srv.URL})
+	s := GenerateScope(v1alpha1.RoleBindingScope{}, "kafka-1")
+	ps := []ResourcePattern{{ResourceType: "Topic", Name: "orders", PatternType: "LITERAL"}}
+	if err := c.BindResources(context.Background(), "User:alice", "DeveloperRead", s, ps); err != nil {
+		t.Fatalf("BindResources(...): %v", err)
+	}
+
+	want := []string{
+		"POST /security/1.0/principals/User:alice/roles/DeveloperRead/bindings",
+		`{"scope":{"clusters":{"kafka-cluster":"kafka-1"}},"resourcePatterns":[{"resourceType":"Topic","name":"orders","patternType":"LITERAL"}]}`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("BindResources(...): -want requests, +got requests:\n%s", diff)
+	}
+}
+
+func TestHasRole(t *testing.T) {
+	cases := map[string]struct {
+		roles string
+		want  bool
+	}{
+		"Bound": {
+			roles: `["DeveloperRead","ClusterAdmin"]`,
+			want:  true,
+		},
+		"NotBound": {
+			roles: `["DeveloperRead"]`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/security/1.0/lookup/principals/User:alice/roleNames" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write([]byte(tc.roles))
+			}))
+			defer srv.Close()
+
+			c := NewClient(&kafka.MDS{URL: srv.URL})
+			got, err := c.HasRole(context.Background(), "User:alice", "ClusterAdmin", GenerateScope(v1alpha1.RoleBindingScope{}, "kafka-1"))
+			if err != nil {
+				t.Fatalf("HasRole(...): %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("HasRole(...) = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnbindRoleNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status_code":404,"error_code":40403,"message":"Role binding not found."}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(&kafka.MDS{URL: srv.URL})
+	if err := c.UnbindRole(context.Background(), "User:alice", "ClusterAdmin", Scope{}); !IsNotFound(err) {
+		t.Errorf("UnbindRole(...): want not found error, got %v", err)
+	}
+}
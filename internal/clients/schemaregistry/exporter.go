@@ -0,0 +1,155 @@
+package schemaregistry
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/schemaregistry/v1alpha1"
+)
+
+const (
+	errCannotGetExporter    = "cannot get schema exporter"
+	errCannotCreateExporter = "cannot create schema exporter"
+	errCannotUpdateExporter = "cannot update schema exporter"
+	errCannotPauseExporter  = "cannot pause schema exporter"
+	errCannotResumeExporter = "cannot resume schema exporter"
+	errCannotDeleteExporter = "cannot delete schema exporter"
+
+	// Exporter config keys of the destination Schema Registry.
+	configURL          = "schema.registry.url"
+	configAuthSource   = "basic.auth.credentials.source"
+	configAuthUserInfo = "basic.auth.user.info"
+	authSourceUserInfo = "USER_INFO"
+
+	exportersPath       = "/exporters"
+	exporterStatePaused = "PAUSED"
+)
+
+// An Exporter is a schema exporter, which exports subjects to a destination
+// Schema Registry.
+type Exporter struct {
+	Name                string            `json:"name,omitempty"`
+	Subjects            []string          `json:"subjects"`
+	SubjectRenameFormat string            `json:"subjectRenameFormat,omitempty"`
+	ContextType         string            `json:"contextType,omitempty"`
+	Context             string            `json:"context,omitempty"`
+	Config              map[string]string `json:"config,omitempty"`
+}
+
+// ExporterStatus is the status of a schema exporter.
+type ExporterStatus struct {
+	State  string `json:"state"`
+	Offset int64  `json:"offset"`
+	Trace  string `json:"trace,omitempty"`
+}
+
+// GenerateExporter returns the exporter of the supplied name described by the
+// supplied parameters, authenticating to the destination with the supplied
+// password.
+func GenerateExporter(name string, p v1alpha1.SchemaExporterParameters, password string) *Exporter {
+	cfg := make(map[string]string, len(p.Destination.Config)+3)
+	maps.Copy(cfg, p.Destination.Config)
+	cfg[configURL] = p.Destination.URL
+	if p.Destination.Username != "" {
+		cfg[configAuthSource] = authSourceUserInfo
+		cfg[configAuthUserInfo] = p.Destination.Username + ":" + password
+	}
+	return &Exporter{
+		Name:                name,
+		Subjects:            p.Subjects,
+		SubjectRenameFormat: p.SubjectRenameFormat,
+		ContextType:         string(p.ContextType),
+		Context:             p.Context,
+		Config:              cfg,
+	}
+}
+
+// IsUpToDate returns true if the observed exporter is the desired one. Config
+// keys that are not desired are ignored, as Schema Registry reports defaults
+// too. The credentials of the destination are never revealed, so a changed
+// password is not detected.
+func IsUpToDate(desired, observed *Exporter) bool {
+	if !slices.Equal(desired.Subjects, observed.Subjects) || desired.SubjectRenameFormat != observed.SubjectRenameFormat {
+		return false
+	}
+	if desired.ContextType != "" && desired.ContextType != observed.ContextType {
+		return false
+	}
+	if desired.Context != "" && desired.Context != observed.Context {
+		return false
+	}
+	for k, v := range desired.Config {
+		if k != configAuthUserInfo && observed.Config[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// GetExporter returns the exporter of the supplied name, along with its
+// config.
+func (c *Client) GetExporter(ctx context.Context, name string) (*Exporter, error) {
+	e := &Exporter{}
+	if err := c.do(ctx, http.MethodGet, exporterPath(name), nil, e); err != nil {
+		return nil, errors.Wrap(err, errCannotGetExporter)
+	}
+	if err := c.do(ctx, http.MethodGet, exporterPath(name)+"/config", nil, &e.Config); err != nil {
+		return nil, errors.Wrap(err, errCannotGetExporter)
+	}
+	return e, nil
+}
+
+// GetExporterStatus returns the status of the exporter of the supplied name.
+func (c *Client) GetExporterStatus(ctx context.Context, name string) (*ExporterStatus, error) {
+	s := &ExporterStatus{}
+	return s, errors.Wrap(c.do(ctx, http.MethodGet, exporterPath(name)+"/status", nil, s), errCannotGetExporter)
+}
+
+// CreateExporter creates the supplied exporter, which starts exporting
+// straight away.
+func (c *Client) CreateExporter(ctx context.Context, e *Exporter) error {
+	return errors.Wrap(c.do(ctx, http.MethodPost, exportersPath, e, nil), errCannotCreateExporter)
+}
+
+// UpdateExporter updates the supplied exporter. Exporters can only be
+// updated while paused, so a running exporter is paused for the update and
+// resumed afterwards.
+func (c *Client) UpdateExporter(ctx context.Context, e *Exporter) error {
+	s, err := c.GetExporterStatus(ctx, e.Name)
+	if err != nil {
+		return err
+	}
+	paused := s.State == exporterStatePaused
+	if !paused {
+		if err := c.do(ctx, http.MethodPut, exporterPath(e.Name)+"/pause", nil, nil); err != nil {
+			return errors.Wrap(err, errCannotPauseExporter)
+		}
+	}
+	u := *e
+	u.Name = ""
+	if err := c.do(ctx, http.MethodPut, exporterPath(e.Name), &u, nil); err != nil {
+		return errors.Wrap(err, errCannotUpdateExporter)
+	}
+	if paused {
+		return nil
+	}
+	return errors.Wrap(c.do(ctx, http.MethodPut, exporterPath(e.Name)+"/resume", nil, nil), errCannotResumeExporter)
+}
+
+// DeleteExporter pauses, then deletes the exporter of the supplied name, as
+// only paused exporters can be deleted.
+func (c *Client) DeleteExporter(ctx context.Context, name string) error {
+	if err := c.do(ctx, http.MethodPut, exporterPath(name)+"/pause", nil, nil); err != nil {
+		return errors.Wrap(err, errCannotPauseExporter)
+	}
+	return errors.Wrap(c.do(ctx, http.MethodDelete, exporterPath(name), nil, nil), errCannotDeleteExporter)
+}
+
+func exporterPath(name string) string {
+	return exportersPath + "/" + url.PathEscape(name)
+}
@@ -0,0 +1,149 @@
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/schemaregistry/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+func TestGenerateExporter(t *testing.T) {
+	p := v1alpha1.SchemaExporterParameters{
+		Subjects:            []string{"orders-value"},
+		SubjectRenameFormat: "dc_${subject}",
+		ContextType:         v1alpha1.ContextTypeNone,
+		Destination: v1alpha1.SchemaExporterDestination{
+			URL:      "https://dr-registry:8081",
+			Username: "exporter",
+			Config:   map[string]string{"ssl.endpoint.identification.algorithm": "https"},
+		},
+	}
+	want := &Exporter{
+		Name:                "orders",
+		Subjects:            []string{"orders-value"},
+		SubjectRenameFormat: "dc_${subject}",
+		ContextType:         "NONE",
+		Config: map[string]string{
+			"schema.registry.url":                   "https://dr-registry:8081",
+			"basic.auth.credentials.source":         "USER_INFO",
+			"basic.auth.user.info":                  "exporter:secret",
+			"ssl.endpoint.identification.algorithm": "https",
+		},
+	}
+	if diff := cmp.Diff(want, GenerateExporter("orders", p, "secret")); diff != "" {
+		t.Errorf("GenerateExporter(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	desired := &Exporter{
+		Subjects:    []string{"orders-value"},
+		ContextType: "AUTO",
+		Config: map[string]string{
+			"schema.registry.url":  "https://dr-registry:8081",
+			"basic.auth.user.info": "exporter:secret",
+		},
+	}
+
+	cases := map[string]struct {
+		observed *Exporter
+		want     bool
+	}{
+		"UpToDate": {
+			observed: &Exporter{
+				Subjects:    []string{"orders-value"},
+				ContextType: "AUTO",
+				Config: map[string]string{
+					"schema.registry.url":  "https://dr-registry:8081",
+					"basic.auth.user.info": "[hidden]",
+					"max.batch.size":       "1000",
+				},
+			},
+			want: true,
+		},
+		"SubjectsChanged": {
+			observed: &Exporter{
+				Subjects:    []string{"payments-value"},
+				ContextType: "AUTO",
+				Config:      map[string]string{"schema.registry.url": "https://dr-registry:8081"},
+			},
+		},
+		"DestinationChanged": {
+			observed: &Exporter{
+				Subjects:    []string{"orders-value"},
+				ContextType: "AUTO",
+				Config:      map[string]string{"schema.registry.url": "https://old-registry:8081"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsUpToDate(desired, tc.observed); got != tc.want {
+				t.Errorf("IsUpToDate(...) = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUpdateExporter(t *testing.T) {
+	cases := map[string]struct {
+		state string
+		want  []string
+	}{
+		"Running": {
+			state: "RUNNING",
+			want: []string{
+				"GET /exporters/orders/status",
+				"PUT /exporters/orders/pause",
+				"PUT /exporters/orders",
+				"PUT /exporters/orders/resume",
+			},
+		},
+		"Paused": {
+			state: "PAUSED",
+			want: []string{
+				"GET /exporters/orders/status",
+				"PUT /exporters/orders",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = append(got, r.Method+" "+r.URL.Path)
+				_ = json.NewEncoder(w).Encode(ExporterStatus{State: tc.state})
+			}))
+			defer srv.Close()
+
+			c := NewClient(&kafka.SchemaRegistry{URL: srv.URL})
+			if err := c.UpdateExporter(context.Background(), &Exporter{Name: "orders", Subjects: []string{"orders-value"}}); err != nil {
+				t.Fatalf("UpdateExporter(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("UpdateExporter(...): -want requests, +got requests:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetExporterNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":40450,"message":"Exporter 'orders' not found."}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(&kafka.SchemaRegistry{URL: srv.URL})
+	if _, err := c.GetExporter(context.Background(), "orders"); !IsNotFound(err) {
+		t.Errorf("GetExporter(...): want not found error, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package schemaregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const (
+	errCannotBuildRequest = "cannot build Schema Registry request"
+	errCannotSendRequest  = "cannot send Schema Registry request"
+	errCannotDecode       = "cannot decode Schema Registry response"
+	errCannotEncode       = "cannot encode Schema Registry request"
+	errCannotGetSubject   = "cannot get Schema Registry subject %q"
+
+	contentType = "application/vnd.schemaregistry.v1+json"
+
+	requestTimeout = 10 * time.Second
+)
+
+// Client is a minimal Schema Registry REST client
+type Client struct {
+	url      string
+	username string
+	password string
+	http     *http.Client
+}
+
+// An APIError is returned by the REST API for unsuccessful requests.
+type APIError struct {
+	// Status is the HTTP status of the response.
+	Status int `json:"-"`
+	// Code is the Schema Registry error code, e.g. 40401.
+	Code    int    `json:"error_code"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Schema Registry returned %d: %s", e.Code, e.Message)
+}
+
+// IsNotFound returns true if the supplied error indicates that the requested
+// Schema Registry object does not exist.
+func IsNotFound(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.Status == http.StatusNotFound
+}
+
+// NewClient returns a Schema Registry client for the supplied configuration,
+// or nil if no Schema Registry is configured.
+func NewClient(cfg *kafka.SchemaRegistry) *Client {
+	if cfg == nil || cfg.URL == "" {
+		return nil
+	}
+	return &Client{
+		url:      strings.TrimSuffix(cfg.URL, "/"),
+		username: cfg.Username,
+		password: cfg.Password,
+		http:     &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// SubjectExists returns true if at least one schema version is registered
+// under the supplied subject.
+func (c *Client) SubjectExists(ctx context.Context, subject string) (bool, error) {
+	err := c.do(ctx, http.MethodGet, "/subjects/"+url.PathEscape(subject)+"/versions/latest", nil, nil)
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, errCannotGetSubject, subject)
+	}
+	return true, nil
+}
+
+// do sends a request with the supplied JSON body to the supplied path of the
+// REST API, and decodes the JSON response into out unless it is nil.
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return errors.Wrap(err, errCannotEncode)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, body)
+	if err != nil {
+		return errors.Wrap(err, errCannotBuildRequest)
+	}
+	req.Header.Set("Accept", contentType)
+	if in != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.Wrap(err, errCannotSendRequest)
+	}
+	defer resp.Body.Close() //nolint:errcheck // Closing a read body can't fail meaningfully.
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		e := &APIError{}
+		if err := json.NewDecoder(resp.Body).Decode(e); err != nil || e.Message == "" {
+			e.Message = http.StatusText(resp.StatusCode)
+		}
+		if e.Code == 0 {
+			e.Code = resp.StatusCode
+		}
+		e.Status = resp.StatusCode
+		return e
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return errors.Wrap(json.NewDecoder(resp.Body).Decode(out), errCannotDecode)
+}
+
+// KeySubject returns the subject holding the key schema of a topic, following
+// the default TopicNameStrategy.
+func KeySubject(topic string) string {
+	return topic + "-key"
+}
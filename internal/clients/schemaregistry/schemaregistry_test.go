@@ -0,0 +1,50 @@
+package schemaregistry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+func TestSubjectExists(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/subjects/orders-key/versions/latest":
+			w.WriteHeader(http.StatusOK)
+		case "/subjects/broken-key/versions/latest":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	type want struct {
+		exists bool
+		err    bool
+	}
+
+	cases := map[string]struct {
+		subject string
+		want    want
+	}{
+		"Registered":    {subject: KeySubject("orders"), want: want{exists: true}},
+		"NotRegistered": {subject: KeySubject("payments")},
+		"Error":         {subject: KeySubject("broken"), want: want{err: true}},
+	}
+
+	c := NewClient(&kafka.SchemaRegistry{URL: srv.URL + "/"})
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exists, err := c.SubjectExists(context.Background(), tc.subject)
+			if diff := cmp.Diff(tc.want, want{exists: exists, err: err != nil}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("SubjectExists(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
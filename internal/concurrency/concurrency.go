@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package concurrency limits how many calls changing external resources the
+// provider's controllers make at once against the cluster of each
+// ProviderConfig, so that e.g. applying hundreds of Topics at once does not
+// send as many parallel CreateTopics requests to its controller broker.
+package concurrency
+
+import (
+	"context"
+	"sync"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+)
+
+const errWait = "cannot wait for one of the %d concurrent operations allowed against ProviderConfig %q"
+
+// A Limiter allows up to a number of operations at once per ProviderConfig.
+type Limiter struct {
+	limit int
+
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+// NewLimiter returns a Limiter allowing up to the supplied number of
+// operations at once per ProviderConfig.
+func NewLimiter(limit int) *Limiter {
+	return &Limiter{limit: limit, slots: map[string]chan struct{}{}}
+}
+
+// Acquire waits until an operation may start against the supplied
+// ProviderConfig, or the supplied context is done. It returns a function that
+// must be called once the operation completed.
+func (l *Limiter) Acquire(ctx context.Context, pc string) (func(), error) {
+	s := l.semaphore(pc)
+	select {
+	case s <- struct{}{}:
+		return func() { <-s }, nil
+	default:
+	}
+
+	metrics.RecordWaitingOperation(pc, 1)
+	defer metrics.RecordWaitingOperation(pc, -1)
+	select {
+	case s <- struct{}{}:
+		return func() { <-s }, nil
+	case <-ctx.Done():
+		return nil, errors.Wrapf(ctx.Err(), errWait, l.limit, pc)
+	}
+}
+
+func (l *Limiter) semaphore(pc string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s, ok := l.slots[pc]
+	if !ok {
+		s = make(chan struct{}, l.limit)
+		l.slots[pc] = s
+	}
+	return s
+}
+
+// NewConnecter returns an ExternalConnecter whose clients create, update and
+// delete external resources within the limit of the supplied Limiter for the
+// ProviderConfig of each managed resource. Observing them is not limited.
+func NewConnecter(c managed.ExternalConnecter, l *Limiter) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, limiter: l}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	limiter *Limiter
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: ec, limiter: c.limiter}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	limiter *Limiter
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	release, err := e.limiter.Acquire(ctx, providerConfigName(mg))
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	defer release()
+	return e.ExternalClient.Create(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	release, err := e.limiter.Acquire(ctx, providerConfigName(mg))
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	defer release()
+	return e.ExternalClient.Update(ctx, mg)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	release, err := e.limiter.Acquire(ctx, providerConfigName(mg))
+	if err != nil {
+		return err
+	}
+	defer release()
+	return e.ExternalClient.Delete(ctx, mg)
+}
+
+func providerConfigName(mg resource.Managed) string {
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		return ref.Name
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+// creator returns a connecter whose clients create external resources by
+// calling the supplied function.
+func creator(create func(ctx context.Context) error) managed.ExternalConnecter {
+	return managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+				return managed.ExternalObservation{}, nil
+			},
+			CreateFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+				return managed.ExternalCreation{}, create(ctx)
+			},
+		}, nil
+	})
+}
+
+func topic(pc string) *v1alpha1.Topic {
+	t := &v1alpha1.Topic{}
+	t.SetProviderConfigReference(&xpv1.Reference{Name: pc})
+	return t
+}
+
+func TestLimit(t *testing.T) {
+	var mu sync.Mutex
+	running := map[string]int{}
+	started := make(chan string)
+	release := map[string]chan struct{}{"a": make(chan struct{}), "b": make(chan struct{})}
+	c := NewConnecter(creator(func(ctx context.Context) error {
+		pc := ctx.Value(providerConfigKey{}).(string)
+		mu.Lock()
+		running[pc]++
+		mu.Unlock()
+		started <- pc
+		<-release[pc]
+		mu.Lock()
+		running[pc]--
+		mu.Unlock()
+		return nil
+	}), NewLimiter(2))
+
+	create := func(ctx context.Context, pc string) error {
+		ctx = context.WithValue(ctx, providerConfigKey{}, pc)
+		ec, err := c.Connect(ctx, topic(pc))
+		if err != nil {
+			return err
+		}
+		_, err = ec.Create(ctx, topic(pc))
+		return err
+	}
+
+	// Two creates start against each ProviderConfig, a third one waits.
+	done := make(chan error, 5)
+	for _, pc := range []string{"a", "a", "a", "b", "b"} {
+		go func(pc string) { done <- create(context.Background(), pc) }(pc)
+	}
+	for i := 0; i < 4; i++ {
+		<-started
+	}
+	select {
+	case pc := <-started:
+		t.Fatalf("Create(...): a third create started against ProviderConfig %q", pc)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Observing is not limited.
+	ec, err := c.Connect(context.Background(), topic("a"))
+	if err != nil {
+		t.Fatalf("Connect(...): %s", err)
+	}
+	if _, err := ec.Observe(context.Background(), topic("a")); err != nil {
+		t.Errorf("Observe(...): %s", err)
+	}
+
+	// A create waiting for the limit gives up once its context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := create(ctx, "b"); err == nil {
+		t.Errorf("Create(...): want error waiting for the limit, got none")
+	}
+
+	// The waiting create starts once one completes.
+	release["a"] <- struct{}{}
+	if pc := <-started; pc != "a" {
+		t.Errorf("Create(...): want the waiting create against ProviderConfig %q to start, got %q", "a", pc)
+	}
+	close(release["a"])
+	close(release["b"])
+	for i := 0; i < 5; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("Create(...): %s", err)
+		}
+	}
+	for pc, n := range running {
+		if n != 0 {
+			t.Errorf("Create(...): %d creates against ProviderConfig %q still running", n, pc)
+		}
+	}
+}
+
+type providerConfigKey struct{}
@@ -20,16 +20,20 @@ import (
 	"context"
 	"strings"
 
-	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
-	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/acl"
+	"github.com/crossplane-contrib/provider-kafka/internal/audit"
+	"github.com/crossplane-contrib/provider-kafka/internal/deletion"
+	"github.com/crossplane-contrib/provider-kafka/internal/dependency"
+	"github.com/crossplane-contrib/provider-kafka/internal/identity"
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka/acl"
 
 	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
-	"github.com/twmb/franz-go/pkg/kadm"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,7 +44,9 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+	topicv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/options"
 )
 
 const (
@@ -48,41 +54,63 @@ const (
 	errTrackPCUsage         = "cannot track ProviderConfig usage"
 	errGetPC                = "cannot get ProviderConfig"
 	errGetCreds             = "cannot get credentials"
+	errGetBrokers           = "cannot get brokers"
+	errPrincipal            = "cannot derive principal from ServiceAccount"
 	errListACL              = "cannot List ACLs"
 	errNewClient            = "cannot create new Service"
 	errUpdateNotSupported   = "updates are not supported"
+	errModeChanged          = "cannot switch an existing AccessControlList between single and bulk mode"
+	errConfluentRBAC        = "ProviderConfig %q uses ConfluentRBAC authorization; manage RoleBindings instead"
+
+	msgAuthorizerMissing = "the Kafka cluster runs no authorizer, so its ACLs cannot be managed; set authorizer.class.name on its brokers"
 )
 
 // Setup adds a controller that reconciles AccessControlList managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.AccessControlListGroupKind)
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.AccessControlListGroupVersionKind),
-		managed.WithExternalConnectDisconnecter(&connectDisconnector{
-			kube:         mgr.GetClient(),
-			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: kafka.NewAdminClient}),
+		managed.WithExternalConnecter(o.ExternalConnecter(identity.NewConnecter(dependency.NewConnecter(deletion.NewConnecter(audit.NewConnecter(metrics.NewConnecter(&connector{
+			kube:         o.CredentialsClient(mgr.GetClient()),
+			usage:        o.UsageTracker(mgr.GetClient()),
+			newServiceFn: o.ClientCache.Get,
+			timeouts:     o.Timeouts}, v1alpha1.AccessControlListKind), v1alpha1.AccessControlListKind, o.Audit, o.Logger), o.Deletion, event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), o.Logger))))),
+		managed.WithReferenceResolver(dependency.NewReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient()), dependency.NewGate(mgr.GetClient(), dependencies))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(o.PollIntervalHook()),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithInitializers())
+		managed.WithConnectionPublishers(o.ConnectionPublishers(mgr.GetClient())...),
+		managed.WithInitializers(o.Initializers(mgr.GetClient())...))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.AccessControlList{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Watches(&topicv1alpha1.Topic{}, dependency.EnqueueDependents(mgr.GetClient(), &v1alpha1.AccessControlListList{}, dependencies)).
+		Complete(o.Sharded(&v1alpha1.AccessControlList{}, ratelimiter.NewReconciler(name, kafka.NewThrottlingReconciler(metrics.NewReconciler(v1alpha1.AccessControlListKind, r)), o.GlobalRateLimiter)))
+}
+
+// dependencies returns the Topic referenced by the supplied AccessControlList,
+// if any, which must be ready before the ACL is created.
+func dependencies(mg resource.Managed) []dependency.Dependency {
+	cr, ok := mg.(*v1alpha1.AccessControlList)
+	if !ok || cr.Spec.ForProvider.TopicRef == nil {
+		return nil
+	}
+	return []dependency.Dependency{{Object: &topicv1alpha1.Topic{}, Name: cr.Spec.ForProvider.TopicRef.Name}}
 }
 
-// A connectDisconnector is expected to produce an ExternalClient when its Connect method
-// is called and close it when its Disconnect method is called.
-type connectDisconnector struct {
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called. Clients are shared between reconciles through a cache, so they
+// are never closed after a reconcile.
+type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
 	log          logging.Logger
-	newServiceFn func(ctx context.Context, creds []byte, kube client.Client) (*kadm.Client, error)
-	cachedClient *kadm.Client
+	newServiceFn func(ctx context.Context, builder string, creds []byte, kube client.Reader) (*kafka.Client, error)
+	timeouts     kafka.Timeouts
 }
 
 // Connect typically produces an ExternalClient by:
@@ -90,7 +118,7 @@ type connectDisconnector struct {
 // 2. Getting the managed resource's ProviderConfig.
 // 3. Getting the credentials specified by the ProviderConfig.
 // 4. Using the credentials to form a client.
-func (c *connectDisconnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
 	cr, ok := mg.(*v1alpha1.AccessControlList)
 	if !ok {
 		return nil, errors.New(errNotAccessControlList)
@@ -104,35 +132,50 @@ func (c *connectDisconnector) Connect(ctx context.Context, mg resource.Managed)
 	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
 		return nil, errors.Wrap(err, errGetPC)
 	}
+	if pc.Spec.Authorization == apisv1alpha1.AuthorizationConfluentRBAC {
+		return nil, errors.Errorf(errConfluentRBAC, pc.GetName())
+	}
 
 	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	data, err := kafka.ExtractCredentials(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
+	if ref := pc.Spec.BrokersConfigMapRef; ref != nil {
+		if data, err = kafka.ReplaceBrokers(ctx, c.kube, data, ref.Namespace, ref.Name, ref.Key); err != nil {
+			return nil, errors.Wrap(err, errGetBrokers)
+		}
+	}
+	if data, err = identity.Credentials(data, pc, cr); err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	var principal string
+	if sa := cr.Spec.ForProvider.ServiceAccountRef; sa != nil {
+		// The principal is derived on every reconcile rather than resolved
+		// once, so that it follows changes to the template.
+		if principal, err = acl.ServiceAccountPrincipal(pc.Spec.PrincipalTemplate, *sa); err != nil {
+			return nil, errors.Wrap(err, errPrincipal)
+		}
+	}
 
-	svc, err := c.newServiceFn(ctx, data, c.kube)
+	svc, err := c.newServiceFn(ctx, pc.Spec.ClientBuilder, data, c.kube)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-	c.cachedClient = svc
-
-	return &external{kafkaClient: svc, log: c.log}, nil
-}
 
-func (c *connectDisconnector) Disconnect(ctx context.Context) error {
-	if c.cachedClient != nil {
-		c.cachedClient.Close()
-	}
-	c.cachedClient = nil
-	return nil
+	return &external{kube: c.kube, kafkaClient: svc, timeouts: c.timeouts, log: c.log, principal: principal}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	kafkaClient *kadm.Client
+	kube        client.Reader
+	kafkaClient *kafka.Client
+	timeouts    kafka.Timeouts
 	log         logging.Logger
+	// principal is derived from the ServiceAccountRef of the
+	// AccessControlList, if any, and replaces its ResourcePrincipal.
+	principal string
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -141,15 +184,25 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotAccessControlList)
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Metadata)
+	defer cancel()
+
 	// Check if the external name is set, to determine if ACL has been created or not
 	ext := meta.GetExternalName(cr)
 	if ext == "" {
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
+	if bulk(cr) {
+		return c.observeBindings(ctx, cr)
+	}
+	if ext == cr.GetName() {
+		return managed.ExternalObservation{}, errors.New(errModeChanged)
+	}
+
 	extname, _ := acl.ConvertFromJSON(meta.GetExternalName(cr))
-	compare := acl.CompareAcls(*extname, *acl.Generate(&cr.Spec.ForProvider))
-	diff := acl.Diff(*extname, *acl.Generate(&cr.Spec.ForProvider))
+	compare := acl.CompareAcls(*extname, *c.generate(cr))
+	diff := acl.Diff(*extname, *c.generate(cr))
 
 	if !compare {
 		err := strings.Join(diff, " ")
@@ -160,16 +213,23 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	ae, err := acl.List(ctx, c.kafkaClient, extname)
-
+	if kafka.IsSecurityDisabled(err) {
+		return observeAuthorizerMissing(cr), nil
+	}
 	if err != nil {
+		err = c.kafkaClient.Diagnose(ctx, err)
+		cr.Status.AtProvider.UnreachableBrokers = kafka.UnreachableBrokers(err)
 		return managed.ExternalObservation{}, errors.Wrap(err, errListACL)
 	}
+	cr.Status.AtProvider.UnreachableBrokers = nil
 
 	if ae == nil {
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
+	setAuthorizerFound(cr)
 	cr.Status.SetConditions(v1.Available())
+	metrics.RecordSuccessfulSync(v1alpha1.AccessControlListKind, cr)
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
@@ -185,22 +245,64 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotAccessControlList)
 	}
 
-	generated := acl.Generate(&cr.Spec.ForProvider)
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutation)
+	defer cancel()
+
+	if bulk(cr) {
+		// The bindings are recorded in status rather than in the external
+		// name, which only marks the AccessControlList as created.
+		meta.SetExternalName(cr, cr.GetName())
+		return managed.ExternalCreation{}, kafka.RetryOnNotController(ctx, c.kafkaClient, func() error {
+			return acl.CreateAll(ctx, c.kafkaClient, acl.GenerateDesired(&cr.Spec.ForProvider))
+		})
+	}
+
+	generated := c.generate(cr)
 	extname, err := acl.ConvertToJSON(generated)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, "could not convert external name to JSON")
 	}
 	if meta.GetExternalName(cr) == "" {
 		meta.SetExternalName(cr, extname)
-		return managed.ExternalCreation{}, acl.Create(ctx, c.kafkaClient, generated)
 	}
 
-	return managed.ExternalCreation{}, acl.Create(ctx, c.kafkaClient, generated)
+	return managed.ExternalCreation{}, kafka.RetryOnNotController(ctx, c.kafkaClient, func() error {
+		return acl.Create(ctx, c.kafkaClient, generated)
+	})
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.AccessControlList)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAccessControlList)
+	}
+	if !bulk(cr) {
+		return managed.ExternalUpdate{}, errors.New(errUpdateNotSupported)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutation)
+	defer cancel()
+
+	desired := acl.GenerateDesired(&cr.Spec.ForProvider)
+	missing, extraneous, err := c.diffBindings(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errListACL)
+	}
 
-	return managed.ExternalUpdate{}, errors.New(errUpdateNotSupported)
+	// Missing bindings are created before extraneous ones are deleted, so
+	// that a principal whose bindings are being reworked never loses access
+	// it keeps in the end.
+	err = kafka.RetryOnNotController(ctx, c.kafkaClient, func() error {
+		if err := acl.CreateAll(ctx, c.kafkaClient, missing); err != nil {
+			return err
+		}
+		return acl.DeleteAll(ctx, c.kafkaClient, extraneous)
+	})
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	cr.Status.AtProvider.Bindings = acl.Bindings(desired)
+	return managed.ExternalUpdate{}, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -210,5 +312,101 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotAccessControlList)
 	}
 
-	return acl.Delete(ctx, c.kafkaClient, acl.Generate(&cr.Spec.ForProvider))
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutation)
+	defer cancel()
+
+	if bulk(cr) {
+		all := acl.Union(acl.GenerateDesired(&cr.Spec.ForProvider), acl.GenerateBindings(cr.Status.AtProvider.Bindings))
+		return kafka.RetryOnNotController(ctx, c.kafkaClient, func() error {
+			return acl.DeleteAll(ctx, c.kafkaClient, all)
+		})
+	}
+
+	return kafka.RetryOnNotController(ctx, c.kafkaClient, func() error {
+		return acl.Delete(ctx, c.kafkaClient, c.generate(cr))
+	})
+}
+
+// generate converts the single ACL of the supplied AccessControlList to
+// Kafka's AccessControlList, granted to the principal derived from its
+// ServiceAccountRef, if any.
+func (c *external) generate(cr *v1alpha1.AccessControlList) *acl.AccessControlList {
+	a := acl.Generate(&cr.Spec.ForProvider)
+	if c.principal != "" {
+		a.ResourcePrincipal = c.principal
+	}
+	return a
+}
+
+// bulk returns true if the supplied AccessControlList manages a list of
+// bindings rather than a single ACL.
+func bulk(cr *v1alpha1.AccessControlList) bool {
+	return len(cr.Spec.ForProvider.Bindings) > 0 || cr.Spec.ForProvider.TransactionalID != nil
+}
+
+// observeBindings observes an AccessControlList in bulk mode. It is up to date
+// when every binding exists and none of the bindings it recorded previously
+// but that were since removed from its spec do.
+func (c *external) observeBindings(ctx context.Context, cr *v1alpha1.AccessControlList) (managed.ExternalObservation, error) {
+	if meta.GetExternalName(cr) != cr.GetName() {
+		return managed.ExternalObservation{}, errors.New(errModeChanged)
+	}
+
+	missing, extraneous, err := c.diffBindings(ctx, cr)
+	if kafka.IsSecurityDisabled(err) {
+		return observeAuthorizerMissing(cr), nil
+	}
+	if err != nil {
+		err = c.kafkaClient.Diagnose(ctx, err)
+		cr.Status.AtProvider.UnreachableBrokers = kafka.UnreachableBrokers(err)
+		return managed.ExternalObservation{}, errors.Wrap(err, errListACL)
+	}
+	cr.Status.AtProvider.UnreachableBrokers = nil
+
+	setAuthorizerFound(cr)
+	cr.Status.SetConditions(v1.Available())
+	metrics.RecordSuccessfulSync(v1alpha1.AccessControlListKind, cr)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(missing) == 0 && len(extraneous) == 0,
+	}, nil
+}
+
+// observeAuthorizerMissing observes an AccessControlList of a Kafka cluster
+// that runs no authorizer. Its ACLs can neither exist nor be created, so
+// rather than retrying with backoff the AccessControlList is reported as up
+// to date until the next poll, and as gone once it is deleted.
+func observeAuthorizerMissing(cr *v1alpha1.AccessControlList) managed.ExternalObservation {
+	cr.Status.AtProvider.UnreachableBrokers = nil
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}
+	}
+	cr.Status.SetConditions(v1alpha1.AuthorizerMissing(msgAuthorizerMissing), v1.Unavailable().WithMessage(msgAuthorizerMissing))
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}
+}
+
+// setAuthorizerFound clears the AuthorizerMissing condition of the supplied
+// AccessControlList, if it was set.
+func setAuthorizerFound(cr *v1alpha1.AccessControlList) {
+	if cr.Status.GetCondition(v1alpha1.TypeAuthorizerMissing).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(v1alpha1.AuthorizerFound())
+	}
+}
+
+// diffBindings returns the bindings of the supplied AccessControlList that are
+// missing, and those that exist but were removed from its spec. It records the
+// bindings that exist in its status, so that they are deleted once removed
+// from its spec.
+func (c *external) diffBindings(ctx context.Context, cr *v1alpha1.AccessControlList) (missing, extraneous []acl.AccessControlList, err error) {
+	desired := acl.GenerateDesired(&cr.Spec.ForProvider)
+	recorded := acl.GenerateBindings(cr.Status.AtProvider.Bindings)
+
+	existing, err := acl.Existing(ctx, c.kafkaClient, acl.Union(desired, recorded))
+	if err != nil {
+		return nil, nil, err
+	}
+	cr.Status.AtProvider.Bindings = acl.Bindings(existing)
+
+	return acl.Subtract(desired, existing), acl.Subtract(existing, desired), nil
 }
@@ -18,15 +18,34 @@ package acl
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
-
-	"github.com/twmb/franz-go/pkg/kadm"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"github.com/twmb/franz-go/pkg/kversion"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+	topicv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/dependency"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka/acl"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -39,7 +58,7 @@ import (
 
 func TestObserve(t *testing.T) {
 	type fields struct {
-		service *kadm.Client
+		service *kafka.Client
 	}
 
 	type args struct {
@@ -58,12 +77,47 @@ func TestObserve(t *testing.T) {
 		args   args
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotCreated": {
+			reason: "An AccessControlList without an external name has not been created yet.",
+			args: args{
+				ctx: context.Background(),
+				mg:  &v1alpha1.AccessControlList{},
+			},
+			want: want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"SwitchedToBulk": {
+			reason: "An AccessControlList created for a single ACL cannot be switched to bulk mode.",
+			args: args{
+				ctx: context.Background(),
+				mg: func() resource.Managed {
+					cr := &v1alpha1.AccessControlList{}
+					cr.SetName("app")
+					meta.SetExternalName(cr, `{"ResourceName":"orders"}`)
+					cr.Spec.ForProvider.Bindings = []v1alpha1.AccessControlListBinding{{ResourceName: "orders"}}
+					return cr
+				}(),
+			},
+			want: want{err: errors.New(errModeChanged)},
+		},
+		"SwitchedToSingle": {
+			reason: "An AccessControlList created in bulk mode cannot be switched to a single ACL.",
+			args: args{
+				ctx: context.Background(),
+				mg: func() resource.Managed {
+					cr := &v1alpha1.AccessControlList{}
+					cr.SetName("app")
+					meta.SetExternalName(cr, "app")
+					cr.Spec.ForProvider.ResourceName = "orders"
+					return cr
+				}(),
+			},
+			want: want{err: errors.New(errModeChanged)},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{kafkaClient: tc.fields.service}
+			e := external{kafkaClient: tc.fields.service, timeouts: kafka.DefaultTimeouts}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -74,3 +128,210 @@ func TestObserve(t *testing.T) {
 		})
 	}
 }
+
+func TestObserveAuthorizerMissing(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.NumBrokers(1))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+	// The fake cluster does not support ACLs, so it is made to advertise
+	// them and refuse them as a broker without an authorizer does.
+	c.ControlKey(int16(kmsg.ApiVersions), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+		c.KeepControl()
+		resp := kreq.ResponseKind().(*kmsg.ApiVersionsResponse)
+		kversion.Stable().EachMaxKeyVersion(func(k, v int16) {
+			resp.ApiKeys = append(resp.ApiKeys, kmsg.ApiVersionsResponseApiKey{ApiKey: k, MaxVersion: v})
+		})
+		return resp, nil, true
+	})
+	c.ControlKey(int16(kmsg.DescribeACLs), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+		c.KeepControl()
+		resp := kreq.ResponseKind().(*kmsg.DescribeACLsResponse)
+		resp.ErrorCode = kerr.SecurityDisabled.Code
+		return resp, nil, true
+	})
+
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(context.Background(), creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	single := func() *v1alpha1.AccessControlList {
+		cr := &v1alpha1.AccessControlList{}
+		cr.SetName("app")
+		cr.Spec.ForProvider.ResourceName = "orders"
+		cr.Spec.ForProvider.ResourceType = "Topic"
+		cr.Spec.ForProvider.ResourcePrincipal = "User:app"
+		cr.Spec.ForProvider.ResourceHost = "*"
+		cr.Spec.ForProvider.ResourceOperation = "Read"
+		cr.Spec.ForProvider.ResourcePermissionType = "Allow"
+		cr.Spec.ForProvider.ResourcePatternTypeFilter = "Literal"
+		extname, _ := acl.ConvertToJSON(acl.Generate(&cr.Spec.ForProvider))
+		meta.SetExternalName(cr, extname)
+		return cr
+	}
+	bulk := func() *v1alpha1.AccessControlList {
+		cr := &v1alpha1.AccessControlList{}
+		cr.SetName("app")
+		meta.SetExternalName(cr, "app")
+		cr.Spec.ForProvider.Bindings = []v1alpha1.AccessControlListBinding{{
+			ResourceName:              "orders",
+			ResourceType:              "Topic",
+			ResourcePrincipal:         "User:app",
+			ResourceHost:              "*",
+			ResourceOperation:         "Read",
+			ResourcePermissionType:    "Allow",
+			ResourcePatternTypeFilter: "Literal",
+		}}
+		return cr
+	}
+	deleted := func(cr *v1alpha1.AccessControlList) *v1alpha1.AccessControlList {
+		cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+		return cr
+	}
+
+	cases := map[string]struct {
+		reason      string
+		cr          *v1alpha1.AccessControlList
+		want        managed.ExternalObservation
+		wantMissing corev1.ConditionStatus
+	}{
+		"Single": {
+			reason:      "A single ACL of a cluster without an authorizer should be reported as up to date and flagged rather than retried.",
+			cr:          single(),
+			want:        managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			wantMissing: corev1.ConditionTrue,
+		},
+		"Bulk": {
+			reason:      "ACL bindings of a cluster without an authorizer should be reported as up to date and flagged rather than retried.",
+			cr:          bulk(),
+			want:        managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			wantMissing: corev1.ConditionTrue,
+		},
+		"Deleted": {
+			reason:      "A deleted AccessControlList of a cluster without an authorizer should be reported as gone, since its ACLs cannot exist.",
+			cr:          deleted(bulk()),
+			want:        managed.ExternalObservation{ResourceExists: false},
+			wantMissing: corev1.ConditionUnknown,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{kafkaClient: cl, timeouts: kafka.DefaultTimeouts}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if got := tc.cr.Status.GetCondition(v1alpha1.TypeAuthorizerMissing).Status; got != tc.wantMissing {
+				t.Errorf("\n%s\ne.Observe(...): want AuthorizerMissing %s, got %s\n", tc.reason, tc.wantMissing, got)
+			}
+		})
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	aclFor := func(ref *xpv1.Reference) *v1alpha1.AccessControlList {
+		cr := &v1alpha1.AccessControlList{}
+		cr.Spec.ForProvider.TopicRef = ref
+		return cr
+	}
+
+	type want struct {
+		unready string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		cr     *v1alpha1.AccessControlList
+		want   want
+	}{
+		"NoReference": {
+			reason: "An ACL that does not reference a Topic can always be created.",
+			cr:     aclFor(nil),
+		},
+		"TopicReady": {
+			reason: "An ACL whose referenced Topic is ready can be created.",
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*topicv1alpha1.Topic).SetConditions(xpv1.Available())
+				return nil
+			})},
+			cr: aclFor(&xpv1.Reference{Name: "orders"}),
+		},
+		"TopicNotReady": {
+			reason: "An ACL whose referenced Topic is not ready yet should wait for it.",
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*topicv1alpha1.Topic).SetConditions(xpv1.Creating())
+				return nil
+			})},
+			cr:   aclFor(&xpv1.Reference{Name: "orders"}),
+			want: want{unready: `Topic "orders" is not ready`},
+		},
+		"TopicMissing": {
+			reason: "An ACL whose referenced Topic does not exist yet should wait for it.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "orders"))},
+			cr:     aclFor(&xpv1.Reference{Name: "orders"}),
+			want:   want{unready: `Topic "orders" does not exist`},
+		},
+		"GetError": {
+			reason: "Errors getting the referenced Topic should be returned.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:     aclFor(&xpv1.Reference{Name: "orders"}),
+			want:   want{err: errors.Wrapf(errBoom, "cannot get %s %q", "Topic", "orders")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			unready, err := dependency.NewGate(tc.kube, dependencies).Unready(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUnready(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.unready, unready); diff != "" {
+				t.Errorf("\n%s\nUnready(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConnectServiceAccount(t *testing.T) {
+	cr := &v1alpha1.AccessControlList{}
+	cr.SetProviderConfigReference(&xpv1.Reference{Name: "example"})
+	cr.Spec.ForProvider.ResourceName = "orders"
+	cr.Spec.ForProvider.ResourceType = "Topic"
+	cr.Spec.ForProvider.ServiceAccountRef = &v1alpha1.ServiceAccountReference{Namespace: "payments", Name: "orders"}
+
+	c := &connector{
+		kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*apisv1alpha1.ProviderConfig).Spec.Credentials.Source = xpv1.CredentialsSourceNone
+			obj.(*apisv1alpha1.ProviderConfig).Spec.PrincipalTemplate = "User:system:serviceaccount:{{ .Namespace }}:{{ .Name }}"
+			return nil
+		})},
+		usage: resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil }),
+		newServiceFn: func(_ context.Context, _ string, _ []byte, _ client.Reader) (*kafka.Client, error) {
+			return &kafka.Client{}, nil
+		},
+	}
+	e, err := c.Connect(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Connect(...): %v", err)
+	}
+
+	if diff := cmp.Diff("", cr.Spec.ForProvider.ResourcePrincipal); diff != "" {
+		t.Errorf("\nThe principal derived from the ServiceAccount should not be written to the spec.\nConnect(...): -want, +got:\n%s", diff)
+	}
+	want := "User:system:serviceaccount:payments:orders"
+	if diff := cmp.Diff(want, e.(*external).generate(cr).ResourcePrincipal); diff != "" {
+		t.Errorf("\nThe ACL should be granted to the principal derived from the ServiceAccount.\ngenerate(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/identity"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const (
+	errGetPC           = "cannot get ProviderConfig"
+	errGetCreds        = "cannot get credentials"
+	errGetBrokers      = "cannot get brokers"
+	errNewClient       = "cannot create new Kafka client"
+	errDescribeCluster = "cannot describe Kafka cluster"
+	errPatchStatus     = "cannot patch ProviderConfig status"
+)
+
+// A clusterReconciler periodically describes the Kafka cluster of each
+// ProviderConfig in its status, e.g. whether the cluster runs in KRaft mode,
+// and reports the cluster's capabilities as conditions.
+type clusterReconciler struct {
+	kube         client.Client
+	newServiceFn func(ctx context.Context, builder string, creds []byte, kube client.Reader) (*kafka.Client, error)
+	timeouts     kafka.Timeouts
+	interval     time.Duration
+	log          logging.Logger
+}
+
+// Reconcile describes the Kafka cluster of a ProviderConfig. A cluster that
+// cannot be described is retried after the interval, keeping its previous
+// description, as managed resources report why their brokers are unusable.
+func (r *clusterReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), errGetPC)
+	}
+	if pc.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	ci, err := r.describe(ctx, pc)
+	if err != nil {
+		log.Debug("Cannot describe Kafka cluster", "error", err)
+		return reconcile.Result{RequeueAfter: r.interval}, nil
+	}
+
+	orig := pc.DeepCopy()
+	pc.Status.Cluster = &v1alpha1.ClusterStatus{
+		ID:               ci.ID,
+		ControllerID:     ci.ControllerID,
+		Brokers:          ci.Brokers,
+		Racks:            ci.Racks,
+		Version:          ci.Version,
+		MetadataMode:     ci.MetadataMode,
+		LastObservedTime: metav1.Now(),
+	}
+	for _, b := range ci.BrokerDetails {
+		pc.Status.Cluster.BrokerDetails = append(pc.Status.Cluster.BrokerDetails, v1alpha1.BrokerStatus{
+			ID:         b.ID,
+			Host:       b.Host,
+			Port:       b.Port,
+			Rack:       b.Rack,
+			Controller: b.Controller,
+		})
+	}
+	if q := ci.Quorum; q != nil {
+		pc.Status.Cluster.Quorum = &v1alpha1.MetadataQuorum{
+			LeaderID:      q.LeaderID,
+			LeaderEpoch:   q.LeaderEpoch,
+			HighWatermark: q.HighWatermark,
+			Voters:        q.Voters,
+			Observers:     q.Observers,
+		}
+	}
+	pc.Status.SetConditions(
+		v1alpha1.Capability(v1alpha1.TypeSupportsTopicIDs, ci.Capabilities.TopicIDs),
+		v1alpha1.Capability(v1alpha1.TypeSupportsIncrementalAlterConfigs, ci.Capabilities.IncrementalAlterConfigs),
+		v1alpha1.Capability(v1alpha1.TypeSupportsDelegationTokens, ci.Capabilities.DelegationTokens),
+		v1alpha1.Capability(v1alpha1.TypeACLsEnabled, ci.Capabilities.ACLs),
+	)
+	if err := r.kube.Status().Patch(ctx, pc, client.MergeFrom(orig)); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, errPatchStatus)
+	}
+	return reconcile.Result{RequeueAfter: r.interval}, nil
+}
+
+func (r *clusterReconciler) describe(ctx context.Context, pc *v1alpha1.ProviderConfig) (*kafka.ClusterInfo, error) {
+	cd := pc.Spec.Credentials
+	data, err := kafka.ExtractCredentials(ctx, cd.Source, r.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	if ref := pc.Spec.BrokersConfigMapRef; ref != nil {
+		if data, err = kafka.ReplaceBrokers(ctx, r.kube, data, ref.Namespace, ref.Name, ref.Key); err != nil {
+			return nil, errors.Wrap(err, errGetBrokers)
+		}
+	}
+	if data, err = identity.Credentials(data, pc, nil); err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	svc, err := r.newServiceFn(ctx, pc.Spec.ClientBuilder, data, r.kube)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Metadata)
+	defer cancel()
+	ci, err := svc.DescribeCluster(ctx)
+	return ci, errors.Wrap(err, errDescribeCluster)
+}
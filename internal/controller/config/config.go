@@ -18,18 +18,23 @@ package config
 
 import (
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/providerconfig"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/options"
+	"github.com/crossplane-contrib/provider-kafka/internal/shard"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
 )
 
 // Setup adds a controller that reconciles ProviderConfigs by accounting for
-// their current usage.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+// their current usage, and one that describes their Kafka clusters.
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := providerconfig.ControllerName(v1alpha1.ProviderConfigGroupKind)
 
 	of := resource.ProviderConfigKinds{
@@ -37,10 +42,42 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		UsageList: v1alpha1.ProviderConfigUsageListGroupVersionKind,
 	}
 
-	r := providerconfig.NewReconciler(mgr, of,
+	var r reconcile.Reconciler = providerconfig.NewReconciler(mgr, of,
 		providerconfig.WithLogger(o.Logger.WithValues("controller", name)),
 		providerconfig.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
 
+	gc := &usageGarbageCollector{
+		reader:   mgr.GetAPIReader(),
+		kube:     mgr.GetClient(),
+		log:      o.Logger.WithValues("controller", name, "component", "usage-gc"),
+		interval: usageGCInterval,
+	}
+	if err := mgr.Add(gc); err != nil {
+		return err
+	}
+
+	cr := &clusterReconciler{
+		kube:         o.CredentialsClient(mgr.GetClient()),
+		newServiceFn: o.ClientCache.Get,
+		timeouts:     o.Timeouts,
+		interval:     o.PollInterval,
+		log:          o.Logger.WithValues("controller", name, "component", "cluster"),
+	}
+	var crr reconcile.Reconciler = kafka.NewThrottlingReconciler(cr)
+	if o.Shard != nil {
+		r = shard.NewProviderConfigReconciler(r, o.Shard)
+		crr = shard.NewProviderConfigReconciler(crr, o.Shard)
+	}
+
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named(name+"-cluster").
+		WithOptions(o.ForControllerRuntime()).
+		// Relabeling a ProviderConfig moves it to another shard.
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}))).
+		Complete(crr); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
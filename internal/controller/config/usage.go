@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+const (
+	// usageGCInterval is how often stale ProviderConfigUsages are collected.
+	usageGCInterval = 1 * time.Hour
+
+	errListUsages  = "cannot list ProviderConfigUsages"
+	errGetResource = "cannot get resource referenced by ProviderConfigUsage"
+	errDeleteUsage = "cannot delete stale ProviderConfigUsage"
+)
+
+// A usageGarbageCollector periodically removes ProviderConfigUsages whose
+// managed resource no longer exists. Usages are normally removed through
+// their owner reference, but that does not happen when the managed resource
+// was recreated with a new UID (e.g. restored from a backup or migrated
+// between clusters). Such orphans would block ProviderConfig deletion forever.
+type usageGarbageCollector struct {
+	// reader is used to look up arbitrary managed resource kinds, so it
+	// should be uncached to avoid starting an informer per kind.
+	reader   client.Reader
+	kube     client.Client
+	log      logging.Logger
+	interval time.Duration
+}
+
+// Start runs the garbage collector until the supplied context is done.
+func (gc *usageGarbageCollector) Start(ctx context.Context) error {
+	t := time.NewTicker(gc.interval)
+	defer t.Stop()
+
+	for {
+		if err := gc.collect(ctx); err != nil {
+			gc.log.Info("Cannot garbage collect ProviderConfigUsages", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// collect deletes every ProviderConfigUsage that references a managed
+// resource that either does not exist anymore, or exists with another UID.
+func (gc *usageGarbageCollector) collect(ctx context.Context) error {
+	l := &v1alpha1.ProviderConfigUsageList{}
+	if err := gc.kube.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListUsages)
+	}
+
+	// A usage that cannot be collected, e.g. because its resource cannot be
+	// read, does not keep the others from being collected.
+	var errs []error
+	for i := range l.Items {
+		pcu := &l.Items[i]
+		if err := gc.collectUsage(ctx, pcu); err != nil {
+			gc.log.Info("Cannot garbage collect ProviderConfigUsage", "name", pcu.GetName(), "error", err)
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// collectUsage deletes the supplied ProviderConfigUsage if it is stale.
+func (gc *usageGarbageCollector) collectUsage(ctx context.Context, pcu *v1alpha1.ProviderConfigUsage) error {
+	stale, err := gc.isStale(ctx, pcu)
+	if err != nil || !stale {
+		return err
+	}
+	if err := gc.kube.Delete(ctx, pcu); client.IgnoreNotFound(err) != nil {
+		return errors.Wrap(err, errDeleteUsage)
+	}
+	gc.log.Debug("Deleted stale ProviderConfigUsage", "name", pcu.GetName(), "resource", pcu.ResourceReference)
+	return nil
+}
+
+func (gc *usageGarbageCollector) isStale(ctx context.Context, pcu *v1alpha1.ProviderConfigUsage) (bool, error) {
+	ref := pcu.GetResourceReference()
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		// A usage we cannot resolve can never be matched to a resource.
+		gc.log.Info("Deleting ProviderConfigUsage referencing an invalid API version", "name", pcu.GetName(), "apiVersion", ref.APIVersion, "error", err)
+		return true, nil //nolint:nilerr
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gv.WithKind(ref.Kind))
+	err = gc.reader.Get(ctx, types.NamespacedName{Name: ref.Name}, u)
+	if kerrors.IsNotFound(err) || kmeta.IsNoMatchError(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, errGetResource)
+	}
+
+	// Usages are named after the UID of the managed resource that created
+	// them, so a mismatch means the resource was recreated.
+	return u.GetUID() != types.UID(pcu.GetName()), nil
+}
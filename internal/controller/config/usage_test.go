@@ -0,0 +1,145 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+func usage(uid string) v1alpha1.ProviderConfigUsage {
+	return usageOf(uid, "orders")
+}
+
+func usageOf(uid, resource string) v1alpha1.ProviderConfigUsage {
+	pcu := v1alpha1.ProviderConfigUsage{}
+	pcu.SetName(uid)
+	pcu.SetResourceReference(xpv1.TypedReference{
+		APIVersion: "topic.kafka.crossplane.io/v1alpha1",
+		Kind:       "Topic",
+		Name:       resource,
+	})
+	return pcu
+}
+
+func TestCollect(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		deleted []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		usages []v1alpha1.ProviderConfigUsage
+		getErr error
+		// getErrs are the errors getting the named resources, which take
+		// precedence over getErr.
+		getErrs map[string]error
+		getUID  types.UID
+		want    want
+	}{
+		"ResourceExists": {
+			reason: "A usage whose resource exists with the same UID should be kept.",
+			usages: []v1alpha1.ProviderConfigUsage{usage("uid-1")},
+			getUID: "uid-1",
+			want:   want{},
+		},
+		"ResourceGone": {
+			reason: "A usage whose resource does not exist anymore should be deleted.",
+			usages: []v1alpha1.ProviderConfigUsage{usage("uid-1")},
+			getErr: kerrors.NewNotFound(schema.GroupResource{}, "orders"),
+			want:   want{deleted: []string{"uid-1"}},
+		},
+		"ResourceRecreated": {
+			reason: "A usage whose resource was recreated with another UID should be deleted.",
+			usages: []v1alpha1.ProviderConfigUsage{usage("uid-1")},
+			getUID: "uid-2",
+			want:   want{deleted: []string{"uid-1"}},
+		},
+		"GetError": {
+			reason: "Errors looking up the resource should be returned and nothing deleted.",
+			usages: []v1alpha1.ProviderConfigUsage{usage("uid-1")},
+			getErr: errBoom,
+			want:   want{err: utilerrors.NewAggregate([]error{errors.Wrap(errBoom, errGetResource)})},
+		},
+		"GetErrorOfOne": {
+			reason: "An error looking up the resource of one usage should not keep the other stale usages from being deleted.",
+			usages: []v1alpha1.ProviderConfigUsage{usageOf("uid-1", "orders"), usageOf("uid-2", "payments")},
+			getErrs: map[string]error{
+				"orders":   errBoom,
+				"payments": kerrors.NewNotFound(schema.GroupResource{}, "payments"),
+			},
+			want: want{
+				deleted: []string{"uid-2"},
+				err:     utilerrors.NewAggregate([]error{errors.Wrap(errBoom, errGetResource)}),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			kube := &test.MockClient{
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					obj.(*v1alpha1.ProviderConfigUsageList).Items = tc.usages
+					return nil
+				}),
+				MockDelete: test.NewMockDeleteFn(nil, func(obj client.Object) error {
+					deleted = append(deleted, obj.GetName())
+					return nil
+				}),
+			}
+			reader := &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					if err, ok := tc.getErrs[key.Name]; ok {
+						return err
+					}
+					if tc.getErr != nil {
+						return tc.getErr
+					}
+					obj.SetUID(tc.getUID)
+					return nil
+				},
+			}
+			gc := &usageGarbageCollector{reader: reader, kube: kube, log: logging.NewNopLogger()}
+
+			err := gc.collect(context.Background())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ngc.collect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\ngc.collect(...): -want deleted, +got deleted:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connectcluster
+
+import (
+	"context"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/connect/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/connect"
+	"github.com/crossplane-contrib/provider-kafka/internal/deletion"
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+	"github.com/crossplane-contrib/provider-kafka/internal/options"
+)
+
+const (
+	errNotConnectCluster = "managed resource is not a ConnectCluster custom resource"
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errNewClient         = "cannot create new Kafka Connect client"
+)
+
+// Setup adds a controller that reconciles ConnectCluster managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.ConnectClusterGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ConnectClusterGroupVersionKind),
+		managed.WithExternalConnecter(o.ExternalConnecter(deletion.NewConnecter(metrics.NewConnecter(&connector{
+			kube:        o.CredentialsClient(mgr.GetClient()),
+			usage:       o.UsageTracker(mgr.GetClient()),
+			newClientFn: connect.NewClient}, v1alpha1.ConnectClusterKind), o.Deletion, event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), o.Logger))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(o.PollIntervalHook()),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithInitializers(o.Initializers(mgr.GetClient(), managed.NewNameAsExternalName(mgr.GetClient()))...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ConnectCluster{}).
+		Complete(o.Sharded(&v1alpha1.ConnectCluster{}, ratelimiter.NewReconciler(name, metrics.NewReconciler(v1alpha1.ConnectClusterKind, r), o.GlobalRateLimiter)))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube        client.Client
+	usage       resource.Tracker
+	newClientFn func(ctx context.Context, kube client.Client, p v1alpha1.ConnectClusterParameters) (*connect.Client, error)
+}
+
+// Connect produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Reading the REST API credentials referenced by the managed resource.
+// 3. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.ConnectCluster)
+	if !ok {
+		return nil, errors.New(errNotConnectCluster)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	svc, err := c.newClientFn(ctx, c.kube, cr.Spec.ForProvider)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{client: svc}, nil
+}
+
+// An ExternalClient checks the health of a Kafka Connect REST API. There is
+// nothing to create, update, or delete: a ConnectCluster only describes how
+// to reach an existing Kafka Connect cluster.
+type external struct {
+	client *connect.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ConnectCluster)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotConnectCluster)
+	}
+
+	// Report the ConnectCluster gone once it is being deleted, so that its
+	// finalizer is removed without touching the Kafka Connect cluster.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	si, err := c.client.ServerInfo(ctx)
+	if err != nil {
+		// An unreachable cluster is reported through the Ready condition
+		// rather than as a reconcile error, since it is the very health
+		// this resource exists to report.
+		cr.Status.SetConditions(v1.Unavailable().WithMessage(err.Error()))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	cr.Status.AtProvider = v1alpha1.ConnectClusterObservation{
+		Version:        si.Version,
+		Commit:         si.Commit,
+		KafkaClusterID: si.KafkaClusterID,
+	}
+	cr.Status.SetConditions(v1.Available())
+	metrics.RecordSuccessfulSync(v1alpha1.ConnectClusterKind, cr)
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (c *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
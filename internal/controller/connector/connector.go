@@ -0,0 +1,245 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/connect/v1alpha1"
+	topicv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/audit"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/connect"
+	"github.com/crossplane-contrib/provider-kafka/internal/deletion"
+	"github.com/crossplane-contrib/provider-kafka/internal/dependency"
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+	"github.com/crossplane-contrib/provider-kafka/internal/options"
+)
+
+const (
+	errNotConnector      = "managed resource is not a Connector custom resource"
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetConnectCluster = "cannot get referenced ConnectCluster"
+	errNewClient         = "cannot create new Kafka Connect client"
+	errInvalidConfig     = "connector config is invalid: %s"
+
+	// stateRunning is the state of a connector that is running.
+	stateRunning = "RUNNING"
+)
+
+// Setup adds a controller that reconciles Connector managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.ConnectorGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ConnectorGroupVersionKind),
+		managed.WithExternalConnecter(o.ExternalConnecter(dependency.NewConnecter(deletion.NewConnecter(audit.NewConnecter(metrics.NewConnecter(&connector{
+			kube:        o.CredentialsClient(mgr.GetClient()),
+			usage:       o.UsageTracker(mgr.GetClient()),
+			newClientFn: connect.NewClient}, v1alpha1.ConnectorKind), v1alpha1.ConnectorKind, o.Audit, o.Logger), o.Deletion, event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), o.Logger)))),
+		managed.WithReferenceResolver(dependency.NewReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient()), dependency.NewGate(mgr.GetClient(), dependencies))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(o.PollIntervalHook()),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithInitializers(o.Initializers(mgr.GetClient(), managed.NewNameAsExternalName(mgr.GetClient()))...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Connector{}).
+		Watches(&v1alpha1.ConnectCluster{}, dependency.EnqueueDependents(mgr.GetClient(), &v1alpha1.ConnectorList{}, dependencies)).
+		Watches(&topicv1alpha1.Topic{}, dependency.EnqueueDependents(mgr.GetClient(), &v1alpha1.ConnectorList{}, dependencies)).
+		Complete(o.Sharded(&v1alpha1.Connector{}, ratelimiter.NewReconciler(name, metrics.NewReconciler(v1alpha1.ConnectorKind, r), o.GlobalRateLimiter)))
+}
+
+// dependencies returns the ConnectCluster and Topics referenced by the
+// supplied Connector, which must be ready before the connector is created.
+func dependencies(mg resource.Managed) []dependency.Dependency {
+	cr, ok := mg.(*v1alpha1.Connector)
+	if !ok {
+		return nil
+	}
+	deps := []dependency.Dependency{{Object: &v1alpha1.ConnectCluster{}, Name: cr.Spec.ForProvider.ConnectClusterRef.Name}}
+	for _, ref := range cr.Spec.ForProvider.TopicRefs {
+		deps = append(deps, dependency.Dependency{Object: &topicv1alpha1.Topic{}, Name: ref.Name})
+	}
+	return deps
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube        client.Client
+	usage       resource.Tracker
+	newClientFn func(ctx context.Context, kube client.Client, p v1alpha1.ConnectClusterParameters) (*connect.Client, error)
+}
+
+// Connect produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the ConnectCluster referenced by the managed resource.
+// 3. Using the ConnectCluster's REST API credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Connector)
+	if !ok {
+		return nil, errors.New(errNotConnector)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	cc := &v1alpha1.ConnectCluster{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.Spec.ForProvider.ConnectClusterRef.Name}, cc); err != nil {
+		return nil, errors.Wrap(err, errGetConnectCluster)
+	}
+
+	svc, err := c.newClientFn(ctx, c.kube, cc.Spec.ForProvider)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{client: svc}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes a
+// Kafka Connect connector to ensure it reflects the managed resource's desired
+// state.
+type external struct {
+	client *connect.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Connector)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotConnector)
+	}
+
+	name := meta.GetExternalName(cr)
+	cfg, err := c.client.GetConnectorConfig(ctx, name)
+	if connect.IsNotFound(err) {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		// The status set while creating a connector is not persisted, so
+		// its config is validated before, where an error persists it.
+		return managed.ExternalObservation{ResourceExists: false}, c.validate(ctx, cr)
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	s, err := c.client.GetConnectorStatus(ctx, name)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.Status.AtProvider = v1alpha1.ConnectorObservation{
+		State:    s.Connector.State,
+		WorkerID: s.Connector.WorkerID,
+	}
+	if s.Connector.State == stateRunning {
+		cr.Status.SetConditions(v1.Available())
+		metrics.RecordSuccessfulSync(v1alpha1.ConnectorKind, cr)
+	} else {
+		cr.Status.SetConditions(v1.Unavailable().WithMessage(s.Connector.State))
+	}
+
+	p := cr.Spec.ForProvider
+	upToDate := maps.Equal(cfg, connect.ConnectorConfig(name, p.Class, p.Config))
+	if upToDate {
+		// The config was accepted by the connector plugin when it was
+		// applied.
+		cr.Status.SetConditions(v1alpha1.ConfigValid())
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Connector)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotConnector)
+	}
+
+	return managed.ExternalCreation{}, c.apply(ctx, cr)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Connector)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotConnector)
+	}
+
+	return managed.ExternalUpdate{}, c.apply(ctx, cr)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Connector)
+	if !ok {
+		return errors.New(errNotConnector)
+	}
+
+	err := c.client.DeleteConnector(ctx, meta.GetExternalName(cr))
+	if connect.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// apply validates the desired connector config against its connector plugin
+// and, if the plugin accepts it, creates or updates the connector, so that a
+// connector whose tasks would immediately fail is never created.
+func (c *external) apply(ctx context.Context, cr *v1alpha1.Connector) error {
+	if err := c.validate(ctx, cr); err != nil {
+		return err
+	}
+	name, p := meta.GetExternalName(cr), cr.Spec.ForProvider
+	return c.client.PutConnectorConfig(ctx, name, connect.ConnectorConfig(name, p.Class, p.Config))
+}
+
+// validate validates the desired connector config against its connector
+// plugin. Field errors are reported through the ConfigValid condition, and
+// returned.
+func (c *external) validate(ctx context.Context, cr *v1alpha1.Connector) error {
+	p := cr.Spec.ForProvider
+	v, err := c.client.ValidateConfig(ctx, connect.ConnectorConfig(meta.GetExternalName(cr), p.Class, p.Config))
+	if err != nil {
+		return err
+	}
+	if v.ErrorCount > 0 {
+		msg := strings.Join(v.FieldErrors(), "; ")
+		cr.Status.SetConditions(v1alpha1.ConfigInvalid(msg))
+		return errors.Errorf(errInvalidConfig, msg)
+	}
+	cr.Status.SetConditions(v1alpha1.ConfigValid())
+	return nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	kfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/connect/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/connect"
+)
+
+func connectorCR() *v1alpha1.Connector {
+	cr := &v1alpha1.Connector{}
+	meta.SetExternalName(cr, "sink")
+	cr.Spec.ForProvider = v1alpha1.ConnectorParameters{
+		Class:  "FileStreamSinkConnector",
+		Config: map[string]string{"topics": "orders"},
+	}
+	return cr
+}
+
+func TestCreate(t *testing.T) {
+	invalid := `{"error_count":1,"configs":[{"value":{"name":"file","errors":["Missing required configuration"]}}]}`
+
+	type want struct {
+		created   bool
+		condition xpv1.Condition
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		validate string
+		want     want
+	}{
+		"ValidConfig": {
+			reason:   "A config accepted by the connector plugin should be used to create the connector.",
+			validate: `{"error_count":0,"configs":[]}`,
+			want: want{
+				created:   true,
+				condition: v1alpha1.ConfigValid(),
+			},
+		},
+		"InvalidConfig": {
+			reason:   "A config rejected by the connector plugin should be reported without creating the connector.",
+			validate: invalid,
+			want: want{
+				condition: v1alpha1.ConfigInvalid("file: Missing required configuration"),
+				err:       errors.Errorf(errInvalidConfig, "file: Missing required configuration"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			created := false
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/connector-plugins/FileStreamSinkConnector/config/validate":
+					_, _ = w.Write([]byte(tc.validate))
+				case "/connectors/sink/config":
+					created = true
+					_, _ = w.Write([]byte(`{}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			cl, err := connect.NewClient(context.Background(), nil, v1alpha1.ConnectClusterParameters{URL: srv.URL})
+			if err != nil {
+				t.Fatalf("NewClient(...): %s", err)
+			}
+
+			cr := connectorCR()
+			e := external{client: cl}
+			_, err = e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.created, created); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want created, +got created:\n%s\n", tc.reason, diff)
+			}
+			got := cr.Status.GetCondition(v1alpha1.TypeConfigValid)
+			if diff := cmp.Diff(tc.want.condition, got, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconcileConfigCondition(t *testing.T) {
+	invalid := `{"error_count":1,"configs":[{"value":{"name":"file","errors":["Missing required configuration"]}}]}`
+
+	type want struct {
+		created   bool
+		condition xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason   string
+		validate string
+		want     want
+	}{
+		"ValidConfig": {
+			reason:   "A connector created with a config accepted by the connector plugin should be reported valid.",
+			validate: `{"error_count":0,"configs":[]}`,
+			want: want{
+				created:   true,
+				condition: v1alpha1.ConfigValid(),
+			},
+		},
+		"InvalidConfig": {
+			reason:   "A config rejected by the connector plugin should be reported in the persisted status, without creating the connector.",
+			validate: invalid,
+			want: want{
+				condition: v1alpha1.ConfigInvalid("file: Missing required configuration"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var config []byte
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case r.URL.Path == "/connector-plugins/FileStreamSinkConnector/config/validate":
+					_, _ = w.Write([]byte(tc.validate))
+				case r.URL.Path == "/connectors/sink/config" && r.Method == http.MethodPut:
+					config, _ = io.ReadAll(r.Body)
+					_, _ = w.Write([]byte(`{}`))
+				case r.URL.Path == "/connectors/sink/config" && config != nil:
+					_, _ = w.Write(config)
+				case r.URL.Path == "/connectors/sink/status" && config != nil:
+					_, _ = w.Write([]byte(`{"connector":{"state":"RUNNING","worker_id":"worker-0"}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			cl, err := connect.NewClient(context.Background(), nil, v1alpha1.ConnectClusterParameters{URL: srv.URL})
+			if err != nil {
+				t.Fatalf("NewClient(...): %s", err)
+			}
+
+			s := runtime.NewScheme()
+			_ = v1alpha1.SchemeBuilder.AddToScheme(s)
+			cr := connectorCR()
+			cr.SetName("sink")
+			kube := kfake.NewClientBuilder().WithScheme(s).WithObjects(cr).WithStatusSubresource(cr).Build()
+
+			// The status set while creating the connector is reloaded from
+			// the API server when the reconciler updates its critical
+			// annotations, so only the status the reconciler persists is
+			// checked.
+			r := managed.NewReconciler(&fake.Manager{Client: kube, Scheme: s}, resource.ManagedKind(v1alpha1.ConnectorGroupVersionKind),
+				managed.WithExternalConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+					return &external{client: cl}, nil
+				})),
+				managed.WithInitializers(),
+				managed.WithReferenceResolver(managed.ReferenceResolverFn(func(_ context.Context, _ resource.Managed) error { return nil })),
+			)
+			for i := 0; i < 2; i++ {
+				_, _ = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+			}
+
+			got := &v1alpha1.Connector{}
+			if err := kube.Get(context.Background(), client.ObjectKeyFromObject(cr), got); err != nil {
+				t.Fatalf("Get(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want.created, config != nil); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want created, +got created:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, got.Status.GetCondition(v1alpha1.TypeConfigValid), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package groupoffsetsnapshot
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/group/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/audit"
+	"github.com/crossplane-contrib/provider-kafka/internal/deletion"
+	"github.com/crossplane-contrib/provider-kafka/internal/identity"
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+	"github.com/crossplane-contrib/provider-kafka/internal/options"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka/group"
+)
+
+const (
+	errNotGroupOffsetSnapshot = "managed resource is not a GroupOffsetSnapshot custom resource"
+	errTrackPCUsage           = "cannot track ProviderConfig usage"
+	errGetPC                  = "cannot get ProviderConfig"
+	errGetCreds               = "cannot get credentials"
+	errGetBrokers             = "cannot get brokers"
+	errFetchOffsets           = "cannot fetch committed offsets"
+
+	errNewClient = "cannot create new Kafka client"
+)
+
+// defaultInterval is the interval between two snapshots when none is
+// configured.
+const defaultInterval = time.Hour
+
+// Setup adds a controller that reconciles GroupOffsetSnapshot managed
+// resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.GroupOffsetSnapshotGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.GroupOffsetSnapshotGroupVersionKind),
+		managed.WithExternalConnecter(o.ExternalConnecter(identity.NewConnecter(deletion.NewConnecter(audit.NewConnecter(metrics.NewConnecter(&connector{
+			kube:         o.CredentialsClient(mgr.GetClient()),
+			usage:        o.UsageTracker(mgr.GetClient()),
+			log:          o.Logger.WithValues("controller", name),
+			newServiceFn: o.ClientCache.Get,
+			timeouts:     o.Timeouts}, v1alpha1.GroupOffsetSnapshotKind), v1alpha1.GroupOffsetSnapshotKind, o.Audit, o.Logger), o.Deletion, event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), o.Logger)))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(o.PollIntervalHook()),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(o.ConnectionPublishers(mgr.GetClient())...),
+		managed.WithInitializers(o.Initializers(mgr.GetClient(), managed.NewNameAsExternalName(mgr.GetClient()))...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.GroupOffsetSnapshot{}).
+		Complete(o.Sharded(&v1alpha1.GroupOffsetSnapshot{}, ratelimiter.NewReconciler(name, kafka.NewThrottlingReconciler(metrics.NewReconciler(v1alpha1.GroupOffsetSnapshotKind, r)), o.GlobalRateLimiter)))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called. Clients are shared between reconciles through a cache, so they
+// are never closed after a reconcile.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	log          logging.Logger
+	newServiceFn func(ctx context.Context, builder string, creds []byte, kube client.Reader) (*kafka.Client, error)
+	timeouts     kafka.Timeouts
+}
+
+// Connect produces an ExternalClient using the credentials of the
+// GroupOffsetSnapshot's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.GroupOffsetSnapshot)
+	if !ok {
+		return nil, errors.New(errNotGroupOffsetSnapshot)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	data, err := kafka.ExtractCredentials(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	if ref := pc.Spec.BrokersConfigMapRef; ref != nil {
+		if data, err = kafka.ReplaceBrokers(ctx, c.kube, data, ref.Namespace, ref.Name, ref.Key); err != nil {
+			return nil, errors.Wrap(err, errGetBrokers)
+		}
+	}
+	if data, err = identity.Credentials(data, pc, cr); err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	svc, err := c.newServiceFn(ctx, pc.Spec.ClientBuilder, data, c.kube)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{kube: c.kube, kafkaClient: svc, timeouts: c.timeouts, log: c.log}, nil
+}
+
+// An ExternalClient takes snapshots of the offsets committed by a consumer
+// group. Snapshots are recorded in the status of the resource, which Create
+// implementations must not alter, so a GroupOffsetSnapshot always exists and
+// a snapshot is taken by updating it whenever the last one is outdated.
+type external struct {
+	kube        client.Client
+	kafkaClient *kafka.Client
+	timeouts    kafka.Timeouts
+	log         logging.Logger
+}
+
+// due returns whether a new snapshot should be taken at the supplied time.
+func due(cr *v1alpha1.GroupOffsetSnapshot, now time.Time) bool {
+	at := cr.Status.AtProvider.CapturedAt
+	if at == nil {
+		return true
+	}
+	interval := defaultInterval
+	if i := cr.Spec.ForProvider.Interval; i != nil && i.Duration > 0 {
+		interval = i.Duration
+	}
+	return !now.Before(at.Add(interval))
+}
+
+func (c *external) Observe(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.GroupOffsetSnapshot)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotGroupOffsetSnapshot)
+	}
+
+	// Snapshots only live in the status of the resource and in its
+	// ConfigMap, which is kept, so there is nothing to delete.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Status.AtProvider.CapturedAt == nil {
+		cr.Status.SetConditions(v1.Unavailable())
+	}
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: !due(cr, time.Now())}, nil
+}
+
+func (c *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.GroupOffsetSnapshot)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotGroupOffsetSnapshot)
+	}
+	return managed.ExternalUpdate{}, c.capture(ctx, cr)
+}
+
+func (c *external) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
+
+// capture takes a snapshot of the offsets committed by the group of the
+// supplied GroupOffsetSnapshot, writes it to its ConfigMap if any and
+// records it in its status.
+func (c *external) capture(ctx context.Context, cr *v1alpha1.GroupOffsetSnapshot) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Metadata)
+	defer cancel()
+
+	offsets, err := group.FetchOffsets(ctx, c.kafkaClient, cr.Spec.ForProvider.Group)
+	if err != nil {
+		err = c.kafkaClient.Diagnose(ctx, err)
+		cr.Status.AtProvider.UnreachableBrokers = kafka.UnreachableBrokers(err)
+		return errors.Wrap(err, errFetchOffsets)
+	}
+	cr.Status.AtProvider.UnreachableBrokers = nil
+
+	// A group whose offsets were deleted, or expired, would otherwise
+	// replace the last snapshot worth restoring with an empty one.
+	if len(offsets) == 0 && len(cr.Status.AtProvider.Offsets) > 0 {
+		c.log.Info("Not replacing snapshot with one of a group without committed offsets", "group", cr.Spec.ForProvider.Group)
+		return nil
+	}
+
+	now := metav1.Now()
+	key := ""
+	if ref := cr.Spec.ForProvider.ConfigMap; ref != nil {
+		key = group.SnapshotKey(now.Time)
+		if err := group.WriteSnapshot(ctx, c.kube, ref, key, offsets); err != nil {
+			return err
+		}
+	}
+
+	cr.Status.AtProvider.CapturedAt = &now
+	cr.Status.AtProvider.Offsets = offsets
+	cr.Status.AtProvider.ConfigMapKey = key
+	cr.Status.SetConditions(v1.Available())
+	metrics.RecordSuccessfulSync(v1alpha1.GroupOffsetSnapshotKind, cr)
+	return nil
+}
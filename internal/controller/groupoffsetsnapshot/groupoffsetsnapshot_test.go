@@ -0,0 +1,72 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package groupoffsetsnapshot
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/group/v1alpha1"
+)
+
+func TestDue(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-d))
+		return &t
+	}
+
+	cases := map[string]struct {
+		reason   string
+		captured *metav1.Time
+		interval *metav1.Duration
+		want     bool
+	}{
+		"NeverCaptured": {
+			reason: "A snapshot should be taken right away if none was taken yet.",
+			want:   true,
+		},
+		"WithinDefaultInterval": {
+			reason:   "A snapshot should not be taken within an hour of the last one by default.",
+			captured: at(59 * time.Minute),
+		},
+		"DefaultIntervalElapsed": {
+			reason:   "A snapshot should be taken an hour after the last one by default.",
+			captured: at(time.Hour),
+			want:     true,
+		},
+		"IntervalElapsed": {
+			reason:   "A snapshot should be taken once the configured interval elapsed.",
+			captured: at(10 * time.Minute),
+			interval: &metav1.Duration{Duration: 5 * time.Minute},
+			want:     true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.GroupOffsetSnapshot{}
+			cr.Spec.ForProvider.Interval = tc.interval
+			cr.Status.AtProvider.CapturedAt = tc.captured
+			if got := due(cr, now); got != tc.want {
+				t.Errorf("\n%s\ndue(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
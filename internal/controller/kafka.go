@@ -17,25 +17,81 @@ limitations under the License.
 package controller
 
 import (
-	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	aclv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+	confluentv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/confluent/v1alpha1"
+	connectv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/connect/v1alpha1"
+	groupv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/group/v1alpha1"
+	schemaregistryv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/schemaregistry/v1alpha1"
+	topicv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
 	"github.com/crossplane-contrib/provider-kafka/internal/controller/acl"
 	"github.com/crossplane-contrib/provider-kafka/internal/controller/config"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/connectcluster"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/connector"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/group"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/groupoffsetsnapshot"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/recordstruncation"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/rolebinding"
+	"github.com/crossplane-contrib/provider-kafka/internal/controller/schemaexporter"
 	"github.com/crossplane-contrib/provider-kafka/internal/controller/topic"
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+	"github.com/crossplane-contrib/provider-kafka/internal/options"
+	"github.com/crossplane-contrib/provider-kafka/internal/status"
 )
 
 // Setup creates all Template controllers with the supplied logger and adds them to
 // the supplied manager.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
-	for _, setup := range []func(ctrl.Manager, controller.Options) error{
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	// Most polls find resources up to date, so their statuses are only
+	// written if they changed.
+	mgr = status.NewManager(mgr)
+	for _, setup := range []func(ctrl.Manager, options.Options) error{
 		config.Setup,
 		topic.Setup,
+		recordstruncation.Setup,
 		acl.Setup,
+		connectcluster.Setup,
+		connector.Setup,
+		group.Setup,
+		groupoffsetsnapshot.Setup,
+		schemaexporter.Setup,
+		rolebinding.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
 			return err
 		}
 	}
-	return nil
+	if o.Cancellation != nil {
+		for _, obj := range []client.Object{
+			&topicv1alpha1.Topic{},
+			&topicv1alpha1.RecordsTruncation{},
+			&aclv1alpha1.AccessControlList{},
+			&connectv1alpha1.ConnectCluster{},
+			&connectv1alpha1.Connector{},
+			&groupv1alpha1.ConsumerGroup{},
+			&groupv1alpha1.GroupOffsetSnapshot{},
+			&schemaregistryv1alpha1.SchemaExporter{},
+			&confluentv1alpha1.RoleBinding{},
+		} {
+			if err := o.Cancellation.Watch(context.Background(), mgr.GetCache(), obj); err != nil {
+				return err
+			}
+		}
+	}
+	return metrics.Register(mgr.GetClient(),
+		metrics.ManagedKind{Kind: topicv1alpha1.TopicKind, NewList: func() resource.ManagedList { return &topicv1alpha1.TopicList{} }},
+		metrics.ManagedKind{Kind: topicv1alpha1.RecordsTruncationKind, NewList: func() resource.ManagedList { return &topicv1alpha1.RecordsTruncationList{} }},
+		metrics.ManagedKind{Kind: aclv1alpha1.AccessControlListKind, NewList: func() resource.ManagedList { return &aclv1alpha1.AccessControlListList{} }},
+		metrics.ManagedKind{Kind: connectv1alpha1.ConnectClusterKind, NewList: func() resource.ManagedList { return &connectv1alpha1.ConnectClusterList{} }},
+		metrics.ManagedKind{Kind: connectv1alpha1.ConnectorKind, NewList: func() resource.ManagedList { return &connectv1alpha1.ConnectorList{} }},
+		metrics.ManagedKind{Kind: groupv1alpha1.ConsumerGroupKind, NewList: func() resource.ManagedList { return &groupv1alpha1.ConsumerGroupList{} }},
+		metrics.ManagedKind{Kind: groupv1alpha1.GroupOffsetSnapshotKind, NewList: func() resource.ManagedList { return &groupv1alpha1.GroupOffsetSnapshotList{} }},
+		metrics.ManagedKind{Kind: schemaregistryv1alpha1.SchemaExporterKind, NewList: func() resource.ManagedList { return &schemaregistryv1alpha1.SchemaExporterList{} }},
+		metrics.ManagedKind{Kind: confluentv1alpha1.RoleBindingKind, NewList: func() resource.ManagedList { return &confluentv1alpha1.RoleBindingList{} }},
+	)
 }
@@ -0,0 +1,188 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recordstruncation
+
+import (
+	"context"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/audit"
+	"github.com/crossplane-contrib/provider-kafka/internal/deletion"
+	"github.com/crossplane-contrib/provider-kafka/internal/identity"
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+	"github.com/crossplane-contrib/provider-kafka/internal/options"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka/topic"
+)
+
+const (
+	errNotRecordsTruncation = "managed resource is not a RecordsTruncation custom resource"
+	errTrackPCUsage         = "cannot track ProviderConfig usage"
+	errGetPC                = "cannot get ProviderConfig"
+	errGetCreds             = "cannot get credentials"
+	errGetBrokers           = "cannot get brokers"
+	errTruncate             = "cannot delete records"
+
+	errNewClient = "cannot create new Kafka client"
+)
+
+// Setup adds a controller that reconciles RecordsTruncation managed
+// resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.RecordsTruncationGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RecordsTruncationGroupVersionKind),
+		managed.WithExternalConnecter(o.ExternalConnecter(identity.NewConnecter(deletion.NewConnecter(audit.NewConnecter(metrics.NewConnecter(&connector{
+			kube:         o.CredentialsClient(mgr.GetClient()),
+			usage:        o.UsageTracker(mgr.GetClient()),
+			newServiceFn: o.ClientCache.Get,
+			timeouts:     o.Timeouts}, v1alpha1.RecordsTruncationKind), v1alpha1.RecordsTruncationKind, o.Audit, o.Logger), o.Deletion, event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), o.Logger)))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(o.PollIntervalHook()),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(o.ConnectionPublishers(mgr.GetClient())...),
+		managed.WithInitializers(o.Initializers(mgr.GetClient(), managed.NewNameAsExternalName(mgr.GetClient()))...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.RecordsTruncation{}).
+		Complete(o.Sharded(&v1alpha1.RecordsTruncation{}, ratelimiter.NewReconciler(name, kafka.NewThrottlingReconciler(metrics.NewReconciler(v1alpha1.RecordsTruncationKind, r)), o.GlobalRateLimiter)))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called. Clients are shared between reconciles through a cache, so they
+// are never closed after a reconcile.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(ctx context.Context, builder string, creds []byte, kube client.Reader) (*kafka.Client, error)
+	timeouts     kafka.Timeouts
+}
+
+// Connect produces an ExternalClient using the credentials of the
+// RecordsTruncation's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RecordsTruncation)
+	if !ok {
+		return nil, errors.New(errNotRecordsTruncation)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	data, err := kafka.ExtractCredentials(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	if ref := pc.Spec.BrokersConfigMapRef; ref != nil {
+		if data, err = kafka.ReplaceBrokers(ctx, c.kube, data, ref.Namespace, ref.Name, ref.Key); err != nil {
+			return nil, errors.Wrap(err, errGetBrokers)
+		}
+	}
+	if data, err = identity.Credentials(data, pc, cr); err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	svc, err := c.newServiceFn(ctx, pc.Spec.ClientBuilder, data, c.kube)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{kafkaClient: svc, timeouts: c.timeouts}, nil
+}
+
+// An ExternalClient deletes the records of a topic once. The outcome is
+// recorded in the status of the resource, which Create implementations must
+// not alter, so a RecordsTruncation always exists and the records are deleted
+// by updating it until that completed.
+type external struct {
+	kafkaClient *kafka.Client
+	timeouts    kafka.Timeouts
+}
+
+func (c *external) Observe(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RecordsTruncation)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRecordsTruncation)
+	}
+
+	// Deleted records cannot be restored, so there is nothing to delete.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Status.AtProvider.CompletedAt == nil {
+		cr.Status.SetConditions(v1.Unavailable())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+	}
+	cr.Status.SetConditions(v1.Available())
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (c *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RecordsTruncation)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRecordsTruncation)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutation)
+	defer cancel()
+
+	partitions, err := topic.Truncate(ctx, c.kafkaClient, cr.Spec.ForProvider)
+	if err != nil {
+		err = c.kafkaClient.Diagnose(ctx, err)
+		cr.Status.AtProvider.UnreachableBrokers = kafka.UnreachableBrokers(err)
+		return managed.ExternalUpdate{}, errors.Wrap(err, errTruncate)
+	}
+
+	now := metav1.Now()
+	cr.Status.AtProvider = v1alpha1.RecordsTruncationObservation{CompletedAt: &now, Partitions: partitions}
+	cr.Status.SetConditions(v1.Available())
+	metrics.RecordSuccessfulSync(v1alpha1.RecordsTruncationKind, cr)
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rolebinding
+
+import (
+	"context"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/confluent/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/audit"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/mds"
+	"github.com/crossplane-contrib/provider-kafka/internal/deletion"
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+	"github.com/crossplane-contrib/provider-kafka/internal/options"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const (
+	errNotRoleBinding = "managed resource is not a RoleBinding custom resource"
+	errTrackPCUsage   = "cannot track ProviderConfig usage"
+	errGetPC          = "cannot get ProviderConfig"
+	errGetCreds       = "cannot get credentials"
+	errParseCreds     = "cannot parse credentials"
+	errNotRBAC        = "ProviderConfig %q does not use ConfluentRBAC authorization"
+	errNoMDS          = "no mds is configured in the provider credentials"
+	errNoKafkaCluster = "the ID of the Kafka cluster of ProviderConfig %q is not known yet; set scope.kafkaCluster"
+)
+
+// Setup adds a controller that reconciles RoleBinding managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.RoleBindingGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RoleBindingGroupVersionKind),
+		managed.WithExternalConnecter(o.ExternalConnecter(deletion.NewConnecter(audit.NewConnecter(metrics.NewConnecter(&connector{
+			kube:  o.CredentialsClient(mgr.GetClient()),
+			usage: o.UsageTracker(mgr.GetClient())}, v1alpha1.RoleBindingKind), v1alpha1.RoleBindingKind, o.Audit, o.Logger), o.Deletion, event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), o.Logger))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(o.PollIntervalHook()),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithInitializers(o.Initializers(mgr.GetClient())...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.RoleBinding{}).
+		Complete(o.Sharded(&v1alpha1.RoleBinding{}, ratelimiter.NewReconciler(name, metrics.NewReconciler(v1alpha1.RoleBindingKind, r), o.GlobalRateLimiter)))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+}
+
+// Connect produces an ExternalClient for the MDS configured in the
+// credentials of the RoleBinding's ProviderConfig, which must use Confluent
+// RBAC authorization.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RoleBinding)
+	if !ok {
+		return nil, errors.New(errNotRoleBinding)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+	if pc.Spec.Authorization != apisv1alpha1.AuthorizationConfluentRBAC {
+		return nil, errors.Errorf(errNotRBAC, pc.GetName())
+	}
+
+	cd := pc.Spec.Credentials
+	data, err := kafka.ExtractCredentials(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	kc, err := kafka.ParseConfig(data)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseCreds)
+	}
+	m := mds.NewClient(kc.MDS)
+	if m == nil {
+		return nil, errors.New(errNoMDS)
+	}
+
+	cluster := cr.Spec.ForProvider.Scope.KafkaCluster
+	if cluster == "" && pc.Status.Cluster != nil {
+		cluster = pc.Status.Cluster.ID
+	}
+	if cluster == "" {
+		return nil, errors.Errorf(errNoKafkaCluster, pc.GetName())
+	}
+
+	return &external{mds: m, kafkaCluster: cluster}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes a role
+// binding to ensure it reflects the managed resource's desired state.
+type external struct {
+	mds *mds.Client
+	// kafkaCluster is the ID of the Kafka cluster the role is bound in.
+	kafkaCluster string
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RoleBinding)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRoleBinding)
+	}
+
+	p := cr.Spec.ForProvider
+	s := c.scope(cr)
+	cr.Status.AtProvider.KafkaCluster = c.kafkaCluster
+
+	if len(p.ResourcePatterns) == 0 {
+		bound, err := c.mds.HasRole(ctx, p.Principal, p.Role, s)
+		if err != nil || !bound {
+			return managed.ExternalObservation{}, err
+		}
+		cr.Status.SetConditions(v1.Available())
+		metrics.RecordSuccessfulSync(v1alpha1.RoleBindingKind, cr)
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	observed, err := c.mds.ResourcePatterns(ctx, p.Principal, p.Role, s)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	desired := mds.GeneratePatterns(p.ResourcePatterns)
+	bound := mds.Intersect(desired, observed)
+	// Patterns bound for this RoleBinding before that are no longer desired
+	// are still tracked, so that Update can unbind them.
+	stale := mds.Intersect(mds.Subtract(mds.GeneratePatterns(cr.Status.AtProvider.ResourcePatterns), desired), observed)
+	cr.Status.AtProvider.ResourcePatterns = observation(append(bound, stale...))
+	if len(bound) == 0 && len(stale) == 0 {
+		return managed.ExternalObservation{}, nil
+	}
+
+	cr.Status.SetConditions(v1.Available())
+	metrics.RecordSuccessfulSync(v1alpha1.RoleBindingKind, cr)
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(bound) == len(desired) && len(stale) == 0,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RoleBinding)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRoleBinding)
+	}
+
+	p := cr.Spec.ForProvider
+	if len(p.ResourcePatterns) == 0 {
+		return managed.ExternalCreation{}, c.mds.BindRole(ctx, p.Principal, p.Role, c.scope(cr))
+	}
+	return managed.ExternalCreation{}, c.mds.BindResources(ctx, p.Principal, p.Role, c.scope(cr), mds.GeneratePatterns(p.ResourcePatterns))
+}
+
+// Update binds the desired resource patterns that are not bound yet, and
+// unbinds those bound for the RoleBinding that are no longer desired. Role
+// bindings for the whole scope are never out of date.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RoleBinding)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRoleBinding)
+	}
+
+	p := cr.Spec.ForProvider
+	desired := mds.GeneratePatterns(p.ResourcePatterns)
+	bound := mds.GeneratePatterns(cr.Status.AtProvider.ResourcePatterns)
+	if missing := mds.Subtract(desired, bound); len(missing) > 0 {
+		if err := c.mds.BindResources(ctx, p.Principal, p.Role, c.scope(cr), missing); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+	if stale := mds.Subtract(bound, desired); len(stale) > 0 {
+		if err := c.mds.UnbindResources(ctx, p.Principal, p.Role, c.scope(cr), stale); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RoleBinding)
+	if !ok {
+		return errors.New(errNotRoleBinding)
+	}
+
+	p := cr.Spec.ForProvider
+	var err error
+	if len(p.ResourcePatterns) == 0 {
+		err = c.mds.UnbindRole(ctx, p.Principal, p.Role, c.scope(cr))
+	} else {
+		ps := mds.GeneratePatterns(p.ResourcePatterns)
+		ps = append(ps, mds.Subtract(mds.GeneratePatterns(cr.Status.AtProvider.ResourcePatterns), ps)...)
+		err = c.mds.UnbindResources(ctx, p.Principal, p.Role, c.scope(cr), ps)
+	}
+	if mds.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *external) scope(cr *v1alpha1.RoleBinding) mds.Scope {
+	return mds.GenerateScope(cr.Spec.ForProvider.Scope, c.kafkaCluster)
+}
+
+// observation returns the supplied resource patterns as reported in the
+// status of a RoleBinding.
+func observation(ps []mds.ResourcePattern) []v1alpha1.ResourcePattern {
+	if len(ps) == 0 {
+		return nil
+	}
+	out := make([]v1alpha1.ResourcePattern, 0, len(ps))
+	for _, p := range ps {
+		out = append(out, v1alpha1.ResourcePattern{ResourceType: p.ResourceType, Name: p.Name, PatternType: v1alpha1.PatternType(p.PatternType)})
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rolebinding
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/confluent/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/mds"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+var (
+	orders  = v1alpha1.ResourcePattern{ResourceType: "Topic", Name: "orders", PatternType: v1alpha1.PatternTypeLiteral}
+	billing = v1alpha1.ResourcePattern{ResourceType: "Topic", Name: "billing", PatternType: v1alpha1.PatternTypeLiteral}
+)
+
+func roleBinding(bound []v1alpha1.ResourcePattern, desired ...v1alpha1.ResourcePattern) *v1alpha1.RoleBinding {
+	cr := &v1alpha1.RoleBinding{}
+	cr.Spec.ForProvider = v1alpha1.RoleBindingParameters{
+		Principal:        "User:alice",
+		Role:             "DeveloperRead",
+		ResourcePatterns: desired,
+	}
+	cr.Status.AtProvider.ResourcePatterns = bound
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o     managed.ExternalObservation
+		bound []v1alpha1.ResourcePattern
+	}
+
+	cases := map[string]struct {
+		reason   string
+		cr       *v1alpha1.RoleBinding
+		observed []mds.ResourcePattern
+		want     want
+	}{
+		"NotBound": {
+			reason: "A role bound for none of the desired patterns should not exist.",
+			cr:     roleBinding(nil, orders),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"UpToDate": {
+			reason:   "A role bound for all desired patterns should be up to date, ignoring patterns bound by others.",
+			cr:       roleBinding(nil, orders),
+			observed: []mds.ResourcePattern{{ResourceType: "Topic", Name: "orders", PatternType: "LITERAL"}, {ResourceType: "Topic", Name: "payments", PatternType: "LITERAL"}},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				bound: []v1alpha1.ResourcePattern{orders},
+			},
+		},
+		"PatternRemoved": {
+			reason:   "A pattern the RoleBinding bound before that is no longer desired should be kept track of until it is unbound.",
+			cr:       roleBinding([]v1alpha1.ResourcePattern{orders, billing}, orders),
+			observed: []mds.ResourcePattern{{ResourceType: "Topic", Name: "orders", PatternType: "LITERAL"}, {ResourceType: "Topic", Name: "billing", PatternType: "LITERAL"}},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: true},
+				bound: []v1alpha1.ResourcePattern{orders, billing},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(tc.observed)
+			}))
+			defer srv.Close()
+
+			e := external{mds: mds.NewClient(&kafka.MDS{URL: srv.URL}), kafkaCluster: "kafka-1"}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.bound, tc.cr.Status.AtProvider.ResourcePatterns); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want bound patterns, +got bound patterns:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b := struct {
+			ResourcePatterns []mds.ResourcePattern `json:"resourcePatterns"`
+		}{}
+		_ = json.NewDecoder(r.Body).Decode(&b)
+		for _, p := range b.ResourcePatterns {
+			got = append(got, r.Method+" "+p.Name)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	e := external{mds: mds.NewClient(&kafka.MDS{URL: srv.URL}), kafkaCluster: "kafka-1"}
+	if _, err := e.Update(context.Background(), roleBinding([]v1alpha1.ResourcePattern{billing}, orders)); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+
+	want := []string{"POST orders", "DELETE billing"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Update(...): -want requests, +got requests:\n%s", diff)
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaexporter
+
+import (
+	"context"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/schemaregistry/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/audit"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/schemaregistry"
+	"github.com/crossplane-contrib/provider-kafka/internal/deletion"
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+	"github.com/crossplane-contrib/provider-kafka/internal/options"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const (
+	errNotSchemaExporter = "managed resource is not a SchemaExporter custom resource"
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetPC             = "cannot get ProviderConfig"
+	errGetCreds          = "cannot get credentials"
+	errParseCreds        = "cannot parse credentials"
+	errNoRegistry        = "no schemaRegistry is configured in the provider credentials"
+	errGetPassword       = "cannot get password of destination Schema Registry"
+	errNoPasswordKey     = "secret %q in namespace %q has no key %q"
+
+	// stateRunning is the state of an exporter that is exporting subjects.
+	stateRunning = "RUNNING"
+)
+
+// Setup adds a controller that reconciles SchemaExporter managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.SchemaExporterGroupKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SchemaExporterGroupVersionKind),
+		managed.WithExternalConnecter(o.ExternalConnecter(deletion.NewConnecter(audit.NewConnecter(metrics.NewConnecter(&connector{
+			kube:  o.CredentialsClient(mgr.GetClient()),
+			usage: o.UsageTracker(mgr.GetClient())}, v1alpha1.SchemaExporterKind), v1alpha1.SchemaExporterKind, o.Audit, o.Logger), o.Deletion, event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), o.Logger))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(o.PollIntervalHook()),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithInitializers(o.Initializers(mgr.GetClient(), managed.NewNameAsExternalName(mgr.GetClient()))...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.SchemaExporter{}).
+		Complete(o.Sharded(&v1alpha1.SchemaExporter{}, ratelimiter.NewReconciler(name, metrics.NewReconciler(v1alpha1.SchemaExporterKind, r), o.GlobalRateLimiter)))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+}
+
+// Connect produces an ExternalClient for the Schema Registry configured in
+// the credentials of the SchemaExporter's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.SchemaExporter)
+	if !ok {
+		return nil, errors.New(errNotSchemaExporter)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	data, err := kafka.ExtractCredentials(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	kc, err := kafka.ParseConfig(data)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseCreds)
+	}
+	registry := schemaregistry.NewClient(kc.SchemaRegistry)
+	if registry == nil {
+		return nil, errors.New(errNoRegistry)
+	}
+
+	password := ""
+	if ref := cr.Spec.ForProvider.Destination.PasswordSecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return nil, errors.Wrap(err, errGetPassword)
+		}
+		pw, ok := s.Data[ref.Key]
+		if !ok {
+			return nil, errors.Errorf(errNoPasswordKey, ref.Name, ref.Namespace, ref.Key)
+		}
+		password = string(pw)
+	}
+
+	return &external{registry: registry, password: password}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes a
+// schema exporter to ensure it reflects the managed resource's desired state.
+type external struct {
+	registry *schemaregistry.Client
+	// password of the destination Schema Registry.
+	password string
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.SchemaExporter)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSchemaExporter)
+	}
+
+	name := meta.GetExternalName(cr)
+	e, err := c.registry.GetExporter(ctx, name)
+	if schemaregistry.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	s, err := c.registry.GetExporterStatus(ctx, name)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.Status.AtProvider = v1alpha1.SchemaExporterObservation{
+		State:  s.State,
+		Offset: s.Offset,
+		Trace:  s.Trace,
+	}
+	if s.State == stateRunning {
+		cr.Status.SetConditions(v1.Available())
+		metrics.RecordSuccessfulSync(v1alpha1.SchemaExporterKind, cr)
+	} else {
+		cr.Status.SetConditions(v1.Unavailable().WithMessage(s.State))
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: schemaregistry.IsUpToDate(c.desired(cr), e),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.SchemaExporter)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSchemaExporter)
+	}
+
+	return managed.ExternalCreation{}, c.registry.CreateExporter(ctx, c.desired(cr))
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.SchemaExporter)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSchemaExporter)
+	}
+
+	return managed.ExternalUpdate{}, c.registry.UpdateExporter(ctx, c.desired(cr))
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.SchemaExporter)
+	if !ok {
+		return errors.New(errNotSchemaExporter)
+	}
+
+	err := c.registry.DeleteExporter(ctx, meta.GetExternalName(cr))
+	if schemaregistry.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *external) desired(cr *v1alpha1.SchemaExporter) *schemaregistry.Exporter {
+	return schemaregistry.GenerateExporter(meta.GetExternalName(cr), cr.Spec.ForProvider, c.password)
+}
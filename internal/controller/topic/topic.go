@@ -18,10 +18,13 @@ package topic
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
@@ -29,56 +32,147 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/pkg/errors"
-	"github.com/twmb/franz-go/pkg/kadm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	aclv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
 	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
-	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka"
-	"github.com/crossplane-contrib/provider-kafka/internal/clients/kafka/topic"
+	"github.com/crossplane-contrib/provider-kafka/internal/audit"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/schemaregistry"
+	"github.com/crossplane-contrib/provider-kafka/internal/deletion"
+	"github.com/crossplane-contrib/provider-kafka/internal/features"
+	"github.com/crossplane-contrib/provider-kafka/internal/identity"
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+	"github.com/crossplane-contrib/provider-kafka/internal/options"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka/topic"
+)
+
+// createInterruptedWindow is how long after a request to create a topic was
+// interrupted the topic is adopted should the brokers have created it.
+const createInterruptedWindow = 10 * time.Minute
+
+// Keys of the connection details of a Topic.
+const (
+	keyTopic            = "topic"
+	keyBootstrapServers = "bootstrapServers"
+	keyPartitions       = "partitions"
 )
 
 const (
-	errNotTopic     = "managed resource is not a Topic custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
-	errGetCreds     = "cannot get credentials"
-	errGetTopic     = "cannot get topic spec from topic client"
+	errNotTopic      = "managed resource is not a Topic custom resource"
+	errTrackPCUsage  = "cannot track ProviderConfig usage"
+	errGetPC         = "cannot get ProviderConfig"
+	errGetCreds      = "cannot get credentials"
+	errGetBrokers    = "cannot get brokers"
+	errGetTopic      = "cannot get topic spec from topic client"
+	errGetUsage      = "cannot determine whether topic is in use"
+	errGetConfigKeys = "cannot get supported topic config keys"
+	errNoRegistry    = "a key schema is linked but no schemaRegistry is configured in the provider credentials"
+	errCheckSchema   = "cannot check key schema"
+	errNoKeySchema   = "compacted topic %q requires its linked key schema to be registered under subject %q"
+	errListPolicies  = "cannot list TopicPolicies"
+	errViolation     = "topic violates %s"
+	errViolated      = "TopicPolicy %q: %s"
+	errUnmanaged     = "topic %q already exists but was not created by this Topic; set spec.forProvider.adoptExisting to true to manage it"
+	errListACLs      = "cannot list AccessControlLists referencing the Topic"
+	errDeleteACL     = "cannot delete AccessControlList %q referencing the Topic"
+	errDependents    = "waiting for AccessControlLists referencing the Topic to be deleted: %s"
+	errPausedACLs    = "%s; paused AccessControlLists %s are only deleted once their annotation " + meta.AnnotationKeyReconciliationPaused + " is removed"
+	errClearRefresh  = "cannot remove annotation " + v1alpha1.AnnotationKeyRefresh
+	errMigrate       = "cannot migrate Topic to its new external name"
+	errNameChanged   = "external name changed to %q after topic %q was created; the change is ignored unless the Topic is annotated " + v1alpha1.AnnotationKeyMigrateExternalName + "=true"
+	errPolicyPending = "not retrying creation of topic rejected by a create topic policy of the brokers until the Topic changes: %s"
+	errRetryAfter    = "cannot parse annotation " + v1alpha1.AnnotationKeyPolicyRetryAfter
+	errViolationNote = "cannot parse annotation " + v1alpha1.AnnotationKeyPolicyViolation
+	errClearNote     = "cannot remove annotation " + v1alpha1.AnnotationKeyPolicyViolation
+	errClearCreate   = "cannot remove annotation " + v1alpha1.AnnotationKeyCreateInterrupted
+	errReserved      = "topic %q is reserved for internal use by Kafka; set spec.forProvider.internal to true to manage it"
+	errMarkedDeleted = "topic %q is still being deleted by the brokers; it is created once they confirm its removal"
+	errPlanReplicas  = "cannot plan replication factor increase"
+	errInfeasible    = "cannot increase the replication factor of topic %q to %d: %s"
+	errApproval      = "increasing the replication factor of topic %q to %d copies about %d bytes, more than the %d bytes copied without approval; annotate the Topic " + v1alpha1.AnnotationKeyApproveReplicationFactor + "=%d to approve it"
 
 	errNewClient = "cannot create new Kafka client"
+
+	reasonDeletionQueued event.Reason = "DeletionQueued"
+	msgDeletionQueued                 = "Topic %q queued for deletion behind %d other topics, deleting at most %g topics per second"
+
+	reasonArchived event.Reason = "Archived"
+	msgArchived                 = "Topic %q archived rather than deleted: it is kept with retention disabled"
+
+	msgDataLoss = "Refusing to delete: %s; set spec.forProvider.allowDataLoss to true to delete it anyway"
+
+	reasonConfigChanged event.Reason = "ConfigChanged"
+	msgConfigChanged                 = "Changed config of topic %q: %s"
+
+	msgUnderReplicated = "partitions %v of topic %q have fewer than %d in-sync replicas"
+	msgUnauthorized    = "%s: create an ACL allowing it, e.g. an AccessControlList with resourceType Topic, resourceName %q and resourceOperation %s"
+
+	reasonReplicationFactorIncreased event.Reason = "ReplicationFactorIncreased"
+	msgReplicationFactorIncreased                 = "Increasing the replication factor of topic %q from %d to %d, copying about %d bytes"
+
+	reasonPartitionsScaled event.Reason = "PartitionsScaled"
+	msgPartitionsScaled                 = "Growing topic %q from %d to %d partitions, as %d bytes per second were produced to it"
 )
 
 // Setup adds a controller that reconciles Topic managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.TopicGroupKind)
 
+	var deleter *topic.BatchDeleter
+	if o.TopicDeletionRate > 0 {
+		deleter = topic.NewBatchDeleter(o.TopicDeletionRate, o.TopicDeletionBatchSize, o.Timeouts.Mutation)
+	}
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.TopicGroupVersionKind),
-		managed.WithExternalConnectDisconnecter(&connectDisconnector{
-			kube:         mgr.GetClient(),
-			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: kafka.NewAdminClient}),
+		managed.WithExternalConnecter(o.ExternalConnecter(identity.NewConnecter(deletion.NewConnecter(audit.NewConnecter(metrics.NewConnecter(&connector{
+			kube:               o.CredentialsClient(mgr.GetClient()),
+			usage:              o.UsageTracker(mgr.GetClient()),
+			newServiceFn:       o.ClientCache.Get,
+			timeouts:           o.Timeouts,
+			configGracePeriod:  o.ConfigVerifyGracePeriod,
+			deletionProtection: o.Features.Enabled(features.EnableAlphaTopicDeletionProtection),
+			deleter:            deleter,
+			recorder:           event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+			observeSize:        o.TopicSizeInStatus,
+			approvalBytes:      o.ReplicationFactorApprovalBytes,
+			log:                o.Logger.WithValues("controller", name)}, v1alpha1.TopicKind), v1alpha1.TopicKind, o.Audit, o.Logger), o.Deletion, event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), o.Logger)))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+		managed.WithPollIntervalHook(o.PollIntervalHook()),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(o.ConnectionPublishers(mgr.GetClient())...),
+		managed.WithInitializers(o.Initializers(mgr.GetClient(), managed.NewNameAsExternalName(mgr.GetClient()))...))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.Topic{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(o.Sharded(&v1alpha1.Topic{}, ratelimiter.NewReconciler(name, kafka.NewThrottlingReconciler(metrics.NewReconciler(v1alpha1.TopicKind, r)), o.GlobalRateLimiter)))
 }
 
-// A connectDisconnector is expected to produce an ExternalClient when its Connect method
-// is called and close it when its Disconnect method is called.
-type connectDisconnector struct {
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called. Clients are shared between reconciles through a cache, so they
+// are never closed after a reconcile.
+type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
 	log          logging.Logger
-	newServiceFn func(ctx context.Context, creds []byte, kube client.Client) (*kadm.Client, error)
-	cachedClient *kadm.Client
+	newServiceFn func(ctx context.Context, builder string, creds []byte, kube client.Reader) (*kafka.Client, error)
+	timeouts     kafka.Timeouts
+
+	configGracePeriod  time.Duration
+	deletionProtection bool
+	deleter            *topic.BatchDeleter
+	recorder           event.Recorder
+	observeSize        bool
+	approvalBytes      int64
 }
 
 // Connect typically produces an ExternalClient by:
@@ -86,7 +180,7 @@ type connectDisconnector struct {
 // 2. Getting the managed resource's ProviderConfig.
 // 3. Getting the credentials specified by the ProviderConfig.
 // 4. Using the credentials to form a client.
-func (c *connectDisconnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
 	cr, ok := mg.(*v1alpha1.Topic)
 	if !ok {
 		return nil, errors.New(errNotTopic)
@@ -102,33 +196,63 @@ func (c *connectDisconnector) Connect(ctx context.Context, mg resource.Managed)
 	}
 
 	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	data, err := kafka.ExtractCredentials(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
+	if ref := pc.Spec.BrokersConfigMapRef; ref != nil {
+		if data, err = kafka.ReplaceBrokers(ctx, c.kube, data, ref.Namespace, ref.Name, ref.Key); err != nil {
+			return nil, errors.Wrap(err, errGetBrokers)
+		}
+	}
+	if data, err = identity.Credentials(data, pc, cr); err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
 
-	svc, err := c.newServiceFn(ctx, data, c.kube)
+	kc, err := kafka.ParseConfig(data)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-	c.cachedClient = svc
-
-	return &external{kafkaClient: svc, log: c.log}, nil
-}
 
-func (c *connectDisconnector) Disconnect(ctx context.Context) error {
-	if c.cachedClient != nil {
-		c.cachedClient.Close()
+	svc, err := c.newServiceFn(ctx, pc.Spec.ClientBuilder, data, c.kube)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
 	}
-	c.cachedClient = nil
-	return nil
+
+	return &external{
+		kube:               c.kube,
+		kafkaClient:        svc,
+		timeouts:           c.timeouts,
+		configGracePeriod:  c.configGracePeriod,
+		registry:           schemaregistry.NewClient(kc.SchemaRegistry),
+		log:                c.log,
+		deletionProtection: c.deletionProtection,
+		deleter:            c.deleter,
+		recorder:           c.recorder,
+		observeSize:        c.observeSize,
+		approvalBytes:      c.approvalBytes,
+	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	kafkaClient *kadm.Client
+	kube        client.Client
+	kafkaClient *kafka.Client
+	timeouts    kafka.Timeouts
+	registry    *schemaregistry.Client
 	log         logging.Logger
+
+	configGracePeriod  time.Duration
+	deletionProtection bool
+	// deleter throttles deletions if set, deleting topics in batches.
+	deleter  *topic.BatchDeleter
+	recorder event.Recorder
+	// observeSize records how much data the topic holds in its status.
+	observeSize bool
+	// approvalBytes is how much data increasing the replication factor of
+	// the topic may copy without the Topic approving it.
+	approvalBytes int64
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -137,32 +261,414 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotTopic)
 	}
 
-	tpc, err := topic.Get(ctx, c.kafkaClient, meta.GetExternalName(cr))
+	// An archived topic is left in Kafka, and no longer managed.
+	if meta.WasDeleted(cr) && cr.Status.GetCondition(v1alpha1.TypeArchived).Status == corev1.ConditionTrue {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Metadata)
+	defer cancel()
+
+	if err := c.reconcileExternalName(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	refresh := refreshRequested(cr)
+
+	if topic.Reserved(topicName(cr)) && !internal(cr) {
+		return observeReserved(cr)
+	}
+
+	referenced, err := topic.ReferencedConfig(ctx, c.kube, cr.Spec.ForProvider.ConfigFrom)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	// Ignored keys are left to other controllers, so they never count as
+	// drift.
+	config := topic.IgnoreConfigKeys(&cr.Spec.ForProvider, topic.MergeConfig(referenced, cr.Spec.ForProvider.Config))
+
+	// Describing a topic's config is expensive, so it is skipped while a
+	// recent verification of the unchanged config can be trusted.
+	verified := !refresh && c.configVerified(cr, config)
+	get := topic.Get
+	if verified {
+		get = topic.GetMetadata
+	}
+
+	tpc, err := get(ctx, c.kafkaClient, topicName(cr))
+	ae := &topic.AuthorizationError{}
+	if errors.As(err, &ae) {
+		return observeUnauthorized(cr, ae)
+	}
 	if err != nil { // Discern whether the topic doesn't exist or something went wrong
 		if strings.HasPrefix(err.Error(), topic.ErrTopicDoesNotExist) {
-			return managed.ExternalObservation{ResourceExists: false}, nil
+			return c.observeMissing(ctx, cr)
 		}
+		err = c.kafkaClient.Diagnose(ctx, err)
+		cr.Status.AtProvider.UnreachableBrokers = kafka.UnreachableBrokers(err)
 		return managed.ExternalObservation{}, errors.Wrapf(err, errGetTopic)
 	}
+	// The annotation is only removed once the topic it asked to be described
+	// in full was, so that a failed describe is retried in full.
+	if refresh {
+		if err := c.removeAnnotations(ctx, cr, v1alpha1.AnnotationKeyRefresh); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errClearRefresh)
+		}
+	}
+	if tpc.Internal && !internal(cr) {
+		return observeReserved(cr)
+	}
+	if cr.Status.GetCondition(v1alpha1.TypeAuthorized).Status == corev1.ConditionFalse {
+		cr.Status.SetConditions(v1alpha1.TopicAuthorized())
+	}
+
+	if !owns(cr, tpc) {
+		// A topic owned by another tool must never be altered or deleted,
+		// so it is reported as up to date, and as gone once the Topic is
+		// being deleted.
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		cr.Status.SetConditions(v1alpha1.TopicExistsUnmanaged(fmt.Sprintf(errUnmanaged, topicName(cr))), v1.Unavailable())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if cr.Status.GetCondition(v1alpha1.TypeTopicExistsUnmanaged).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(v1alpha1.TopicManaged())
+	}
+	if cr.Status.GetCondition(v1alpha1.TypeDeletionInProgress).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(v1alpha1.DeletionConfirmed())
+	}
+	if _, ok := cr.GetAnnotations()[v1alpha1.AnnotationKeyCreateInterrupted]; ok {
+		if err := c.removeAnnotations(ctx, cr, v1alpha1.AnnotationKeyCreateInterrupted); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errClearCreate)
+		}
+	}
+	if err := c.clearPolicyViolation(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
 
-	cr.Status.AtProvider.ID = tpc.ID
-	cr.Status.SetConditions(v1.Available())
+	if verified {
+		tpc.Config = config
+	} else if err := topic.ValidateConfigKeys(config, tpc.ConfigKeys()); err != nil {
+		return managed.ExternalObservation{}, err
+	}
 
-	lateInitialized := topic.LateInitializeSpec(&cr.Spec.ForProvider, tpc)
+	last := cr.Status.AtProvider
+	cr.Status.AtProvider = topic.Observe(tpc)
+	cr.Status.AtProvider.PartitionsAutoScale = last.PartitionsAutoScale
+	cr.Status.AtProvider.DriftCount = last.DriftCount
+	cr.Status.AtProvider.LastDriftTime = last.LastDriftTime
+	// The plan to increase the replication factor is kept until it was.
+	if p := last.ReplicationFactorPlan; p != nil && p.ReplicationFactor == cr.Spec.ForProvider.ReplicationFactor && p.ReplicationFactor != cr.Status.AtProvider.ReplicationFactor {
+		cr.Status.AtProvider.ReplicationFactorPlan = p
+	}
+	// A verified config only holds the keys the Topic sets, so the last
+	// observed min.insync.replicas is kept unless it sets one.
+	cr.Status.AtProvider.MinInSyncReplicas = last.MinInSyncReplicas
+	if n, ok := topic.MinInSyncReplicas(tpc.Config); ok {
+		cr.Status.AtProvider.MinInSyncReplicas = n
+	}
+	cr.Status.SetConditions(availability(cr, tpc))
+	metrics.RecordSuccessfulSync(v1alpha1.TopicKind, cr)
+
+	if cr.Spec.ForProvider.PartitionsAutoScale != nil && !meta.WasDeleted(cr) {
+		c.autoScale(ctx, cr, tpc)
+	}
+
+	// Keys set by ConfigMaps are not late initialized, so that they keep
+	// following their ConfigMaps.
+	observed := topic.WithoutIgnoredConfigKeys(&cr.Spec.ForProvider, tpc)
+	lateInitialized := topic.LateInitializeSpec(&cr.Spec.ForProvider, topic.WithoutConfigKeys(observed, referenced))
+	config = topic.IgnoreConfigKeys(&cr.Spec.ForProvider, topic.MergeConfig(referenced, cr.Spec.ForProvider.Config))
+	upToDate := topic.IsUpToDate(topic.WithConfig(desiredParameters(cr), config), observed)
+
+	// Only a topic last verified to be up to date with the current spec and
+	// config drifted, rather than the Topic changing.
+	if !upToDate && lastVerified(cr, last, config) {
+		now := metav1.Now()
+		cr.Status.AtProvider.DriftCount++
+		cr.Status.AtProvider.LastDriftTime = &now
+		metrics.RecordDrift(v1alpha1.TopicKind, cr)
+	}
+
+	switch {
+	// A topic found not up to date is no longer verified to be, so that a
+	// topic staying out of date only drifted once: its drift is counted by
+	// the poll finding it, rather than by every poll until it is up to date
+	// again.
+	case !upToDate:
+	// Without a grace period the time a config was verified is not relied
+	// on, so it is kept while the config is unchanged rather than having
+	// every poll rewrite the status.
+	case verified, c.configGracePeriod == 0 && lastVerified(cr, last, config):
+		cr.Status.AtProvider.ObservedGeneration = last.ObservedGeneration
+		cr.Status.AtProvider.ConfigHash = last.ConfigHash
+		cr.Status.AtProvider.ConfigVerifiedTime = last.ConfigVerifiedTime
+	default:
+		now := metav1.Now()
+		cr.Status.AtProvider.ObservedGeneration = cr.GetGeneration()
+		cr.Status.AtProvider.ConfigHash = topic.ConfigHash(config)
+		cr.Status.AtProvider.ConfigVerifiedTime = &now
+	}
+	if c.observeSize {
+		c.observeTopicSize(ctx, cr, last)
+	}
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        topic.IsUpToDate(&cr.Spec.ForProvider, tpc),
+		ResourceUpToDate:        upToDate,
 		ResourceLateInitialized: lateInitialized,
+		ConnectionDetails:       connectionDetails(tpc, c.kafkaClient.Brokers()),
 	}, nil
 }
 
+// lastVerified returns whether the supplied Topic was last verified to be up
+// to date with its current spec and the supplied config, given the supplied
+// last observation.
+func lastVerified(cr *v1alpha1.Topic, last v1alpha1.TopicObservation, config map[string]*string) bool {
+	return last.ConfigVerifiedTime != nil && last.ObservedGeneration == cr.GetGeneration() && last.ConfigHash == topic.ConfigHash(config)
+}
+
+// availability returns the Ready condition of the supplied Topic, whose topic
+// exists. A Topic waiting for ready replicas is Unavailable while a partition
+// of its topic has fewer in-sync replicas than its min.insync.replicas.
+func availability(cr *v1alpha1.Topic, tpc *topic.Topic) v1.Condition {
+	if !waitForReadyReplicas(cr) {
+		return v1.Available()
+	}
+	minInSync := cr.Status.AtProvider.MinInSyncReplicas
+	if p := topic.UnderReplicated(tpc, minInSync); len(p) > 0 {
+		return v1.Unavailable().WithMessage(fmt.Sprintf(msgUnderReplicated, p, topicName(cr), minInSync))
+	}
+	return v1.Available()
+}
+
+// connectionDetails returns what applications need to produce to or consume
+// from the supplied topic.
+func connectionDetails(tpc *topic.Topic, brokers []string) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		keyTopic:            []byte(tpc.Name),
+		keyBootstrapServers: []byte(strings.Join(brokers, ",")),
+		keyPartitions:       []byte(strconv.Itoa(int(tpc.Partitions))),
+	}
+}
+
+// observeTopicSize records how much data the topic holds in the status of the
+// Topic. It is best effort, as the provider may not be allowed to describe
+// the log dirs of the brokers: the last observed size is kept if the size
+// cannot be got.
+func (c *external) observeTopicSize(ctx context.Context, cr *v1alpha1.Topic, last v1alpha1.TopicObservation) {
+	sz, err := topic.GetSize(ctx, c.kafkaClient, topicName(cr))
+	if err != nil {
+		c.log.Debug("Cannot get topic size", "topic", topicName(cr), "error", err)
+		cr.Status.AtProvider.Records, cr.Status.AtProvider.SizeBytes = last.Records, last.SizeBytes
+		return
+	}
+	cr.Status.AtProvider.Records, cr.Status.AtProvider.SizeBytes = &sz.Records, &sz.Bytes
+}
+
+// autoScale measures the throughput of the topic, and records the partitions
+// to grow it to in the status of the Topic once a window is complete, from
+// where Update picks them up. Like observing the topic size it is best effort:
+// the window continues if the throughput cannot be sampled.
+func (c *external) autoScale(ctx context.Context, cr *v1alpha1.Topic, tpc *topic.Topic) {
+	sample, err := topic.SampleThroughput(ctx, c.kafkaClient, topicName(cr), tpc.ReplicationFactor)
+	if err != nil {
+		c.log.Debug("Cannot sample topic throughput", "topic", topicName(cr), "error", err)
+		return
+	}
+	o := cr.Status.AtProvider.PartitionsAutoScale
+	if o == nil {
+		o = &v1alpha1.PartitionsAutoScaleObservation{}
+		cr.Status.AtProvider.PartitionsAutoScale = o
+	}
+	from := int(tpc.Partitions)
+	if p := desiredParameters(cr).Partitions; p > from {
+		from = p
+	}
+	if to := topic.AutoScale(cr.Spec.ForProvider.PartitionsAutoScale, o, from, sample, time.Now()); to > from {
+		c.recorder.Event(cr, event.Normal(reasonPartitionsScaled, fmt.Sprintf(msgPartitionsScaled, topicName(cr), from, to, o.BytesInPerSecond)))
+	}
+}
+
+// desiredParameters returns the parameters of the supplied Topic, with its
+// partitions raised to those it was autoscaled to, if more.
+func desiredParameters(cr *v1alpha1.Topic) *v1alpha1.TopicParameters {
+	params := &cr.Spec.ForProvider
+	o := cr.Status.AtProvider.PartitionsAutoScale
+	if o == nil || o.Partitions <= params.Partitions || len(params.ReplicaAssignment) > 0 {
+		return params
+	}
+	p := params.DeepCopy()
+	p.Partitions = o.Partitions
+	return p
+}
+
+// configVerified returns true if the Topic's config was verified to be up to
+// date within the grace period, and neither the Topic nor the supplied config,
+// resolved from the Topic and its ConfigMaps, changed since.
+func (c *external) configVerified(cr *v1alpha1.Topic, config map[string]*string) bool {
+	o := cr.Status.AtProvider
+	return c.configGracePeriod > 0 &&
+		o.ConfigVerifiedTime != nil &&
+		time.Since(o.ConfigVerifiedTime.Time) < c.configGracePeriod &&
+		o.ObservedGeneration == cr.GetGeneration() &&
+		o.ConfigHash == topic.ConfigHash(config)
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1alpha1.Topic)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotTopic)
 	}
-	return managed.ExternalCreation{}, topic.Create(ctx, c.kafkaClient, topic.Generate(meta.GetExternalName(cr), &cr.Spec.ForProvider))
+
+	// Learning the known config keys may read the metadata of all topics.
+	kctx, kcancel := context.WithTimeout(ctx, c.timeouts.ClusterMetadata)
+	defer kcancel()
+
+	known, err := topic.ConfigKeys(kctx, c.kafkaClient)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGetConfigKeys)
+	}
+	config, err := topic.ResolveConfig(ctx, c.kube, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := topic.ValidateConfigKeys(config, known); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	params := topic.WithConfig(&cr.Spec.ForProvider, config)
+
+	vctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutation)
+	defer cancel()
+
+	if err := topic.ValidateReplicaAssignment(cr.Spec.ForProvider.ReplicaAssignment); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := c.checkPolicies(vctx, cr, params); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := c.checkKeySchema(vctx, cr, params); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	// Have the brokers validate the topic before creating it, so that
+	// requests they would reject never have side effects.
+	desired := topic.Generate(topicName(cr), params)
+	if err := topic.Validate(vctx, c.kafkaClient, desired); err != nil {
+		recordPolicyViolation(cr, err)
+		return managed.ExternalCreation{}, err
+	}
+	meta.RemoveAnnotations(cr, v1alpha1.AnnotationKeyPolicyViolation)
+
+	ctx, cancelCreate := context.WithTimeout(ctx, c.timeouts.Create)
+	defer cancelCreate()
+
+	err = kafka.RetryOnNotController(ctx, c.kafkaClient, func() error {
+		return topic.Create(ctx, c.kafkaClient, desired)
+	})
+	// The brokers may still create a topic whose request was interrupted,
+	// which must not then be mistaken for a topic of another tool.
+	if kafka.Interrupted(err) {
+		meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeyCreateInterrupted: time.Now().Format(time.RFC3339)})
+	}
+	recordPolicyViolation(cr, err)
+	return managed.ExternalCreation{}, err
+}
+
+// observeMissing observes a Topic whose topic does not exist. It returns an
+// error while the topic must not be created yet, which keeps the Topic from
+// being created and has its status persisted.
+func (c *external) observeMissing(ctx context.Context, cr *v1alpha1.Topic) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err := observePolicyViolation(cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if topic.MarkedForDeletion(ctx, c.kafkaClient, topicName(cr)) {
+		msg := fmt.Sprintf(errMarkedDeleted, topicName(cr))
+		cr.Status.SetConditions(v1alpha1.DeletionInProgress(msg), v1.Unavailable())
+		return managed.ExternalObservation{}, errors.New(msg)
+	}
+	return managed.ExternalObservation{ResourceExists: false}, nil
+}
+
+// A policyViolation is a rejection of a Topic by a create topic policy of the
+// brokers, as recorded in its policy violation annotation.
+type policyViolation struct {
+	Generation int64       `json:"generation"`
+	Time       metav1.Time `json:"time"`
+	Reason     string      `json:"reason,omitempty"`
+}
+
+// recordPolicyViolation records on the Topic that a create topic policy of
+// the brokers rejected it, if the supplied error says so. The rejection is
+// recorded in an annotation, as the status set while creating a topic is not
+// persisted.
+func recordPolicyViolation(cr *v1alpha1.Topic, err error) {
+	pv := &topic.PolicyViolationError{}
+	if !errors.As(err, &pv) {
+		return
+	}
+	v, _ := json.Marshal(policyViolation{Generation: cr.GetGeneration(), Time: metav1.Now(), Reason: pv.Reason})
+	meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeyPolicyViolation: string(v)})
+}
+
+// observePolicyViolation reports in the status of a Topic whose topic does not
+// exist that a create topic policy of the brokers rejected the unchanged
+// Topic, if its annotation says so. It returns an error while the Topic must
+// not be created again, which keeps it from being created and has its status
+// persisted.
+func observePolicyViolation(cr *v1alpha1.Topic) error {
+	a, ok := cr.GetAnnotations()[v1alpha1.AnnotationKeyPolicyViolation]
+	if !ok {
+		return nil
+	}
+	pv := policyViolation{}
+	if err := json.Unmarshal([]byte(a), &pv); err != nil {
+		return errors.Wrap(err, errViolationNote)
+	}
+	if pv.Generation != cr.GetGeneration() {
+		return nil
+	}
+	cr.Status.SetConditions(v1alpha1.BrokerPolicyViolated(pv.Reason), v1.Unavailable())
+	cr.Status.AtProvider.PolicyViolationGeneration = pv.Generation
+	cr.Status.AtProvider.PolicyViolationTime = &pv.Time
+	return policyViolationPending(cr)
+}
+
+// clearPolicyViolation removes the policy violation annotation of a Topic
+// whose topic exists, as the brokers accepted it.
+func (c *external) clearPolicyViolation(ctx context.Context, cr *v1alpha1.Topic) error {
+	if cr.Status.GetCondition(v1alpha1.TypeBrokerPolicyCompliant).Status == corev1.ConditionFalse {
+		cr.Status.SetConditions(v1alpha1.BrokerPolicyCompliant())
+	}
+	if _, ok := cr.GetAnnotations()[v1alpha1.AnnotationKeyPolicyViolation]; !ok {
+		return nil
+	}
+	return errors.Wrap(c.removeAnnotations(ctx, cr, v1alpha1.AnnotationKeyPolicyViolation), errClearNote)
+}
+
+// policyViolationPending returns an error if a create topic policy of the
+// brokers rejected the unchanged Topic, and the retry-after annotation does
+// not allow creating it again yet. Policies reject a topic deterministically,
+// so retrying it would only load the brokers.
+func policyViolationPending(cr *v1alpha1.Topic) error {
+	o := cr.Status.AtProvider
+	c := cr.Status.GetCondition(v1alpha1.TypeBrokerPolicyCompliant)
+	if c.Reason != v1alpha1.ReasonPolicyViolation || o.PolicyViolationTime == nil || o.PolicyViolationGeneration != cr.GetGeneration() {
+		return nil
+	}
+	if a, ok := cr.GetAnnotations()[v1alpha1.AnnotationKeyPolicyRetryAfter]; ok {
+		d, err := time.ParseDuration(a)
+		if err != nil {
+			return errors.Wrap(err, errRetryAfter)
+		}
+		if time.Since(o.PolicyViolationTime.Time) >= d {
+			return nil
+		}
+	}
+	return errors.Errorf(errPolicyPending, c.Message)
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -171,7 +677,105 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotTopic)
 	}
 
-	return managed.ExternalUpdate{}, topic.Update(ctx, c.kafkaClient, topic.Generate(meta.GetExternalName(cr), &cr.Spec.ForProvider))
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutation)
+	defer cancel()
+	config, err := topic.ResolveConfig(ctx, c.kube, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	// Ignored keys are only set when the topic is created.
+	params := topic.WithConfig(desiredParameters(cr), topic.IgnoreConfigKeys(&cr.Spec.ForProvider, config))
+	if err := c.checkPolicies(ctx, cr, params); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := c.checkKeySchema(ctx, cr, params); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	desired := topic.Generate(topicName(cr), params)
+	if increasesReplicationFactor(cr, desired) {
+		return managed.ExternalUpdate{}, c.increaseReplicationFactor(ctx, cr, desired.ReplicationFactor)
+	}
+	var changes []topic.ConfigChange
+	err = kafka.RetryOnNotController(ctx, c.kafkaClient, func() error {
+		var err error
+		changes, err = topic.ApplyUpdate(ctx, c.kafkaClient, desired)
+		return err
+	})
+	// Changes applied before other keys failed are reported too, as they
+	// remain in effect.
+	if len(changes) > 0 {
+		c.recorder.Event(cr, event.Normal(reasonConfigChanged, fmt.Sprintf(msgConfigChanged, topicName(cr), configChangesMessage(changes))))
+	}
+	// An update that changed nothing means the topic was found out of date
+	// in a way the update cannot correct.
+	if err == nil || len(changes) > 0 {
+		metrics.RecordUpdate(v1alpha1.TopicKind, cr, len(changes) > 0 || int(desired.Partitions) != cr.Status.AtProvider.PartitionCount)
+	}
+	return managed.ExternalUpdate{}, err
+}
+
+// increasesReplicationFactor returns whether the supplied desired topic of the
+// supplied Topic only increases the replication factor of its topic. Topics
+// placed by a replica assignment, or whose partitions change too, are not.
+func increasesReplicationFactor(cr *v1alpha1.Topic, desired *topic.Topic) bool {
+	observed := cr.Status.AtProvider
+	return len(desired.ReplicaAssignment) == 0 && observed.ReplicationFactor > 0 &&
+		int(desired.Partitions) == observed.PartitionCount && int(desired.ReplicationFactor) > observed.ReplicationFactor
+}
+
+// increaseReplicationFactor increases the replication factor of the topic of
+// the supplied Topic to the supplied one, recording the plan to do so in its
+// status. A plan copying more data than approvalBytes waits for the Topic to
+// be annotated to approve it.
+func (c *external) increaseReplicationFactor(ctx context.Context, cr *v1alpha1.Topic, replicationFactor int16) error {
+	plan, err := topic.PlanReplicationFactor(ctx, c.kafkaClient, topicName(cr), replicationFactor)
+	if err != nil {
+		return errors.Wrap(err, errPlanReplicas)
+	}
+	p := replicationFactorPlan(plan, c.approvalBytes)
+	cr.Status.AtProvider.ReplicationFactorPlan = p
+	switch {
+	case !p.Feasible:
+		return errors.Errorf(errInfeasible, topicName(cr), replicationFactor, p.Reason)
+	case p.ApprovalRequired && cr.GetAnnotations()[v1alpha1.AnnotationKeyApproveReplicationFactor] != strconv.Itoa(p.ReplicationFactor):
+		return errors.Errorf(errApproval, topicName(cr), replicationFactor, p.Bytes, c.approvalBytes, replicationFactor)
+	}
+
+	if err := kafka.RetryOnNotController(ctx, c.kafkaClient, func() error {
+		return topic.IncreaseReplicationFactor(ctx, c.kafkaClient, topicName(cr), plan)
+	}); err != nil {
+		return err
+	}
+	c.recorder.Event(cr, event.Normal(reasonReplicationFactorIncreased, fmt.Sprintf(msgReplicationFactorIncreased, topicName(cr), cr.Status.AtProvider.ReplicationFactor, replicationFactor, p.Bytes)))
+	metrics.RecordUpdate(v1alpha1.TopicKind, cr, true)
+	return nil
+}
+
+// replicationFactorPlan returns the supplied plan as recorded in the status of
+// a Topic, requiring approval if it copies more than the supplied bytes.
+func replicationFactorPlan(plan *topic.ReplicationPlan, approvalBytes int64) *v1alpha1.ReplicationFactorPlan {
+	return &v1alpha1.ReplicationFactorPlan{
+		ReplicationFactor: int(plan.ReplicationFactor),
+		Brokers:           plan.Brokers,
+		Racks:             plan.Racks,
+		Feasible:          plan.Infeasible == "",
+		Reason:            plan.Infeasible,
+		Records:           plan.Records,
+		Bytes:             plan.Bytes,
+		ApprovalRequired:  plan.Infeasible == "" && plan.Bytes > approvalBytes,
+		PlannedTime:       metav1.Now(),
+	}
+}
+
+// configChangesMessage returns the supplied config changes as a message, with
+// the values of sensitive keys redacted.
+func configChangesMessage(changes []topic.ConfigChange) string {
+	s := make([]string, len(changes))
+	for i, ch := range changes {
+		s[i] = ch.String()
+	}
+	return strings.Join(s, ", ")
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -179,5 +783,272 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	if !ok {
 		return errors.New(errNotTopic)
 	}
-	return topic.Delete(ctx, c.kafkaClient, meta.GetExternalName(cr))
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Mutation)
+	defer cancel()
+
+	// Archiving loses no data, and leaves the topic to the ACLs referencing
+	// it.
+	if cr.Spec.ForProvider.DeletionStrategy == v1alpha1.DeletionStrategyArchive {
+		if err := topic.Archive(ctx, c.kafkaClient, topicName(cr)); err != nil {
+			return err
+		}
+		msg := fmt.Sprintf(msgArchived, topicName(cr))
+		cr.Status.SetConditions(v1alpha1.Archived(msg))
+		c.recorder.Event(cr, event.Normal(reasonArchived, msg))
+		return nil
+	}
+
+	if c.deletionProtection && !allowDataLoss(cr) {
+		u, err := topic.GetUsage(ctx, c.kafkaClient, topicName(cr))
+		if err != nil {
+			return errors.Wrap(err, errGetUsage)
+		}
+		if u.InUse() {
+			err := &topic.InUseError{Name: topicName(cr), Usage: *u}
+			cr.Status.SetConditions(v1alpha1.InUse(fmt.Sprintf(msgDataLoss, err)))
+			return err
+		}
+	}
+
+	if cr.Spec.ForProvider.DeletionPropagation == v1alpha1.DeletionPropagationDelete {
+		if err := c.deleteDependents(ctx, cr); err != nil {
+			return err
+		}
+	}
+
+	if c.deleter != nil {
+		// The topic is only queued for deletion, and reported as gone
+		// once a later Observe no longer finds it.
+		ahead, err := c.deleter.Delete(c.kafkaClient, topicName(cr))
+		if err != nil {
+			return err
+		}
+		c.recorder.Event(cr, event.Normal(reasonDeletionQueued, fmt.Sprintf(msgDeletionQueued, topicName(cr), ahead, c.deleter.Rate())))
+		return nil
+	}
+
+	return kafka.RetryOnNotController(ctx, c.kafkaClient, func() error {
+		return topic.Delete(ctx, c.kafkaClient, topicName(cr))
+	})
+}
+
+// deleteDependents deletes the AccessControlLists referencing the Topic. It
+// returns an error naming those that are not gone yet, so that the topic is
+// only deleted after its ACLs. Paused ACLs are never gone, because their
+// finalizer is not removed, so they are reported for operators to unpause.
+func (c *external) deleteDependents(ctx context.Context, cr *v1alpha1.Topic) error {
+	l := &aclv1alpha1.AccessControlListList{}
+	if err := c.kube.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListACLs)
+	}
+	var waiting, paused []string
+	for i := range l.Items {
+		a := &l.Items[i]
+		if a.Spec.ForProvider.TopicRef == nil || a.Spec.ForProvider.TopicRef.Name != cr.GetName() {
+			continue
+		}
+		waiting = append(waiting, a.GetName())
+		if meta.IsPaused(a) {
+			paused = append(paused, a.GetName())
+		}
+		if meta.WasDeleted(a) {
+			continue
+		}
+		if err := c.kube.Delete(ctx, a); resource.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, errDeleteACL, a.GetName())
+		}
+	}
+	if len(waiting) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf(errDependents, strings.Join(waiting, ", "))
+	if len(paused) > 0 {
+		msg = fmt.Sprintf(errPausedACLs, msg, strings.Join(paused, ", "))
+	}
+	return errors.New(msg)
+}
+
+// checkPolicies returns an error reporting every violation of every
+// TopicPolicy of its ProviderConfig by the topic, with the supplied
+// parameters, if there are any.
+func (c *external) checkPolicies(ctx context.Context, cr *v1alpha1.Topic, params *v1alpha1.TopicParameters) error {
+	l := &apisv1alpha1.TopicPolicyList{}
+	if err := c.kube.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListPolicies)
+	}
+	var violated []string
+	for i := range l.Items {
+		p := &l.Items[i]
+		if ref := cr.GetProviderConfigReference(); ref == nil || ref.Name != p.Spec.ProviderConfigRef.Name {
+			continue
+		}
+		if v := topic.CheckPolicy(&p.Spec, topicName(cr), params); len(v) > 0 {
+			violated = append(violated, fmt.Sprintf(errViolated, p.GetName(), strings.Join(v, "; ")))
+		}
+	}
+	if len(violated) > 0 {
+		return errors.Errorf(errViolation, strings.Join(violated, "; "))
+	}
+	return nil
+}
+
+// checkKeySchema returns an error if the topic, with the supplied parameters,
+// is compacted and linked to a key schema that is not registered.
+func (c *external) checkKeySchema(ctx context.Context, cr *v1alpha1.Topic, p *v1alpha1.TopicParameters) error {
+	if p.KeySchemaRef == nil || !topic.IsCompacted(p.Config) {
+		return nil
+	}
+	if c.registry == nil {
+		return errors.New(errNoRegistry)
+	}
+
+	subject := p.KeySchemaRef.Subject
+	if subject == "" {
+		subject = schemaregistry.KeySubject(topicName(cr))
+	}
+	ok, err := c.registry.SubjectExists(ctx, subject)
+	if err != nil {
+		return errors.Wrap(err, errCheckSchema)
+	}
+	if !ok {
+		return errors.Errorf(errNoKeySchema, topicName(cr), subject)
+	}
+	return nil
+}
+
+// refreshRequested returns true if the Topic is annotated to be refreshed,
+// i.e. to have its topic described in full. Observe removes the annotation
+// once it did so.
+func refreshRequested(cr *v1alpha1.Topic) bool {
+	return cr.GetAnnotations()[v1alpha1.AnnotationKeyRefresh] == "true"
+}
+
+// topicName returns the name of the topic managed by the Topic. It is the
+// external name of the Topic until a topic was recorded.
+func topicName(cr *v1alpha1.Topic) string {
+	if n := cr.Status.AtProvider.TopicName; n != "" {
+		return n
+	}
+	return meta.GetExternalName(cr)
+}
+
+// reconcileExternalName ignores changes to the external name of a Topic made
+// after its topic was recorded, which would otherwise silently abandon the
+// topic and create a new one, unless the Topic is annotated to migrate to its
+// new external name.
+func (c *external) reconcileExternalName(ctx context.Context, cr *v1alpha1.Topic) error {
+	recorded, ext := cr.Status.AtProvider.TopicName, meta.GetExternalName(cr)
+	_, migrate := cr.GetAnnotations()[v1alpha1.AnnotationKeyMigrateExternalName]
+	if recorded == "" || recorded == ext {
+		if cr.Status.GetCondition(v1alpha1.TypeExternalNameChanged).Status == corev1.ConditionTrue {
+			cr.Status.SetConditions(v1alpha1.ExternalNameMatches())
+		}
+		// A leftover annotation would migrate the Topic the next time its
+		// external name is changed by mistake.
+		if migrate {
+			return errors.Wrap(c.removeAnnotations(ctx, cr, v1alpha1.AnnotationKeyMigrateExternalName), errMigrate)
+		}
+		return nil
+	}
+	if cr.GetAnnotations()[v1alpha1.AnnotationKeyMigrateExternalName] != "true" {
+		cr.Status.SetConditions(v1alpha1.ExternalNameIgnored(fmt.Sprintf(errNameChanged, ext, recorded)))
+		return nil
+	}
+
+	// The Topic neither created nor observed the topic of its new external
+	// name, so it forgets it created a topic, and creates or adopts that one
+	// like a new Topic would.
+	if err := c.removeAnnotations(ctx, cr,
+		v1alpha1.AnnotationKeyMigrateExternalName,
+		meta.AnnotationKeyExternalCreatePending,
+		meta.AnnotationKeyExternalCreateSucceeded,
+		meta.AnnotationKeyExternalCreateFailed); err != nil {
+		return errors.Wrap(err, errMigrate)
+	}
+	cr.Status.AtProvider = v1alpha1.TopicObservation{}
+	cr.Status.SetConditions(v1alpha1.ExternalNameMatches())
+	return nil
+}
+
+// removeAnnotations removes the supplied annotations from the Topic.
+func (c *external) removeAnnotations(ctx context.Context, cr *v1alpha1.Topic, keys ...string) error {
+	fields := make([]string, len(keys))
+	for i, k := range keys {
+		fields[i] = fmt.Sprintf("%q:null", k)
+	}
+	p := fmt.Sprintf(`{"metadata":{"annotations":{%s}}}`, strings.Join(fields, ","))
+	return c.kube.Patch(ctx, cr, client.RawPatch(types.MergePatchType, []byte(p)))
+}
+
+// owns returns true if the Topic owns the observed topic: it created the
+// topic, possibly with a request that was interrupted, it observed the same
+// topic before, or it may adopt existing topics.
+func owns(cr *v1alpha1.Topic, tpc *topic.Topic) bool {
+	if cr.Spec.ForProvider.AdoptExisting != nil && *cr.Spec.ForProvider.AdoptExisting {
+		return true
+	}
+	if !meta.GetExternalCreateSucceeded(cr).IsZero() {
+		return true
+	}
+	if createInterrupted(cr) {
+		return true
+	}
+	last := cr.Status.AtProvider
+	id := last.TopicID
+	if id == "" {
+		// Topics observed by earlier releases only recorded their ID here.
+		id = last.ID
+	}
+	if id != "" {
+		return id == tpc.ID
+	}
+	// Brokers older than Kafka 2.8 do not assign topic IDs, in which case a
+	// previous observation of the topic has to do.
+	return last.PartitionCount > 0 && tpc.ID == ""
+}
+
+// createInterrupted returns true if a request to create the topic of the Topic
+// was recently interrupted, so that the brokers may have created it since.
+func createInterrupted(cr *v1alpha1.Topic) bool {
+	t, err := time.Parse(time.RFC3339, cr.GetAnnotations()[v1alpha1.AnnotationKeyCreateInterrupted])
+	return err == nil && time.Since(t) < createInterruptedWindow
+}
+
+func internal(cr *v1alpha1.Topic) bool {
+	return cr.Spec.ForProvider.Internal != nil && *cr.Spec.ForProvider.Internal
+}
+
+// observeReserved observes a Topic whose topic is reserved for internal use
+// but which is not allowed to manage internal topics. Such a topic must never
+// be created, altered or deleted, so it is reported as gone once the Topic is
+// being deleted.
+func observeReserved(cr *v1alpha1.Topic) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	return managed.ExternalObservation{}, errors.Errorf(errReserved, topicName(cr))
+}
+
+// observeUnauthorized reports that the brokers refused to describe the topic
+// of the supplied Topic to the principal of the provider. Missing ACLs are
+// not fixed by retrying soon, so the Topic is reported as up to date rather
+// than failing, and is polled again as usual. A Topic being deleted keeps
+// failing, as its topic cannot be known to be gone.
+func observeUnauthorized(cr *v1alpha1.Topic, ae *topic.AuthorizationError) (managed.ExternalObservation, error) {
+	cr.Status.AtProvider.UnreachableBrokers = nil
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{}, errors.Wrap(ae, errGetTopic)
+	}
+	msg := fmt.Sprintf(msgUnauthorized, ae, ae.Topic, ae.Operation)
+	cr.Status.SetConditions(v1alpha1.TopicUnauthorized(msg), v1.Unavailable().WithMessage(msg))
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func waitForReadyReplicas(cr *v1alpha1.Topic) bool {
+	return cr.Spec.ForProvider.WaitForReadyReplicas != nil && *cr.Spec.ForProvider.WaitForReadyReplicas
+}
+
+func allowDataLoss(cr *v1alpha1.Topic) bool {
+	return cr.Spec.ForProvider.AllowDataLoss != nil && *cr.Spec.ForProvider.AllowDataLoss
 }
@@ -2,18 +2,41 @@ package topic
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
-	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	aclv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/clients/schemaregistry"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka/topic"
 )
 
 func Test_external_Observe(t *testing.T) {
 	type fields struct {
-		kafkaClient *kadm.Client
+		kafkaClient *kafka.Client
 		log         logging.Logger
 	}
 	type args struct {
@@ -27,10 +50,20 @@ func Test_external_Observe(t *testing.T) {
 		want    managed.ExternalObservation
 		wantErr bool
 	}{
-		// TODO: Add test cases.
+		"ArchivedTopicOfDeletedTopic": {
+			args: args{
+				ctx: context.Background(),
+				mg: func() resource.Managed {
+					cr := &v1alpha1.Topic{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{Time: time.Now()}}}
+					cr.Status.SetConditions(v1alpha1.Archived(""))
+					return cr
+				}(),
+			},
+			want: managed.ExternalObservation{ResourceExists: false},
+		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
 			c := &external{
 				kafkaClient: tt.fields.kafkaClient,
 				log:         tt.fields.log,
@@ -46,3 +79,1120 @@ func Test_external_Observe(t *testing.T) {
 		})
 	}
 }
+
+func Test_external_configVerified(t *testing.T) {
+	retention := "604800000"
+	config := map[string]*string{"retention.ms": &retention}
+	verifiedAt := func(ago time.Duration) *v1alpha1.Topic {
+		cr := &v1alpha1.Topic{}
+		cr.SetGeneration(2)
+		cr.Spec.ForProvider.Config = config
+		t := metav1.NewTime(time.Now().Add(-ago))
+		cr.Status.AtProvider.ObservedGeneration = 2
+		cr.Status.AtProvider.ConfigHash = topic.ConfigHash(config)
+		cr.Status.AtProvider.ConfigVerifiedTime = &t
+		return cr
+	}
+
+	tests := map[string]struct {
+		gracePeriod time.Duration
+		cr          func() *v1alpha1.Topic
+		want        bool
+	}{
+		"RecentlyVerified": {
+			gracePeriod: 10 * time.Minute,
+			cr:          func() *v1alpha1.Topic { return verifiedAt(time.Minute) },
+			want:        true,
+		},
+		"Disabled": {
+			cr:   func() *v1alpha1.Topic { return verifiedAt(time.Minute) },
+			want: false,
+		},
+		"GracePeriodExpired": {
+			gracePeriod: 10 * time.Minute,
+			cr:          func() *v1alpha1.Topic { return verifiedAt(time.Hour) },
+			want:        false,
+		},
+		"NeverVerified": {
+			gracePeriod: 10 * time.Minute,
+			cr:          func() *v1alpha1.Topic { return &v1alpha1.Topic{} },
+			want:        false,
+		},
+		"GenerationChanged": {
+			gracePeriod: 10 * time.Minute,
+			cr: func() *v1alpha1.Topic {
+				cr := verifiedAt(time.Minute)
+				cr.SetGeneration(3)
+				return cr
+			},
+			want: false,
+		},
+		"ConfigChanged": {
+			gracePeriod: 10 * time.Minute,
+			cr: func() *v1alpha1.Topic {
+				cr := verifiedAt(time.Minute)
+				cr.Spec.ForProvider.Config = map[string]*string{}
+				return cr
+			},
+			want: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &external{configGracePeriod: tt.gracePeriod}
+			cr := tt.cr()
+			if got := c.configVerified(cr, cr.Spec.ForProvider.Config); got != tt.want {
+				t.Errorf("configVerified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_external_checkPolicies(t *testing.T) {
+	three := 3
+	policy := func(name, pc string) apisv1alpha1.TopicPolicy {
+		p := apisv1alpha1.TopicPolicy{}
+		p.SetName(name)
+		p.Spec.ProviderConfigRef = xpv1.Reference{Name: pc}
+		p.Spec.MinPartitions = &three
+		return p
+	}
+	topicWith := func(pc string) *v1alpha1.Topic {
+		cr := &v1alpha1.Topic{}
+		meta.SetExternalName(cr, "orders")
+		cr.SetProviderConfigReference(&xpv1.Reference{Name: pc})
+		cr.Spec.ForProvider.Partitions = 1
+		return cr
+	}
+
+	named := func(p apisv1alpha1.TopicPolicy, pattern string) apisv1alpha1.TopicPolicy {
+		p.Spec.NamePattern = pattern
+		return p
+	}
+
+	tests := map[string]struct {
+		policies []apisv1alpha1.TopicPolicy
+		cr       *v1alpha1.Topic
+		wantErr  bool
+		// wantViolated are the TopicPolicies the error should report.
+		wantViolated []string
+	}{
+		"NoPolicies": {
+			cr: topicWith("tenant-a"),
+		},
+		"OtherProviderConfig": {
+			policies: []apisv1alpha1.TopicPolicy{policy("strict", "tenant-b")},
+			cr:       topicWith("tenant-a"),
+		},
+		"Violated": {
+			policies:     []apisv1alpha1.TopicPolicy{policy("strict", "tenant-a")},
+			cr:           topicWith("tenant-a"),
+			wantErr:      true,
+			wantViolated: []string{"strict"},
+		},
+		"SeveralViolated": {
+			policies:     []apisv1alpha1.TopicPolicy{policy("strict", "tenant-a"), named(policy("naming", "tenant-a"), "^team-")},
+			cr:           topicWith("tenant-a"),
+			wantErr:      true,
+			wantViolated: []string{"strict", "naming"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+				obj.(*apisv1alpha1.TopicPolicyList).Items = tt.policies
+				return nil
+			})}
+			c := &external{kube: kube}
+			err := c.checkPolicies(context.Background(), tt.cr, &tt.cr.Spec.ForProvider)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkPolicies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, n := range tt.wantViolated {
+				if !strings.Contains(err.Error(), fmt.Sprintf("TopicPolicy %q", n)) {
+					t.Errorf("checkPolicies() error = %v, want it to report TopicPolicy %q", err, n)
+				}
+			}
+		})
+	}
+}
+
+func Test_owns(t *testing.T) {
+	adopt := true
+	observed := &topic.Topic{ID: "f3Xk0pGmQ2u0cHnXbYHRCg", Partitions: 3}
+
+	tests := map[string]struct {
+		reason string
+		cr     func() *v1alpha1.Topic
+		tpc    *topic.Topic
+		want   bool
+	}{
+		"PreExisting": {
+			cr:   func() *v1alpha1.Topic { return &v1alpha1.Topic{} },
+			want: false,
+		},
+		"Created": {
+			cr: func() *v1alpha1.Topic {
+				cr := &v1alpha1.Topic{}
+				meta.SetExternalCreateSucceeded(cr, time.Now())
+				return cr
+			},
+			want: true,
+		},
+		"CreateInterrupted": {
+			cr: func() *v1alpha1.Topic {
+				cr := &v1alpha1.Topic{}
+				cr.SetAnnotations(map[string]string{v1alpha1.AnnotationKeyCreateInterrupted: time.Now().Add(-time.Minute).Format(time.RFC3339)})
+				return cr
+			},
+			want: true,
+		},
+		"CreateInterruptedLongAgo": {
+			cr: func() *v1alpha1.Topic {
+				cr := &v1alpha1.Topic{}
+				cr.SetAnnotations(map[string]string{v1alpha1.AnnotationKeyCreateInterrupted: time.Now().Add(-time.Hour).Format(time.RFC3339)})
+				return cr
+			},
+			want: false,
+		},
+		"Adopted": {
+			cr: func() *v1alpha1.Topic {
+				cr := &v1alpha1.Topic{}
+				cr.Spec.ForProvider.AdoptExisting = &adopt
+				return cr
+			},
+			want: true,
+		},
+		"ObservedBefore": {
+			cr: func() *v1alpha1.Topic {
+				cr := &v1alpha1.Topic{}
+				cr.Status.AtProvider = topic.Observe(observed)
+				return cr
+			},
+			want: true,
+		},
+		"ObservedBeforeWithDeprecatedID": {
+			cr: func() *v1alpha1.Topic {
+				cr := &v1alpha1.Topic{}
+				cr.Status.AtProvider = v1alpha1.TopicObservation{ID: observed.ID, PartitionCount: 3}
+				return cr
+			},
+			want: true,
+		},
+		"UpgradedWithDeprecatedID": {
+			reason: "A Topic observed by an earlier release only recorded the topic ID, in its deprecated field.",
+			cr: func() *v1alpha1.Topic {
+				cr := &v1alpha1.Topic{}
+				meta.SetExternalName(cr, "orders")
+				cr.Status.AtProvider = v1alpha1.TopicObservation{ID: observed.ID}
+				return cr
+			},
+			want: true,
+		},
+		"ObservedBeforeWithoutTopicIDs": {
+			cr: func() *v1alpha1.Topic {
+				cr := &v1alpha1.Topic{}
+				cr.Status.AtProvider = v1alpha1.TopicObservation{PartitionCount: 3}
+				return cr
+			},
+			tpc:  &topic.Topic{Partitions: 3},
+			want: true,
+		},
+		"RecreatedByAnotherTool": {
+			cr: func() *v1alpha1.Topic {
+				cr := &v1alpha1.Topic{}
+				cr.Status.AtProvider = v1alpha1.TopicObservation{TopicID: "AAAAAAAAAAAAAAAAAAAAAA", PartitionCount: 3}
+				return cr
+			},
+			want: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tpc := observed
+			if tt.tpc != nil {
+				tpc = tt.tpc
+			}
+			if got := owns(tt.cr(), tpc); got != tt.want {
+				t.Errorf("\n%s\nowns() = %v, want %v", tt.reason, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_observeReserved(t *testing.T) {
+	tests := map[string]struct {
+		deleted bool
+		want    managed.ExternalObservation
+		wantErr bool
+	}{
+		"Refused": {
+			wantErr: true,
+		},
+		"Deleted": {
+			deleted: true,
+			want:    managed.ExternalObservation{ResourceExists: false},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Topic{}
+			meta.SetExternalName(cr, "__consumer_offsets")
+			if tt.deleted {
+				cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+			}
+			got, err := observeReserved(cr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("observeReserved() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("observeReserved() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_observeUnauthorized(t *testing.T) {
+	ae := &topic.AuthorizationError{Topic: "payments", Operation: topic.OperationDescribe}
+	msg := `the principal of the provider is not allowed the Describe operation on topic "payments": create an ACL allowing it, e.g. an AccessControlList with resourceType Topic, resourceName "payments" and resourceOperation Describe`
+
+	tests := map[string]struct {
+		deleted        bool
+		want           managed.ExternalObservation
+		wantErr        bool
+		wantConditions []xpv1.Condition
+	}{
+		"Unauthorized": {
+			want:           managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			wantConditions: []xpv1.Condition{v1alpha1.TopicUnauthorized(msg), xpv1.Unavailable().WithMessage(msg)},
+		},
+		"Deleted": {
+			deleted: true,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Topic{}
+			meta.SetExternalName(cr, "payments")
+			if tt.deleted {
+				cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+			}
+			got, err := observeUnauthorized(cr, ae)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("observeUnauthorized() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("observeUnauthorized() got = %v, want %v", got, tt.want)
+			}
+			for _, c := range tt.wantConditions {
+				if !cr.Status.GetCondition(c.Type).Equal(c) {
+					t.Errorf("observeUnauthorized() condition %s = %v, want %v", c.Type, cr.Status.GetCondition(c.Type), c)
+				}
+			}
+		})
+	}
+}
+
+func Test_availability(t *testing.T) {
+	wait := true
+	tpc := &topic.Topic{Name: "orders", Partitions: 3, ReadyReplicas: []int32{3, 1, 2}}
+
+	tests := map[string]struct {
+		wait      *bool
+		minInSync int
+		want      xpv1.Condition
+	}{
+		"NotWaiting": {
+			minInSync: 2,
+			want:      xpv1.Available(),
+		},
+		"UnderReplicated": {
+			wait:      &wait,
+			minInSync: 2,
+			want:      xpv1.Unavailable().WithMessage(`partitions [1] of topic "orders" have fewer than 2 in-sync replicas`),
+		},
+		"Ready": {
+			wait:      &wait,
+			minInSync: 1,
+			want:      xpv1.Available(),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Topic{}
+			meta.SetExternalName(cr, "orders")
+			cr.Spec.ForProvider.WaitForReadyReplicas = tt.wait
+			cr.Status.AtProvider.MinInSyncReplicas = tt.minInSync
+			got := availability(cr, tpc)
+			if !got.Equal(tt.want) {
+				t.Errorf("availability() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_lastVerified(t *testing.T) {
+	v := "1"
+	config := map[string]*string{"retention.ms": &v}
+	now := metav1.Now()
+
+	tests := map[string]struct {
+		last v1alpha1.TopicObservation
+		want bool
+	}{
+		"NeverVerified": {
+			last: v1alpha1.TopicObservation{ObservedGeneration: 2, ConfigHash: topic.ConfigHash(config)},
+			want: false,
+		},
+		"SpecChanged": {
+			last: v1alpha1.TopicObservation{ObservedGeneration: 1, ConfigHash: topic.ConfigHash(config), ConfigVerifiedTime: &now},
+			want: false,
+		},
+		"ConfigChanged": {
+			last: v1alpha1.TopicObservation{ObservedGeneration: 2, ConfigHash: "other", ConfigVerifiedTime: &now},
+			want: false,
+		},
+		"Verified": {
+			last: v1alpha1.TopicObservation{ObservedGeneration: 2, ConfigHash: topic.ConfigHash(config), ConfigVerifiedTime: &now},
+			want: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Topic{}
+			cr.SetGeneration(2)
+			if got := lastVerified(cr, tt.last, config); got != tt.want {
+				t.Errorf("lastVerified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_external_ObserveDriftCount(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(1, "orders"))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(context.Background(), creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	// The Topic was verified to be up to date, after which another tool
+	// removed a partition the provider cannot add back right away.
+	cr := &v1alpha1.Topic{}
+	cr.SetGeneration(1)
+	meta.SetExternalName(cr, "orders")
+	meta.SetExternalCreateSucceeded(cr, time.Now())
+	cr.Spec.ForProvider.Partitions = 2
+	cr.Spec.ForProvider.ReplicationFactor = 1
+	now := metav1.Now()
+	cr.Status.AtProvider = v1alpha1.TopicObservation{
+		ObservedGeneration: 1,
+		ConfigHash:         topic.ConfigHash(map[string]*string{}),
+		ConfigVerifiedTime: &now,
+	}
+
+	e := &external{
+		kube:              &test.MockClient{MockList: test.NewMockListFn(nil)},
+		kafkaClient:       cl,
+		timeouts:          kafka.DefaultTimeouts,
+		configGracePeriod: time.Hour,
+		log:               logging.NewNopLogger(),
+	}
+	for i := 0; i < 3; i++ {
+		got, err := e.Observe(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("Observe(...): %v", err)
+		}
+		if got.ResourceUpToDate {
+			t.Fatalf("Observe(...): want the topic not up to date")
+		}
+	}
+	if got := cr.Status.AtProvider.DriftCount; got != 1 {
+		t.Errorf("Observe(...) of a topic staying out of date: DriftCount = %d, want 1", got)
+	}
+}
+
+func Test_increasesReplicationFactor(t *testing.T) {
+	tests := map[string]struct {
+		observed v1alpha1.TopicObservation
+		desired  *topic.Topic
+		want     bool
+	}{
+		"Increased": {
+			observed: v1alpha1.TopicObservation{PartitionCount: 3, ReplicationFactor: 2},
+			desired:  &topic.Topic{Partitions: 3, ReplicationFactor: 3},
+			want:     true,
+		},
+		"Decreased": {
+			observed: v1alpha1.TopicObservation{PartitionCount: 3, ReplicationFactor: 3},
+			desired:  &topic.Topic{Partitions: 3, ReplicationFactor: 2},
+		},
+		"PartitionsChanged": {
+			observed: v1alpha1.TopicObservation{PartitionCount: 3, ReplicationFactor: 2},
+			desired:  &topic.Topic{Partitions: 6, ReplicationFactor: 3},
+		},
+		"ReplicaAssignment": {
+			observed: v1alpha1.TopicObservation{PartitionCount: 1, ReplicationFactor: 1},
+			desired:  &topic.Topic{Partitions: 1, ReplicationFactor: 2, ReplicaAssignment: map[int32][]int32{0: {1, 2}}},
+		},
+		"NotObserved": {
+			desired: &topic.Topic{Partitions: 3, ReplicationFactor: 3},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Topic{}
+			cr.Status.AtProvider = tt.observed
+			if got := increasesReplicationFactor(cr, tt.desired); got != tt.want {
+				t.Errorf("increasesReplicationFactor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_replicationFactorPlan(t *testing.T) {
+	tests := map[string]struct {
+		plan         *topic.ReplicationPlan
+		wantFeasible bool
+		wantApproval bool
+	}{
+		"Small": {
+			plan:         &topic.ReplicationPlan{ReplicationFactor: 3, Bytes: 100},
+			wantFeasible: true,
+		},
+		"Large": {
+			plan:         &topic.ReplicationPlan{ReplicationFactor: 3, Bytes: 2000},
+			wantFeasible: true,
+			wantApproval: true,
+		},
+		"Infeasible": {
+			plan: &topic.ReplicationPlan{ReplicationFactor: 3, Infeasible: "too few brokers"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := replicationFactorPlan(tt.plan, 1000)
+			if got.Feasible != tt.wantFeasible || got.ApprovalRequired != tt.wantApproval {
+				t.Errorf("replicationFactorPlan() feasible = %v, approvalRequired = %v, want %v, %v", got.Feasible, got.ApprovalRequired, tt.wantFeasible, tt.wantApproval)
+			}
+		})
+	}
+}
+
+func Test_external_deleteDependents(t *testing.T) {
+	acl := func(name, topic string, deleting bool) aclv1alpha1.AccessControlList {
+		a := aclv1alpha1.AccessControlList{}
+		a.SetName(name)
+		if topic != "" {
+			a.Spec.ForProvider.TopicRef = &xpv1.Reference{Name: topic}
+		}
+		if deleting {
+			now := metav1.Now()
+			a.SetDeletionTimestamp(&now)
+		}
+		return a
+	}
+	paused := func(a aclv1alpha1.AccessControlList) aclv1alpha1.AccessControlList {
+		meta.AddAnnotations(&a, map[string]string{meta.AnnotationKeyReconciliationPaused: "true"})
+		return a
+	}
+	cr := &v1alpha1.Topic{}
+	cr.SetName("orders")
+
+	tests := map[string]struct {
+		acls        []aclv1alpha1.AccessControlList
+		wantDeleted []string
+		wantErr     string
+	}{
+		"NoDependents": {
+			acls: []aclv1alpha1.AccessControlList{acl("other", "payments", false), acl("by-name", "", false)},
+		},
+		"DeletesDependents": {
+			acls:        []aclv1alpha1.AccessControlList{acl("readers", "orders", false), acl("other", "payments", false)},
+			wantDeleted: []string{"readers"},
+			wantErr:     fmt.Sprintf(errDependents, "readers"),
+		},
+		"WaitsForDependents": {
+			acls:    []aclv1alpha1.AccessControlList{acl("readers", "orders", true), acl("writers", "orders", true)},
+			wantErr: fmt.Sprintf(errDependents, "readers, writers"),
+		},
+		"ReportsPausedDependents": {
+			acls: []aclv1alpha1.AccessControlList{
+				acl("readers", "orders", true),
+				paused(acl("writers", "orders", true)),
+			},
+			wantErr: fmt.Sprintf(errPausedACLs, fmt.Sprintf(errDependents, "readers, writers"), "writers"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			kube := &test.MockClient{
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					obj.(*aclv1alpha1.AccessControlListList).Items = tt.acls
+					return nil
+				}),
+				MockDelete: test.NewMockDeleteFn(nil, func(obj client.Object) error {
+					deleted = append(deleted, obj.GetName())
+					return nil
+				}),
+			}
+			c := &external{kube: kube}
+			err := c.deleteDependents(context.Background(), cr)
+			if got := fmt.Sprint(err); (err != nil || tt.wantErr != "") && got != tt.wantErr {
+				t.Errorf("deleteDependents() error = %v, wantErr %v", got, tt.wantErr)
+			}
+			if !reflect.DeepEqual(deleted, tt.wantDeleted) {
+				t.Errorf("deleteDependents() deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func Test_policyViolationPending(t *testing.T) {
+	violated := func(ago time.Duration, annotations map[string]string) *v1alpha1.Topic {
+		cr := &v1alpha1.Topic{}
+		cr.SetGeneration(2)
+		cr.SetAnnotations(annotations)
+		at := metav1.NewTime(time.Now().Add(-ago))
+		cr.Status.SetConditions(v1alpha1.BrokerPolicyViolated("topic names must start with the team name"))
+		cr.Status.AtProvider.PolicyViolationGeneration = 2
+		cr.Status.AtProvider.PolicyViolationTime = &at
+		return cr
+	}
+
+	tests := map[string]struct {
+		cr      func() *v1alpha1.Topic
+		wantErr bool
+	}{
+		"NeverViolated": {
+			cr: func() *v1alpha1.Topic { return &v1alpha1.Topic{} },
+		},
+		"Violated": {
+			cr:      func() *v1alpha1.Topic { return violated(time.Hour, nil) },
+			wantErr: true,
+		},
+		"Changed": {
+			cr: func() *v1alpha1.Topic {
+				cr := violated(time.Minute, nil)
+				cr.SetGeneration(3)
+				return cr
+			},
+		},
+		"RetryAfterElapsed": {
+			cr: func() *v1alpha1.Topic {
+				return violated(time.Hour, map[string]string{v1alpha1.AnnotationKeyPolicyRetryAfter: "10m"})
+			},
+		},
+		"RetryAfterPending": {
+			cr: func() *v1alpha1.Topic {
+				return violated(time.Minute, map[string]string{v1alpha1.AnnotationKeyPolicyRetryAfter: "10m"})
+			},
+			wantErr: true,
+		},
+		"InvalidRetryAfter": {
+			cr: func() *v1alpha1.Topic {
+				return violated(time.Hour, map[string]string{v1alpha1.AnnotationKeyPolicyRetryAfter: "soon"})
+			},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := policyViolationPending(tt.cr()); (err != nil) != tt.wantErr {
+				t.Errorf("policyViolationPending() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_external_CreateObservePolicyViolation(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.NumBrokers(1))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+	reason := "topic names must start with the team name"
+	c.ControlKey(int16(kmsg.CreateTopics), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+		c.KeepControl()
+		req := kreq.(*kmsg.CreateTopicsRequest)
+		resp := req.ResponseKind().(*kmsg.CreateTopicsResponse)
+		for _, rt := range req.Topics {
+			st := kmsg.NewCreateTopicsResponseTopic()
+			st.Topic = rt.Topic
+			st.ErrorCode = kerr.PolicyViolation.Code
+			st.ErrorMessage = &reason
+			resp.Topics = append(resp.Topics, st)
+		}
+		return resp, nil, true
+	})
+
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(context.Background(), creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	e := &external{
+		kube:        &test.MockClient{MockList: test.NewMockListFn(nil)},
+		kafkaClient: cl,
+		timeouts:    kafka.DefaultTimeouts,
+		log:         logging.NewNopLogger(),
+	}
+	cr := &v1alpha1.Topic{}
+	cr.SetName("orders")
+	cr.SetGeneration(1)
+	cr.Spec.ForProvider.Partitions = 1
+	cr.Spec.ForProvider.ReplicationFactor = 1
+	meta.SetExternalName(cr, "orders")
+
+	if _, err := e.Create(context.Background(), cr); !errors.Is(err, kerr.PolicyViolation) {
+		t.Fatalf("Create(...): got error %v, want %v", err, kerr.PolicyViolation)
+	}
+
+	// Only the annotations of a Topic are persisted when creating its topic
+	// fails.
+	persisted := &v1alpha1.Topic{}
+	persisted.SetName(cr.GetName())
+	persisted.SetGeneration(cr.GetGeneration())
+	persisted.SetAnnotations(cr.GetAnnotations())
+	persisted.Spec = cr.Spec
+
+	got, err := e.Observe(context.Background(), persisted)
+	if err == nil {
+		t.Errorf("Observe(...): want error while the policy violation is pending")
+	}
+	if !reflect.DeepEqual(got, managed.ExternalObservation{}) {
+		t.Errorf("Observe(...) = %v, want %v", got, managed.ExternalObservation{})
+	}
+	cond := persisted.Status.GetCondition(v1alpha1.TypeBrokerPolicyCompliant)
+	if cond.Reason != v1alpha1.ReasonPolicyViolation || cond.Message != reason {
+		t.Errorf("Observe(...): condition %v, want reason %q and message %q", cond, v1alpha1.ReasonPolicyViolation, reason)
+	}
+	if persisted.Status.AtProvider.PolicyViolationGeneration != 1 || persisted.Status.AtProvider.PolicyViolationTime == nil {
+		t.Errorf("Observe(...): policy violation not recorded in status: %+v", persisted.Status.AtProvider)
+	}
+
+	// A changed Topic is created again.
+	persisted.SetGeneration(2)
+	if _, err := e.Observe(context.Background(), persisted); err != nil {
+		t.Errorf("Observe(...) of changed Topic: %v", err)
+	}
+}
+
+func Test_external_observeMissing(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.NumBrokers(1))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+	// The brokers report a topic they are still deleting as existing when
+	// asked to validate creating it.
+	c.ControlKey(int16(kmsg.CreateTopics), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+		c.KeepControl()
+		req := kreq.(*kmsg.CreateTopicsRequest)
+		resp := req.ResponseKind().(*kmsg.CreateTopicsResponse)
+		for _, rt := range req.Topics {
+			st := kmsg.NewCreateTopicsResponseTopic()
+			st.Topic = rt.Topic
+			if rt.Topic == "deleting" {
+				st.ErrorCode = kerr.TopicAlreadyExists.Code
+			}
+			resp.Topics = append(resp.Topics, st)
+		}
+		return resp, nil, true
+	})
+
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(context.Background(), creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	named := func(name string) *v1alpha1.Topic {
+		cr := &v1alpha1.Topic{}
+		meta.SetExternalName(cr, name)
+		return cr
+	}
+
+	tests := map[string]struct {
+		reason    string
+		cr        *v1alpha1.Topic
+		want      managed.ExternalObservation
+		wantErr   bool
+		wantState corev1.ConditionStatus
+	}{
+		"Missing": {
+			reason:    "A topic that is neither present nor being deleted should be created.",
+			cr:        named("orders"),
+			want:      managed.ExternalObservation{ResourceExists: false},
+			wantState: corev1.ConditionUnknown,
+		},
+		"MarkedForDeletion": {
+			reason:    "A topic the brokers are still deleting should not be created yet.",
+			cr:        named("deleting"),
+			wantErr:   true,
+			wantState: corev1.ConditionTrue,
+		},
+		"TopicDeleted": {
+			reason: "The topic of a deleted Topic should be gone, whatever the brokers are doing.",
+			cr: func() *v1alpha1.Topic {
+				cr := named("deleting")
+				cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+				return cr
+			}(),
+			want:      managed.ExternalObservation{ResourceExists: false},
+			wantState: corev1.ConditionUnknown,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kafkaClient: cl}
+			got, err := e.observeMissing(context.Background(), tt.cr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("\n%s\nobserveMissing() error = %v, wantErr %v", tt.reason, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("\n%s\nobserveMissing() = %v, want %v", tt.reason, got, tt.want)
+			}
+			if got := tt.cr.Status.GetCondition(v1alpha1.TypeDeletionInProgress).Status; got != tt.wantState {
+				t.Errorf("\n%s\nobserveMissing() condition = %v, want %v", tt.reason, got, tt.wantState)
+			}
+		})
+	}
+}
+
+func Test_external_DeleteInUse(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(1, "orders", "empty", "payments"))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	p, err := kgo.NewClient(kgo.SeedBrokers(c.ListenAddrs()...))
+	if err != nil {
+		t.Fatalf("kgo.NewClient(...): %v", err)
+	}
+	defer p.Close()
+	for _, n := range []string{"orders", "payments"} {
+		if err := p.ProduceSync(context.Background(), &kgo.Record{Topic: n, Value: []byte("v")}).FirstErr(); err != nil {
+			t.Fatalf("ProduceSync(...): %v", err)
+		}
+	}
+
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(context.Background(), creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	named := func(name string, allowDataLoss bool) *v1alpha1.Topic {
+		cr := &v1alpha1.Topic{}
+		meta.SetExternalName(cr, name)
+		if allowDataLoss {
+			cr.Spec.ForProvider.AllowDataLoss = &allowDataLoss
+		}
+		return cr
+	}
+
+	tests := map[string]struct {
+		reason     string
+		cr         *v1alpha1.Topic
+		wantInUse  bool
+		wantExists bool
+	}{
+		"InUse": {
+			reason:     "A topic holding records should be kept, and the Topic should say why.",
+			cr:         named("orders", false),
+			wantInUse:  true,
+			wantExists: true,
+		},
+		"Empty": {
+			reason: "A topic holding no records should be deleted.",
+			cr:     named("empty", false),
+		},
+		"AllowDataLoss": {
+			reason: "A topic holding records should be deleted when data loss is allowed.",
+			cr:     named("payments", true),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kafkaClient: cl, timeouts: kafka.DefaultTimeouts, deletionProtection: true}
+			err := e.Delete(context.Background(), tt.cr)
+			var inUse *topic.InUseError
+			if errors.As(err, &inUse) != tt.wantInUse {
+				t.Errorf("\n%s\nDelete() error = %v, want InUseError %t", tt.reason, err, tt.wantInUse)
+			}
+			if !tt.wantInUse && err != nil {
+				t.Errorf("\n%s\nDelete() error = %v", tt.reason, err)
+			}
+			cond := tt.cr.Status.GetCondition(v1alpha1.TypeInUse)
+			if (cond.Reason == v1alpha1.ReasonDataLossRefused) != tt.wantInUse {
+				t.Errorf("\n%s\nDelete() condition = %v, want InUse %t", tt.reason, cond, tt.wantInUse)
+			}
+			_, err = topic.Get(context.Background(), cl, meta.GetExternalName(tt.cr))
+			if exists := err == nil; exists != tt.wantExists {
+				t.Errorf("\n%s\nDelete(): topic exists %t, want %t", tt.reason, exists, tt.wantExists)
+			}
+		})
+	}
+}
+
+func Test_external_checkKeySchema(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/subjects/orders-key/versions/latest" && r.URL.Path != "/subjects/shared-key/versions/latest" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"version":1}`))
+	}))
+	defer srv.Close()
+
+	compacted := "compact"
+	params := func(ref *v1alpha1.KeySchemaReference, compact bool) *v1alpha1.TopicParameters {
+		p := &v1alpha1.TopicParameters{KeySchemaRef: ref}
+		if compact {
+			p.Config = map[string]*string{"cleanup.policy": &compacted}
+		}
+		return p
+	}
+	named := func(name string) *v1alpha1.Topic {
+		cr := &v1alpha1.Topic{}
+		meta.SetExternalName(cr, name)
+		return cr
+	}
+
+	tests := map[string]struct {
+		reason   string
+		cr       *v1alpha1.Topic
+		params   *v1alpha1.TopicParameters
+		registry bool
+		wantErr  bool
+	}{
+		"NotLinked": {
+			reason: "A compacted topic not linked to a key schema should not be checked.",
+			cr:     named("payments"),
+			params: params(nil, true),
+		},
+		"NotCompacted": {
+			reason:   "A topic that is not compacted should not be checked.",
+			cr:       named("payments"),
+			params:   params(&v1alpha1.KeySchemaReference{}, false),
+			registry: true,
+		},
+		"Registered": {
+			reason:   "A compacted topic should be accepted once its key schema is registered under the default subject.",
+			cr:       named("orders"),
+			params:   params(&v1alpha1.KeySchemaReference{}, true),
+			registry: true,
+		},
+		"RegisteredSubject": {
+			reason:   "A compacted topic should be checked against the subject it is linked to.",
+			cr:       named("payments"),
+			params:   params(&v1alpha1.KeySchemaReference{Subject: "shared-key"}, true),
+			registry: true,
+		},
+		"NotRegistered": {
+			reason:   "A compacted topic should be refused while its linked key schema is not registered.",
+			cr:       named("payments"),
+			params:   params(&v1alpha1.KeySchemaReference{}, true),
+			registry: true,
+			wantErr:  true,
+		},
+		"NoRegistry": {
+			reason:  "A compacted topic linked to a key schema should be refused without a Schema Registry.",
+			cr:      named("orders"),
+			params:  params(&v1alpha1.KeySchemaReference{}, true),
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &external{}
+			if tt.registry {
+				e.registry = schemaregistry.NewClient(&kafka.SchemaRegistry{URL: srv.URL})
+			}
+			if err := e.checkKeySchema(context.Background(), tt.cr, tt.params); (err != nil) != tt.wantErr {
+				t.Errorf("\n%s\ncheckKeySchema() error = %v, wantErr %v", tt.reason, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_external_ObserveRefresh(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(1, "orders"))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(context.Background(), creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	annotated := func(name, v string) *v1alpha1.Topic {
+		cr := &v1alpha1.Topic{}
+		meta.SetExternalName(cr, name)
+		meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeyRefresh: v})
+		return cr
+	}
+
+	tests := map[string]struct {
+		reason    string
+		cr        *v1alpha1.Topic
+		patchErr  error
+		wantPatch string
+		wantErr   bool
+	}{
+		"NotTrue": {
+			reason: "A Topic not annotated to be refreshed should keep its annotation.",
+			cr:     annotated("orders", "false"),
+		},
+		"Described": {
+			reason:    "The annotation should be removed once the topic was described.",
+			cr:        annotated("orders", "true"),
+			wantPatch: `{"metadata":{"annotations":{"kafka.crossplane.io/refresh":null}}}`,
+		},
+		"NotDescribed": {
+			reason: "The annotation should be kept while the topic could not be described.",
+			cr:     annotated("payments", "true"),
+		},
+		"PatchError": {
+			reason:    "Failing to remove the annotation should be an error.",
+			cr:        annotated("orders", "true"),
+			patchErr:  errors.New("boom"),
+			wantPatch: `{"metadata":{"annotations":{"kafka.crossplane.io/refresh":null}}}`,
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var patch string
+			kube := &test.MockClient{
+				MockList: test.NewMockListFn(nil),
+				MockPatch: func(_ context.Context, _ client.Object, p client.Patch, _ ...client.PatchOption) error {
+					b, _ := p.Data(nil)
+					patch = string(b)
+					return tt.patchErr
+				},
+			}
+			e := &external{kube: kube, kafkaClient: cl, timeouts: kafka.DefaultTimeouts, log: logging.NewNopLogger()}
+			_, err := e.Observe(context.Background(), tt.cr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("\n%s\nObserve() error = %v, wantErr %v", tt.reason, err, tt.wantErr)
+			}
+			if patch != tt.wantPatch {
+				t.Errorf("\n%s\nObserve() patch = %s, want %s", tt.reason, patch, tt.wantPatch)
+			}
+		})
+	}
+}
+
+func Test_external_reconcileExternalName(t *testing.T) {
+	topicFor := func(ext, recorded string, annotations map[string]string) *v1alpha1.Topic {
+		cr := &v1alpha1.Topic{}
+		for k, v := range annotations {
+			meta.AddAnnotations(cr, map[string]string{k: v})
+		}
+		meta.SetExternalName(cr, ext)
+		cr.Status.AtProvider = v1alpha1.TopicObservation{TopicName: recorded, TopicID: "AAAAAAAAAAAAAAAAAAAAAQ", PartitionCount: 3}
+		return cr
+	}
+	migrate := map[string]string{v1alpha1.AnnotationKeyMigrateExternalName: "true"}
+	migratePatch := `{"metadata":{"annotations":{"kafka.crossplane.io/migrate-external-name":null,"crossplane.io/external-create-pending":null,"crossplane.io/external-create-succeeded":null,"crossplane.io/external-create-failed":null}}}`
+
+	tests := map[string]struct {
+		cr            *v1alpha1.Topic
+		patchErr      error
+		wantTopicName string
+		wantCondition corev1.ConditionStatus
+		wantPatch     string
+		wantErr       bool
+	}{
+		"NotRecorded": {
+			cr:            topicFor("orders", "", nil),
+			wantTopicName: "orders",
+		},
+		"Unchanged": {
+			cr:            topicFor("orders", "orders", nil),
+			wantTopicName: "orders",
+		},
+		"ChangedIsIgnored": {
+			cr:            topicFor("orders.v2", "orders", nil),
+			wantTopicName: "orders",
+			wantCondition: corev1.ConditionTrue,
+		},
+		"Migrated": {
+			cr:            topicFor("orders.v2", "orders", migrate),
+			wantTopicName: "orders.v2",
+			wantCondition: corev1.ConditionFalse,
+			wantPatch:     migratePatch,
+		},
+		"LeftoverMigrateAnnotation": {
+			cr:            topicFor("orders", "orders", migrate),
+			wantTopicName: "orders",
+			wantPatch:     `{"metadata":{"annotations":{"kafka.crossplane.io/migrate-external-name":null}}}`,
+		},
+		"PatchError": {
+			cr:            topicFor("orders.v2", "orders", migrate),
+			patchErr:      errors.New("boom"),
+			wantTopicName: "orders",
+			wantPatch:     migratePatch,
+			wantErr:       true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var patch string
+			kube := &test.MockClient{MockPatch: func(_ context.Context, _ client.Object, p client.Patch, _ ...client.PatchOption) error {
+				b, _ := p.Data(nil)
+				patch = string(b)
+				return tt.patchErr
+			}}
+			c := &external{kube: kube}
+			err := c.reconcileExternalName(context.Background(), tt.cr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reconcileExternalName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := topicName(tt.cr); got != tt.wantTopicName {
+				t.Errorf("topicName() = %q, want %q", got, tt.wantTopicName)
+			}
+			want := tt.wantCondition
+			if want == "" {
+				want = corev1.ConditionUnknown
+			}
+			if got := tt.cr.Status.GetCondition(v1alpha1.TypeExternalNameChanged).Status; got != want {
+				t.Errorf("reconcileExternalName() condition = %s, want %s", got, want)
+			}
+			if patch != tt.wantPatch {
+				t.Errorf("reconcileExternalName() patch = %s, want %s", patch, tt.wantPatch)
+			}
+		})
+	}
+}
+
+func Test_connectionDetails(t *testing.T) {
+	got := connectionDetails(&topic.Topic{Name: "orders", Partitions: 6}, []string{"kafka-0:9092", "kafka-1:9092"})
+	want := managed.ConnectionDetails{
+		"topic":            []byte("orders"),
+		"bootstrapServers": []byte("kafka-0:9092,kafka-1:9092"),
+		"partitions":       []byte("6"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("connectionDetails() = %v, want %v", got, want)
+	}
+}
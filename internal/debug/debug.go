@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package debug serves the endpoints used to debug the provider, such as the
+// one changing its log level, on a listener of their own, apart from the
+// unauthenticated metrics server.
+package debug
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	readHeaderTimeout = 10 * time.Second
+
+	errAddress     = "cannot parse debug address %q"
+	errNotLoopback = "debug address %q must be a loopback address, such as 127.0.0.1:8081, as its endpoints are not authenticated"
+	errListen      = "cannot listen on debug address %q"
+)
+
+// A Server serves debugging endpoints on a loopback address, so that they are
+// only reachable from within the pod of the provider, e.g. through kubectl
+// port-forward.
+type Server struct {
+	server   *http.Server
+	listener net.Listener
+}
+
+// NewServer returns a Server listening on the supplied loopback address, which
+// serves the supplied handlers by path.
+func NewServer(addr string, handlers map[string]http.Handler) (*Server, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, errAddress, addr)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, errors.Errorf(errNotLoopback, addr)
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, errListen, addr)
+	}
+	mux := http.NewServeMux()
+	for path, h := range handlers {
+		mux.Handle(path, h)
+	}
+	return &Server{server: &http.Server{Handler: mux, ReadHeaderTimeout: readHeaderTimeout}, listener: l}, nil
+}
+
+// Addr returns the address the Server listens on.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Start serving until the supplied context is done.
+func (s *Server) Start(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		_ = s.server.Shutdown(context.Background())
+	}()
+	if err := s.server.Serve(s.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false, as every replica of the provider logs.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestNewServer(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		addr    string
+		wantErr bool
+	}{
+		"Loopback": {
+			reason: "A loopback address should be listened on.",
+			addr:   "127.0.0.1:0",
+		},
+		"Localhost": {
+			reason: "localhost should be listened on.",
+			addr:   "localhost:0",
+		},
+		"AllInterfaces": {
+			reason:  "An address reachable from outside the pod should be refused.",
+			addr:    ":0",
+			wantErr: true,
+		},
+		"PodIP": {
+			reason:  "A non-loopback address should be refused.",
+			addr:    "10.0.0.1:8081",
+			wantErr: true,
+		},
+		"Invalid": {
+			reason:  "An address without a port should be refused.",
+			addr:    "127.0.0.1",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewServer(tc.addr, nil)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nNewServer(...): error = %v, wantErr %v", tc.reason, err, tc.wantErr)
+			}
+			if s != nil {
+				_ = s.listener.Close()
+			}
+		})
+	}
+}
+
+func TestServerStart(t *testing.T) {
+	s, err := NewServer("127.0.0.1:0", map[string]http.Handler{
+		"/debug/loglevel": http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"level":"info"}`))
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewServer(...): %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Start(ctx) }()
+
+	resp, err := http.Get("http://" + s.Addr().String() + "/debug/loglevel")
+	if err != nil {
+		t.Fatalf("GET /debug/loglevel: %v", err)
+	}
+	b, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(b) != `{"level":"info"}` {
+		t.Errorf("GET /debug/loglevel: got %q", b)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Start(...): %v", err)
+	}
+}
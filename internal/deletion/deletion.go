@@ -0,0 +1,182 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deletion caps how long the deletion of a managed resource can be
+// blocked, so that managed resources of a decommissioned cluster do not stay
+// Terminating forever.
+package deletion
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	// AnnotationKeyTimeout overrides the deletion timeout of the provider
+	// for a managed resource, e.g. 30m. A timeout of 0 disables it.
+	AnnotationKeyTimeout = "kafka.crossplane.io/deletion-timeout"
+	// AnnotationKeyOrphanOnTimeout orphans the external resource of a
+	// managed resource once its deletion timed out, if set to true.
+	AnnotationKeyOrphanOnTimeout = "kafka.crossplane.io/orphan-on-deletion-timeout"
+
+	// ReasonDeletionBlocked is the reason of events emitted while the
+	// deletion of a managed resource is blocked past its timeout.
+	ReasonDeletionBlocked event.Reason = "DeletionBlocked"
+	// ReasonOrphaned is the reason of events emitted when the external
+	// resource of a managed resource is orphaned.
+	ReasonOrphaned event.Reason = "OrphanedExternalResource"
+
+	errBlocked = "deletion has been blocked for %s, longer than the deletion timeout of %s; annotate the resource " + AnnotationKeyOrphanOnTimeout + "=true to orphan its external resource"
+	msgOrphan  = "deletion did not complete within the deletion timeout of %s; orphaning the external resource"
+)
+
+// A Policy determines how long the deletion of a managed resource may take,
+// and what happens once it took longer.
+type Policy struct {
+	// Timeout is how long the deletion of a managed resource may take. Zero
+	// disables the timeout.
+	Timeout time.Duration
+
+	// OrphanOnTimeout orphans the external resource once the deletion of its
+	// managed resource timed out, instead of only emitting events.
+	OrphanOnTimeout bool
+}
+
+// For returns the policy of the supplied managed resource, which is the
+// supplied policy overridden by the annotations of the managed resource.
+// Invalid annotations are ignored.
+func (p Policy) For(mg resource.Managed) Policy {
+	if v, ok := mg.GetAnnotations()[AnnotationKeyTimeout]; ok {
+		if d, err := time.ParseDuration(v); err == nil {
+			p.Timeout = d
+		}
+	}
+	if v, ok := mg.GetAnnotations()[AnnotationKeyOrphanOnTimeout]; ok {
+		if b, err := strconv.ParseBool(v); err == nil {
+			p.OrphanOnTimeout = b
+		}
+	}
+	return p
+}
+
+// TimedOut returns how long the deletion of the supplied managed resource has
+// been going on, and whether that is longer than the timeout.
+func (p Policy) TimedOut(mg resource.Managed, now time.Time) (time.Duration, bool) {
+	if !meta.WasDeleted(mg) || p.Timeout <= 0 {
+		return 0, false
+	}
+	d := now.Sub(mg.GetDeletionTimestamp().Time)
+	return d, d > p.Timeout
+}
+
+// NewConnecter returns an ExternalConnecter whose clients enforce the supplied
+// policy on managed resources being deleted. Once the deletion of a managed
+// resource timed out, the external resource is either orphaned by reporting
+// it no longer exists, or every error blocking the deletion is emitted as a
+// DeletionBlocked event.
+func NewConnecter(c managed.ExternalConnecter, p Policy, rec event.Recorder, log logging.Logger) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, policy: p, record: rec, log: log, now: time.Now}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	policy Policy
+	record event.Recorder
+	log    logging.Logger
+	now    func() time.Time
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		if c.orphan(mg) {
+			return &orphaned{}, nil
+		}
+		return nil, c.blocked(mg, err)
+	}
+	return &external{ExternalClient: ec, connecter: c}, nil
+}
+
+// orphan returns true, and emits an event, if the external resource of the
+// supplied managed resource is to be orphaned.
+func (c *connecter) orphan(mg resource.Managed) bool {
+	p := c.policy.For(mg)
+	if _, timedOut := p.TimedOut(mg, c.now()); !timedOut || !p.OrphanOnTimeout {
+		return false
+	}
+	c.log.Info("Orphaning external resource after deletion timeout", "name", mg.GetName(), "timeout", p.Timeout)
+	c.record.Event(mg, event.Warning(ReasonOrphaned, errors.Errorf(msgOrphan, p.Timeout)))
+	return true
+}
+
+// blocked emits an event if the supplied error blocks the deletion of the
+// supplied managed resource past its timeout. It returns the supplied error.
+func (c *connecter) blocked(mg resource.Managed, err error) error {
+	p := c.policy.For(mg)
+	if d, timedOut := p.TimedOut(mg, c.now()); timedOut && err != nil {
+		c.record.Event(mg, event.Warning(ReasonDeletionBlocked, errors.Wrapf(err, errBlocked, d.Round(time.Second), p.Timeout)))
+	}
+	return err
+}
+
+type external struct {
+	managed.ExternalClient
+	*connecter
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	// The external resource is orphaned by reporting that it does not exist,
+	// so that the managed reconciler removes its finalizer without deleting
+	// it. Deleting it is not attempted anymore, as it might fail after
+	// succeeding partially.
+	if e.orphan(mg) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	return o, e.blocked(mg, err)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	return e.blocked(mg, e.ExternalClient.Delete(ctx, mg))
+}
+
+// orphaned is the client of a managed resource whose external resource is
+// orphaned. It reports that the external resource does not exist.
+type orphaned struct{}
+
+func (orphaned) Observe(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+	return managed.ExternalObservation{ResourceExists: false}, nil
+}
+
+func (orphaned) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+func (orphaned) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (orphaned) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
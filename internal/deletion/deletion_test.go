@@ -0,0 +1,149 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+type recorder struct {
+	reasons []event.Reason
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestConnecter(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Now()
+
+	type want struct {
+		o       managed.ExternalObservation
+		err     error
+		reasons []event.Reason
+	}
+
+	cases := map[string]struct {
+		reason      string
+		policy      Policy
+		annotations map[string]string
+		deleted     time.Duration
+		connectErr  error
+		observeErr  error
+		want        want
+	}{
+		"NotDeleted": {
+			reason:     "Errors of managed resources that are not being deleted should be returned unchanged.",
+			policy:     Policy{Timeout: time.Minute, OrphanOnTimeout: true},
+			observeErr: errBoom,
+			want:       want{o: managed.ExternalObservation{ResourceExists: true}, err: errBoom},
+		},
+		"WithinTimeout": {
+			reason:     "Errors blocking a deletion should be returned unchanged until the deletion timed out.",
+			policy:     Policy{Timeout: time.Hour, OrphanOnTimeout: true},
+			deleted:    time.Minute,
+			observeErr: errBoom,
+			want:       want{o: managed.ExternalObservation{ResourceExists: true}, err: errBoom},
+		},
+		"Blocked": {
+			reason:     "Errors blocking a deletion past its timeout should be emitted as events unless orphaning is opted into.",
+			policy:     Policy{Timeout: time.Minute},
+			deleted:    time.Hour,
+			connectErr: errBoom,
+			want:       want{err: errBoom, reasons: []event.Reason{ReasonDeletionBlocked}},
+		},
+		"OrphanedOnConnectError": {
+			reason:     "The external resource should be reported gone once its deletion timed out, even if the brokers cannot be connected to.",
+			policy:     Policy{Timeout: time.Minute, OrphanOnTimeout: true},
+			deleted:    time.Hour,
+			connectErr: errBoom,
+			want:       want{reasons: []event.Reason{ReasonOrphaned}},
+		},
+		"OrphanedByAnnotation": {
+			reason:      "Annotations should override the policy of the provider.",
+			annotations: map[string]string{AnnotationKeyTimeout: "10m", AnnotationKeyOrphanOnTimeout: "true"},
+			deleted:     time.Hour,
+			observeErr:  errBoom,
+			want:        want{reasons: []event.Reason{ReasonOrphaned}},
+		},
+		"TimeoutDisabledByAnnotation": {
+			reason:      "A timeout of zero in the annotation should disable the timeout of the provider.",
+			policy:      Policy{Timeout: time.Minute, OrphanOnTimeout: true},
+			annotations: map[string]string{AnnotationKeyTimeout: "0"},
+			deleted:     time.Hour,
+			observeErr:  errBoom,
+			want:        want{o: managed.ExternalObservation{ResourceExists: true}, err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &recorder{}
+			c := &connecter{
+				ExternalConnecter: managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+					if tc.connectErr != nil {
+						return nil, tc.connectErr
+					}
+					return &managed.ExternalClientFns{
+						ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+							return managed.ExternalObservation{ResourceExists: true}, tc.observeErr
+						},
+					}, nil
+				}),
+				policy: tc.policy,
+				record: rec,
+				log:    logging.NewNopLogger(),
+				now:    func() time.Time { return now },
+			}
+
+			mg := &v1alpha1.Topic{}
+			mg.SetAnnotations(tc.annotations)
+			if tc.deleted > 0 {
+				mg.SetDeletionTimestamp(&metav1.Time{Time: now.Add(-tc.deleted)})
+			}
+
+			got := want{}
+			ec, err := c.Connect(context.Background(), mg)
+			if err == nil {
+				got.o, err = ec.Observe(context.Background(), mg)
+			}
+			got.err, got.reasons = err, rec.reasons
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nConnect(...).Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dependency gates managed resources on the managed resources they
+// depend on, such as AccessControlLists on the Topics they reference, so that
+// a managed resource waits for its dependencies to be ready instead of
+// failing to reconcile until they happen to be.
+package dependency
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+const (
+	errGetDependency = "cannot get %s %q"
+
+	msgMissing  = "%s %q does not exist"
+	msgNotReady = "%s %q is not ready"
+)
+
+// A Dependency is a managed resource another managed resource depends on.
+type Dependency struct {
+	// Object the dependency is read into, e.g. an empty Topic. It determines
+	// the kind of the dependency.
+	Object resource.Managed
+
+	// Name of the dependency.
+	Name string
+}
+
+// kind returns the kind of the dependency, e.g. Topic.
+func (d Dependency) kind() string {
+	return reflect.TypeOf(d.Object).Elem().Name()
+}
+
+// A Fn returns the dependencies of the supplied managed resource.
+type Fn func(mg resource.Managed) []Dependency
+
+// A Gate tells whether the dependencies of managed resources are ready.
+type Gate struct {
+	kube client.Reader
+	deps Fn
+}
+
+// NewGate returns a Gate reading the supplied dependencies of managed
+// resources through the supplied client.
+func NewGate(kube client.Reader, deps Fn) Gate {
+	return Gate{kube: kube, deps: deps}
+}
+
+// Unready returns why the dependencies of the supplied managed resource are
+// not ready, or an empty string if they are.
+func (g Gate) Unready(ctx context.Context, mg resource.Managed) (string, error) {
+	reasons := []string{}
+	for _, d := range g.deps(mg) {
+		err := g.kube.Get(ctx, types.NamespacedName{Name: d.Name}, d.Object)
+		switch {
+		case resource.IgnoreNotFound(err) != nil:
+			return "", errors.Wrapf(err, errGetDependency, d.kind(), d.Name)
+		case err != nil:
+			reasons = append(reasons, fmt.Sprintf(msgMissing, d.kind(), d.Name))
+		case d.Object.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue:
+			reasons = append(reasons, fmt.Sprintf(msgNotReady, d.kind(), d.Name))
+		}
+	}
+	sort.Strings(reasons)
+	return strings.Join(reasons, "; "), nil
+}
+
+// waiting returns true if the supplied managed resource waits for its
+// dependencies. Managed resources being deleted never wait.
+func waiting(mg resource.Managed) bool {
+	return !meta.WasDeleted(mg) && mg.GetCondition(apisv1alpha1.TypeDependenciesReady).Status == corev1.ConditionFalse
+}
+
+// NewReferenceResolver returns a ReferenceResolver that records whether the
+// dependencies of a managed resource are ready in its DependenciesReady
+// condition, before the supplied resolver resolves its references. Failing
+// to resolve references to dependencies that are not ready is no error, as
+// the managed resource waits for them.
+func NewReferenceResolver(r managed.ReferenceResolver, g Gate) managed.ReferenceResolver {
+	return managed.ReferenceResolverFn(func(ctx context.Context, mg resource.Managed) error {
+		rerr := r.ResolveReferences(ctx, mg)
+		msg, err := g.Unready(ctx, mg)
+		if err != nil {
+			return err
+		}
+		if msg != "" {
+			mg.SetConditions(apisv1alpha1.WaitingForDependencies(msg))
+			return nil
+		}
+		if mg.GetCondition(apisv1alpha1.TypeDependenciesReady).Status == corev1.ConditionFalse {
+			mg.SetConditions(apisv1alpha1.DependenciesReady())
+		}
+		return rerr
+	})
+}
+
+// NewConnecter returns an ExternalConnecter whose clients leave the external
+// resource of a managed resource waiting for its dependencies alone. They
+// report it as up to date, and the managed resource as unavailable, until a
+// NewReferenceResolver found its dependencies ready.
+func NewConnecter(c managed.ExternalConnecter) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if waiting(mg) {
+		return &waitingClient{}, nil
+	}
+	return c.ExternalConnecter.Connect(ctx, mg)
+}
+
+// A waitingClient is the client of a managed resource waiting for its
+// dependencies.
+type waitingClient struct{}
+
+func (waitingClient) Observe(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	mg.SetConditions(xpv1.Unavailable())
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (waitingClient) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+func (waitingClient) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (waitingClient) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
+
+// EnqueueDependents returns an event handler that, for every event of a
+// dependency, enqueues the managed resources of the supplied list waiting for
+// it, so that they are reconciled as soon as it is ready.
+func EnqueueDependents(kube client.Reader, list resource.ManagedList, deps Fn) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		l, ok := list.DeepCopyObject().(resource.ManagedList)
+		if !ok {
+			return nil
+		}
+		if err := kube.List(ctx, l); err != nil {
+			return nil
+		}
+		reqs := []reconcile.Request{}
+		for _, mg := range l.GetItems() {
+			if !waiting(mg) {
+				continue
+			}
+			for _, d := range deps(mg) {
+				if reflect.TypeOf(d.Object) == reflect.TypeOf(obj) && d.Name == obj.GetName() {
+					reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: mg.GetName()}})
+					break
+				}
+			}
+		}
+		return reqs
+	})
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	aclv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+	topicv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// onTopic makes every managed resource depend on the Topic named orders.
+func onTopic(_ resource.Managed) []Dependency {
+	return []Dependency{{Object: &topicv1alpha1.Topic{}, Name: "orders"}}
+}
+
+func TestGating(t *testing.T) {
+	errUnresolved := errors.New("cannot resolve references")
+	deleted := metav1.Now()
+
+	type want struct {
+		err       error
+		status    corev1.ConditionStatus
+		connected bool
+	}
+
+	cases := map[string]struct {
+		reason  string
+		topic   func(obj client.Object) error
+		deleted bool
+		want    want
+	}{
+		"Missing": {
+			reason: "A managed resource whose dependency does not exist should wait for it, without failing to resolve references to it.",
+			topic:  func(_ client.Object) error { return kerrors.NewNotFound(schema.GroupResource{}, "orders") },
+			want:   want{status: corev1.ConditionFalse},
+		},
+		"NotReady": {
+			reason: "A managed resource whose dependency is not ready should wait for it.",
+			topic: func(obj client.Object) error {
+				obj.(*topicv1alpha1.Topic).SetConditions(xpv1.Creating())
+				return nil
+			},
+			want: want{status: corev1.ConditionFalse},
+		},
+		"Ready": {
+			reason: "A managed resource whose dependencies are ready should be reconciled as usual.",
+			topic: func(obj client.Object) error {
+				obj.(*topicv1alpha1.Topic).SetConditions(xpv1.Available())
+				return nil
+			},
+			want: want{err: errUnresolved, connected: true},
+		},
+		"Deleted": {
+			reason:  "A managed resource being deleted should never wait for its dependencies.",
+			topic:   func(_ client.Object) error { return kerrors.NewNotFound(schema.GroupResource{}, "orders") },
+			deleted: true,
+			want:    want{status: corev1.ConditionFalse, connected: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				return tc.topic(obj)
+			}}
+			cr := &aclv1alpha1.AccessControlList{}
+			if tc.deleted {
+				cr.SetDeletionTimestamp(&deleted)
+			}
+
+			r := NewReferenceResolver(managed.ReferenceResolverFn(func(_ context.Context, _ resource.Managed) error {
+				return errUnresolved
+			}), NewGate(kube, onTopic))
+			err := r.ResolveReferences(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolveReferences(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if got := cr.GetCondition(apisv1alpha1.TypeDependenciesReady).Status; got != tc.want.status && tc.want.status != "" {
+				t.Errorf("\n%s\nResolveReferences(...): want DependenciesReady %s, got %s", tc.reason, tc.want.status, got)
+			}
+
+			connected := false
+			c := NewConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				connected = true
+				return &managed.ExternalClientFns{}, nil
+			}))
+			ec, err := c.Connect(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Connect(...): %s", err)
+			}
+			if connected != tc.want.connected {
+				t.Errorf("\n%s\nConnect(...): want connected %t, got %t", tc.reason, tc.want.connected, connected)
+			}
+			if !connected {
+				o, _ := ec.Observe(context.Background(), cr)
+				if !o.ResourceExists || !o.ResourceUpToDate {
+					t.Errorf("\n%s\nObserve(...): a waiting external resource should be reported as existing and up to date", tc.reason)
+				}
+			}
+		})
+	}
+}
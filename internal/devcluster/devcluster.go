@@ -0,0 +1,59 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package devcluster runs an in-process fake Kafka cluster, so that Topics
+// and ConsumerGroups can be exercised during local development and in CI
+// without any real broker.
+package devcluster
+
+import (
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+const errStart = "cannot start fake Kafka cluster"
+
+// Start starts a fake Kafka cluster listening on random local ports. It must
+// be closed once no longer needed.
+func Start(opts ...kfake.Opt) (*kfake.Cluster, error) {
+	c, err := kfake.NewCluster(opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, errStart)
+	}
+	c.ControlKey(int16(kmsg.CreateTopics), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+		c.KeepControl()
+		return validateOnly(kreq.(*kmsg.CreateTopicsRequest))
+	})
+	return c, nil
+}
+
+// validateOnly accepts topics that are only to be validated. The fake cluster
+// does not honour validateOnly, and would create them instead.
+func validateOnly(req *kmsg.CreateTopicsRequest) (kmsg.Response, error, bool) {
+	if !req.ValidateOnly {
+		return nil, nil, false
+	}
+	resp := req.ResponseKind().(*kmsg.CreateTopicsResponse)
+	for _, t := range req.Topics {
+		rt := kmsg.NewCreateTopicsResponseTopic()
+		rt.Topic = t.Topic
+		rt.NumPartitions = t.NumPartitions
+		rt.ReplicationFactor = t.ReplicationFactor
+		resp.Topics = append(resp.Topics, rt)
+	}
+	return resp, nil, true
+}
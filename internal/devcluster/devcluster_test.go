@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devcluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka/topic"
+)
+
+func TestStart(t *testing.T) {
+	c, err := Start()
+	if err != nil {
+		t.Fatalf("Start(): %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(ctx, creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	tp := &topic.Topic{Name: "orders", Partitions: 3, ReplicationFactor: 1}
+	if err := topic.Validate(ctx, cl, tp); err != nil {
+		t.Fatalf("topic.Validate(...): %v", err)
+	}
+	if _, err := topic.Get(ctx, cl, tp.Name); err == nil {
+		t.Fatalf("topic.Get(...): validating a topic must not create it")
+	}
+	if err := topic.Create(ctx, cl, tp); err != nil {
+		t.Fatalf("topic.Create(...): %v", err)
+	}
+	got, err := topic.Get(ctx, cl, tp.Name)
+	if err != nil {
+		t.Fatalf("topic.Get(...): %v", err)
+	}
+	if got.Partitions != tp.Partitions {
+		t.Errorf("topic.Get(...): want %d partitions, got %d", tp.Partitions, got.Partitions)
+	}
+}
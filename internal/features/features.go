@@ -0,0 +1,33 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package features defines the feature flags of the Kafka provider.
+package features
+
+import "github.com/crossplane/crossplane-runtime/pkg/feature"
+
+// Alpha feature flags.
+const (
+	// EnableAlphaTopicDeletionProtection refuses to delete topics that still
+	// hold records or are being consumed, unless the Topic explicitly allows
+	// data loss.
+	EnableAlphaTopicDeletionProtection feature.Flag = "EnableAlphaTopicDeletionProtection"
+
+	// EnableAlphaExternalSecretStores publishes the connection details of
+	// managed resources to the External Secret Stores their StoreConfig
+	// configures, in addition to Kubernetes Secrets.
+	EnableAlphaExternalSecretStores feature.Flag = "EnableAlphaExternalSecretStores"
+)
@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package identity selects the client certificate identity managed resources
+// connect to Kafka with, and makes resources using a read-only identity only
+// observe their external resources.
+package identity
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const (
+	// AnnotationKeyReadOnly makes a managed resource connect with the
+	// read-only client certificate identity of its ProviderConfig, and only
+	// observe its external resource, if set to true.
+	AnnotationKeyReadOnly = "kafka.crossplane.io/read-only-identity"
+
+	errNoReadOnlyIdentity = "ProviderConfig %q has no readOnlyClientCertificateIdentity for resources annotated " + AnnotationKeyReadOnly
+	errReadOnly           = "resources annotated " + AnnotationKeyReadOnly + " only observe their external resource, which cannot be %s"
+)
+
+// ReadOnly returns true if the supplied managed resource uses the read-only
+// identity of its ProviderConfig. Invalid annotations are ignored.
+func ReadOnly(mg resource.Managed) bool {
+	b, err := strconv.ParseBool(mg.GetAnnotations()[AnnotationKeyReadOnly])
+	return err == nil && b
+}
+
+// Credentials returns the supplied credentials of the supplied ProviderConfig
+// with the client certificate of the identity the supplied managed resource
+// connects with, or of the ProviderConfig's identity if the resource is nil.
+func Credentials(data []byte, pc *apisv1alpha1.ProviderConfig, mg resource.Managed) ([]byte, error) {
+	id := pc.Spec.ClientCertificateIdentity
+	if mg != nil && ReadOnly(mg) {
+		if pc.Spec.ReadOnlyClientCertificateIdentity == "" {
+			return nil, errors.Errorf(errNoReadOnlyIdentity, pc.GetName())
+		}
+		id = pc.Spec.ReadOnlyClientCertificateIdentity
+	}
+	return kafka.SelectClientCertificate(data, id)
+}
+
+// NewConnecter returns an ExternalConnecter whose clients only observe the
+// external resources of managed resources using a read-only identity. Their
+// external resources are neither created nor updated, and are orphaned when
+// they are deleted.
+func NewConnecter(c managed.ExternalConnecter) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil || !ReadOnly(mg) {
+		return ec, err
+	}
+	return &readOnly{ExternalClient: ec}, nil
+}
+
+type readOnly struct {
+	managed.ExternalClient
+}
+
+func (e *readOnly) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	// Reporting the external resource gone lets the managed resource be
+	// deleted without deleting it.
+	if meta.WasDeleted(mg) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	return e.ExternalClient.Observe(ctx, mg)
+}
+
+func (e *readOnly) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, errors.Errorf(errReadOnly, "created")
+}
+
+func (e *readOnly) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, errors.Errorf(errReadOnly, "updated")
+}
+
+func (e *readOnly) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+func TestCredentials(t *testing.T) {
+	creds := []byte(`{"brokers":["kafka:9093"],"tls":{"clientCertificateSecretRef":{"name":"certs","namespace":"crossplane-system"}}}`)
+	pc := &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{ClientCertificateIdentity: "admin", ReadOnlyClientCertificateIdentity: "readonly"}}
+	pc.SetName("prod")
+	readOnly := &v1alpha1.Topic{}
+	readOnly.SetAnnotations(map[string]string{AnnotationKeyReadOnly: "true"})
+
+	type want struct {
+		creds string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		pc     *apisv1alpha1.ProviderConfig
+		mg     resource.Managed
+		want   want
+	}{
+		"ProviderConfigIdentity": {
+			reason: "Managed resources should connect with the identity of their ProviderConfig.",
+			pc:     pc,
+			mg:     &v1alpha1.Topic{},
+			want:   want{creds: `{"brokers":["kafka:9093"],"tls":{"clientCertificateSecretRef":{"certField":"admin.crt","keyField":"admin.key","name":"certs","namespace":"crossplane-system"}}}`},
+		},
+		"ReadOnlyIdentity": {
+			reason: "Managed resources annotated to be read-only should connect with the read-only identity.",
+			pc:     pc,
+			mg:     readOnly,
+			want:   want{creds: `{"brokers":["kafka:9093"],"tls":{"clientCertificateSecretRef":{"certField":"readonly.crt","keyField":"readonly.key","name":"certs","namespace":"crossplane-system"}}}`},
+		},
+		"NoReadOnlyIdentity": {
+			reason: "Managed resources annotated to be read-only should not connect if their ProviderConfig has no read-only identity.",
+			pc: func() *apisv1alpha1.ProviderConfig {
+				pc := pc.DeepCopy()
+				pc.Spec.ReadOnlyClientCertificateIdentity = ""
+				return pc
+			}(),
+			mg:   readOnly,
+			want: want{err: errors.Errorf(errNoReadOnlyIdentity, "prod")},
+		},
+		"NoIdentity": {
+			reason: "The credentials should be used as they are if no identity is selected.",
+			pc:     &apisv1alpha1.ProviderConfig{},
+			want:   want{creds: string(creds)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Credentials(creds, tc.pc, tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCredentials(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.creds, string(got)); diff != "" {
+				t.Errorf("\n%s\nCredentials(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	now := metav1.Now()
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		call   func(e managed.ExternalClient, mg resource.Managed) (managed.ExternalObservation, error)
+		mg     func() resource.Managed
+		want   want
+	}{
+		"Observe": {
+			reason: "External resources of read-only resources should be observed.",
+			call: func(e managed.ExternalClient, mg resource.Managed) (managed.ExternalObservation, error) {
+				return e.Observe(context.Background(), mg)
+			},
+			want: want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"ObserveDeleted": {
+			reason: "External resources of deleted read-only resources should be orphaned.",
+			call: func(e managed.ExternalClient, mg resource.Managed) (managed.ExternalObservation, error) {
+				return e.Observe(context.Background(), mg)
+			},
+			mg: func() resource.Managed {
+				cr := &v1alpha1.Topic{}
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			},
+		},
+		"Update": {
+			reason: "External resources of read-only resources should not be updated.",
+			call: func(e managed.ExternalClient, mg resource.Managed) (managed.ExternalObservation, error) {
+				_, err := e.Update(context.Background(), mg)
+				return managed.ExternalObservation{}, err
+			},
+			want: want{err: errors.Errorf(errReadOnly, "updated")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := resource.Managed(&v1alpha1.Topic{})
+			if tc.mg != nil {
+				mg = tc.mg()
+			}
+			mg.SetAnnotations(map[string]string{AnnotationKeyReadOnly: "true"})
+
+			c := NewConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ResourceExists: true}, nil
+					},
+					UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+						return managed.ExternalUpdate{}, nil
+					},
+				}, nil
+			}))
+			e, err := c.Connect(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Connect(...): %v", err)
+			}
+			got, err := tc.call(e, mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\n-want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\n-want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/version"
+)
+
+var buildInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "build_info",
+	Help:      "Always 1, labelled with the version and git commit the provider was built from, the Go version it was built with, and the API versions it serves.",
+}, []string{"version", "git_commit", "go_version", "api_versions"})
+
+// RecordBuildInfo records the build of the provider.
+func RecordBuildInfo(i version.Info) {
+	buildInfo.WithLabelValues(i.Version, i.GitCommit, i.GoVersion, strings.Join(i.APIVersions, ",")).Set(1)
+}
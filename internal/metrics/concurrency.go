@@ -0,0 +1,31 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var waitingOperations = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "waiting_admin_operations",
+	Help:      "Number of calls creating, updating or deleting external resources that wait for the concurrency limit of their ProviderConfig.",
+}, []string{"providerconfig"})
+
+// RecordWaitingOperation adds the supplied delta to the number of operations
+// waiting for the concurrency limit of the supplied ProviderConfig.
+func RecordWaitingOperation(providerConfig string, delta float64) {
+	waitingOperations.WithLabelValues(providerConfig).Add(delta)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var driftDetected = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "drift_detected_total",
+	Help:      "Number of times a managed resource whose spec did not change since it was last up to date was found out of date, e.g. as another tool changed its external resource, per kind and ProviderConfig.",
+}, []string{"kind", "providerconfig"})
+
+var updates = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "updates_total",
+	Help:      "Number of updates of external resources, per kind, ProviderConfig and whether the update changed anything.",
+}, []string{"kind", "providerconfig", "effective"})
+
+var statusUpdatesSkipped = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "status_updates_skipped_total",
+	Help:      "Number of status updates of managed resources that were skipped as the status did not change since it was read, per kind.",
+}, []string{"kind"})
+
+// RecordDrift records that the supplied managed resource of the supplied kind
+// drifted from its unchanged spec.
+func RecordDrift(kind string, mg resource.Managed) {
+	driftDetected.WithLabelValues(kind, providerConfigName(mg)).Inc()
+}
+
+// RecordUpdate records that the external resource of the supplied managed
+// resource of the supplied kind was updated, and whether that changed it.
+func RecordUpdate(kind string, mg resource.Managed, effective bool) {
+	updates.WithLabelValues(kind, providerConfigName(mg), strconv.FormatBool(effective)).Inc()
+}
+
+// RecordStatusUpdateSkipped records that the status of a managed resource of
+// the supplied kind was not written, as it did not change.
+func RecordStatusUpdateSkipped(kind string) {
+	statusUpdatesSkipped.WithLabelValues(kind).Inc()
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Results of reconciles and external calls.
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+	resultRetry   = "retry"
+)
+
+// durationBuckets range from 10ms to about 40s.
+var durationBuckets = prometheus.ExponentialBuckets(0.01, 2, 13)
+
+var reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "reconcile_duration_seconds",
+	Help:      "How long reconciling a managed resource took end to end, per kind and result. Failed reconciles that are retried with backoff have result retry.",
+	Buckets:   durationBuckets,
+}, []string{"kind", "result"})
+
+var externalCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "external_call_duration_seconds",
+	Help:      "How long observing, creating, updating or deleting an external resource took, per kind, operation and result.",
+	Buckets:   durationBuckets,
+}, []string{"kind", "operation", "result"})
+
+// NewReconciler returns a reconciler recording how long each reconcile of the
+// supplied reconciler, which reconciles the supplied kind, takes.
+func NewReconciler(kind string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		start := time.Now()
+		res, err := r.Reconcile(ctx, req)
+		result := resultSuccess
+		switch {
+		case err != nil:
+			result = resultError
+		case res.Requeue:
+			// The managed reconciler requeues with backoff, rather than
+			// returning an error, when a reconcile failed.
+			result = resultRetry
+		}
+		reconcileDuration.WithLabelValues(kind, result).Observe(time.Since(start).Seconds())
+		return res, err
+	})
+}
+
+// NewConnecter returns an ExternalConnecter whose clients record how long
+// each call to the external resources of the supplied kind takes.
+func NewConnecter(c managed.ExternalConnecter, kind string) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, kind: kind}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	kind string
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: ec, kind: c.kind}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	kind string
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	start := time.Now()
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	e.record("Observe", start, err)
+	return o, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	start := time.Now()
+	c, err := e.ExternalClient.Create(ctx, mg)
+	e.record("Create", start, err)
+	return c, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	start := time.Now()
+	u, err := e.ExternalClient.Update(ctx, mg)
+	e.record("Update", start, err)
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	start := time.Now()
+	err := e.ExternalClient.Delete(ctx, mg)
+	e.record("Delete", start, err)
+	return err
+}
+
+func (e *external) record(op string, start time.Time, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+	}
+	externalCallDuration.WithLabelValues(e.kind, op, result).Observe(time.Since(start).Seconds())
+}
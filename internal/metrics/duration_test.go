@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+func sampleCount(t *testing.T, h *prometheus.HistogramVec, labels ...string) uint64 {
+	t.Helper()
+	m := &dto.Metric{}
+	if err := h.WithLabelValues(labels...).(prometheus.Metric).Write(m); err != nil {
+		t.Fatalf("Write(...): %v", err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestNewConnecter(t *testing.T) {
+	externalCallDuration.Reset()
+
+	ec := &managed.ExternalClientFns{
+		ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+			return managed.ExternalObservation{}, nil
+		},
+		CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+			return managed.ExternalCreation{}, errors.New("boom")
+		},
+	}
+	c := NewConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		return ec, nil
+	}), v1alpha1.TopicKind)
+
+	mg := &v1alpha1.Topic{}
+	cl, err := c.Connect(context.Background(), mg)
+	if err != nil {
+		t.Fatalf("Connect(...): %v", err)
+	}
+	_, _ = cl.Observe(context.Background(), mg)
+	_, _ = cl.Create(context.Background(), mg)
+
+	if n := sampleCount(t, externalCallDuration, v1alpha1.TopicKind, "Observe", resultSuccess); n != 1 {
+		t.Errorf("Observe: want 1 successful sample, got %d", n)
+	}
+	if n := sampleCount(t, externalCallDuration, v1alpha1.TopicKind, "Create", resultError); n != 1 {
+		t.Errorf("Create: want 1 failed sample, got %d", n)
+	}
+}
+
+func TestNewReconciler(t *testing.T) {
+	reconcileDuration.Reset()
+
+	cases := map[string]struct {
+		result reconcile.Result
+		err    error
+		want   string
+	}{
+		"Success": {result: reconcile.Result{RequeueAfter: 1}, want: resultSuccess},
+		"Retry":   {result: reconcile.Result{Requeue: true}, want: resultRetry},
+		"Error":   {err: errors.New("boom"), want: resultError},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewReconciler(v1alpha1.TopicKind, reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				return tc.result, tc.err
+			}))
+			_, _ = r.Reconcile(context.Background(), reconcile.Request{})
+			if n := sampleCount(t, reconcileDuration, v1alpha1.TopicKind, tc.want); n != 1 {
+				t.Errorf("Reconcile(...): want 1 sample with result %s, got %d", tc.want, n)
+			}
+		})
+	}
+}
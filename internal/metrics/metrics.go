@@ -0,0 +1,178 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains the Prometheus metrics exported by the Kafka
+// provider, in addition to those of controller-runtime.
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "provider_kafka"
+
+	// collectTimeout bounds how long listing managed resources may take
+	// while metrics are being scraped.
+	collectTimeout = 10 * time.Second
+)
+
+// A series is identified by the kind of managed resource and the name of
+// their ProviderConfig.
+type series struct {
+	kind           string
+	providerConfig string
+}
+
+// lastSuccessfulSync holds the time at which a managed resource was last
+// successfully observed, per series. It is reported by the FleetCollector,
+// which forgets the series whose ProviderConfig has no managed resources of
+// the kind any more, e.g. because it was deleted.
+var lastSuccessfulSync = struct {
+	sync.Mutex
+	at map[series]time.Time
+}{at: map[series]time.Time{}}
+
+// RecordSuccessfulSync records that the supplied managed resource of the
+// supplied kind was just successfully observed.
+func RecordSuccessfulSync(kind string, mg resource.Managed) {
+	recordSync(series{kind: kind, providerConfig: providerConfigName(mg)}, time.Now())
+}
+
+func recordSync(s series, t time.Time) {
+	lastSuccessfulSync.Lock()
+	defer lastSuccessfulSync.Unlock()
+	lastSuccessfulSync.at[s] = t
+}
+
+// A ManagedKind is a kind of managed resource whose fleet is reported.
+type ManagedKind struct {
+	// Kind of the managed resource, e.g. Topic.
+	Kind string
+	// NewList returns an empty list of the managed resource kind.
+	NewList func() resource.ManagedList
+}
+
+// A FleetCollector reports the number of managed resources, how many of them
+// are ready, and when one was last successfully observed, per kind and
+// ProviderConfig. Resources are listed from the supplied reader on every
+// scrape, which is expected to be cache backed.
+type FleetCollector struct {
+	kube  client.Reader
+	kinds []ManagedKind
+
+	managed  *prometheus.Desc
+	ready    *prometheus.Desc
+	lastSync *prometheus.Desc
+}
+
+// NewFleetCollector returns a FleetCollector reporting the supplied kinds.
+func NewFleetCollector(kube client.Reader, kinds ...ManagedKind) *FleetCollector {
+	return &FleetCollector{
+		kube:  kube,
+		kinds: kinds,
+		managed: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "managed_resources"),
+			"Number of managed resources, per kind and ProviderConfig.",
+			[]string{"kind", "providerconfig"}, nil),
+		ready: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "managed_resources_ready"),
+			"Number of managed resources that are ready, per kind and ProviderConfig.",
+			[]string{"kind", "providerconfig"}, nil),
+		lastSync: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "last_successful_sync_timestamp_seconds"),
+			"Unix time at which a managed resource of a kind was last successfully observed, per ProviderConfig.",
+			[]string{"kind", "providerconfig"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *FleetCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.managed
+	ch <- c.ready
+	ch <- c.lastSync
+}
+
+// Collect implements prometheus.Collector.
+func (c *FleetCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	for _, k := range c.kinds {
+		l := k.NewList()
+		if err := c.kube.List(ctx, l); err != nil {
+			ch <- prometheus.NewInvalidMetric(c.managed, err)
+			continue
+		}
+
+		total := map[string]int{}
+		ready := map[string]int{}
+		for _, mg := range l.GetItems() {
+			pc := providerConfigName(mg)
+			total[pc]++
+			if mg.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue {
+				ready[pc]++
+			}
+		}
+		for pc, n := range total {
+			ch <- prometheus.MustNewConstMetric(c.managed, prometheus.GaugeValue, float64(n), k.Kind, pc)
+			ch <- prometheus.MustNewConstMetric(c.ready, prometheus.GaugeValue, float64(ready[pc]), k.Kind, pc)
+		}
+		c.collectLastSync(ch, k.Kind, total)
+	}
+}
+
+// collectLastSync reports when a managed resource of the supplied kind was
+// last successfully observed, for each ProviderConfig with managed resources
+// of the kind, and forgets the other ProviderConfigs.
+func (c *FleetCollector) collectLastSync(ch chan<- prometheus.Metric, kind string, total map[string]int) {
+	lastSuccessfulSync.Lock()
+	defer lastSuccessfulSync.Unlock()
+	for s, t := range lastSuccessfulSync.at {
+		if s.kind != kind {
+			continue
+		}
+		if total[s.providerConfig] == 0 {
+			delete(lastSuccessfulSync.at, s)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.lastSync, prometheus.GaugeValue, float64(t.UnixNano())/1e9, kind, s.providerConfig)
+	}
+}
+
+// Register registers the provider's metrics, reporting the fleet of the
+// supplied kinds, with the controller-runtime metrics registry.
+func Register(kube client.Reader, kinds ...ManagedKind) error {
+	for _, c := range []prometheus.Collector{reconcileDuration, externalCallDuration, brokerThrottle, inflightExternalCalls, draining, waitingOperations, buildInfo, driftDetected, updates, statusUpdatesSkipped, NewFleetCollector(kube, kinds...)} {
+		if err := metrics.Registry.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func providerConfigName(mg resource.Managed) string {
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		return ref.Name
+	}
+	return ""
+}
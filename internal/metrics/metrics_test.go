@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+func topic(pc string, c xpv1.Condition) v1alpha1.Topic {
+	t := v1alpha1.Topic{}
+	t.SetProviderConfigReference(&xpv1.Reference{Name: pc})
+	t.SetConditions(c)
+	return t
+}
+
+func TestFleetCollector(t *testing.T) {
+	kube := &test.MockClient{
+		MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			obj.(*v1alpha1.TopicList).Items = []v1alpha1.Topic{
+				topic("prod", xpv1.Available()),
+				topic("prod", xpv1.Creating()),
+				topic("dev", xpv1.Available()),
+			}
+			return nil
+		}),
+	}
+	c := NewFleetCollector(kube, ManagedKind{Kind: v1alpha1.TopicKind, NewList: func() resource.ManagedList { return &v1alpha1.TopicList{} }})
+
+	// The deleted ProviderConfig has no managed resources any more.
+	recordSync(series{kind: v1alpha1.TopicKind, providerConfig: "prod"}, time.Unix(1700000000, 0))
+	recordSync(series{kind: v1alpha1.TopicKind, providerConfig: "deleted"}, time.Unix(1600000000, 0))
+
+	want := `
+# HELP provider_kafka_managed_resources Number of managed resources, per kind and ProviderConfig.
+# TYPE provider_kafka_managed_resources gauge
+provider_kafka_managed_resources{kind="Topic",providerconfig="dev"} 1
+provider_kafka_managed_resources{kind="Topic",providerconfig="prod"} 2
+# HELP provider_kafka_managed_resources_ready Number of managed resources that are ready, per kind and ProviderConfig.
+# TYPE provider_kafka_managed_resources_ready gauge
+provider_kafka_managed_resources_ready{kind="Topic",providerconfig="dev"} 1
+provider_kafka_managed_resources_ready{kind="Topic",providerconfig="prod"} 1
+# HELP provider_kafka_last_successful_sync_timestamp_seconds Unix time at which a managed resource of a kind was last successfully observed, per ProviderConfig.
+# TYPE provider_kafka_last_successful_sync_timestamp_seconds gauge
+provider_kafka_last_successful_sync_timestamp_seconds{kind="Topic",providerconfig="prod"} 1.7e+09
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want)); err != nil {
+		t.Errorf("CollectAndCompare(...): %s", err)
+	}
+	if _, ok := lastSuccessfulSync.at[series{kind: v1alpha1.TopicKind, providerConfig: "deleted"}]; ok {
+		t.Errorf("Collect(...): did not forget the last successful sync of a deleted ProviderConfig")
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var inflightExternalCalls = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "inflight_external_calls",
+	Help:      "Number of calls to external systems, such as observing or updating a topic, that are in flight.",
+})
+
+var draining = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "draining",
+	Help:      "Whether the provider is shutting down, refusing new calls to external systems while draining those in flight.",
+})
+
+// RecordInflightCalls records the number of calls to external systems that
+// are in flight.
+func RecordInflightCalls(n int) {
+	inflightExternalCalls.Set(float64(n))
+}
+
+// RecordDraining records whether the provider is draining the calls to
+// external systems in flight as it shuts down.
+func RecordDraining(d bool) {
+	v := 0.0
+	if d {
+		v = 1
+	}
+	draining.Set(v)
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var brokerThrottle = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "broker_throttle_seconds",
+	Help:      "How long brokers throttled the provider's Kafka clients, e.g. because they exceeded their request quota, per broker.",
+	Buckets:   durationBuckets,
+}, []string{"broker"})
+
+// RecordThrottle records that the supplied broker throttled a Kafka client for
+// the supplied duration. It is a kafka.ThrottleObserver.
+func RecordThrottle(broker int32, d time.Duration) {
+	brokerThrottle.WithLabelValues(strconv.Itoa(int(broker))).Observe(d.Seconds())
+}
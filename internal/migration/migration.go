@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migration migrates custom resources to the storage version of their
+// CRD, so that versions they were once stored as can stop being served.
+package migration
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// pageSize is the number of resources listed at once.
+	pageSize = 500
+
+	errGetCRD           = "cannot get CRD %s"
+	errNoStorageVersion = "CRD %s has no storage version"
+	errList             = "cannot list %s"
+	errMigrate          = "cannot migrate %s %s"
+	errUpdateCRD        = "cannot record %s as the only stored version of CRD %s"
+)
+
+// A Result of migrating a CRD.
+type Result struct {
+	// StorageVersion the resources were migrated to.
+	StorageVersion string
+	// StoredVersions the CRD recorded before the migration.
+	StoredVersions []string
+	// Migrated is the number of resources that were rewritten.
+	Migrated int
+}
+
+// Migrate rewrites every resource of the CRD of the supplied name unchanged,
+// which makes the API server store it as the storage version of the CRD. It
+// then records the storage version as the only version the resources are
+// stored as, after which the other versions can stop being served. Nothing is
+// written if dry run is set, but the resources are still counted.
+func Migrate(ctx context.Context, kube client.Client, crdName string, dryRun bool) (*Result, error) {
+	crd := &extv1.CustomResourceDefinition{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: crdName}, crd); err != nil {
+		return nil, errors.Wrapf(err, errGetCRD, crdName)
+	}
+	r := &Result{StoredVersions: crd.Status.StoredVersions}
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			r.StorageVersion = v.Name
+		}
+	}
+	if r.StorageVersion == "" {
+		return nil, errors.Errorf(errNoStorageVersion, crdName)
+	}
+
+	gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: r.StorageVersion, Kind: crd.Spec.Names.ListKind}
+	l := &unstructured.UnstructuredList{}
+	l.SetGroupVersionKind(gvk)
+	for {
+		if err := kube.List(ctx, l, client.Limit(pageSize), client.Continue(l.GetContinue())); err != nil {
+			return nil, errors.Wrapf(err, errList, crd.Spec.Names.Plural)
+		}
+		for i := range l.Items {
+			if err := rewrite(ctx, kube, &l.Items[i], dryRun); err != nil {
+				return nil, errors.Wrapf(err, errMigrate, crd.Spec.Names.Kind, l.Items[i].GetName())
+			}
+			r.Migrated++
+		}
+		if l.GetContinue() == "" {
+			break
+		}
+	}
+
+	if dryRun {
+		return r, nil
+	}
+	crd.Status.StoredVersions = []string{r.StorageVersion}
+	return r, errors.Wrapf(kube.Status().Update(ctx, crd), errUpdateCRD, r.StorageVersion, crdName)
+}
+
+// rewrite writes the supplied resource back unchanged, getting it again if it
+// changed since it was listed. A resource that was deleted needs no rewrite.
+func rewrite(ctx context.Context, kube client.Client, u *unstructured.Unstructured, dryRun bool) error {
+	if dryRun {
+		return nil
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := kube.Update(ctx, u)
+		if kerrors.IsConflict(err) {
+			if gerr := kube.Get(ctx, client.ObjectKeyFromObject(u), u); gerr != nil {
+				return gerr
+			}
+		}
+		return err
+	})
+	return client.IgnoreNotFound(err)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crossplane-contrib/provider-kafka/apis"
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+func TestMigrate(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := extv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	crd := &extv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "topics." + v1alpha1.Group},
+		Spec: extv1.CustomResourceDefinitionSpec{
+			Group: v1alpha1.Group,
+			Names: extv1.CustomResourceDefinitionNames{Kind: "Topic", ListKind: "TopicList", Plural: "topics"},
+			Versions: []extv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true, Storage: true},
+				{Name: "v1beta1", Served: true},
+			},
+		},
+		Status: extv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1beta1", "v1alpha1"}},
+	}
+	topic := func(name string) client.Object {
+		t := &v1alpha1.Topic{}
+		t.SetName(name)
+		return t
+	}
+
+	cases := map[string]struct {
+		reason     string
+		dryRun     bool
+		want       *Result
+		wantStored []string
+	}{
+		"Migrate": {
+			reason:     "Every Topic should be rewritten, and the storage version recorded as the only stored version.",
+			want:       &Result{StorageVersion: "v1alpha1", StoredVersions: []string{"v1beta1", "v1alpha1"}, Migrated: 3},
+			wantStored: []string{"v1alpha1"},
+		},
+		"DryRun": {
+			reason:     "A dry run should count the Topics but leave the CRD alone.",
+			dryRun:     true,
+			want:       &Result{StorageVersion: "v1alpha1", StoredVersions: []string{"v1beta1", "v1alpha1"}, Migrated: 3},
+			wantStored: []string{"v1beta1", "v1alpha1"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := fake.NewClientBuilder().
+				WithScheme(s).
+				WithObjects(crd.DeepCopy(), topic("orders"), topic("payments"), topic("invoices")).
+				WithStatusSubresource(&extv1.CustomResourceDefinition{}).
+				Build()
+
+			got, err := Migrate(context.Background(), kube, crd.GetName(), tc.dryRun)
+			if err != nil {
+				t.Fatalf("\n%s\nMigrate(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nMigrate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			updated := &extv1.CustomResourceDefinition{}
+			if err := kube.Get(context.Background(), types.NamespacedName{Name: crd.GetName()}, updated); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantStored, updated.Status.StoredVersions); diff != "" {
+				t.Errorf("\n%s\nMigrate(...): -want stored versions, +got stored versions:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
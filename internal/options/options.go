@@ -0,0 +1,213 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package options contains the options shared by the controllers of the
+// Kafka provider.
+package options
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/internal/audit"
+	"github.com/crossplane-contrib/provider-kafka/internal/cancellation"
+	"github.com/crossplane-contrib/provider-kafka/internal/concurrency"
+	"github.com/crossplane-contrib/provider-kafka/internal/deletion"
+	"github.com/crossplane-contrib/provider-kafka/internal/features"
+	"github.com/crossplane-contrib/provider-kafka/internal/providerconfig"
+	"github.com/crossplane-contrib/provider-kafka/internal/secrets"
+	"github.com/crossplane-contrib/provider-kafka/internal/shard"
+	"github.com/crossplane-contrib/provider-kafka/internal/shutdown"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+// Options configures the controllers of the Kafka provider.
+type Options struct {
+	controller.Options
+
+	// Timeouts bound how long individual Kafka admin operations may take.
+	Timeouts kafka.Timeouts
+
+	// ClientCache shares the Kafka admin clients of each ProviderConfig, and
+	// with them their connections, circuit breakers and throttling, between
+	// the controllers of all kinds.
+	ClientCache *kafka.ClientCache
+
+	// ConfigVerifyGracePeriod is how long a topic config that was verified
+	// to be up to date is trusted without describing it again, as long as
+	// the topic's spec does not change. Zero describes it on every observe.
+	ConfigVerifyGracePeriod time.Duration
+
+	// PollJitter spreads the polls of managed resources by up to this much
+	// around the poll interval, so that resources created at once, e.g. by a
+	// bulk import, do not poll the brokers in lockstep. Zero disables it.
+	PollJitter time.Duration
+
+	// Audit records every Create, Update and Delete issued by the
+	// controllers. Nothing is recorded if it is nil.
+	Audit audit.Sink
+
+	// DisableUsageTracking stops managed resources from being tracked by a
+	// ProviderConfigUsage each, which at tens of thousands of resources
+	// bloats etcd. ProviderConfigs can then be deleted while still in use.
+	DisableUsageTracking bool
+
+	// Deletion caps how long the deletion of a managed resource can be
+	// blocked, e.g. by brokers that are unreachable for good.
+	Deletion deletion.Policy
+
+	// TopicDeletionRate throttles the deletion of topics to at most this
+	// many per second, deleting up to TopicDeletionBatchSize topics with a
+	// single request. Zero deletes each topic as soon as its Topic is.
+	TopicDeletionRate      float64
+	TopicDeletionBatchSize int
+
+	// TopicSizeInStatus records the number of records and size on disk of
+	// each topic in the status of its Topic, at the cost of listing offsets
+	// and describing log dirs on every observe.
+	TopicSizeInStatus bool
+
+	// ReplicationFactorApprovalBytes is how much data increasing the
+	// replication factor of a topic may copy to new replicas before the
+	// Topic must be annotated to approve it.
+	ReplicationFactorApprovalBytes int64
+
+	// NamespaceProviderConfig sets the ProviderConfig of managed resources
+	// claimed from a namespace to the one the namespace is annotated with.
+	NamespaceProviderConfig bool
+
+	// Shutdown drains the calls of the controllers to external systems, and
+	// closes their clients, when the provider shuts down. Calls are not
+	// drained if it is nil.
+	Shutdown *shutdown.Drainer
+
+	// Secrets caches the Secrets credentials are read from, each with an
+	// informer watching only that Secret. Credentials are read through the
+	// client of the manager if it is nil.
+	Secrets *secrets.Cache
+
+	// Cancellation cancels the calls in flight for managed resources once
+	// they are deleted. Calls are not cancelled by deletion if it is nil.
+	Cancellation *cancellation.Registry
+
+	// Concurrency limits how many external resources may be created,
+	// updated or deleted at once per ProviderConfig, across all kinds.
+	// Operations are not limited if it is nil.
+	Concurrency *concurrency.Limiter
+
+	// Shard restricts the controllers to the ProviderConfigs of a shard, and
+	// the managed resources using them. Every resource is reconciled if it
+	// is nil.
+	Shard *shard.Filter
+}
+
+// ExternalConnecter returns the supplied ExternalConnecter, with its calls
+// cancelled once their managed resource is deleted if Cancellation is set, its
+// clients drained at shutdown if Shutdown is set, and limited by Concurrency if
+// it is set. Operations still waiting for the limit when the provider shuts
+// down are refused rather than drained.
+func (o Options) ExternalConnecter(c managed.ExternalConnecter) managed.ExternalConnecter {
+	// Calls are detached from the reconcile at shutdown, so deletion must
+	// cancel them after they were.
+	if o.Cancellation != nil {
+		c = cancellation.NewConnecter(c, o.Cancellation)
+	}
+	if o.Shutdown != nil {
+		c = shutdown.NewConnecter(c, o.Shutdown)
+	}
+	if o.Concurrency != nil {
+		c = concurrency.NewConnecter(c, o.Concurrency)
+	}
+	return c
+}
+
+// Sharded returns the supplied reconciler of managed resources of the kind of
+// the supplied one, only reconciling those of the Shard if it is set.
+func (o Options) Sharded(of resource.Managed, r reconcile.Reconciler) reconcile.Reconciler {
+	if o.Shard == nil {
+		return r
+	}
+	return shard.NewManagedReconciler(r, o.Shard, of)
+}
+
+// CredentialsClient returns the supplied client, reading Secrets from the
+// Secrets cache if it is set.
+func (o Options) CredentialsClient(c client.Client) client.Client {
+	if o.Secrets == nil {
+		return c
+	}
+	return secrets.NewClient(c, o.Secrets)
+}
+
+// UsageTracker returns a tracker recording which ProviderConfig each managed
+// resource uses, or one that records nothing if usage tracking is disabled.
+func (o Options) UsageTracker(c client.Client) resource.Tracker {
+	if o.DisableUsageTracking {
+		return resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil })
+	}
+	return resource.NewProviderConfigUsageTracker(c, &apisv1alpha1.ProviderConfigUsage{})
+}
+
+// ConnectionPublishers returns the publishers of the connection details of
+// managed resources: to the Secret they reference, and through their
+// StoreConfig if External Secret Stores are enabled.
+func (o Options) ConnectionPublishers(c client.Client) []managed.ConnectionPublisher {
+	ps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(c, c.Scheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		ps = append(ps, connection.NewDetailsManager(c, apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+	return ps
+}
+
+// Initializers returns the supplied initializers of managed resources,
+// preceded by one resolving the ProviderConfig of resources claimed from a
+// namespace bound to one, if enabled.
+func (o Options) Initializers(c client.Client, is ...managed.Initializer) []managed.Initializer {
+	if !o.NamespaceProviderConfig {
+		return is
+	}
+	return append([]managed.Initializer{providerconfig.NewNamespaceDefaulter(c)}, is...)
+}
+
+// PollIntervalHook returns a hook that jitters the poll interval of managed
+// resources by up to PollJitter. Half of the jitter is a stable offset derived
+// from the resource's UID, which smears resources created at the same time
+// over the jitter window. The other half is random on every poll.
+func (o Options) PollIntervalHook() managed.PollIntervalHook {
+	return func(mg resource.Managed, interval time.Duration) time.Duration {
+		if o.PollJitter <= 0 {
+			return interval
+		}
+		half := float64(o.PollJitter) / 2
+
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(mg.GetUID()))
+		stable := float64(h.Sum64())/float64(^uint64(0))*2 - 1
+		random := rand.Float64()*2 - 1 //nolint:gosec // No need for secure randomness.
+
+		return interval + time.Duration((stable+random)*half)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+func TestPollIntervalHook(t *testing.T) {
+	interval := time.Minute
+
+	topic := func(uid string) *v1alpha1.Topic {
+		tp := &v1alpha1.Topic{}
+		tp.SetUID(types.UID(uid))
+		return tp
+	}
+
+	cases := map[string]struct {
+		reason string
+		jitter time.Duration
+	}{
+		"Disabled": {
+			reason: "Without jitter the poll interval should not change.",
+		},
+		"Jittered": {
+			reason: "With jitter the poll interval should stay within the jitter window.",
+			jitter: 10 * time.Second,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			hook := Options{PollJitter: tc.jitter}.PollIntervalHook()
+			seen := map[time.Duration]bool{}
+			for _, uid := range []string{"a", "b", "c", "d"} {
+				got := hook(topic(uid), interval)
+				if got < interval-tc.jitter || got > interval+tc.jitter {
+					t.Errorf("\n%s\nPollIntervalHook(): got %s, want within %s of %s", tc.reason, got, tc.jitter, interval)
+				}
+				seen[got] = true
+			}
+			if tc.jitter > 0 && len(seen) == 1 {
+				t.Errorf("\n%s\nPollIntervalHook(): every resource polls after %s", tc.reason, interval)
+			}
+		})
+	}
+}
+
+func TestUsageTracker(t *testing.T) {
+	errBoom := errors.New("boom")
+	kube := &test.MockClient{
+		MockGet:    test.NewMockGetFn(errBoom),
+		MockCreate: test.NewMockCreateFn(errBoom),
+	}
+
+	cases := map[string]struct {
+		disabled bool
+		wantErr  bool
+	}{
+		"Enabled":  {wantErr: true},
+		"Disabled": {disabled: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &v1alpha1.Topic{}
+			mg.SetProviderConfigReference(&xpv1.Reference{Name: "default"})
+			err := Options{DisableUsageTracking: tc.disabled}.UsageTracker(kube).Track(context.Background(), mg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Track(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package providerconfig resolves the ProviderConfig of managed resources
+// claimed from namespaces bound to a Kafka cluster.
+package providerconfig
+
+import (
+	"context"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationKeyProviderConfig binds a namespace to the ProviderConfig it
+	// names. Managed resources claimed from the namespace use it.
+	AnnotationKeyProviderConfig = "kafka.crossplane.io/provider-config"
+
+	// LabelKeyClaimNamespace is the label Crossplane records the namespace
+	// of the claim of a composed managed resource in.
+	LabelKeyClaimNamespace = "crossplane.io/claim-namespace"
+
+	// defaultName is the name a providerConfigRef defaults to when it is
+	// omitted.
+	defaultName = "default"
+
+	errGetNamespace = "cannot get namespace %q of claim"
+	errBound        = "namespace %q is bound to ProviderConfig %q, but the resource references ProviderConfig %q"
+	errUpdate       = "cannot update managed resource with ProviderConfig of namespace"
+)
+
+// A NamespaceDefaulter sets the ProviderConfig of managed resources claimed
+// from a namespace to the one the namespace is bound to.
+type NamespaceDefaulter struct {
+	kube client.Client
+}
+
+// NewNamespaceDefaulter returns an Initializer that sets the ProviderConfig
+// of managed resources claimed from a namespace bound to a ProviderConfig.
+func NewNamespaceDefaulter(kube client.Client) *NamespaceDefaulter {
+	return &NamespaceDefaulter{kube: kube}
+}
+
+// Initialize sets the ProviderConfig of the supplied managed resource if it
+// was claimed from a namespace bound to a ProviderConfig and references none,
+// or the default one. Resources referencing another ProviderConfig than the
+// one their namespace is bound to are rejected.
+func (d *NamespaceDefaulter) Initialize(ctx context.Context, mg resource.Managed) error {
+	namespace := mg.GetLabels()[LabelKeyClaimNamespace]
+	if namespace == "" {
+		return nil
+	}
+	ns := &corev1.Namespace{}
+	if err := d.kube.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return errors.Wrapf(client.IgnoreNotFound(err), errGetNamespace, namespace)
+	}
+	bound := ns.GetAnnotations()[AnnotationKeyProviderConfig]
+	if bound == "" {
+		return nil
+	}
+
+	ref := mg.GetProviderConfigReference()
+	switch {
+	case ref != nil && ref.Name == bound:
+		return nil
+	case ref != nil && ref.Name != "" && ref.Name != defaultName:
+		return errors.Errorf(errBound, namespace, bound, ref.Name)
+	}
+	mg.SetProviderConfigReference(&xpv1.Reference{Name: bound})
+	return errors.Wrap(d.kube.Update(ctx, mg), errUpdate)
+}
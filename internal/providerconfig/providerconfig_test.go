@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providerconfig
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNamespaceDefaulterInitialize(t *testing.T) {
+	namespace := func(bound string) test.MockGetFn {
+		return test.NewMockGetFn(nil, func(obj client.Object) error {
+			if bound != "" {
+				obj.SetAnnotations(map[string]string{AnnotationKeyProviderConfig: bound})
+			}
+			return nil
+		})
+	}
+	claimed := func(ref string) *fake.Managed {
+		mg := &fake.Managed{}
+		mg.SetLabels(map[string]string{LabelKeyClaimNamespace: "team-a"})
+		if ref != "" {
+			mg.SetProviderConfigReference(&xpv1.Reference{Name: ref})
+		}
+		return mg
+	}
+
+	type want struct {
+		ref     string
+		updated bool
+		err     bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		get    test.MockGetFn
+		mg     *fake.Managed
+		want   want
+	}{
+		"NotClaimed": {
+			reason: "Resources not claimed from a namespace should be left alone.",
+			mg:     &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}}},
+			want:   want{ref: "default"},
+		},
+		"UnboundNamespace": {
+			reason: "Resources claimed from a namespace bound to no ProviderConfig should be left alone.",
+			get:    namespace(""),
+			mg:     claimed("default"),
+			want:   want{ref: "default"},
+		},
+		"NamespaceNotFound": {
+			reason: "Resources claimed from a deleted namespace should be left alone.",
+			get:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "team-a")),
+			mg:     claimed("default"),
+			want:   want{ref: "default"},
+		},
+		"DefaultReference": {
+			reason: "Resources referencing the default ProviderConfig should use the one of their namespace.",
+			get:    namespace("cluster-a"),
+			mg:     claimed("default"),
+			want:   want{ref: "cluster-a", updated: true},
+		},
+		"NoReference": {
+			reason: "Resources referencing no ProviderConfig should use the one of their namespace.",
+			get:    namespace("cluster-a"),
+			mg:     claimed(""),
+			want:   want{ref: "cluster-a", updated: true},
+		},
+		"BoundReference": {
+			reason: "Resources already referencing the ProviderConfig of their namespace should not be updated.",
+			get:    namespace("cluster-a"),
+			mg:     claimed("cluster-a"),
+			want:   want{ref: "cluster-a"},
+		},
+		"OtherReference": {
+			reason: "Resources referencing another ProviderConfig than the one of their namespace should be rejected.",
+			get:    namespace("cluster-a"),
+			mg:     claimed("cluster-b"),
+			want:   want{ref: "cluster-b", err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updated := false
+			kube := &test.MockClient{
+				MockGet: tc.get,
+				MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+					updated = true
+					return nil
+				},
+			}
+			err := NewNamespaceDefaulter(kube).Initialize(context.Background(), tc.mg)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nInitialize(...): error = %v, wantErr %t", tc.reason, err, tc.want.err)
+			}
+			got := ""
+			if ref := tc.mg.GetProviderConfigReference(); ref != nil {
+				got = ref.Name
+			}
+			if diff := cmp.Diff(tc.want.ref, got); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want providerConfigRef, +got:\n%s", tc.reason, diff)
+			}
+			if updated != tc.want.updated {
+				t.Errorf("\n%s\nInitialize(...): updated = %t, want %t", tc.reason, updated, tc.want.updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secrets reads the Secrets holding credentials through informers
+// that each watch a single Secret.
+package secrets
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// defaultMaxIdle is how long the informer of a Secret keeps running
+	// after the Secret was last read.
+	defaultMaxIdle = 10 * time.Minute
+
+	errNewInformer = "cannot create informer for Secret %s"
+	errSync        = "cannot sync informer for Secret %s"
+)
+
+// A Cache reads Secrets through informers that each watch a single Secret,
+// selected by name, so that only the Secrets the provider reads credentials
+// from are cached rather than every Secret of the cluster. An informer is
+// started the first time its Secret is read. Credentials are read on every
+// reconcile, so an informer whose Secret was not read for a while, e.g.
+// because no ProviderConfig references it any more, is stopped. Secrets are
+// read from the API server until the Cache is started.
+type Cache struct {
+	newCache func(key types.NamespacedName) (cache.Cache, error)
+	api      client.Reader
+	maxIdle  time.Duration
+
+	mu     sync.Mutex
+	ctx    context.Context
+	caches map[types.NamespacedName]*entry
+}
+
+// An entry is the informer of a single Secret, which is synced once ready is
+// closed.
+type entry struct {
+	cache    cache.Cache
+	ready    chan struct{}
+	err      error
+	stop     context.CancelFunc
+	lastRead time.Time
+}
+
+// NewCache returns a Cache creating informers with the REST config, scheme,
+// mapper and HTTP client of the supplied manager, and reading from its API
+// reader until started.
+func NewCache(mgr manager.Manager) *Cache {
+	return &Cache{
+		newCache: func(key types.NamespacedName) (cache.Cache, error) {
+			return cache.New(mgr.GetConfig(), cache.Options{
+				HTTPClient: mgr.GetHTTPClient(),
+				Scheme:     mgr.GetScheme(),
+				Mapper:     mgr.GetRESTMapper(),
+				DefaultNamespaces: map[string]cache.Config{
+					key.Namespace: {FieldSelector: fields.OneTermEqualSelector("metadata.name", key.Name)},
+				},
+			})
+		},
+		api:     mgr.GetAPIReader(),
+		maxIdle: defaultMaxIdle,
+		caches:  map[types.NamespacedName]*entry{},
+	}
+}
+
+// Start the Cache. Informers are started with the supplied context, and stop
+// when it is done or once their Secret was not read for a while.
+func (c *Cache) Start(ctx context.Context) error {
+	c.mu.Lock()
+	c.ctx = ctx
+	c.mu.Unlock()
+
+	t := time.NewTicker(c.maxIdle)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-t.C:
+			c.evict(now)
+		}
+	}
+}
+
+// evict stops the informers whose Secret was not read for longer than
+// maxIdle. Their Secret is watched again the next time it is read.
+func (c *Cache) evict(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, e := range c.caches {
+		if now.Sub(e.lastRead) > c.maxIdle {
+			e.stop()
+			delete(c.caches, key)
+		}
+	}
+}
+
+// NeedLeaderElection returns false. The Cache starts no informer before
+// credentials are read, which only the controllers of the elected replica do,
+// so it need not wait for the election to be won.
+func (c *Cache) NeedLeaderElection() bool {
+	return false
+}
+
+// Get the Secret of the supplied key.
+func (c *Cache) Get(ctx context.Context, key types.NamespacedName, s *corev1.Secret) error {
+	e, started := c.informer(key)
+	if !started {
+		return c.api.Get(ctx, key, s)
+	}
+	select {
+	case <-e.ready:
+	case <-ctx.Done():
+		return errors.Wrapf(ctx.Err(), errSync, key)
+	}
+	if e.err != nil {
+		return e.err
+	}
+	return e.cache.Get(ctx, key, s)
+}
+
+// informer returns the informer of the Secret of the supplied key, starting
+// it if it was not yet, and whether the Cache was started.
+func (c *Cache) informer(key types.NamespacedName) (*entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ctx == nil {
+		return nil, false
+	}
+	if e, ok := c.caches[key]; ok {
+		e.lastRead = time.Now()
+		return e, true
+	}
+
+	e := &entry{ready: make(chan struct{}), lastRead: time.Now()}
+	ca, err := c.newCache(key)
+	if err == nil {
+		// Informers are created lazily, so the one of the Secret is
+		// created before the cache is started to sync with it.
+		_, err = ca.GetInformer(c.ctx, &corev1.Secret{})
+	}
+	if err != nil {
+		// The informer is created again the next time its Secret is read.
+		e.err = errors.Wrapf(err, errNewInformer, key)
+		close(e.ready)
+		return e, true
+	}
+	ctx, stop := context.WithCancel(c.ctx)
+	c.caches[key] = e
+	e.cache = ca
+	e.stop = stop
+	go func() {
+		_ = ca.Start(ctx)
+	}()
+	go func() {
+		if !ca.WaitForCacheSync(ctx) {
+			e.err = errors.Errorf(errSync, key)
+		}
+		close(e.ready)
+	}()
+	return e, true
+}
+
+// NewClient returns the supplied client, reading Secrets from the supplied
+// Cache instead.
+func NewClient(c client.Client, secrets *Cache) client.Client {
+	return &secretsClient{Client: c, secrets: secrets}
+}
+
+type secretsClient struct {
+	client.Client
+	secrets *Cache
+}
+
+// Get the object of the supplied key, from the Cache if it is a Secret.
+func (c *secretsClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if s, ok := obj.(*corev1.Secret); ok {
+		return c.secrets.Get(ctx, key, s)
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
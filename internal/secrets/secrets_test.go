@@ -0,0 +1,234 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// secretCache is a fake cache holding a single Secret.
+type secretCache struct {
+	informertest.FakeInformers
+	data string
+	// stopped is closed once the cache stopped, if it is set.
+	stopped chan struct{}
+}
+
+func (c *secretCache) Start(ctx context.Context) error {
+	<-ctx.Done()
+	if c.stopped != nil {
+		close(c.stopped)
+	}
+	return nil
+}
+
+func (c *secretCache) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	s := obj.(*corev1.Secret)
+	s.SetName(key.Name)
+	s.SetNamespace(key.Namespace)
+	s.Data = map[string][]byte{"credentials": []byte(c.data)}
+	return nil
+}
+
+func TestCacheGet(t *testing.T) {
+	errBoom := errors.New("boom")
+	key := types.NamespacedName{Namespace: "crossplane-system", Name: "kafka-creds"}
+	secret := func(data string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Data:       map[string][]byte{"credentials": []byte(data)},
+		}
+	}
+
+	cases := map[string]struct {
+		reason     string
+		started    bool
+		newCache   error
+		reads      int
+		want       *corev1.Secret
+		wantErr    error
+		wantCaches int
+	}{
+		"NotStarted": {
+			reason: "Secrets should be read from the API server until the Cache is started.",
+			reads:  2,
+			want:   secret("api"),
+		},
+		"Started": {
+			reason:     "Secrets should be read from a single informer once the Cache is started.",
+			started:    true,
+			reads:      2,
+			want:       secret("cache"),
+			wantCaches: 1,
+		},
+		"NewCacheError": {
+			reason:     "An informer that cannot be created should be created again the next time its Secret is read.",
+			started:    true,
+			newCache:   errBoom,
+			reads:      2,
+			want:       &corev1.Secret{},
+			wantErr:    errors.Wrapf(errBoom, errNewInformer, key),
+			wantCaches: 2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			created := 0
+			c := &Cache{
+				newCache: func(_ types.NamespacedName) (cache.Cache, error) {
+					created++
+					return &secretCache{data: "cache"}, tc.newCache
+				},
+				api: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					*obj.(*corev1.Secret) = *secret("api")
+					return nil
+				}},
+				caches: map[types.NamespacedName]*entry{},
+			}
+			if tc.started {
+				c.ctx = ctx
+			}
+
+			var got *corev1.Secret
+			var err error
+			for i := 0; i < tc.reads; i++ {
+				got = &corev1.Secret{}
+				err = c.Get(ctx, key, got)
+			}
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGet(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGet(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if created != tc.wantCaches {
+				t.Errorf("\n%s\nGet(...): want %d informers created, got %d", tc.reason, tc.wantCaches, created)
+			}
+		})
+	}
+}
+
+func TestClientGet(t *testing.T) {
+	c := &Cache{
+		newCache: func(_ types.NamespacedName) (cache.Cache, error) {
+			return &secretCache{data: "cache"}, nil
+		},
+		ctx:    context.Background(),
+		caches: map[types.NamespacedName]*entry{},
+	}
+	delegated := 0
+	kube := NewClient(&test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, _ client.Object) error {
+		delegated++
+		return nil
+	}}, c)
+
+	s := &corev1.Secret{}
+	if err := kube.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "creds"}, s); err != nil {
+		t.Fatalf("Get(Secret): %v", err)
+	}
+	if got := string(s.Data["credentials"]); got != "cache" {
+		t.Errorf("Get(Secret): want Secret read from the cache, got data %q", got)
+	}
+	if err := kube.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "cm"}, &corev1.ConfigMap{}); err != nil {
+		t.Fatalf("Get(ConfigMap): %v", err)
+	}
+	if delegated != 1 {
+		t.Errorf("Get(...): want 1 read delegated to the client, got %d", delegated)
+	}
+}
+
+func TestCacheEvict(t *testing.T) {
+	key := types.NamespacedName{Namespace: "crossplane-system", Name: "kafka-creds"}
+
+	cases := map[string]struct {
+		reason      string
+		idle        time.Duration
+		wantCaches  int
+		wantCreated int
+	}{
+		"Read": {
+			reason:      "The informer of a Secret read recently should keep running.",
+			idle:        time.Minute,
+			wantCaches:  1,
+			wantCreated: 1,
+		},
+		"NotRead": {
+			reason:      "The informer of a Secret no longer read should be stopped, and started again once it is read.",
+			idle:        defaultMaxIdle + time.Minute,
+			wantCreated: 2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			created := 0
+			var stopped chan struct{}
+			c := &Cache{
+				newCache: func(_ types.NamespacedName) (cache.Cache, error) {
+					created++
+					stopped = make(chan struct{})
+					return &secretCache{data: "cache", stopped: stopped}, nil
+				},
+				maxIdle: defaultMaxIdle,
+				ctx:     ctx,
+				caches:  map[types.NamespacedName]*entry{},
+			}
+			if err := c.Get(ctx, key, &corev1.Secret{}); err != nil {
+				t.Fatalf("Get(...): %v", err)
+			}
+			first := stopped
+
+			c.evict(time.Now().Add(tc.idle))
+			if len(c.caches) != tc.wantCaches {
+				t.Errorf("\n%s\nevict(...): want %d informers, got %d", tc.reason, tc.wantCaches, len(c.caches))
+			}
+			if tc.wantCaches == 0 {
+				select {
+				case <-time.After(time.Second):
+					t.Errorf("\n%s\nevict(...): want the informer stopped", tc.reason)
+				case <-first:
+				}
+			}
+
+			if err := c.Get(ctx, key, &corev1.Secret{}); err != nil {
+				t.Fatalf("Get(...): %v", err)
+			}
+			if created != tc.wantCreated {
+				t.Errorf("\n%s\nGet(...): want %d informers created, got %d", tc.reason, tc.wantCreated, created)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shard partitions the resources of the provider between several
+// deployments of it by the shard label of their ProviderConfigs.
+package shard
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// LabelKey is the label of a ProviderConfig naming the shard that reconciles
+// it and the managed resources using it.
+const LabelKey = "kafka.crossplane.io/shard"
+
+const (
+	errGetManaged        = "cannot get managed resource"
+	errGetProviderConfig = "cannot get ProviderConfig"
+)
+
+// A Filter tells whether resources belong to a shard.
+type Filter struct {
+	kube  client.Reader
+	shard string
+}
+
+// NewFilter returns a Filter of the supplied shard, reading ProviderConfigs
+// with the supplied client.
+func NewFilter(kube client.Reader, shard string) *Filter {
+	return &Filter{kube: kube, shard: shard}
+}
+
+// ProviderConfig returns whether the ProviderConfig of the supplied name
+// belongs to the shard. Unlabeled ProviderConfigs belong to the shard without
+// a name. A ProviderConfig that does not exist belongs to every shard, so that
+// the managed resources using it report it missing.
+func (f *Filter) ProviderConfig(ctx context.Context, name string) (bool, error) {
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := f.kube.Get(ctx, client.ObjectKey{Name: name}, pc); err != nil {
+		return kerrors.IsNotFound(err), errors.Wrap(resource.IgnoreNotFound(err), errGetProviderConfig)
+	}
+	return pc.GetLabels()[LabelKey] == f.shard, nil
+}
+
+// Managed returns whether the supplied managed resource belongs to the shard,
+// i.e. whether its ProviderConfig does.
+func (f *Filter) Managed(ctx context.Context, mg resource.Managed) (bool, error) {
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return true, nil
+	}
+	return f.ProviderConfig(ctx, ref.Name)
+}
+
+// NewProviderConfigReconciler returns the supplied reconciler of
+// ProviderConfigs, only reconciling those that belong to the shard of the
+// supplied Filter.
+func NewProviderConfigReconciler(r reconcile.Reconciler, f *Filter) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		ok, err := f.ProviderConfig(ctx, req.Name)
+		if err != nil || !ok {
+			return reconcile.Result{}, err
+		}
+		return r.Reconcile(ctx, req)
+	})
+}
+
+// NewManagedReconciler returns the supplied reconciler of managed resources
+// of the kind of the supplied one, only reconciling those that belong to the
+// shard of the supplied Filter. Resources that do not are neither reconciled
+// nor requeued, so that they are left to the deployment of their shard.
+func NewManagedReconciler(r reconcile.Reconciler, f *Filter, of resource.Managed) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		mg := of.DeepCopyObject().(resource.Managed)
+		if err := f.kube.Get(ctx, req.NamespacedName, mg); err != nil {
+			// A deleted resource is left to the reconciler, which ignores it.
+			if kerrors.IsNotFound(err) {
+				return r.Reconcile(ctx, req)
+			}
+			return reconcile.Result{}, errors.Wrap(err, errGetManaged)
+		}
+		ok, err := f.Managed(ctx, mg)
+		if err != nil || !ok {
+			return reconcile.Result{}, err
+		}
+		return r.Reconcile(ctx, req)
+	})
+}
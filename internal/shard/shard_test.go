@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+func TestNewManagedReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+	notFound := kerrors.NewNotFound(schema.GroupResource{}, "")
+
+	kube := func(mgErr, pcErr error, shard *string) client.Reader {
+		return &test.MockClient{
+			MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				switch o := obj.(type) {
+				case *v1alpha1.Topic:
+					o.SetProviderConfigReference(&xpv1.Reference{Name: "eu"})
+					return mgErr
+				case *apisv1alpha1.ProviderConfig:
+					if shard != nil {
+						o.SetLabels(map[string]string{LabelKey: *shard})
+					}
+					return pcErr
+				}
+				return errBoom
+			},
+		}
+	}
+	prodEU, prodUS := "prod-eu", "prod-us"
+
+	type want struct {
+		reconciled bool
+		err        error
+	}
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		want   want
+	}{
+		"InShard": {
+			reason: "A resource whose ProviderConfig is labeled with the shard should be reconciled.",
+			kube:   kube(nil, nil, &prodEU),
+			want:   want{reconciled: true},
+		},
+		"OtherShard": {
+			reason: "A resource whose ProviderConfig is labeled with another shard should be ignored.",
+			kube:   kube(nil, nil, &prodUS),
+			want:   want{},
+		},
+		"Unlabeled": {
+			reason: "A resource whose ProviderConfig is not labeled should be ignored.",
+			kube:   kube(nil, nil, nil),
+			want:   want{},
+		},
+		"ProviderConfigNotFound": {
+			reason: "A resource whose ProviderConfig does not exist should be reconciled, so that it reports it missing.",
+			kube:   kube(nil, notFound, nil),
+			want:   want{reconciled: true},
+		},
+		"ManagedNotFound": {
+			reason: "A deleted resource should be left to the reconciler.",
+			kube:   kube(notFound, nil, nil),
+			want:   want{reconciled: true},
+		},
+		"GetProviderConfigError": {
+			reason: "Errors getting the ProviderConfig should be returned.",
+			kube:   kube(nil, errBoom, nil),
+			want:   want{err: errors.Wrap(errBoom, errGetProviderConfig)},
+		},
+		"GetManagedError": {
+			reason: "Errors getting the resource should be returned.",
+			kube:   kube(errBoom, nil, nil),
+			want:   want{err: errors.Wrap(errBoom, errGetManaged)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reconciled := false
+			r := reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				reconciled = true
+				return reconcile.Result{}, nil
+			})
+			_, err := NewManagedReconciler(r, NewFilter(tc.kube, prodEU), &v1alpha1.Topic{}).
+				Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "orders"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reconciled, reconciled); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want reconciled, +got reconciled:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFilterProviderConfig(t *testing.T) {
+	kube := func(labels map[string]string) client.Reader {
+		return &test.MockClient{
+			MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				obj.SetLabels(labels)
+				return nil
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		shard  string
+		want   bool
+	}{
+		"UnshardedUnlabeled": {
+			reason: "A deployment without a shard should reconcile unlabeled ProviderConfigs.",
+			kube:   kube(nil),
+			want:   true,
+		},
+		"UnshardedLabeled": {
+			reason: "A deployment without a shard should leave labeled ProviderConfigs to their shard.",
+			kube:   kube(map[string]string{LabelKey: "prod-eu"}),
+			want:   false,
+		},
+		"ShardedUnlabeled": {
+			reason: "A shard should leave unlabeled ProviderConfigs to the deployment without a shard.",
+			kube:   kube(nil),
+			shard:  "prod-eu",
+			want:   false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewFilter(tc.kube, tc.shard).ProviderConfig(context.Background(), "eu")
+			if err != nil {
+				t.Fatalf("\n%s\nProviderConfig(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nProviderConfig(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shutdown drains the calls of the provider's controllers to external
+// systems when the provider shuts down, so that e.g. a deployment rolling the
+// provider does not cancel a topic's config update half way through.
+package shutdown
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+)
+
+const errShuttingDown = "provider is shutting down"
+
+// A Drainer tracks the calls of controllers to external systems. Once the
+// provider shuts down it refuses new calls, and waits for those in flight to
+// complete before closing the clients they use. Calls that change external
+// resources are not cancelled by the shutdown, but only once the drain times
+// out.
+type Drainer struct {
+	timeout time.Duration
+	log     logging.Logger
+
+	// stop is cancelled once the drain timed out.
+	stop       context.Context
+	cancelStop context.CancelFunc
+
+	mu       sync.Mutex
+	draining bool
+	inflight int
+	idle     chan struct{}
+	closers  []func()
+}
+
+// NewDrainer returns a Drainer that waits up to the supplied timeout for calls
+// in flight to complete.
+func NewDrainer(timeout time.Duration, log logging.Logger) *Drainer {
+	stop, cancel := context.WithCancel(context.Background())
+	return &Drainer{timeout: timeout, log: log, stop: stop, cancelStop: cancel}
+}
+
+// OnDrained registers a function, e.g. one closing cached clients, to call
+// once the calls in flight completed or the drain timed out.
+func (d *Drainer) OnDrained(fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.closers = append(d.closers, fn)
+}
+
+// Start waits for the supplied context to be cancelled, which the controller
+// manager does on SIGTERM, and then drains. It implements manager.Runnable.
+func (d *Drainer) Start(ctx context.Context) error {
+	<-ctx.Done()
+	d.Drain()
+	return nil
+}
+
+// NeedLeaderElection returns false, as the calls of a provider that lost its
+// lease must be drained too. It implements
+// manager.LeaderElectionRunnable.
+func (d *Drainer) NeedLeaderElection() bool {
+	return false
+}
+
+// Drain refuses new calls, waits up to the timeout for the calls in flight to
+// complete, cancels those that did not, and calls the functions registered
+// with OnDrained. It returns whether all calls completed in time.
+func (d *Drainer) Drain() bool {
+	d.mu.Lock()
+	d.draining = true
+	n := d.inflight
+	idle := make(chan struct{})
+	if n == 0 {
+		close(idle)
+	}
+	d.idle = idle
+	d.mu.Unlock()
+
+	metrics.RecordDraining(true)
+	d.log.Info("Shutting down, draining calls to external systems", "inflight", n, "timeout", d.timeout)
+
+	drained := true
+	t := time.NewTimer(d.timeout)
+	select {
+	case <-idle:
+		t.Stop()
+	case <-t.C:
+		drained = false
+	}
+	d.cancelStop()
+
+	d.mu.Lock()
+	closers := d.closers
+	cancelled := d.inflight
+	d.mu.Unlock()
+	for _, fn := range closers {
+		fn()
+	}
+
+	if !drained {
+		d.log.Info("Shut down after cancelling calls to external systems that did not complete within the drain timeout", "cancelled", cancelled, "timeout", d.timeout)
+		return false
+	}
+	d.log.Info("Shut down orderly, all calls to external systems completed")
+	return true
+}
+
+// track records the start of a call. It returns a function recording its end,
+// or an error if the provider is shutting down.
+func (d *Drainer) track() (func(), error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return nil, errors.New(errShuttingDown)
+	}
+	d.inflight++
+	metrics.RecordInflightCalls(d.inflight)
+	return d.done, nil
+}
+
+func (d *Drainer) done() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.inflight--
+	metrics.RecordInflightCalls(d.inflight)
+	if d.draining && d.inflight == 0 {
+		close(d.idle)
+	}
+}
+
+// detach returns a context with the values and deadline of the supplied one
+// that is only cancelled once the drain timed out, not once the supplied one
+// is.
+func (d *Drainer) detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	dctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	if dl, ok := ctx.Deadline(); ok {
+		dctx, cancel = context.WithDeadline(context.WithoutCancel(ctx), dl)
+	}
+	stop := context.AfterFunc(d.stop, cancel)
+	return dctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// NewConnecter returns an ExternalConnecter whose clients are drained by the
+// supplied Drainer. Creating, updating and deleting external resources is not
+// cancelled when the provider shuts down, as it might otherwise succeed
+// partially. Observing them is, as that is safe to retry.
+func NewConnecter(c managed.ExternalConnecter, d *Drainer) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, drainer: d}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	drainer *Drainer
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	done, err := c.drainer.track()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: ec, drainer: c.drainer}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	drainer *Drainer
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	done, err := e.drainer.track()
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	defer done()
+	return e.ExternalClient.Observe(ctx, mg)
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	done, err := e.drainer.track()
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	defer done()
+	ctx, cancel := e.drainer.detach(ctx)
+	defer cancel()
+	return e.ExternalClient.Create(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	done, err := e.drainer.track()
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	defer done()
+	ctx, cancel := e.drainer.detach(ctx)
+	defer cancel()
+	return e.ExternalClient.Update(ctx, mg)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	done, err := e.drainer.track()
+	if err != nil {
+		return err
+	}
+	defer done()
+	ctx, cancel := e.drainer.detach(ctx)
+	defer cancel()
+	return e.ExternalClient.Delete(ctx, mg)
+}
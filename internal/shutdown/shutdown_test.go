@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shutdown
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+// updater returns a connecter whose clients update external resources by
+// calling the supplied function.
+func updater(update func(ctx context.Context) error) managed.ExternalConnecter {
+	return managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+				return managed.ExternalObservation{ResourceExists: true}, nil
+			},
+			UpdateFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+				return managed.ExternalUpdate{}, update(ctx)
+			},
+		}, nil
+	})
+}
+
+func TestDrain(t *testing.T) {
+	d := NewDrainer(time.Minute, logging.NewNopLogger())
+	closed := false
+	d.OnDrained(func() { closed = true })
+
+	started, release := make(chan struct{}), make(chan struct{})
+	c := NewConnecter(updater(func(ctx context.Context) error {
+		close(started)
+		<-release
+		return ctx.Err()
+	}), d)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ec, err := c.Connect(ctx, &v1alpha1.Topic{})
+	if err != nil {
+		t.Fatalf("Connect(...): %s", err)
+	}
+	updated := make(chan error)
+	go func() {
+		_, err := ec.Update(ctx, &v1alpha1.Topic{})
+		updated <- err
+	}()
+	<-started
+
+	// The manager cancels the contexts of reconciles as it shuts down.
+	cancel()
+	drained := make(chan bool)
+	go func() { drained <- d.Drain() }()
+
+	// New calls are refused as soon as the drain started.
+	for {
+		if _, err := ec.Observe(context.Background(), &v1alpha1.Topic{}); err != nil {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	close(release)
+	if err := <-updated; err != nil {
+		t.Errorf("Update(...): the update should not be cancelled by the shutdown, got %s", err)
+	}
+	if !<-drained {
+		t.Errorf("Drain(): want drained, got timed out")
+	}
+	if !closed {
+		t.Errorf("Drain(): want clients closed")
+	}
+}
+
+func TestDrainTimeout(t *testing.T) {
+	d := NewDrainer(10*time.Millisecond, logging.NewNopLogger())
+
+	started := make(chan struct{})
+	c := NewConnecter(updater(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}), d)
+
+	ec, err := c.Connect(context.Background(), &v1alpha1.Topic{})
+	if err != nil {
+		t.Fatalf("Connect(...): %s", err)
+	}
+	updated := make(chan error)
+	go func() {
+		_, err := ec.Update(context.Background(), &v1alpha1.Topic{})
+		updated <- err
+	}()
+	<-started
+
+	if d.Drain() {
+		t.Errorf("Drain(): want timed out, got drained")
+	}
+	if err := <-updated; err == nil {
+		t.Errorf("Update(...): want the update cancelled once the drain timed out")
+	}
+	if _, err := c.Connect(context.Background(), &v1alpha1.Topic{}); err == nil {
+		t.Errorf("Connect(...): want new calls refused after the drain")
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package status skips writing the statuses of managed resources that did not
+// change since they were read, as most polls of up to date resources observe,
+// to spare the API server writes and resourceVersion churn.
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/crossplane-contrib/provider-kafka/internal/metrics"
+)
+
+// NewManager returns a manager whose client skips writing the statuses of
+// managed resources that did not change since they were read.
+func NewManager(m manager.Manager) manager.Manager {
+	return &mgr{Manager: m, client: NewClient(m.GetClient())}
+}
+
+type mgr struct {
+	manager.Manager
+	client client.Client
+}
+
+func (m *mgr) GetClient() client.Client {
+	return m.client
+}
+
+// NewClient returns a client that skips writing the status of a managed
+// resource if it is the status it had when the client last read it, at the
+// same resourceVersion.
+func NewClient(c client.Client) client.Client {
+	return &Client{Client: c, read: map[key]snapshot{}}
+}
+
+// A key identifies a managed resource of a kind by its name.
+type key struct {
+	kind string
+	client.ObjectKey
+}
+
+// A snapshot is the status of a managed resource as it was read.
+type snapshot struct {
+	uid             types.UID
+	resourceVersion string
+	status          []byte
+}
+
+// A Client skips writing statuses of managed resources that did not change.
+// The status of a managed resource is forgotten once it is written, once the
+// resource is updated, e.g. to remove its finalizer, or deleted, and once it
+// is no longer found.
+type Client struct {
+	client.Client
+
+	mu   sync.Mutex
+	read map[key]snapshot
+}
+
+// Get the supplied object, remembering its status if it is a managed
+// resource.
+func (c *Client) Get(ctx context.Context, k client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, ok := obj.(resource.Managed); !ok {
+		return c.Client.Get(ctx, k, obj, opts...)
+	}
+	if err := c.Client.Get(ctx, k, obj, opts...); err != nil {
+		if kerrors.IsNotFound(err) {
+			c.mu.Lock()
+			delete(c.read, key{kind: kind(obj), ObjectKey: k})
+			c.mu.Unlock()
+		}
+		return err
+	}
+	s, err := statusOf(obj)
+	if err != nil {
+		return nil //nolint:nilerr // Statuses that cannot be read are always written.
+	}
+	c.mu.Lock()
+	c.read[keyOf(obj)] = snapshot{uid: obj.GetUID(), resourceVersion: obj.GetResourceVersion(), status: s}
+	c.mu.Unlock()
+	return nil
+}
+
+// Update the supplied object, which changes its resourceVersion.
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.forget(obj)
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// Patch the supplied object, which changes its resourceVersion.
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.forget(obj)
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// Delete the supplied object.
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.forget(obj)
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+// Status returns a writer that skips unchanged statuses.
+func (c *Client) Status() client.SubResourceWriter {
+	return &writer{SubResourceWriter: c.Client.Status(), client: c}
+}
+
+// unchanged returns true if the supplied object has the status it was last
+// read with, at the same resourceVersion. The object is forgotten either way,
+// as writing its status changes its resourceVersion.
+func (c *Client) unchanged(obj client.Object) bool {
+	c.mu.Lock()
+	last, ok := c.read[keyOf(obj)]
+	delete(c.read, keyOf(obj))
+	c.mu.Unlock()
+	if !ok || last.uid != obj.GetUID() || last.resourceVersion != obj.GetResourceVersion() {
+		return false
+	}
+	s, err := statusOf(obj)
+	return err == nil && bytes.Equal(s, last.status)
+}
+
+func (c *Client) forget(obj client.Object) {
+	c.mu.Lock()
+	delete(c.read, keyOf(obj))
+	c.mu.Unlock()
+}
+
+// keyOf returns the key of the supplied object.
+func keyOf(obj client.Object) key {
+	return key{kind: kind(obj), ObjectKey: client.ObjectKeyFromObject(obj)}
+}
+
+type writer struct {
+	client.SubResourceWriter
+	client *Client
+}
+
+// Update the status of the supplied object, unless it did not change since
+// it was read.
+func (w *writer) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if w.client.unchanged(obj) {
+		metrics.RecordStatusUpdateSkipped(kind(obj))
+		return nil
+	}
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+// statusOf returns the status of the supplied object as JSON, whose object
+// keys are sorted so that equal statuses are equal bytes.
+func statusOf(obj client.Object) ([]byte, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(u["status"])
+}
+
+// kind returns the kind of the supplied object, whose type meta typed objects
+// read from a cache lack.
+func kind(obj client.Object) string {
+	if k := obj.GetObjectKind().GroupVersionKind().Kind; k != "" {
+		return k
+	}
+	return reflect.TypeOf(obj).Elem().Name()
+}
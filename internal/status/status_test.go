@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+func TestStatusUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		modify func(cr *v1alpha1.Topic)
+		want   bool
+	}{
+		"Unchanged": {
+			reason: "The status of a managed resource that did not change since it was read should not be written.",
+			modify: func(_ *v1alpha1.Topic) {},
+		},
+		"StatusChanged": {
+			reason: "A changed status should be written.",
+			modify: func(cr *v1alpha1.Topic) { cr.Status.AtProvider.PartitionCount = 3 },
+			want:   true,
+		},
+		"ResourceVersionChanged": {
+			reason: "The status of a managed resource updated since it was read should be written.",
+			modify: func(cr *v1alpha1.Topic) { cr.SetResourceVersion("2") },
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			written := false
+			c := NewClient(&test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					cr := obj.(*v1alpha1.Topic)
+					cr.SetUID("cool-uid")
+					cr.SetResourceVersion("1")
+					cr.Status.AtProvider.PartitionCount = 1
+					return nil
+				}),
+				MockStatusUpdate: func(_ context.Context, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+					written = true
+					return nil
+				},
+			})
+
+			cr := &v1alpha1.Topic{}
+			if err := c.Get(context.Background(), client.ObjectKey{Name: "cool-topic"}, cr); err != nil {
+				t.Fatalf("Get(...): %v", err)
+			}
+			tc.modify(cr)
+			if err := c.Status().Update(context.Background(), cr); err != nil {
+				t.Fatalf("Status().Update(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, written); diff != "" {
+				t.Errorf("\n%s\nStatus().Update(...): -want written, +got written:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestForget(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		then   func(c client.Client, cr *v1alpha1.Topic) error
+	}{
+		"NotFound": {
+			reason: "The status of a managed resource that is no longer found should be forgotten.",
+			then: func(c client.Client, cr *v1alpha1.Topic) error {
+				return c.Get(context.Background(), client.ObjectKeyFromObject(cr), &v1alpha1.Topic{})
+			},
+		},
+		"FinalizerRemoved": {
+			reason: "The status of a managed resource whose finalizer was removed should be forgotten.",
+			then: func(c client.Client, cr *v1alpha1.Topic) error {
+				cr.SetFinalizers(nil)
+				return c.Update(context.Background(), cr)
+			},
+		},
+		"Deleted": {
+			reason: "The status of a deleted managed resource should be forgotten.",
+			then: func(c client.Client, cr *v1alpha1.Topic) error {
+				return c.Delete(context.Background(), cr)
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			found := true
+			c := NewClient(&test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					if !found {
+						return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+					}
+					cr := obj.(*v1alpha1.Topic)
+					cr.SetName(key.Name)
+					cr.SetUID("cool-uid")
+					cr.SetResourceVersion("1")
+					cr.SetFinalizers([]string{"finalizer.managedresource.crossplane.io"})
+					return nil
+				},
+				MockUpdate: test.NewMockUpdateFn(nil),
+				MockDelete: test.NewMockDeleteFn(nil),
+			}).(*Client)
+
+			cr := &v1alpha1.Topic{}
+			if err := c.Get(context.Background(), client.ObjectKey{Name: "cool-topic"}, cr); err != nil {
+				t.Fatalf("Get(...): %v", err)
+			}
+			found = false
+			_ = tc.then(c, cr)
+			if diff := cmp.Diff(0, len(c.read)); diff != "" {
+				t.Errorf("\n%s\n-want statuses remembered, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package strimzi converts the KafkaTopics of the Strimzi Topic Operator to
+// Topics, to migrate topics from Strimzi to the Kafka provider.
+package strimzi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+const (
+	// LabelKeyCluster is the label of a KafkaTopic naming the Strimzi Kafka
+	// cluster the topic belongs to.
+	LabelKeyCluster = "strimzi.io/cluster"
+
+	kindKafkaTopic = "KafkaTopic"
+	kindList       = "List"
+
+	errParseDocument  = "cannot parse document %d"
+	errParseTopic     = "cannot parse KafkaTopic %s/%s"
+	errConfigValue    = "KafkaTopic %s/%s has config %q of unsupported value %s"
+	errNoProvider     = "KafkaTopic %s/%s has no %s label, and no ProviderConfig was supplied"
+	errDuplicateTopic = "KafkaTopics %s and %s both convert to Topic %q"
+	errMarshalTopic   = "cannot marshal Topic %s"
+)
+
+// A KafkaTopic is the part of a Strimzi KafkaTopic that is converted.
+type KafkaTopic struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KafkaTopicSpec `json:"spec"`
+}
+
+// A KafkaTopicSpec is the desired state of a Strimzi KafkaTopic.
+type KafkaTopicSpec struct {
+	// TopicName is the name of the topic, if it differs from the name of the
+	// KafkaTopic.
+	TopicName string `json:"topicName,omitempty"`
+	// Partitions of the topic.
+	Partitions int `json:"partitions,omitempty"`
+	// Replicas is the replication factor of the topic.
+	Replicas int `json:"replicas,omitempty"`
+	// Config of the topic, whose values may be strings, numbers, booleans
+	// or lists of strings.
+	Config map[string]json.RawMessage `json:"config,omitempty"`
+}
+
+// Options of a conversion.
+type Options struct {
+	// ProviderConfig every Topic references. The Strimzi cluster of a
+	// KafkaTopic is used if it is empty.
+	ProviderConfig string
+	// NamePrefix is prepended to the names of Topics.
+	NamePrefix string
+}
+
+// Parse returns the KafkaTopics of the supplied YAML documents, which may be
+// KafkaTopics or Lists of them, as output by kubectl get kafkatopics -o yaml.
+// Documents of other kinds are skipped.
+func Parse(data []byte) ([]KafkaTopic, error) {
+	var kts []KafkaTopic
+	for i, doc := range bytes.Split(data, []byte("\n---")) {
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		l := &struct {
+			metav1.TypeMeta `json:",inline"`
+			Items           []json.RawMessage `json:"items"`
+		}{}
+		if err := yaml.Unmarshal(doc, l); err != nil {
+			return nil, errors.Wrapf(err, errParseDocument, i)
+		}
+		items := [][]byte{doc}
+		if l.Kind == kindList || strings.HasSuffix(l.Kind, kindKafkaTopic+kindList) {
+			items = items[:0]
+			for _, item := range l.Items {
+				items = append(items, item)
+			}
+		}
+		for _, item := range items {
+			kt := KafkaTopic{}
+			if err := yaml.Unmarshal(item, &kt); err != nil {
+				return nil, errors.Wrapf(err, errParseDocument, i)
+			}
+			if kt.Kind == kindKafkaTopic {
+				kts = append(kts, kt)
+			}
+		}
+	}
+	return kts, nil
+}
+
+// Convert returns the Topic equivalent to the supplied KafkaTopic. Its
+// external name is the name of the topic, and it adopts the topic Strimzi
+// created.
+func Convert(kt KafkaTopic, o Options) (*v1alpha1.Topic, error) {
+	pc := o.ProviderConfig
+	if pc == "" {
+		pc = kt.GetLabels()[LabelKeyCluster]
+	}
+	if pc == "" {
+		return nil, errors.Errorf(errNoProvider, kt.GetNamespace(), kt.GetName(), LabelKeyCluster)
+	}
+
+	name := kt.Spec.TopicName
+	if name == "" {
+		name = kt.GetName()
+	}
+
+	cfg := make(map[string]*string, len(kt.Spec.Config))
+	for k, raw := range kt.Spec.Config {
+		v, err := configValue(raw)
+		if err != nil {
+			return nil, errors.Errorf(errConfigValue, kt.GetNamespace(), kt.GetName(), k, string(raw))
+		}
+		cfg[k] = &v
+	}
+	if len(cfg) == 0 {
+		cfg = nil
+	}
+
+	adopt := true
+	t := &v1alpha1.Topic{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: v1alpha1.TopicKind},
+		ObjectMeta: metav1.ObjectMeta{Name: o.NamePrefix + kt.GetName()},
+		Spec: v1alpha1.TopicSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: pc}},
+			ForProvider: v1alpha1.TopicParameters{
+				ReplicationFactor: kt.Spec.Replicas,
+				Partitions:        kt.Spec.Partitions,
+				Config:            cfg,
+				AdoptExisting:     &adopt,
+			},
+		},
+	}
+	meta.SetExternalName(t, name)
+	return t, nil
+}
+
+// configValue returns the supplied config value of a KafkaTopic as a string.
+// Lists are joined by commas, as Kafka expects them.
+func configValue(raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, nil
+	}
+	var l []string
+	if err := json.Unmarshal(raw, &l); err == nil {
+		return strings.Join(l, ","), nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String(), nil
+	}
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return fmt.Sprint(b), nil
+	}
+	return "", errors.New("unsupported value")
+}
+
+// ConvertAll returns the YAML documents of the Topics equivalent to the
+// supplied KafkaTopics, sorted by name. KafkaTopics of different namespaces
+// with the same name must be told apart by converting them with different
+// name prefixes.
+func ConvertAll(kts []KafkaTopic, o Options) ([]byte, error) {
+	topics := make([]*v1alpha1.Topic, 0, len(kts))
+	from := map[string]string{}
+	for _, kt := range kts {
+		t, err := Convert(kt, o)
+		if err != nil {
+			return nil, err
+		}
+		src := kt.GetNamespace() + "/" + kt.GetName()
+		if prev, ok := from[t.GetName()]; ok {
+			return nil, errors.Errorf(errDuplicateTopic, prev, src, t.GetName())
+		}
+		from[t.GetName()] = src
+		topics = append(topics, t)
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].GetName() < topics[j].GetName() })
+
+	out := &bytes.Buffer{}
+	for i, t := range topics {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(t)
+		if err != nil {
+			return nil, errors.Wrapf(err, errMarshalTopic, t.GetName())
+		}
+		// Neither is meaningful for a Topic that was not created yet.
+		delete(u, "status")
+		delete(u["metadata"].(map[string]any), "creationTimestamp")
+		b, err := yaml.Marshal(u)
+		if err != nil {
+			return nil, errors.Wrapf(err, errMarshalTopic, t.GetName())
+		}
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(b)
+	}
+	return out.Bytes(), nil
+}
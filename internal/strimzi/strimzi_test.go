@@ -0,0 +1,173 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strimzi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertAll(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		input   string
+		o       Options
+		want    string
+		wantErr bool
+	}{
+		"List": {
+			reason: "KafkaTopics of a List are converted to Topics adopting their topics, sorted by name.",
+			input: `apiVersion: v1
+kind: List
+items:
+- apiVersion: kafka.strimzi.io/v1beta2
+  kind: KafkaTopic
+  metadata:
+    name: payments
+    namespace: kafka
+    labels:
+      strimzi.io/cluster: main
+  spec:
+    partitions: 3
+    replicas: 3
+- apiVersion: kafka.strimzi.io/v1beta2
+  kind: KafkaTopic
+  metadata:
+    name: orders
+    namespace: kafka
+    labels:
+      strimzi.io/cluster: main
+  spec:
+    topicName: orders.v1
+    partitions: 6
+    replicas: 3
+    config:
+      retention.ms: 604800000
+      cleanup.policy: [compact, delete]
+      unclean.leader.election.enable: false
+      compression.type: lz4
+`,
+			want: `apiVersion: topic.kafka.crossplane.io/v1alpha1
+kind: Topic
+metadata:
+  annotations:
+    crossplane.io/external-name: orders.v1
+  name: orders
+spec:
+  forProvider:
+    adoptExisting: true
+    config:
+      cleanup.policy: compact,delete
+      compression.type: lz4
+      retention.ms: "604800000"
+      unclean.leader.election.enable: "false"
+    partitions: 6
+    replicationFactor: 3
+  providerConfigRef:
+    name: main
+---
+apiVersion: topic.kafka.crossplane.io/v1alpha1
+kind: Topic
+metadata:
+  annotations:
+    crossplane.io/external-name: payments
+  name: payments
+spec:
+  forProvider:
+    adoptExisting: true
+    partitions: 3
+    replicationFactor: 3
+  providerConfigRef:
+    name: main
+`,
+		},
+		"Documents": {
+			reason: "KafkaTopics of separate documents are converted, and other kinds skipped.",
+			input: `apiVersion: kafka.strimzi.io/v1beta2
+kind: Kafka
+metadata:
+  name: main
+---
+apiVersion: kafka.strimzi.io/v1beta2
+kind: KafkaTopic
+metadata:
+  name: audit
+spec:
+  partitions: 1
+  replicas: 1
+`,
+			o: Options{ProviderConfig: "example", NamePrefix: "kafka-"},
+			want: `apiVersion: topic.kafka.crossplane.io/v1alpha1
+kind: Topic
+metadata:
+  annotations:
+    crossplane.io/external-name: audit
+  name: kafka-audit
+spec:
+  forProvider:
+    adoptExisting: true
+    partitions: 1
+    replicationFactor: 1
+  providerConfigRef:
+    name: example
+`,
+		},
+		"NoProviderConfig": {
+			reason: "A KafkaTopic without cluster label cannot be converted without a ProviderConfig.",
+			input: `apiVersion: kafka.strimzi.io/v1beta2
+kind: KafkaTopic
+metadata:
+  name: audit
+`,
+			wantErr: true,
+		},
+		"DuplicateName": {
+			reason: "KafkaTopics of different namespaces must not convert to the same Topic.",
+			input: `apiVersion: kafka.strimzi.io/v1beta2
+kind: KafkaTopic
+metadata:
+  name: audit
+  namespace: a
+---
+apiVersion: kafka.strimzi.io/v1beta2
+kind: KafkaTopic
+metadata:
+  name: audit
+  namespace: b
+`,
+			o:       Options{ProviderConfig: "example"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kts, err := Parse([]byte(tc.input))
+			if err != nil {
+				t.Fatalf("\n%s\nParse(...): %v", tc.reason, err)
+			}
+			got, err := ConvertAll(kts, tc.o)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nConvertAll(...): error = %v, wantErr %v", tc.reason, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("\n%s\nConvertAll(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package watch scopes the informers of the provider to the resources it
+// should reconcile or read, e.g. to run one replica per set of labeled
+// resources.
+package watch
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Options scope the informers of the provider.
+type Options struct {
+	// Namespaces namespaced resources, such as the ConfigMaps topic configs
+	// are read from, are watched in. Every namespace is watched if empty.
+	Namespaces []string
+
+	// Selector the labels of watched resources must match. Every resource
+	// is watched if nil.
+	Selector labels.Selector
+}
+
+// Cache returns the supplied cache options scoped to the Options. Resources
+// of the supplied unfiltered types, such as ProviderConfigs, are watched
+// whatever their labels, as every managed resource may read them. Secrets are
+// watched in every namespace whatever their labels, as connection details are
+// published to Secrets of any namespace, which are created without labels.
+func (o Options) Cache(co cache.Options, unfiltered ...client.Object) cache.Options {
+	scoped := len(o.Namespaces) > 0 || (o.Selector != nil && !o.Selector.Empty())
+	if !scoped {
+		return co
+	}
+	if co.ByObject == nil {
+		co.ByObject = make(map[client.Object]cache.ByObject, len(unfiltered)+1)
+	}
+	co.ByObject[&corev1.Secret{}] = cache.ByObject{
+		Namespaces: map[string]cache.Config{cache.AllNamespaces: {}},
+		Label:      labels.Everything(),
+	}
+
+	if len(o.Namespaces) > 0 {
+		co.DefaultNamespaces = make(map[string]cache.Config, len(o.Namespaces))
+		for _, ns := range o.Namespaces {
+			co.DefaultNamespaces[ns] = cache.Config{}
+		}
+	}
+	if o.Selector == nil || o.Selector.Empty() {
+		return co
+	}
+	co.DefaultLabelSelector = o.Selector
+	for _, obj := range unfiltered {
+		// A non-nil selector is not defaulted to the default one.
+		bo := co.ByObject[obj]
+		bo.Label = labels.Everything()
+		co.ByObject[obj] = bo
+	}
+	return co
+}
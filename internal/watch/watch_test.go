@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	topicv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+func TestCache(t *testing.T) {
+	shard := labels.SelectorFromSet(labels.Set{"shard": "a"})
+	pc := &v1alpha1.ProviderConfig{}
+
+	type want struct {
+		namespaces []string
+		selector   string
+		unfiltered bool
+	}
+	tests := map[string]struct {
+		reason string
+		o      Options
+		want   want
+	}{
+		"Unscoped": {
+			reason: "Options without namespaces nor a selector should not scope the cache.",
+			want:   want{},
+		},
+		"Namespaces": {
+			reason: "Namespaced resources should only be watched in the supplied namespaces.",
+			o:      Options{Namespaces: []string{"kafka", "apps"}},
+			want:   want{namespaces: []string{"apps", "kafka"}},
+		},
+		"EmptySelector": {
+			reason: "An empty selector should not filter resources.",
+			o:      Options{Selector: labels.Everything()},
+			want:   want{},
+		},
+		"Selector": {
+			reason: "Resources should be filtered by the selector, except those of unfiltered types.",
+			o:      Options{Selector: shard},
+			want:   want{selector: "shard=a", unfiltered: true},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.o.Cache(cache.Options{}, pc)
+
+			var namespaces []string
+			for ns := range got.DefaultNamespaces {
+				namespaces = append(namespaces, ns)
+			}
+			if diff := cmp.Diff(tc.want.namespaces, namespaces, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nCache(...) namespaces: -want, +got:\n%s", tc.reason, diff)
+			}
+			var selector string
+			if got.DefaultLabelSelector != nil {
+				selector = got.DefaultLabelSelector.String()
+			}
+			if selector != tc.want.selector {
+				t.Errorf("\n%s\nCache(...) selector = %q, want %q", tc.reason, selector, tc.want.selector)
+			}
+			_, unfiltered := got.ByObject[pc]
+			if unfiltered != tc.want.unfiltered {
+				t.Errorf("\n%s\nCache(...) unfiltered = %v, want %v", tc.reason, unfiltered, tc.want.unfiltered)
+			}
+			if unfiltered && !got.ByObject[pc].Label.Empty() {
+				t.Errorf("\n%s\nCache(...) selector of unfiltered type = %v, want everything", tc.reason, got.ByObject[pc].Label)
+			}
+		})
+	}
+}
+
+// scopedClient reads objects as a cache scoped by the supplied options would,
+// i.e. objects outside their namespaces or not matching their selector are
+// not found. Writes go to the API server.
+type scopedClient struct {
+	client.Client
+	opts cache.Options
+}
+
+func (c *scopedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.Client.Get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	namespaces, selector := c.opts.DefaultNamespaces, c.opts.DefaultLabelSelector
+	for o, bo := range c.opts.ByObject {
+		if reflect.TypeOf(o) != reflect.TypeOf(obj) {
+			continue
+		}
+		if bo.Namespaces != nil {
+			namespaces = bo.Namespaces
+		}
+		if bo.Label != nil {
+			selector = bo.Label
+		}
+	}
+	_, all := namespaces[cache.AllNamespaces]
+	_, watched := namespaces[key.Namespace]
+	if (len(namespaces) > 0 && !all && !watched) || (selector != nil && !selector.Matches(labels.Set(obj.GetLabels()))) {
+		return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	return nil
+}
+
+func TestCachePublishConnection(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	_ = topicv1alpha1.SchemeBuilder.AddToScheme(s)
+
+	o := Options{Namespaces: []string{"kafka"}, Selector: labels.SelectorFromSet(labels.Set{"shard": "a"})}
+	c := &scopedClient{Client: fake.NewClientBuilder().WithScheme(s).Build(), opts: o.Cache(cache.Options{})}
+
+	cr := &topicv1alpha1.Topic{}
+	cr.SetName("orders")
+	cr.SetUID("cool-uid")
+	cr.SetGroupVersionKind(topicv1alpha1.TopicGroupVersionKind)
+	cr.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Namespace: "apps", Name: "orders"})
+
+	// The Secret is created by the first publish, without the labels of the
+	// selector and outside the watched namespaces, and must be found by the
+	// second rather than created again.
+	p := managed.NewAPISecretPublisher(c, s)
+	for i := 0; i < 2; i++ {
+		if _, err := p.PublishConnection(context.Background(), cr, managed.ConnectionDetails{"topic": []byte("orders")}); err != nil {
+			t.Fatalf("PublishConnection(...) #%d: %v", i+1, err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+)
+
+const (
+	errNotAccessControlList = "object is not an AccessControlList"
+
+	// clusterResourceName is the name of the only Cluster resource.
+	clusterResourceName = "kafka-cluster"
+)
+
+// aclOperations are the operations each resource type supports, in the order
+// they are listed in error messages.
+var aclOperations = map[string][]string{
+	"Topic":           {"All", "Read", "Write", "Create", "Delete", "Alter", "Describe", "DescribeConfigs", "AlterConfigs"},
+	"Group":           {"All", "Read", "Delete", "Describe"},
+	"Cluster":         {"All", "Create", "Alter", "Describe", "ClusterAction", "DescribeConfigs", "AlterConfigs", "IdempotentWrite"},
+	"TransactionalID": {"All", "Write", "Describe"},
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-acl-kafka-crossplane-io-v1alpha1-accesscontrollist,mutating=false,failurePolicy=fail,groups=acl.kafka.crossplane.io,resources=accesscontrollists,versions=v1alpha1,name=accesscontrollists.acl.kafka.crossplane.io,sideEffects=None,admissionReviewVersions=v1
+
+// An aclValidator rejects AccessControlLists describing ACLs the brokers
+// would refuse to create, which they only report as INVALID_REQUEST.
+type aclValidator struct{}
+
+func (v *aclValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr, ok := obj.(*v1alpha1.AccessControlList)
+	if !ok {
+		return nil, errors.New(errNotAccessControlList)
+	}
+	return nil, validateACLs(&cr.Spec.ForProvider).ToAggregate()
+}
+
+// ValidateUpdate validates AccessControlLists whose ACLs changed, so that
+// AccessControlLists created before validation existed can still have their
+// finalizers removed and be deleted.
+func (v *aclValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	o, ok := oldObj.(*v1alpha1.AccessControlList)
+	if !ok {
+		return nil, errors.New(errNotAccessControlList)
+	}
+	n, ok := newObj.(*v1alpha1.AccessControlList)
+	if !ok {
+		return nil, errors.New(errNotAccessControlList)
+	}
+	if reflect.DeepEqual(o.Spec.ForProvider, n.Spec.ForProvider) {
+		return nil, nil
+	}
+	return nil, validateACLs(&n.Spec.ForProvider).ToAggregate()
+}
+
+func (v *aclValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateACLs validates the single ACL or the bindings of the supplied
+// parameters. Fields that are not set are left to the CRD validation, which
+// requires them.
+func validateACLs(p *v1alpha1.AccessControlListParameters) field.ErrorList {
+	path := field.NewPath("spec", "forProvider")
+	if len(p.Bindings) == 0 {
+		return validateACL(path, v1alpha1.AccessControlListBinding{
+			ResourceName:              p.ResourceName,
+			ResourceType:              p.ResourceType,
+			ResourceOperation:         p.ResourceOperation,
+			ResourcePermissionType:    p.ResourcePermissionType,
+			ResourcePatternTypeFilter: p.ResourcePatternTypeFilter,
+		})
+	}
+	errs := field.ErrorList{}
+	for i, b := range p.Bindings {
+		errs = append(errs, validateACL(path.Child("bindings").Index(i), b)...)
+	}
+	return errs
+}
+
+// validateACL validates the combination of the fields of a single ACL.
+func validateACL(path *field.Path, b v1alpha1.AccessControlListBinding) field.ErrorList {
+	errs := field.ErrorList{}
+
+	ops, known := aclOperations[b.ResourceType]
+	if b.ResourceType != "" && !known {
+		errs = append(errs, field.NotSupported(path.Child("resourceType"), b.ResourceType, []string{"Topic", "Group", "Cluster", "TransactionalID"}))
+	}
+	switch b.ResourcePermissionType {
+	case "", "Allow", "Deny":
+	default:
+		errs = append(errs, field.NotSupported(path.Child("resourcePermissionType"), b.ResourcePermissionType, []string{"Allow", "Deny"}))
+	}
+	switch b.ResourcePatternTypeFilter {
+	case "", "Literal", "Prefixed":
+	default:
+		errs = append(errs, field.NotSupported(path.Child("resourcePatternTypeFilter"), b.ResourcePatternTypeFilter, []string{"Literal", "Prefixed"}))
+	}
+	if known && b.ResourceOperation != "" && !contains(ops, b.ResourceOperation) {
+		errs = append(errs, field.NotSupported(path.Child("resourceOperation"), b.ResourceOperation, ops))
+	}
+
+	if b.ResourceType == "Cluster" {
+		if b.ResourceName != "" && b.ResourceName != clusterResourceName {
+			errs = append(errs, field.Invalid(path.Child("resourceName"), b.ResourceName, "the Cluster resource type only has a resource named "+clusterResourceName+"; omit resourceName or set it to "+clusterResourceName))
+		}
+		if b.ResourcePatternTypeFilter == "Prefixed" {
+			errs = append(errs, field.Invalid(path.Child("resourcePatternTypeFilter"), b.ResourcePatternTypeFilter, "the Cluster resource type only supports Literal"))
+		}
+	}
+	if b.ResourceName == "*" && b.ResourcePatternTypeFilter == "Prefixed" {
+		errs = append(errs, field.Invalid(path.Child("resourcePatternTypeFilter"), b.ResourcePatternTypeFilter, "the wildcard resourceName * only matches all resources with Literal; a Prefixed ACL would only match names starting with *"))
+	}
+	return errs
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
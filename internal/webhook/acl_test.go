@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+)
+
+func TestACLValidateCreate(t *testing.T) {
+	binding := func(typ, name, op, pattern string) v1alpha1.AccessControlListBinding {
+		return v1alpha1.AccessControlListBinding{
+			ResourceType:              typ,
+			ResourceName:              name,
+			ResourcePrincipal:         "User:app",
+			ResourceOperation:         op,
+			ResourcePermissionType:    "Allow",
+			ResourcePatternTypeFilter: pattern,
+		}
+	}
+	single := func(b v1alpha1.AccessControlListBinding) *v1alpha1.AccessControlList {
+		cr := &v1alpha1.AccessControlList{}
+		cr.Spec.ForProvider = v1alpha1.AccessControlListParameters{
+			ResourceType:              b.ResourceType,
+			ResourceName:              b.ResourceName,
+			ResourcePrincipal:         b.ResourcePrincipal,
+			ResourceOperation:         b.ResourceOperation,
+			ResourcePermissionType:    b.ResourcePermissionType,
+			ResourcePatternTypeFilter: b.ResourcePatternTypeFilter,
+		}
+		return cr
+	}
+	bulk := func(b ...v1alpha1.AccessControlListBinding) *v1alpha1.AccessControlList {
+		cr := &v1alpha1.AccessControlList{}
+		cr.Spec.ForProvider.Bindings = b
+		return cr
+	}
+
+	cases := map[string]struct {
+		reason  string
+		cr      *v1alpha1.AccessControlList
+		wantErr bool
+	}{
+		"ValidTopic": {
+			reason: "A Topic ACL with a Topic operation should be allowed.",
+			cr:     single(binding("Topic", "orders", "Write", "Literal")),
+		},
+		"GroupWrite": {
+			reason:  "Groups cannot be written to.",
+			cr:      single(binding("Group", "billing", "Write", "Literal")),
+			wantErr: true,
+		},
+		"AnyResourceType": {
+			reason:  "The Any resource type only matches ACLs, so it cannot be created.",
+			cr:      single(binding("Any", "orders", "Read", "Literal")),
+			wantErr: true,
+		},
+		"MatchPattern": {
+			reason:  "The Match pattern type only matches ACLs, so it cannot be created.",
+			cr:      single(binding("Topic", "orders", "Read", "Match")),
+			wantErr: true,
+		},
+		"ClusterUnnamed": {
+			reason: "A Cluster ACL may omit the resource name.",
+			cr:     single(binding("Cluster", "", "IdempotentWrite", "Literal")),
+		},
+		"ClusterNamed": {
+			reason: "A Cluster ACL may name the only cluster resource.",
+			cr:     single(binding("Cluster", "kafka-cluster", "Describe", "Literal")),
+		},
+		"ClusterMisnamed": {
+			reason:  "A Cluster ACL cannot name another resource.",
+			cr:      single(binding("Cluster", "prod", "Describe", "Literal")),
+			wantErr: true,
+		},
+		"ClusterPrefixed": {
+			reason:  "A Cluster ACL cannot be Prefixed.",
+			cr:      single(binding("Cluster", "", "Describe", "Prefixed")),
+			wantErr: true,
+		},
+		"PrefixedWildcard": {
+			reason:  "The wildcard resource name only applies to Literal ACLs.",
+			cr:      single(binding("Topic", "*", "Read", "Prefixed")),
+			wantErr: true,
+		},
+		"InvalidBinding": {
+			reason:  "Every binding should be validated.",
+			cr:      bulk(binding("Topic", "orders", "Read", "Literal"), binding("Group", "billing", "IdempotentWrite", "Literal")),
+			wantErr: true,
+		},
+		"TopicRef": {
+			reason: "A Topic ACL whose resource name is resolved from a Topic should be allowed.",
+			cr:     single(binding("Topic", "", "Read", "Prefixed")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := (&aclValidator{}).ValidateCreate(context.Background(), tc.cr)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("\n%s\nValidateCreate(...): error = %v, wantErr %t", tc.reason, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestACLValidateUpdate(t *testing.T) {
+	invalid := &v1alpha1.AccessControlList{}
+	invalid.Spec.ForProvider.ResourceType = "Group"
+	invalid.Spec.ForProvider.ResourceOperation = "Write"
+
+	finalized := invalid.DeepCopy()
+	finalized.SetFinalizers(nil)
+	if _, err := (&aclValidator{}).ValidateUpdate(context.Background(), invalid, finalized); err != nil {
+		t.Errorf("ValidateUpdate(...): an update leaving the ACL alone should be allowed, got %v", err)
+	}
+
+	changed := invalid.DeepCopy()
+	changed.Spec.ForProvider.ResourceName = "billing"
+	if _, err := (&aclValidator{}).ValidateUpdate(context.Background(), invalid, changed); err == nil {
+		t.Errorf("ValidateUpdate(...): an update changing an invalid ACL should be rejected")
+	}
+}
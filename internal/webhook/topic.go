@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+const (
+	errNotTopic                = "object is not a Topic"
+	errReplicationFactorChange = "cannot decrease the replicationFactor of existing topic %q from %d to %d: the provider only increases replication factors. " +
+		"Reassign its partitions with kafka-reassign-partitions instead, then set replicationFactor to the new number of replicas"
+)
+
+// +kubebuilder:webhook:verbs=update,path=/validate-topic-kafka-crossplane-io-v1alpha1-topic,mutating=false,failurePolicy=fail,groups=topic.kafka.crossplane.io,resources=topics,versions=v1alpha1,name=topics.topic.kafka.crossplane.io,sideEffects=None,admissionReviewVersions=v1
+
+// A topicValidator rejects changes to Topics the provider cannot apply.
+type topicValidator struct{}
+
+func (v *topicValidator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate rejects decreases of the replicationFactor of a Topic whose
+// topic exists, unless the change matches the replication factor the topic
+// was last observed with, e.g. after its partitions were reassigned.
+// Increases are planned and applied by the provider.
+func (v *topicValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	o, ok := oldObj.(*v1alpha1.Topic)
+	if !ok {
+		return nil, errors.New(errNotTopic)
+	}
+	n, ok := newObj.(*v1alpha1.Topic)
+	if !ok {
+		return nil, errors.New(errNotTopic)
+	}
+
+	from, to := o.Spec.ForProvider.ReplicationFactor, n.Spec.ForProvider.ReplicationFactor
+	observed := o.Status.AtProvider.ReplicationFactor
+	// Topics placed by a replica assignment have no replicationFactor, and
+	// topics never observed may still be created with any.
+	if from == to || to == 0 || observed == 0 || to >= observed {
+		return nil, nil
+	}
+	name := o.Status.AtProvider.TopicName
+	if name == "" {
+		name = o.GetName()
+	}
+	return nil, errors.Errorf(errReplicationFactorChange, name, observed, to)
+}
+
+func (v *topicValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+func TestTopicValidateUpdate(t *testing.T) {
+	topic := func(replicationFactor, observed int) *v1alpha1.Topic {
+		cr := &v1alpha1.Topic{}
+		cr.SetName("orders")
+		cr.Spec.ForProvider.ReplicationFactor = replicationFactor
+		cr.Status.AtProvider.ReplicationFactor = observed
+		return cr
+	}
+
+	cases := map[string]struct {
+		reason  string
+		old     *v1alpha1.Topic
+		new     *v1alpha1.Topic
+		wantErr bool
+	}{
+		"Unchanged": {
+			reason: "An update leaving the replicationFactor alone should be allowed.",
+			old:    topic(3, 3),
+			new:    topic(3, 3),
+		},
+		"DecreasedOnExistingTopic": {
+			reason:  "Decreasing the replicationFactor of an existing topic should be rejected.",
+			old:     topic(3, 3),
+			new:     topic(2, 3),
+			wantErr: true,
+		},
+		"IncreasedOnExistingTopic": {
+			reason: "Increasing the replicationFactor of an existing topic should be allowed, as the provider plans it.",
+			old:    topic(2, 2),
+			new:    topic(3, 2),
+		},
+		"ChangedBeforeObserved": {
+			reason: "Changing the replicationFactor of a topic that was never observed should be allowed.",
+			old:    topic(3, 0),
+			new:    topic(2, 0),
+		},
+		"MatchesObserved": {
+			reason: "Setting the replicationFactor the topic was reassigned to should be allowed.",
+			old:    topic(3, 2),
+			new:    topic(2, 2),
+		},
+		"ReplicaAssignment": {
+			reason: "Switching to a replica assignment should be allowed.",
+			old:    topic(3, 3),
+			new:    topic(0, 3),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := (&topicValidator{}).ValidateUpdate(context.Background(), tc.old, tc.new)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("\n%s\nValidateUpdate(...): error = %v, wantErr %t", tc.reason, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -1,3 +1,4 @@
+// Package acl creates, observes and deletes Kafka access control lists.
 package acl
 
 import (
@@ -7,12 +8,23 @@ import (
 	"strings"
 
 	"github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
 
 	"github.com/pkg/errors"
 	"github.com/twmb/franz-go/pkg/kadm"
 	"github.com/twmb/franz-go/pkg/kmsg"
 )
 
+const (
+	// wildcardHost is the host of ACLs that apply to every host.
+	wildcardHost = "*"
+
+	errUnsupportedPermission = "unsupported permission type %q: must be Allow or Deny"
+	errUnsupportedOperation  = "unsupported operation %q: Any and Unknown only filter ACLs"
+	errUnsupportedPattern    = "unsupported pattern type %q: must be Prefixed or Literal"
+	errUnsupportedType       = "unsupported resource type %q: must be Topic, Group, Cluster or TransactionalID"
+)
+
 // AccessControlList is a holistic representation of a Kafka ACL with configurable
 // fields
 type AccessControlList struct {
@@ -26,77 +38,38 @@ type AccessControlList struct {
 }
 
 // List lists all the ACLs in Kafka
-func List(ctx context.Context, cl *kadm.Client, accessControlList *AccessControlList) (*AccessControlList, error) { //nolint:gocyclo
-
-	o, err := kmsg.ParseACLOperation(strings.ToLower(accessControlList.ResourceOperation))
-	if err != nil {
-		return nil, errors.Wrap(err, "did not return ACL Operation")
-	}
-
-	ao := []kadm.ACLOperation{o}
-
-	rpt, err := kmsg.ParseACLResourcePatternType(strings.ToLower(accessControlList.ResourcePatternTypeFilter))
+func List(ctx context.Context, cl *kafka.Client, accessControlList *AccessControlList) (*AccessControlList, error) {
+	ab, err := newBuilder(accessControlList)
 	if err != nil {
-		return nil, errors.Wrap(err, "did not return parsing of ACL pattern")
-	}
-
-	b := kadm.ACLBuilder{}
-	ab := b.Allow(accessControlList.ResourcePrincipal).AllowHosts(accessControlList.ResourceHost).Operations(ao[0]).ResourcePatternType(rpt)
-
-	switch accessControlList.ResourceType {
-	case "Topic":
-		ab = ab.Topics(accessControlList.ResourceName)
-	case "Group":
-		ab = ab.Groups(accessControlList.ResourceName)
-	case "TransactionalID":
-		ab = ab.TransactionalIDs(accessControlList.ResourceName)
-	case "Cluster":
-		ab = ab.Clusters()
-	case "Any":
-		ab = ab.AnyResource(accessControlList.ResourceName)
+		return nil, err
 	}
 
 	resp, err := cl.DescribeACLs(ctx, ab)
 	if err != nil {
 		return nil, errors.Wrap(err, "describe ACLs response is empty")
 	}
-	if exists := resp[0].Described; len(exists) == 0 {
-		return nil, nil
+	if resp[0].Err != nil {
+		return nil, errors.Wrap(resp[0].Err, "cannot describe ACLs")
 	}
 
-	acl := AccessControlList{}
-	acl.ResourceType = accessControlList.ResourceType
-	acl.ResourcePrincipal = accessControlList.ResourcePrincipal
-	acl.ResourceHost = accessControlList.ResourceHost
-	acl.ResourceOperation = accessControlList.ResourceOperation
-	acl.ResourcePermissionType = accessControlList.ResourcePermissionType
-	acl.ResourcePatternTypeFilter = accessControlList.ResourcePatternTypeFilter
+	// The describe filter may match more than the ACL we are looking for, so
+	// only an ACL that matches every field, including its permission type and
+	// host, counts as existing.
+	for _, d := range resp[0].Described {
+		if matches(accessControlList, d) {
+			acl := *accessControlList
+			return &acl, nil
+		}
+	}
 
-	return &acl, nil
+	return nil, nil
 }
 
 // Create creates an ACL from the Kafka side
-func Create(ctx context.Context, cl *kadm.Client, accessControlList *AccessControlList) error {
-
-	o, _ := kmsg.ParseACLOperation(strings.ToLower(accessControlList.ResourceOperation))
-	ao := []kadm.ACLOperation{o}
-
-	rpt, _ := kmsg.ParseACLResourcePatternType(strings.ToLower(accessControlList.ResourcePatternTypeFilter))
-
-	b := kadm.ACLBuilder{}
-	ab := b.Allow(accessControlList.ResourcePrincipal).AllowHosts(accessControlList.ResourceHost).Operations(ao[0]).ResourcePatternType(rpt)
-
-	switch accessControlList.ResourceType {
-	case "Topic":
-		ab = ab.Topics(accessControlList.ResourceName)
-	case "Group":
-		ab = ab.Groups(accessControlList.ResourceName)
-	case "TransactionalID":
-		ab = ab.TransactionalIDs(accessControlList.ResourceName)
-	case "Cluster":
-		ab = ab.Clusters()
-	case "Any":
-		ab = ab.AnyResource(accessControlList.ResourceName)
+func Create(ctx context.Context, cl *kafka.Client, accessControlList *AccessControlList) error {
+	ab, err := newBuilder(accessControlList)
+	if err != nil {
+		return err
 	}
 
 	resp, err := cl.CreateACLs(ctx, ab)
@@ -114,37 +87,97 @@ func Create(ctx context.Context, cl *kadm.Client, accessControlList *AccessContr
 }
 
 // Delete creates an ACL from the Kafka side
-func Delete(ctx context.Context, cl *kadm.Client, accessControlList *AccessControlList) error {
+func Delete(ctx context.Context, cl *kafka.Client, accessControlList *AccessControlList) error {
+	ab, err := newBuilder(accessControlList)
+	if err != nil {
+		return err
+	}
+
+	resp, err := cl.DeleteACLs(ctx, ab)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Delete Response:", resp)
+
+	return nil
+}
+
+// host returns the host of the supplied ACL, defaulting to the wildcard host.
+func host(accessControlList *AccessControlList) string {
+	if accessControlList.ResourceHost == "" {
+		return wildcardHost
+	}
+	return accessControlList.ResourceHost
+}
 
-	o, _ := kmsg.ParseACLOperation(strings.ToLower(accessControlList.ResourceOperation))
-	ao := []kadm.ACLOperation{o}
+// newBuilder returns an ACL builder that matches exactly the supplied ACL,
+// including whether it allows or denies the operation and for which host.
+// Values that only filter ACLs, such as the Any operation or the Match pattern
+// type, are refused, since the ACL could then neither be created nor matched.
+func newBuilder(accessControlList *AccessControlList) (*kadm.ACLBuilder, error) {
+	o, err := kmsg.ParseACLOperation(strings.ToLower(accessControlList.ResourceOperation))
+	if err != nil {
+		return nil, errors.Wrap(err, "did not return ACL Operation")
+	}
+	if o == kmsg.ACLOperationAny || o == kmsg.ACLOperationUnknown {
+		return nil, errors.Errorf(errUnsupportedOperation, accessControlList.ResourceOperation)
+	}
 
-	rpt, _ := kmsg.ParseACLResourcePatternType(strings.ToLower(accessControlList.ResourcePatternTypeFilter))
+	rpt, err := kmsg.ParseACLResourcePatternType(strings.ToLower(accessControlList.ResourcePatternTypeFilter))
+	if err != nil {
+		return nil, errors.Wrap(err, "did not return parsing of ACL pattern")
+	}
+	if rpt != kmsg.ACLResourcePatternTypeLiteral && rpt != kmsg.ACLResourcePatternTypePrefixed {
+		return nil, errors.Errorf(errUnsupportedPattern, accessControlList.ResourcePatternTypeFilter)
+	}
 
-	b := kadm.ACLBuilder{}
-	ab := b.Allow(accessControlList.ResourcePrincipal).AllowHosts(accessControlList.ResourceHost).Operations(ao[0]).ResourcePatternType(rpt)
+	b := &kadm.ACLBuilder{}
+	switch accessControlList.ResourcePermissionType {
+	case "Allow":
+		b = b.Allow(accessControlList.ResourcePrincipal).AllowHosts(host(accessControlList))
+	case "Deny":
+		b = b.Deny(accessControlList.ResourcePrincipal).DenyHosts(host(accessControlList))
+	default:
+		return nil, errors.Errorf(errUnsupportedPermission, accessControlList.ResourcePermissionType)
+	}
+	b = b.Operations(o).ResourcePatternType(rpt)
 
 	switch accessControlList.ResourceType {
 	case "Topic":
-		ab = ab.Topics(accessControlList.ResourceName)
+		b = b.Topics(accessControlList.ResourceName)
 	case "Group":
-		ab = ab.Groups(accessControlList.ResourceName)
+		b = b.Groups(accessControlList.ResourceName)
 	case "TransactionalID":
-		ab = ab.TransactionalIDs(accessControlList.ResourceName)
+		b = b.TransactionalIDs(accessControlList.ResourceName)
 	case "Cluster":
-		ab = ab.Clusters()
-	case "Any":
-		ab = ab.AnyResource(accessControlList.ResourceName)
-	}
-
-	resp, err := cl.DeleteACLs(ctx, ab)
-	if err != nil {
-		return err
+		b = b.Clusters()
+	default:
+		return nil, errors.Errorf(errUnsupportedType, accessControlList.ResourceType)
 	}
 
-	fmt.Println("Delete Response:", resp)
+	return b, nil
+}
 
-	return nil
+// matches returns true if the described ACL is the supplied ACL.
+func matches(accessControlList *AccessControlList, d kadm.DescribedACL) bool {
+	if d.Principal != accessControlList.ResourcePrincipal || d.Host != host(accessControlList) {
+		return false
+	}
+	if p, err := kmsg.ParseACLPermissionType(strings.ToLower(accessControlList.ResourcePermissionType)); err != nil || p != d.Permission {
+		return false
+	}
+	if o, err := kmsg.ParseACLOperation(strings.ToLower(accessControlList.ResourceOperation)); err != nil || o != d.Operation {
+		return false
+	}
+	if t, err := kmsg.ParseACLResourceType(strings.ToLower(accessControlList.ResourceType)); err != nil || t != d.Type {
+		return false
+	}
+	if rpt, err := kmsg.ParseACLResourcePatternType(strings.ToLower(accessControlList.ResourcePatternTypeFilter)); err != nil || rpt != d.Pattern {
+		return false
+	}
+	// There is only one cluster resource, whose name is always kafka-cluster.
+	return accessControlList.ResourceType == "Cluster" || d.Name == accessControlList.ResourceName
 }
 
 // ConvertToJSON performs a json marshalling for ACLs
@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	"github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
 
 	"k8s.io/apimachinery/pkg/util/json"
 )
@@ -257,7 +259,7 @@ func TestConvertToJSON(t *testing.T) {
 func TestCreate(t *testing.T) {
 	type args struct {
 		ctx               context.Context
-		cl                *kadm.Client
+		cl                *kafka.Client
 		accessControlList *AccessControlList
 	}
 	tests := []struct {
@@ -279,7 +281,7 @@ func TestCreate(t *testing.T) {
 func TestDelete(t *testing.T) {
 	type args struct {
 		ctx               context.Context
-		cl                *kadm.Client
+		cl                *kafka.Client
 		accessControlList *AccessControlList
 	}
 	tests := []struct {
@@ -342,7 +344,7 @@ func TestIsUpToDate(t *testing.T) {
 func TestList(t *testing.T) {
 	type args struct {
 		ctx               context.Context
-		cl                *kadm.Client
+		cl                *kafka.Client
 		accessControlList *AccessControlList
 	}
 	tests := []struct {
@@ -366,3 +368,82 @@ func TestList(t *testing.T) {
 		})
 	}
 }
+
+func TestNewBuilder(t *testing.T) {
+	deny := baseACL
+	deny.ResourcePermissionType = "Deny"
+	unsupported := baseACL
+	unsupported.ResourcePermissionType = "Any"
+	anyOperation := baseACL
+	anyOperation.ResourceOperation = "Any"
+	matchPattern := baseACL
+	matchPattern.ResourcePatternTypeFilter = "Match"
+	anyPattern := baseACL
+	anyPattern.ResourcePatternTypeFilter = "Any"
+	anyType := baseACL
+	anyType.ResourceType = "Any"
+
+	tests := []struct {
+		name    string
+		acl     AccessControlList
+		wantErr bool
+	}{
+		{name: "Allow", acl: baseACL},
+		{name: "Deny", acl: deny},
+		{name: "UnsupportedPermissionType", acl: unsupported, wantErr: true},
+		{name: "AnyOperation", acl: anyOperation, wantErr: true},
+		{name: "MatchPatternType", acl: matchPattern, wantErr: true},
+		{name: "AnyPatternType", acl: anyPattern, wantErr: true},
+		{name: "AnyResourceType", acl: anyType, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newBuilder(&tt.acl)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newBuilder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	described := kadm.DescribedACL{
+		Principal:  "User:Ken",
+		Host:       "*",
+		Type:       kmsg.ACLResourceTypeTopic,
+		Name:       "acl1",
+		Pattern:    kmsg.ACLResourcePatternTypeLiteral,
+		Operation:  kmsg.ACLOperationAlterConfigs,
+		Permission: kmsg.ACLPermissionTypeAllow,
+	}
+	denied := described
+	denied.Permission = kmsg.ACLPermissionTypeDeny
+	otherHost := described
+	otherHost.Host = "10.0.0.12"
+
+	emptyHost := baseACL
+	emptyHost.ResourceHost = ""
+	deny := baseACL
+	deny.ResourcePermissionType = "Deny"
+
+	tests := []struct {
+		name      string
+		acl       AccessControlList
+		described kadm.DescribedACL
+		want      bool
+	}{
+		{name: "Identical", acl: baseACL, described: described, want: true},
+		{name: "EmptyHostIsWildcard", acl: emptyHost, described: described, want: true},
+		{name: "Deny", acl: deny, described: denied, want: true},
+		{name: "DenyIsNotAllow", acl: deny, described: described, want: false},
+		{name: "AllowIsNotDeny", acl: baseACL, described: denied, want: false},
+		{name: "HostDiffers", acl: baseACL, described: otherHost, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matches(&tt.acl, tt.described); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package acl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const (
+	// clusterName is the name of the only cluster resource.
+	clusterName = "kafka-cluster"
+
+	errDescribeBindings = "cannot describe ACLs of principal %q"
+	errCreateBindings   = "cannot create ACL bindings"
+	errDeleteBindings   = "cannot delete ACL bindings"
+	errBindingFailed    = "binding %d: %s"
+)
+
+// defaultTransactionalIDOperations are the operations on its transactional
+// IDs a transactional producer needs.
+var defaultTransactionalIDOperations = []v1alpha1.TransactionalIDOperation{
+	v1alpha1.TransactionalIDOperationWrite,
+	v1alpha1.TransactionalIDOperationDescribe,
+}
+
+// GenerateDesired returns the ACLs of an AccessControlList in bulk mode.
+func GenerateDesired(params *v1alpha1.AccessControlListParameters) []AccessControlList {
+	if params.TransactionalID != nil {
+		return GenerateTransactionalID(params.TransactionalID)
+	}
+	return GenerateBindings(params.Bindings)
+}
+
+// GenerateTransactionalID converts a Crossplane TransactionalIDAccessControl
+// to Kafka's AccessControlLists, one per operation.
+func GenerateTransactionalID(t *v1alpha1.TransactionalIDAccessControl) []AccessControlList {
+	ops := t.Operations
+	if len(ops) == 0 {
+		ops = defaultTransactionalIDOperations
+	}
+	pattern := "Literal"
+	if t.Prefixed {
+		pattern = "Prefixed"
+	}
+	out := make([]AccessControlList, 0, len(ops))
+	for _, o := range ops {
+		out = append(out, AccessControlList{
+			ResourceName:              t.ID,
+			ResourceType:              "TransactionalID",
+			ResourcePrincipal:         t.Principal,
+			ResourceHost:              host(&AccessControlList{ResourceHost: t.Host}),
+			ResourceOperation:         string(o),
+			ResourcePermissionType:    "Allow",
+			ResourcePatternTypeFilter: pattern,
+		})
+	}
+	return out
+}
+
+// GenerateBindings converts the bindings of Crossplane
+// AccessControlListParameters to Kafka's AccessControlLists.
+func GenerateBindings(bindings []v1alpha1.AccessControlListBinding) []AccessControlList {
+	out := make([]AccessControlList, 0, len(bindings))
+	for _, b := range bindings {
+		out = append(out, AccessControlList{
+			ResourceName:              b.ResourceName,
+			ResourceType:              b.ResourceType,
+			ResourcePrincipal:         b.ResourcePrincipal,
+			ResourceHost:              host(&AccessControlList{ResourceHost: b.ResourceHost}),
+			ResourceOperation:         b.ResourceOperation,
+			ResourcePermissionType:    b.ResourcePermissionType,
+			ResourcePatternTypeFilter: b.ResourcePatternTypeFilter,
+		})
+	}
+	return out
+}
+
+// Bindings converts Kafka's AccessControlLists to Crossplane bindings.
+func Bindings(acls []AccessControlList) []v1alpha1.AccessControlListBinding {
+	if len(acls) == 0 {
+		return nil
+	}
+	out := make([]v1alpha1.AccessControlListBinding, 0, len(acls))
+	for _, a := range acls {
+		out = append(out, v1alpha1.AccessControlListBinding{
+			ResourceName:              a.ResourceName,
+			ResourceType:              a.ResourceType,
+			ResourcePrincipal:         a.ResourcePrincipal,
+			ResourceHost:              a.ResourceHost,
+			ResourceOperation:         a.ResourceOperation,
+			ResourcePermissionType:    a.ResourcePermissionType,
+			ResourcePatternTypeFilter: a.ResourcePatternTypeFilter,
+		})
+	}
+	return out
+}
+
+// Union returns the supplied ACLs without duplicates, in order of appearance.
+func Union(acls ...[]AccessControlList) []AccessControlList {
+	seen := map[AccessControlList]bool{}
+	out := []AccessControlList{}
+	for _, l := range acls {
+		for _, a := range l {
+			if !seen[a] {
+				seen[a] = true
+				out = append(out, a)
+			}
+		}
+	}
+	return out
+}
+
+// Subtract returns the ACLs of a that are not in b.
+func Subtract(a, b []AccessControlList) []AccessControlList {
+	in := map[AccessControlList]bool{}
+	for _, acl := range b {
+		in[acl] = true
+	}
+	out := []AccessControlList{}
+	for _, acl := range a {
+		if !in[acl] {
+			out = append(out, acl)
+		}
+	}
+	return out
+}
+
+// Existing returns those of the supplied ACLs that exist in Kafka. ACLs are
+// described once per principal rather than once per ACL.
+func Existing(ctx context.Context, cl *kafka.Client, acls []AccessControlList) ([]AccessControlList, error) {
+	described := map[string][]kadm.DescribedACL{}
+	out := []AccessControlList{}
+	for i := range acls {
+		a := &acls[i]
+		d, ok := described[a.ResourcePrincipal]
+		if !ok {
+			var err error
+			if d, err = describe(ctx, cl, &a.ResourcePrincipal); err != nil {
+				return nil, errors.Wrapf(err, errDescribeBindings, a.ResourcePrincipal)
+			}
+			described[a.ResourcePrincipal] = d
+		}
+		for _, dacl := range d {
+			if matches(a, dacl) {
+				out = append(out, *a)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// describe returns every ACL of the supplied principal, or of every principal
+// if it is nil.
+func describe(ctx context.Context, cl *kafka.Client, principal *string) ([]kadm.DescribedACL, error) {
+	req := kmsg.NewPtrDescribeACLsRequest()
+	req.ResourceType = kmsg.ACLResourceTypeAny
+	req.ResourcePatternType = kmsg.ACLResourcePatternTypeAny
+	req.Principal = principal
+	req.Operation = kmsg.ACLOperationAny
+	req.PermissionType = kmsg.ACLPermissionTypeAny
+
+	r, err := cl.Request(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp := r.(*kmsg.DescribeACLsResponse)
+	if err := kerr.ErrorForCode(resp.ErrorCode); err != nil {
+		return nil, err
+	}
+
+	var out []kadm.DescribedACL
+	for _, res := range resp.Resources {
+		for _, a := range res.ACLs {
+			out = append(out, kadm.DescribedACL{
+				Principal:  a.Principal,
+				Host:       a.Host,
+				Type:       res.ResourceType,
+				Name:       res.ResourceName,
+				Pattern:    res.ResourcePatternType,
+				Operation:  a.Operation,
+				Permission: a.PermissionType,
+			})
+		}
+	}
+	return out, nil
+}
+
+// CreateAll creates the supplied ACLs in a single request, so that brokers
+// either see all of them or, if the request fails, none of them.
+func CreateAll(ctx context.Context, cl *kafka.Client, acls []AccessControlList) error {
+	if len(acls) == 0 {
+		return nil
+	}
+	req := kmsg.NewPtrCreateACLsRequest()
+	for i := range acls {
+		f, err := toFilter(&acls[i])
+		if err != nil {
+			return err
+		}
+		c := kmsg.NewCreateACLsRequestCreation()
+		c.ResourceType, c.ResourceName, c.ResourcePatternType = f.ResourceType, *f.ResourceName, f.ResourcePatternType
+		c.Principal, c.Host = *f.Principal, *f.Host
+		c.Operation, c.PermissionType = f.Operation, f.PermissionType
+		req.Creations = append(req.Creations, c)
+	}
+
+	r, err := cl.Request(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, errCreateBindings)
+	}
+	failed := []string{}
+	for i, res := range r.(*kmsg.CreateACLsResponse).Results {
+		if err := kerr.ErrorForCode(res.ErrorCode); err != nil {
+			failed = append(failed, fmt.Sprintf(errBindingFailed, i, err))
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("%s: %s", errCreateBindings, strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// DeleteAll deletes the supplied ACLs in a single request. Each ACL is
+// matched exactly, so that no other ACL of the same resource is deleted.
+func DeleteAll(ctx context.Context, cl *kafka.Client, acls []AccessControlList) error {
+	if len(acls) == 0 {
+		return nil
+	}
+	req := kmsg.NewPtrDeleteACLsRequest()
+	for i := range acls {
+		f, err := toFilter(&acls[i])
+		if err != nil {
+			return err
+		}
+		req.Filters = append(req.Filters, f)
+	}
+
+	r, err := cl.Request(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, errDeleteBindings)
+	}
+	failed := []string{}
+	for i, res := range r.(*kmsg.DeleteACLsResponse).Results {
+		if err := kerr.ErrorForCode(res.ErrorCode); err != nil {
+			failed = append(failed, fmt.Sprintf(errBindingFailed, i, err))
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("%s: %s", errDeleteBindings, strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// toFilter returns a filter matching exactly the supplied ACL.
+func toFilter(accessControlList *AccessControlList) (kmsg.DeleteACLsRequestFilter, error) {
+	f := kmsg.NewDeleteACLsRequestFilter()
+	t, err := kmsg.ParseACLResourceType(strings.ToLower(accessControlList.ResourceType))
+	if err != nil {
+		return f, errors.Wrap(err, "did not return ACL resource type")
+	}
+	rpt, err := kmsg.ParseACLResourcePatternType(strings.ToLower(accessControlList.ResourcePatternTypeFilter))
+	if err != nil {
+		return f, errors.Wrap(err, "did not return parsing of ACL pattern")
+	}
+	o, err := kmsg.ParseACLOperation(strings.ToLower(accessControlList.ResourceOperation))
+	if err != nil {
+		return f, errors.Wrap(err, "did not return ACL Operation")
+	}
+	p, err := kmsg.ParseACLPermissionType(strings.ToLower(accessControlList.ResourcePermissionType))
+	if err != nil || (p != kmsg.ACLPermissionTypeAllow && p != kmsg.ACLPermissionTypeDeny) {
+		return f, errors.Errorf(errUnsupportedPermission, accessControlList.ResourcePermissionType)
+	}
+
+	name := accessControlList.ResourceName
+	if t == kmsg.ACLResourceTypeCluster {
+		name = clusterName
+	}
+	h := host(accessControlList)
+	principal := accessControlList.ResourcePrincipal
+
+	f.ResourceType, f.ResourceName, f.ResourcePatternType = t, &name, rpt
+	f.Principal, f.Host = &principal, &h
+	f.Operation, f.PermissionType = o, p
+	return f, nil
+}
@@ -0,0 +1,141 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+)
+
+func TestGenerateBindings(t *testing.T) {
+	bindings := []v1alpha1.AccessControlListBinding{{
+		ResourceName:              "acl1",
+		ResourceType:              "Topic",
+		ResourcePrincipal:         "User:Ken",
+		ResourceOperation:         "AlterConfigs",
+		ResourcePermissionType:    "Allow",
+		ResourcePatternTypeFilter: "Literal",
+	}}
+
+	got := GenerateBindings(bindings)
+	if diff := cmp.Diff([]AccessControlList{baseACL}, got); diff != "" {
+		t.Errorf("GenerateBindings(...): -want, +got:\n%s", diff)
+	}
+
+	// The host is recorded defaulted, so that bindings recorded in status
+	// compare equal to those generated from the spec.
+	bindings[0].ResourceHost = "*"
+	if diff := cmp.Diff(bindings, Bindings(got)); diff != "" {
+		t.Errorf("Bindings(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestUnionSubtract(t *testing.T) {
+	read := baseACL
+	read.ResourceOperation = "Read"
+	write := baseACL
+	write.ResourceOperation = "Write"
+
+	desired := []AccessControlList{baseACL, read}
+	recorded := []AccessControlList{read, write}
+
+	if diff := cmp.Diff([]AccessControlList{baseACL, read, write}, Union(desired, recorded)); diff != "" {
+		t.Errorf("Union(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]AccessControlList{baseACL}, Subtract(desired, recorded)); diff != "" {
+		t.Errorf("Subtract(desired, recorded): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]AccessControlList{write}, Subtract(recorded, desired)); diff != "" {
+		t.Errorf("Subtract(recorded, desired): -want, +got:\n%s", diff)
+	}
+}
+
+func TestToFilter(t *testing.T) {
+	cluster := baseACL
+	cluster.ResourceType = "Cluster"
+	cluster.ResourceName = ""
+	cluster.ResourceHost = ""
+	anyPermission := baseACL
+	anyPermission.ResourcePermissionType = "Any"
+
+	name, clusterName, principal, host := "acl1", "kafka-cluster", "User:Ken", "*"
+	want := kmsg.NewDeleteACLsRequestFilter()
+	want.ResourceType = kmsg.ACLResourceTypeTopic
+	want.ResourceName = &name
+	want.ResourcePatternType = kmsg.ACLResourcePatternTypeLiteral
+	want.Principal = &principal
+	want.Host = &host
+	want.Operation = kmsg.ACLOperationAlterConfigs
+	want.PermissionType = kmsg.ACLPermissionTypeAllow
+	wantCluster := want
+	wantCluster.ResourceType = kmsg.ACLResourceTypeCluster
+	wantCluster.ResourceName = &clusterName
+
+	tests := []struct {
+		name    string
+		acl     AccessControlList
+		want    kmsg.DeleteACLsRequestFilter
+		wantErr bool
+	}{
+		{name: "Topic", acl: baseACL, want: want},
+		{name: "ClusterWithDefaultHost", acl: cluster, want: wantCluster},
+		{name: "AnyPermission", acl: anyPermission, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toFilter(&tt.acl)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("toFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(kmsg.DeleteACLsRequestFilter{}, "UnknownTags")); diff != "" {
+				t.Errorf("toFilter(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateTransactionalID(t *testing.T) {
+	txn := func(op string, pattern string) AccessControlList {
+		return AccessControlList{
+			ResourceName:              "orders-",
+			ResourceType:              "TransactionalID",
+			ResourcePrincipal:         "User:orders",
+			ResourceHost:              "*",
+			ResourceOperation:         op,
+			ResourcePermissionType:    "Allow",
+			ResourcePatternTypeFilter: pattern,
+		}
+	}
+
+	tests := map[string]struct {
+		t    v1alpha1.TransactionalIDAccessControl
+		want []AccessControlList
+	}{
+		"DefaultOperations": {
+			t:    v1alpha1.TransactionalIDAccessControl{ID: "orders-", Principal: "User:orders"},
+			want: []AccessControlList{txn("Write", "Literal"), txn("Describe", "Literal")},
+		},
+		"PrefixedDescribeOnly": {
+			t: v1alpha1.TransactionalIDAccessControl{
+				ID:         "orders-",
+				Prefixed:   true,
+				Principal:  "User:orders",
+				Operations: []v1alpha1.TransactionalIDOperation{v1alpha1.TransactionalIDOperationDescribe},
+			},
+			want: []AccessControlList{txn("Describe", "Prefixed")},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, GenerateDesired(&v1alpha1.AccessControlListParameters{TransactionalID: &tt.t})); diff != "" {
+				t.Errorf("GenerateDesired(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package acl
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const errDescribeAll = "cannot describe ACLs"
+
+// The values of the fields of an AccessControlList that can be managed, as
+// the AccessControlList CRD spells them.
+var (
+	resourceTypes   = []string{"Topic", "Group", "Cluster", "TransactionalID"}
+	operations      = []string{"All", "Read", "Write", "Create", "Delete", "Alter", "Describe", "ClusterAction", "DescribeConfigs", "AlterConfigs", "IdempotentWrite"}
+	permissionTypes = []string{"Allow", "Deny"}
+	patternTypes    = []string{"Literal", "Prefixed"}
+)
+
+// DescribeAll returns the ACLs of the supplied principals, or every ACL of the
+// cluster if none is supplied, sorted by principal, resource and operation.
+// The second return value counts the ACLs that cannot be managed by an
+// AccessControlList, e.g. those of delegation tokens, which are skipped.
+func DescribeAll(ctx context.Context, cl *kafka.Client, principals ...string) ([]AccessControlList, int, error) {
+	var described []kadm.DescribedACL
+	if len(principals) == 0 {
+		d, err := describe(ctx, cl, nil)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, errDescribeAll)
+		}
+		described = d
+	}
+	for i := range principals {
+		d, err := describe(ctx, cl, &principals[i])
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, errDescribeBindings, principals[i])
+		}
+		described = append(described, d...)
+	}
+
+	out := make([]AccessControlList, 0, len(described))
+	skipped := 0
+	for _, d := range described {
+		a, ok := FromDescribed(d)
+		if !ok {
+			skipped++
+			continue
+		}
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool { return less(out[i], out[j]) })
+	return out, skipped, nil
+}
+
+// FromDescribed returns the AccessControlList of the supplied described ACL,
+// and whether an AccessControlList can manage it. The wildcard host is
+// omitted, as it is the default.
+func FromDescribed(d kadm.DescribedACL) (AccessControlList, bool) {
+	a := AccessControlList{ResourceName: d.Name, ResourcePrincipal: d.Principal}
+	if d.Host != wildcardHost {
+		a.ResourceHost = d.Host
+	}
+	var ok [4]bool
+	a.ResourceType, ok[0] = spell(resourceTypes, d.Type, kmsg.ParseACLResourceType)
+	a.ResourceOperation, ok[1] = spell(operations, d.Operation, kmsg.ParseACLOperation)
+	a.ResourcePermissionType, ok[2] = spell(permissionTypes, d.Permission, kmsg.ParseACLPermissionType)
+	a.ResourcePatternTypeFilter, ok[3] = spell(patternTypes, d.Pattern, kmsg.ParseACLResourcePatternType)
+	return a, ok == [4]bool{true, true, true, true}
+}
+
+// spell returns the one of the supplied names that parses as the supplied
+// value, and whether there is one.
+func spell[T comparable](names []string, v T, parse func(string) (T, error)) (string, bool) {
+	for _, n := range names {
+		if p, err := parse(strings.ToLower(n)); err == nil && p == v {
+			return n, true
+		}
+	}
+	return "", false
+}
+
+func less(a, b AccessControlList) bool {
+	for _, f := range [][2]string{
+		{a.ResourcePrincipal, b.ResourcePrincipal},
+		{a.ResourceType, b.ResourceType},
+		{a.ResourceName, b.ResourceName},
+		{a.ResourcePatternTypeFilter, b.ResourcePatternTypeFilter},
+		{a.ResourceOperation, b.ResourceOperation},
+		{a.ResourcePermissionType, b.ResourcePermissionType},
+		{a.ResourceHost, b.ResourceHost},
+	} {
+		if f[0] != f[1] {
+			return f[0] < f[1]
+		}
+	}
+	return false
+}
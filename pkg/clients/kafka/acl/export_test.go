@@ -0,0 +1,94 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+func TestFromDescribed(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		d      kadm.DescribedACL
+		want   AccessControlList
+		wantOK bool
+	}{
+		"Topic": {
+			reason: "An ACL on a topic should be spelled as the AccessControlList CRD spells it, omitting the wildcard host.",
+			d: kadm.DescribedACL{
+				Principal:  "User:payments",
+				Host:       "*",
+				Type:       kmsg.ACLResourceTypeTopic,
+				Name:       "payments.",
+				Pattern:    kmsg.ACLResourcePatternTypePrefixed,
+				Operation:  kmsg.ACLOperationDescribeConfigs,
+				Permission: kmsg.ACLPermissionTypeAllow,
+			},
+			want: AccessControlList{
+				ResourceName:              "payments.",
+				ResourceType:              "Topic",
+				ResourcePrincipal:         "User:payments",
+				ResourceOperation:         "DescribeConfigs",
+				ResourcePermissionType:    "Allow",
+				ResourcePatternTypeFilter: "Prefixed",
+			},
+			wantOK: true,
+		},
+		"Host": {
+			reason: "The host of an ACL for a single host should be kept.",
+			d: kadm.DescribedACL{
+				Principal:  "User:payments",
+				Host:       "10.0.0.1",
+				Type:       kmsg.ACLResourceTypeTransactionalId,
+				Name:       "payments",
+				Pattern:    kmsg.ACLResourcePatternTypeLiteral,
+				Operation:  kmsg.ACLOperationWrite,
+				Permission: kmsg.ACLPermissionTypeDeny,
+			},
+			want: AccessControlList{
+				ResourceName:              "payments",
+				ResourceType:              "TransactionalID",
+				ResourcePrincipal:         "User:payments",
+				ResourceHost:              "10.0.0.1",
+				ResourceOperation:         "Write",
+				ResourcePermissionType:    "Deny",
+				ResourcePatternTypeFilter: "Literal",
+			},
+			wantOK: true,
+		},
+		"DelegationToken": {
+			reason: "An ACL on a resource type the AccessControlList CRD does not support should not be managed.",
+			d: kadm.DescribedACL{
+				Principal:  "User:payments",
+				Host:       "*",
+				Type:       kmsg.ACLResourceTypeDelegationToken,
+				Name:       "token",
+				Pattern:    kmsg.ACLResourcePatternTypeLiteral,
+				Operation:  kmsg.ACLOperationDescribe,
+				Permission: kmsg.ACLPermissionTypeAllow,
+			},
+			want: AccessControlList{
+				ResourceName:              "token",
+				ResourcePrincipal:         "User:payments",
+				ResourceOperation:         "Describe",
+				ResourcePermissionType:    "Allow",
+				ResourcePatternTypeFilter: "Literal",
+			},
+			wantOK: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := FromDescribed(tc.d)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nFromDescribed(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if ok != tc.wantOK {
+				t.Errorf("\n%s\nFromDescribed(...): want ok %t, got %t", tc.reason, tc.wantOK, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package acl
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+)
+
+// DefaultPrincipalTemplate renders the principal of a ServiceAccount when a
+// ProviderConfig does not specify a template.
+const DefaultPrincipalTemplate = "User:CN={{ .Namespace }}/{{ .Name }}"
+
+const (
+	errParsePrincipalTemplate  = "cannot parse principal template"
+	errRenderPrincipalTemplate = "cannot render principal template"
+	errInvalidPrincipal        = "principal %q rendered from template is not of the form <type>:<name>"
+)
+
+// ServiceAccountPrincipal returns the principal of the workloads running as
+// the supplied ServiceAccount, rendered from the supplied template.
+// DefaultPrincipalTemplate is used if the template is empty.
+func ServiceAccountPrincipal(tmpl string, sa v1alpha1.ServiceAccountReference) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultPrincipalTemplate
+	}
+	t, err := template.New("principal").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", errors.Wrap(err, errParsePrincipalTemplate)
+	}
+	b := &strings.Builder{}
+	if err := t.Execute(b, sa); err != nil {
+		return "", errors.Wrap(err, errRenderPrincipalTemplate)
+	}
+	p := strings.TrimSpace(b.String())
+	if typ, name, ok := strings.Cut(p, ":"); !ok || typ == "" || name == "" {
+		return "", errors.Errorf(errInvalidPrincipal, p)
+	}
+	return p, nil
+}
@@ -0,0 +1,48 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/acl/v1alpha1"
+)
+
+func TestServiceAccountPrincipal(t *testing.T) {
+	sa := v1alpha1.ServiceAccountReference{Namespace: "payments", Name: "orders"}
+
+	type want struct {
+		principal string
+		err       bool
+	}
+
+	cases := map[string]struct {
+		tmpl string
+		want want
+	}{
+		"Default": {
+			want: want{principal: "User:CN=payments/orders"},
+		},
+		"OIDCSubject": {
+			tmpl: "User:system:serviceaccount:{{ .Namespace }}:{{ .Name }}",
+			want: want{principal: "User:system:serviceaccount:payments:orders"},
+		},
+		"UnknownField": {
+			tmpl: "User:{{ .UID }}",
+			want: want{err: true},
+		},
+		"NoType": {
+			tmpl: "{{ .Namespace }}-{{ .Name }}",
+			want: want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := ServiceAccountPrincipal(tc.tmpl, sa)
+			if diff := cmp.Diff(tc.want, want{principal: p, err: err != nil}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("ServiceAccountPrincipal(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultBrokersKey is the key of a ConfigMap listing brokers when none
+	// is specified.
+	DefaultBrokersKey = "brokers"
+
+	errGetBrokersConfigMap = "cannot get ConfigMap %s/%s listing brokers"
+	errNoBrokersInKey      = "ConfigMap %s/%s lists no brokers under key %q"
+	errMarshalBrokers      = "cannot add brokers to credentials"
+)
+
+// ReplaceBrokers returns the supplied credentials with their brokers replaced
+// by those listed under the supplied key of a ConfigMap, separated by commas
+// or whitespace. This allows broker endpoints published to a ConfigMap by
+// another operator to be used as they are, rather than copied into the
+// credentials Secret.
+func ReplaceBrokers(ctx context.Context, kube client.Reader, data []byte, namespace, name, key string) ([]byte, error) {
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, cm); err != nil {
+		return nil, errors.Wrapf(err, errGetBrokersConfigMap, namespace, name)
+	}
+	if key == "" {
+		key = DefaultBrokersKey
+	}
+	brokers := strings.FieldsFunc(cm.Data[key], func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t' || r == '\r'
+	})
+	if len(brokers) == 0 {
+		return nil, errors.Errorf(errNoBrokersInKey, namespace, name, key)
+	}
+
+	// Only the brokers are replaced, so that fields unknown to Config are
+	// passed on unchanged.
+	creds := map[string]json.RawMessage{}
+	if len(data) > 0 {
+		j, err := credentialsJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(j, &creds); err != nil {
+			return nil, errors.Wrap(err, errCannotParse)
+		}
+	}
+	b, err := json.Marshal(brokers)
+	if err != nil {
+		return nil, errors.Wrap(err, errMarshalBrokers)
+	}
+	creds["brokers"] = b
+	out, err := json.Marshal(creds)
+	return out, errors.Wrap(err, errMarshalBrokers)
+}
@@ -0,0 +1,65 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReplaceBrokers(t *testing.T) {
+	configMap := func(data map[string]string) client.Reader {
+		return &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.ConfigMap).Data = data
+			return nil
+		})}
+	}
+
+	cases := map[string]struct {
+		kube    client.Reader
+		data    string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		"ReplacesBrokers": {
+			kube: configMap(map[string]string{"brokers": "kafka-0:9092, kafka-1:9092\n"}),
+			data: `{"brokers":["old:9092"],"sasl":{"mechanism":"PLAIN","username":"u","password":"p"}}`,
+			want: `{"brokers":["kafka-0:9092","kafka-1:9092"],"sasl":{"mechanism":"PLAIN","username":"u","password":"p"}}`,
+		},
+		"CustomKey": {
+			kube: configMap(map[string]string{"bootstrap": "kafka:9093"}),
+			key:  "bootstrap",
+			want: `{"brokers":["kafka:9093"]}`,
+		},
+		"NoBrokers": {
+			kube:    configMap(map[string]string{"other": "kafka:9092"}),
+			wantErr: true,
+		},
+		"GetError": {
+			kube:    &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			wantErr: true,
+		},
+		"InvalidCredentials": {
+			kube:    configMap(map[string]string{"brokers": "kafka:9092"}),
+			data:    "brokers",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ReplaceBrokers(context.Background(), tc.kube, []byte(tc.data), "kafka", "endpoints", tc.key)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ReplaceBrokers(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("ReplaceBrokers(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Names of the builders of a Builders registry returned by DefaultBuilders.
+const (
+	// BuilderStandard connects as described by the credentials.
+	BuilderStandard = "Standard"
+	// BuilderMSKIAM connects to Amazon MSK over TLS, authenticating with
+	// the AWS credentials of the provider through IAM.
+	BuilderMSKIAM = "MSKIAM"
+	// BuilderConfluentCloud connects to Confluent Cloud over TLS,
+	// authenticating with an API key and secret as SASL username and
+	// password.
+	BuilderConfluentCloud = "ConfluentCloud"
+	// BuilderEventHubs connects to the Kafka endpoint of Azure Event Hubs
+	// over TLS, authenticating with a connection string as SASL password.
+	BuilderEventHubs = "EventHubs"
+
+	// eventHubsUsername is the SASL username Event Hubs expects along with
+	// a connection string.
+	eventHubsUsername = "$ConnectionString"
+
+	errUnknownBuilder    = "unknown client builder %q"
+	errMarshalAdapted    = "cannot marshal adapted credentials"
+	errNoSASLCredentials = "client builder %s requires a SASL username and password"
+)
+
+// A Builder creates an admin client from the supplied credentials, with the
+// supplied extra client options.
+type Builder func(ctx context.Context, data []byte, kube client.Reader, extra ...kgo.Opt) (*Client, error)
+
+type loggerKey struct{}
+
+// withLogger returns the supplied context carrying the supplied logger, which
+// builders log how they connect with, e.g. the negotiated SASL mechanism.
+func withLogger(ctx context.Context, l logging.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// loggerFrom returns the logger carried by the supplied context, or one that
+// logs nothing.
+func loggerFrom(ctx context.Context) logging.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(logging.Logger); ok {
+		return l
+	}
+	return logging.NewNopLogger()
+}
+
+// Builders are the builders of admin clients a ProviderConfig can select, by
+// name. Connection flavors that need more than the credentials describe,
+// e.g. a hosted Kafka, are added by registering a builder for them.
+type Builders map[string]Builder
+
+// DefaultBuilders returns the Standard, MSKIAM, ConfluentCloud and EventHubs
+// builders.
+func DefaultBuilders() Builders {
+	return Builders{
+		BuilderStandard:       NewAdminClient,
+		BuilderMSKIAM:         adapt(mskIAM),
+		BuilderConfluentCloud: adapt(saslPlainOverTLS(BuilderConfluentCloud, "")),
+		BuilderEventHubs:      adapt(saslPlainOverTLS(BuilderEventHubs, eventHubsUsername)),
+	}
+}
+
+// Get returns the builder of the supplied name, or the Standard one if the
+// name is empty.
+func (b Builders) Get(name string) (Builder, error) {
+	if name == "" {
+		name = BuilderStandard
+	}
+	fn, ok := b[name]
+	if !ok {
+		return nil, errors.Errorf(errUnknownBuilder, name)
+	}
+	return fn, nil
+}
+
+// adapt returns a builder that creates clients using NewAdminClient, from
+// credentials adapted by the supplied function.
+func adapt(fn func(kc *Config) error) Builder {
+	return func(ctx context.Context, data []byte, kube client.Reader, extra ...kgo.Opt) (*Client, error) {
+		kc, err := ParseConfig(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := fn(kc); err != nil {
+			return nil, err
+		}
+		adapted, err := json.Marshal(kc)
+		if err != nil {
+			return nil, errors.Wrap(err, errMarshalAdapted)
+		}
+		return NewAdminClient(ctx, adapted, kube, extra...)
+	}
+}
+
+// mskIAM adapts credentials to Amazon MSK, which only needs brokers.
+func mskIAM(kc *Config) error {
+	kc.SASL = &SASL{Mechanism: "aws-msk-iam"}
+	if kc.TLS == nil {
+		kc.TLS = &TLS{}
+	}
+	return nil
+}
+
+// saslPlainOverTLS returns a function adapting credentials to a hosted Kafka
+// that authenticates with SASL PLAIN over TLS. The username is replaced by
+// the supplied one, unless it is empty.
+func saslPlainOverTLS(builder, username string) func(kc *Config) error {
+	return func(kc *Config) error {
+		if kc.SASL == nil || kc.SASL.Password == "" || (username == "" && kc.SASL.Username == "") {
+			return errors.Errorf(errNoSASLCredentials, builder)
+		}
+		kc.SASL.Mechanism, kc.SASL.Mechanisms = "PLAIN", nil
+		if username != "" {
+			kc.SASL.Username = username
+		}
+		if kc.TLS == nil {
+			kc.TLS = &TLS{}
+		}
+		return nil
+	}
+}
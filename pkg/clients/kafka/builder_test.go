@@ -0,0 +1,104 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestBuildersGet(t *testing.T) {
+	b := DefaultBuilders()
+	for _, name := range []string{"", BuilderStandard, BuilderMSKIAM, BuilderConfluentCloud, BuilderEventHubs} {
+		if _, err := b.Get(name); err != nil {
+			t.Errorf("Get(%q): %v", name, err)
+		}
+	}
+	if _, err := b.Get("Kinesis"); err == nil {
+		t.Errorf("Get(%q): want error for an unknown builder", "Kinesis")
+	}
+}
+
+func TestAdapt(t *testing.T) {
+	cases := map[string]struct {
+		adapt   func(kc *Config) error
+		creds   Config
+		want    *Config
+		wantErr bool
+	}{
+		"MSKIAM": {
+			adapt: mskIAM,
+			creds: Config{Brokers: []string{"b-1.msk:9098"}},
+			want: &Config{
+				Brokers: []string{"b-1.msk:9098"},
+				SASL:    &SASL{Mechanism: "aws-msk-iam"},
+				TLS:     &TLS{},
+			},
+		},
+		"ConfluentCloud": {
+			adapt: saslPlainOverTLS(BuilderConfluentCloud, ""),
+			creds: Config{Brokers: []string{"pkc.confluent.cloud:9092"}, SASL: &SASL{Username: "key", Password: "secret"}},
+			want: &Config{
+				Brokers: []string{"pkc.confluent.cloud:9092"},
+				SASL:    &SASL{Mechanism: "PLAIN", Username: "key", Password: "secret"},
+				TLS:     &TLS{},
+			},
+		},
+		"ConfluentCloudWithoutKey": {
+			adapt:   saslPlainOverTLS(BuilderConfluentCloud, ""),
+			creds:   Config{Brokers: []string{"pkc.confluent.cloud:9092"}},
+			wantErr: true,
+		},
+		"EventHubs": {
+			adapt: saslPlainOverTLS(BuilderEventHubs, eventHubsUsername),
+			creds: Config{Brokers: []string{"ns.servicebus.windows.net:9093"}, SASL: &SASL{Password: "Endpoint=sb://ns/"}},
+			want: &Config{
+				Brokers: []string{"ns.servicebus.windows.net:9093"},
+				SASL:    &SASL{Mechanism: "PLAIN", Username: "$ConnectionString", Password: "Endpoint=sb://ns/"},
+				TLS:     &TLS{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kc := tc.creds
+			err := tc.adapt(&kc)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("adapt(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, &kc); diff != "" {
+				t.Errorf("adapt(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClientCacheWithBuilder(t *testing.T) {
+	c := newTestCache(t)
+	var got []byte
+	c.WithBuilder("Custom", func(ctx context.Context, data []byte, kube client.Reader, opts ...kgo.Opt) (*Client, error) {
+		got = data
+		return c.builders[BuilderStandard](ctx, data, kube, opts...)
+	})
+
+	creds, _ := json.Marshal(Config{Brokers: []string{"kafka:9092"}})
+	ctx := context.Background()
+	custom, err := c.Get(ctx, "Custom", creds, nil)
+	if err != nil {
+		t.Fatalf("Get(Custom): %v", err)
+	}
+	if diff := cmp.Diff(string(creds), string(got)); diff != "" {
+		t.Errorf("Get(Custom): -want credentials, +got:\n%s", diff)
+	}
+	standard, _ := c.Get(ctx, BuilderStandard, creds, nil)
+	if custom == standard {
+		t.Errorf("Get(Standard): want a client distinct from the one of another builder")
+	}
+}
@@ -0,0 +1,54 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// default Secret field name for CA certificates, like managed by cert-manager
+	defaultCACertificateField = "ca.crt"
+
+	errMissingCASecretRefKeys = "missing CA certificate secret ref name or namespace"
+	errCannotReadCASecret     = "cannot read CA certificate secret"
+	errNoCACertificates       = "no PEM encoded CA certificates found"
+)
+
+// Add CA certificates used to verify the brokers to the TLS config (if
+// configured). They are trusted in addition to those of a truststore.
+func configureCA(ctx context.Context, kc Config, kube client.Reader, tc *tls.Config) error {
+	var pems [][]byte
+	if kc.TLS.CACertificate != "" {
+		pems = append(pems, []byte(kc.TLS.CACertificate))
+	}
+
+	if sr := kc.TLS.CACertificateSecretRef; sr != nil {
+		if sr.Name == "" || sr.Namespace == "" {
+			return errors.New(errMissingCASecretRefKeys)
+		}
+		secret := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: sr.Namespace, Name: sr.Name}, secret); err != nil {
+			return errors.Wrap(err, errCannotReadCASecret)
+		}
+		pems = append(pems, secret.Data[valueOrDefault(sr.Field, defaultCACertificateField)])
+	}
+
+	if len(pems) == 0 {
+		return nil
+	}
+	if tc.RootCAs == nil {
+		tc.RootCAs = x509.NewCertPool()
+	}
+	for _, pem := range pems {
+		if !tc.RootCAs.AppendCertsFromPEM(pem) {
+			return errors.New(errNoCACertificates)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestConfigureCA(t *testing.T) {
+	_, cert := selfSigned(t)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+
+	secretWith := func(field string, data []byte) client.Client {
+		return &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{field: data}
+			return nil
+		})}
+	}
+
+	cases := map[string]struct {
+		tls       TLS
+		kube      client.Client
+		wantErr   bool
+		wantTrust bool
+	}{
+		"Inline": {
+			tls:       TLS{CACertificate: string(caPEM)},
+			wantTrust: true,
+		},
+		"SecretDefaultField": {
+			tls:       TLS{CACertificateSecretRef: &CACertificateSecretRef{Name: "ca", Namespace: "crossplane-system"}},
+			kube:      secretWith(defaultCACertificateField, caPEM),
+			wantTrust: true,
+		},
+		"SecretMissingNamespace": {
+			tls:     TLS{CACertificateSecretRef: &CACertificateSecretRef{Name: "ca"}},
+			wantErr: true,
+		},
+		"SecretGetError": {
+			tls:     TLS{CACertificateSecretRef: &CACertificateSecretRef{Name: "ca", Namespace: "crossplane-system"}},
+			kube:    &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			wantErr: true,
+		},
+		"NotPEM": {
+			tls:     TLS{CACertificate: "not a certificate"},
+			wantErr: true,
+		},
+		"None": {},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tlsConfig := &tls.Config{}
+			err := configureCA(context.Background(), Config{TLS: &tc.tls}, tc.kube, tlsConfig)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("configureCA(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantTrust {
+				return
+			}
+			if _, err := cert.Verify(x509.VerifyOptions{Roots: tlsConfig.RootCAs}); err != nil {
+				t.Errorf("configureCA(...): CA is not trusted: %s", err)
+			}
+		})
+	}
+}
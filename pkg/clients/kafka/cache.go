@@ -0,0 +1,285 @@
+package kafka
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"golang.org/x/sync/singleflight"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// defaultMaxIdle is how long a cached client may go unused before it is
+	// closed.
+	defaultMaxIdle = 10 * time.Minute
+	// defaultMaxAge is how long a cached client is used before it is
+	// replaced, so that e.g. rotated client certificates are picked up.
+	defaultMaxAge = 1 * time.Hour
+	// defaultCloseGrace is how long a replaced client is kept open so that
+	// requests still using it can complete.
+	defaultCloseGrace = 2 * time.Minute
+
+	// defaultBreakerThreshold is the number of consecutive failed broker
+	// dials after which the circuit breaker trips.
+	defaultBreakerThreshold = 5
+	// defaultBreakerCooldown is how long a tripped circuit breaker fails
+	// requests fast before dials are attempted again.
+	defaultBreakerCooldown = 30 * time.Second
+
+	errCircuitOpen = "brokers are unreachable, not connecting until %s"
+)
+
+// A ClientCache shares admin clients between concurrent reconciles, keyed by
+// the credentials they were created from and the builder that created them. The Client is safe for
+// concurrent use; every request is scoped to the context of the reconcile
+// that issued it. A circuit breaker per client fails fast while its brokers
+// are unreachable, rather than letting every reconcile block on dialing them.
+type ClientCache struct {
+	builders Builders
+	timeouts Timeouts
+
+	maxReadBytes int32
+	onThrottle   ThrottleObserver
+	log          logging.Logger
+
+	maxIdle    time.Duration
+	maxAge     time.Duration
+	closeGrace time.Duration
+
+	mu      sync.Mutex
+	clients map[[sha256.Size]byte]*cachedClient
+	// building creates each client once, however many reconciles need it
+	// while it is created.
+	building singleflight.Group
+}
+
+type cachedClient struct {
+	client   *Client
+	breaker  *breaker
+	throttle *throttle
+	created  time.Time
+	lastUsed time.Time
+}
+
+// NewClientCache returns a ClientCache that creates clients using the
+// DefaultBuilders, with requests bounded by the supplied timeouts.
+func NewClientCache(t Timeouts) *ClientCache {
+	return &ClientCache{
+		builders:   DefaultBuilders(),
+		timeouts:   t,
+		log:        logging.NewNopLogger(),
+		maxIdle:    defaultMaxIdle,
+		maxAge:     defaultMaxAge,
+		closeGrace: defaultCloseGrace,
+		clients:    make(map[[sha256.Size]byte]*cachedClient),
+	}
+}
+
+// WithBuilder registers a builder of the supplied name, replacing any
+// builder of the same name, and returns the ClientCache.
+func (c *ClientCache) WithBuilder(name string, b Builder) *ClientCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.builders[name] = b
+	return c
+}
+
+// WithMaxReadBytes sets the size of the largest response clients accept from
+// a broker, which must fit the metadata of all topics of the cluster, and
+// returns the ClientCache. Zero keeps the default of 100MiB.
+func (c *ClientCache) WithMaxReadBytes(n int32) *ClientCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxReadBytes = n
+	return c
+}
+
+// WithThrottleObserver sets the observer notified whenever a broker throttles
+// one of the cached clients, and returns the ClientCache.
+func (c *ClientCache) WithThrottleObserver(o ThrottleObserver) *ClientCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onThrottle = o
+	return c
+}
+
+// WithLogger sets the logger clients log how they connect with, e.g. the
+// negotiated SASL mechanism, and returns the ClientCache.
+func (c *ClientCache) WithLogger(l logging.Logger) *ClientCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.log = l
+	return c
+}
+
+// Get returns the cached client for the supplied credentials, creating it
+// with the named builder if necessary. Clients returned by Get must not be
+// closed by the caller. The client is recorded in the Throttling of the
+// supplied context, if any.
+func (c *ClientCache) Get(ctx context.Context, builder string, data []byte, kube client.Reader) (*Client, error) {
+	key := sha256.Sum256(append([]byte(builder+"\x00"), data...))
+
+	cc, err := c.lookup(key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if cc == nil {
+		// Creating a client may dial its brokers, so it is created without
+		// holding the lock, once for all concurrent reconciles needing it.
+		// It is created with a context detached from the reconcile that
+		// happens to create it, so that cancelling that reconcile does not
+		// fail the others waiting for the client.
+		v, err, _ := c.building.Do(string(key[:]), func() (any, error) {
+			if cc, err := c.lookup(key, time.Now()); cc != nil || err != nil {
+				return cc, err
+			}
+			bctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.timeouts.Metadata)
+			defer cancel()
+			cc, err := c.newClient(bctx, key, builder, data, kube)
+			if err != nil {
+				return nil, err
+			}
+			c.mu.Lock()
+			c.clients[key] = cc
+			c.mu.Unlock()
+			return cc, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		cc = v.(*cachedClient)
+	}
+	recordThrottle(ctx, cc.throttle)
+	return cc.client, nil
+}
+
+// lookup returns the cached client of the supplied key, if any, after
+// evicting idle and old clients. It returns an error if the circuit breaker
+// of the client is open.
+func (c *ClientCache) lookup(key [sha256.Size]byte, now time.Time) (*cachedClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evict(now)
+
+	cc, ok := c.clients[key]
+	if !ok {
+		return nil, nil
+	}
+	if err := cc.breaker.allow(now); err != nil {
+		return nil, err
+	}
+	cc.lastUsed = now
+	return cc, nil
+}
+
+// newClient creates a client of the supplied key for the supplied credentials
+// with the named builder.
+func (c *ClientCache) newClient(ctx context.Context, key [sha256.Size]byte, builder string, data []byte, kube client.Reader) (*cachedClient, error) {
+	c.mu.Lock()
+	newFn, err := c.builders.Get(builder)
+	onThrottle, maxReadBytes, log := c.onThrottle, c.maxReadBytes, c.log
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	b := &breaker{threshold: defaultBreakerThreshold, cooldown: defaultBreakerCooldown}
+	th := &throttle{observe: onThrottle}
+	// Requests without a broker side timeout, such as metadata requests,
+	// time out after the overhead alone, so it must leave room for reading
+	// the metadata of all topics. Other reads are bounded by their context.
+	overhead := c.timeouts.Metadata
+	if c.timeouts.ClusterMetadata > overhead {
+		overhead = c.timeouts.ClusterMetadata
+	}
+	opts := []kgo.Opt{
+		kgo.WithHooks(b, th),
+		kgo.RequestTimeoutOverhead(overhead),
+		kgo.RetryTimeout(c.timeouts.Mutation),
+	}
+	if maxReadBytes > 0 {
+		opts = append(opts, kgo.BrokerMaxReadBytes(maxReadBytes))
+	}
+	cl, err := newFn(withLogger(ctx, log), data, kube, opts...)
+	if err != nil {
+		return nil, err
+	}
+	cl.SetTimeoutMillis(int32(c.timeouts.Mutation.Milliseconds()))
+	cl.key = hex.EncodeToString(key[:])
+	now := time.Now()
+	return &cachedClient{client: cl, breaker: b, throttle: th, created: now, lastUsed: now}, nil
+}
+
+// Close closes all cached clients.
+func (c *ClientCache) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, cc := range c.clients {
+		cc.client.Close()
+		delete(c.clients, k)
+	}
+}
+
+// evict removes clients that are idle or too old. Idle clients are not in
+// use and are closed immediately. Old clients may still be in use, so they
+// are closed after a grace period.
+func (c *ClientCache) evict(now time.Time) {
+	for k, cc := range c.clients {
+		switch {
+		case now.Sub(cc.lastUsed) > c.maxIdle:
+			cc.client.Close()
+		case now.Sub(cc.created) > c.maxAge:
+			time.AfterFunc(c.closeGrace, cc.client.Close)
+		default:
+			continue
+		}
+		delete(c.clients, k)
+	}
+}
+
+// A breaker is a circuit breaker that trips after a number of consecutive
+// failed broker dials, and then refuses new requests until its cooldown has
+// elapsed. It is notified of dials through the kgo.HookBrokerConnect hook.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// OnBrokerConnect implements kgo.HookBrokerConnect.
+func (b *breaker) OnBrokerConnect(_ kgo.BrokerMetadata, _ time.Duration, _ net.Conn, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+		// Trip again on the first failure after the cooldown.
+		b.failures = b.threshold - 1
+	}
+}
+
+func (b *breaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now.Before(b.openUntil) {
+		return errors.Errorf(errCircuitOpen, b.openUntil.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newTestCache(t *testing.T) *ClientCache {
+	t.Helper()
+	c := NewClientCache(DefaultTimeouts)
+	c.builders[BuilderStandard] = func(_ context.Context, _ []byte, _ client.Reader, opts ...kgo.Opt) (*Client, error) {
+		cl, err := kgo.NewClient(append(opts, kgo.SeedBrokers("127.0.0.1:1"))...)
+		if err != nil {
+			return nil, err
+		}
+		return &Client{Client: kadm.NewClient(cl), raw: cl}, nil
+	}
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestClientCacheGet(t *testing.T) {
+	c := newTestCache(t)
+	ctx := context.Background()
+
+	a, err := c.Get(ctx, "", []byte("a"), nil)
+	if err != nil {
+		t.Fatalf("Get(a): %s", err)
+	}
+	again, _ := c.Get(ctx, "", []byte("a"), nil)
+	if a != again {
+		t.Errorf("Get(a) twice: want the cached client, got a new one")
+	}
+	b, _ := c.Get(ctx, "", []byte("b"), nil)
+	if a == b {
+		t.Errorf("Get(b): want a client distinct from credentials a")
+	}
+
+	// Make client a idle for longer than allowed.
+	for _, cc := range c.clients {
+		cc.lastUsed = time.Now().Add(-2 * c.maxIdle)
+	}
+	fresh, _ := c.Get(ctx, "", []byte("a"), nil)
+	if fresh == a {
+		t.Errorf("Get(a) after idling: want a new client, got the evicted one")
+	}
+}
+
+func TestClientCacheGetConcurrent(t *testing.T) {
+	c := newTestCache(t)
+	build := c.builders[BuilderStandard]
+	var built atomic.Int32
+	started, release := make(chan struct{}, 5), make(chan struct{})
+	c.builders[BuilderStandard] = func(ctx context.Context, data []byte, kube client.Reader, opts ...kgo.Opt) (*Client, error) {
+		built.Add(1)
+		started <- struct{}{}
+		<-release
+		return build(ctx, data, kube, opts...)
+	}
+
+	var wg sync.WaitGroup
+	clients := make([]*Client, 5)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i], _ = c.Get(context.Background(), "", []byte("a"), nil)
+		}(i)
+	}
+	// Clients of other credentials are not held up by one being created.
+	<-started
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ClientCache lock held while creating a client")
+	}
+	close(release)
+	wg.Wait()
+
+	if n := built.Load(); n != 1 {
+		t.Errorf("Get(a) concurrently: want the client created once, created %d times", n)
+	}
+	for _, cl := range clients {
+		if cl == nil || cl != clients[0] {
+			t.Errorf("Get(a) concurrently: want the same client for all, got %v", clients)
+			break
+		}
+	}
+}
+
+func TestClientCacheGetCancelled(t *testing.T) {
+	c := newTestCache(t)
+	build := c.builders[BuilderStandard]
+	c.builders[BuilderStandard] = func(ctx context.Context, data []byte, kube client.Reader, opts ...kgo.Opt) (*Client, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return build(ctx, data, kube, opts...)
+	}
+
+	// Other reconciles may be waiting for the client the cancelled one
+	// creates, so it must be created regardless.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Get(ctx, "", []byte("a"), nil); err != nil {
+		t.Errorf("Get(a) by a cancelled reconcile: want the client created, got %s", err)
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	b := &breaker{threshold: 2, cooldown: time.Minute}
+	errDial := errors.New("dial failed")
+	now := time.Now()
+
+	b.OnBrokerConnect(kgo.BrokerMetadata{}, 0, nil, errDial)
+	if err := b.allow(now); err != nil {
+		t.Errorf("allow() after one failure: want nil, got %s", err)
+	}
+	b.OnBrokerConnect(kgo.BrokerMetadata{}, 0, nil, errDial)
+	if err := b.allow(now); err == nil {
+		t.Errorf("allow() after threshold failures: want error, got nil")
+	}
+	if err := b.allow(now.Add(2 * time.Minute)); err != nil {
+		t.Errorf("allow() after cooldown: want nil, got %s", err)
+	}
+	b.OnBrokerConnect(kgo.BrokerMetadata{}, 0, nil, nil)
+	if err := b.allow(now); err != nil {
+		t.Errorf("allow() after successful dial: want nil, got %s", err)
+	}
+}
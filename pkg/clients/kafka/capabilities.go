@@ -0,0 +1,101 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+const (
+	// topicIDsMetadataVersion is the first version of metadata requests
+	// returning topic IDs, introduced with Kafka 2.8.
+	topicIDsMetadataVersion = 10
+
+	errDescribeACLsProbe            = "cannot describe ACLs to probe for an authorizer"
+	errDescribeDelegationTokenProbe = "cannot describe delegation tokens to probe whether they are enabled"
+)
+
+// Capabilities are the features a Kafka cluster supports. A feature is only
+// supported if all brokers support it.
+type Capabilities struct {
+	// TopicIDs is whether brokers identify topics by ID, as of Kafka 2.8.
+	TopicIDs bool
+	// IncrementalAlterConfigs is whether configs can be altered one by one,
+	// as of Kafka 2.3.
+	IncrementalAlterConfigs bool
+	// DelegationTokens is whether delegation tokens are enabled.
+	DelegationTokens bool
+	// ACLs is whether the brokers run an authorizer.
+	ACLs bool
+}
+
+// apiCapabilities returns the capabilities that follow from the API versions
+// supported by all of the supplied brokers.
+func apiCapabilities(vs kadm.BrokersApiVersions) Capabilities {
+	all := func(key kmsg.Key, minVersion int16) bool {
+		n := 0
+		for _, v := range vs {
+			if v.Err != nil {
+				continue
+			}
+			if max, ok := v.KeyMaxVersion(key.Int16()); !ok || max < minVersion {
+				return false
+			}
+			n++
+		}
+		return n > 0
+	}
+	return Capabilities{
+		TopicIDs:                all(kmsg.Metadata, topicIDsMetadataVersion),
+		IncrementalAlterConfigs: all(kmsg.IncrementalAlterConfigs, 0),
+		DelegationTokens:        all(kmsg.DescribeDelegationToken, 0),
+		ACLs:                    all(kmsg.DescribeACLs, 0),
+	}
+}
+
+// probeCapabilities refines the supplied capabilities, which follow from the
+// supported API versions, by asking the brokers whether the features behind
+// those APIs are enabled.
+func (c *Client) probeCapabilities(ctx context.Context, caps *Capabilities) error {
+	if caps.ACLs {
+		enabled, err := c.ACLsEnabled(ctx)
+		if err != nil {
+			return err
+		}
+		caps.ACLs = enabled
+	}
+	if caps.DelegationTokens {
+		r, err := c.Request(ctx, kmsg.NewPtrDescribeDelegationTokenRequest())
+		if err != nil {
+			return errors.Wrap(err, errDescribeDelegationTokenProbe)
+		}
+		caps.DelegationTokens = !errors.Is(kerr.ErrorForCode(r.(*kmsg.DescribeDelegationTokenResponse).ErrorCode), kerr.DelegationTokenAuthDisabled)
+	}
+	return nil
+}
+
+// ACLsEnabled returns whether the brokers run an authorizer. Brokers without
+// one refuse to describe ACLs with SECURITY_DISABLED; any other error, e.g.
+// lacking authorization, is returned by an authorizer.
+func (c *Client) ACLsEnabled(ctx context.Context) (bool, error) {
+	req := kmsg.NewPtrDescribeACLsRequest()
+	req.ResourceType = kmsg.ACLResourceTypeAny
+	req.ResourcePatternType = kmsg.ACLResourcePatternTypeAny
+	req.Operation = kmsg.ACLOperationAny
+	req.PermissionType = kmsg.ACLPermissionTypeAny
+	r, err := c.Request(ctx, req)
+	if err != nil {
+		return false, errors.Wrap(err, errDescribeACLsProbe)
+	}
+	return !IsSecurityDisabled(kerr.ErrorForCode(r.(*kmsg.DescribeACLsResponse).ErrorCode)), nil
+}
+
+// IsSecurityDisabled returns whether the supplied error is, or wraps, the
+// SECURITY_DISABLED error brokers without an authorizer return to ACL
+// requests.
+func IsSecurityDisabled(err error) bool {
+	return errors.Is(err, kerr.SecurityDisabled)
+}
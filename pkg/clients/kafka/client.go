@@ -0,0 +1,281 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"github.com/twmb/franz-go/pkg/sasl"
+	kaws "github.com/twmb/franz-go/pkg/sasl/aws"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+	"golang.org/x/sync/singleflight"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// default Secret field names for TLS certificates, like managed by cert-manager
+	defaultClientCertificateKeyField  = "tls.key"
+	defaultClientCertificateCertField = "tls.crt"
+
+	errCannotParse                    = "cannot parse credentials"
+	errMissingClientCertSecretRefKeys = "missing client cert ref secret name or namespace"
+	errCannotReadClientCertSecret     = "cannot read client cert secret"
+	errCannotNegotiateSASL            = "cannot negotiate SASL mechanism"
+	errMechanismAndMechanisms         = "SASL mechanism and mechanisms cannot both be set"
+	errTokenAuthMechanism             = "SASL mechanism %q does not support token authentication, only SCRAM-SHA-256 / SCRAM-SHA-512 do"
+	errAuthzidMechanism               = "SASL mechanism %q does not support an authorization ID, only PLAIN / SCRAM-SHA-256 / SCRAM-SHA-512 do"
+)
+
+// Client is a Kafka admin client. It embeds a kadm.Client, and can issue raw
+// requests for the operations kadm does not support.
+type Client struct {
+	*kadm.Client
+	raw *kgo.Client
+
+	// seeds and opts are those the client was created with, less any extra
+	// options, so that Diagnose can probe each seed broker on its own.
+	seeds []string
+	opts  []kgo.Opt
+
+	// key identifies the credentials of a client created by a ClientCache.
+	key string
+
+	// topicConfigKeys are the topic config keys the cluster supports, which
+	// take reading the metadata of all topics to learn.
+	mu              sync.Mutex
+	topicConfigKeys []string
+
+	// diagnosis is the last one of Diagnose, made once however many
+	// requests failed at once.
+	diagnosis  *diagnosis
+	diagnosing singleflight.Group
+}
+
+// TopicConfigKeys returns the topic config keys stored with SetTopicConfigKeys,
+// or nil if none were.
+func (c *Client) TopicConfigKeys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.topicConfigKeys
+}
+
+// SetTopicConfigKeys stores the topic config keys the cluster supports for the
+// lifetime of the client. They only change when brokers are upgraded, and
+// cached clients are replaced regularly.
+func (c *Client) SetTopicConfigKeys(keys []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.topicConfigKeys = keys
+}
+
+// Key returns a key identifying the credentials the client was created from,
+// which the clients a ClientCache creates from the same credentials share, e.g.
+// once a client was replaced. Clients not created by a ClientCache each have a
+// key of their own.
+func (c *Client) Key() string {
+	if c.key == "" {
+		return fmt.Sprintf("%p", c)
+	}
+	return c.key
+}
+
+// Brokers returns the seed brokers the client was created with.
+func (c *Client) Brokers() []string {
+	return c.seeds
+}
+
+// Request issues the supplied raw request.
+func (c *Client) Request(ctx context.Context, req kmsg.Request) (kmsg.Response, error) {
+	return c.raw.Request(ctx, req)
+}
+
+// NewAdminClient creates a new AdminClient with supplied credentials and any
+// additional client options
+func NewAdminClient(ctx context.Context, data []byte, kube client.Reader, extra ...kgo.Opt) (*Client, error) { // nolint: gocyclo
+	kc, err := ParseConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveMSKBrokers(ctx, kc); err != nil {
+		return nil, err
+	}
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(kc.Brokers...),
+		kgo.WithLogger(kgo.BasicLogger(os.Stdout, kgo.LogLevelWarn, nil)),
+	}
+
+	if kc.TLS != nil {
+		tc := new(tls.Config)
+		tc.InsecureSkipVerify = kc.TLS.InsecureSkipVerify
+		if err := configureTLSVersions(*kc, tc); err != nil {
+			return nil, err
+		}
+		if err := configureClientCertificate(ctx, *kc, kube, tc); err != nil {
+			return nil, err
+		}
+		if err := configureStores(ctx, *kc, kube, tc); err != nil {
+			return nil, err
+		}
+		if err := configureCA(ctx, *kc, kube, tc); err != nil {
+			return nil, err
+		}
+		opts = append(opts, kgo.DialTLSConfig(tc))
+	}
+
+	if kc.SASL != nil {
+		name := kc.SASL.Mechanism
+		switch {
+		case name != "" && len(kc.SASL.Mechanisms) > 0:
+			return nil, errors.New(errMechanismAndMechanisms)
+		case len(kc.SASL.Mechanisms) > 0:
+			n, err := tryMechanisms(ctx, opts, kc.SASL)
+			if err != nil {
+				return nil, err
+			}
+			name = n
+		case name == "":
+			n, err := negotiateSASLMechanism(ctx, kc.Brokers, opts)
+			if err != nil {
+				return nil, errors.Wrap(err, errCannotNegotiateSASL)
+			}
+			name = n
+		}
+		mechanism, mopts, err := saslMechanism(name, kc.SASL)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, mopts...)
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+
+	base := opts
+	opts = append(append([]kgo.Opt{}, base...), extra...)
+	c, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{Client: kadm.NewClient(c), raw: c, seeds: kc.Brokers, opts: base}, nil
+}
+
+// saslMechanism returns the SASL mechanism of the supplied name, along with
+// any additional client options the mechanism requires.
+func saslMechanism(name string, s *SASL) (sasl.Mechanism, []kgo.Opt, error) {
+	m := strings.ToLower(name)
+	if s.TokenAuth && !strings.HasPrefix(m, "scram-") {
+		return nil, nil, errors.Errorf(errTokenAuthMechanism, name)
+	}
+	if s.Authzid != "" && m != "plain" && !strings.HasPrefix(m, "scram-") {
+		return nil, nil, errors.Errorf(errAuthzidMechanism, name)
+	}
+	switch m {
+	case "plain":
+		return plain.Auth{
+			Zid:  s.Authzid,
+			User: s.Username,
+			Pass: s.Password,
+		}.AsMechanism(), nil, nil
+	case "aws-msk-iam":
+		return kaws.ManagedStreamingIAM(awsIAMAuth(s.AWSCredentials)),
+			[]kgo.Opt{kgo.Dialer((&tls.Dialer{NetDialer: &net.Dialer{Timeout: 10 * time.Second}}).DialContext)}, nil
+	case "scram-sha-256":
+		return scramAuth(s).AsSha256Mechanism(), nil, nil
+	case "scram-sha-512":
+		return scramAuth(s).AsSha512Mechanism(), nil, nil
+	default:
+		return nil, nil, errors.Errorf("SASL mechanism %q not supported, only PLAIN / SCRAM-SHA-256 / SCRAM-SHA-512 / AWS-MSK-IAM are supported for now.", name)
+	}
+}
+
+func scramAuth(s *SASL) scram.Auth {
+	return scram.Auth{
+		Zid:     s.Authzid,
+		User:    s.Username,
+		Pass:    s.Password,
+		IsToken: s.TokenAuth,
+	}
+}
+
+// awsIAMAuth returns a function authenticating with the supplied AWS
+// credentials, or with those of the default credential chain if nil.
+func awsIAMAuth(creds *credentials.Credentials) func(ctx context.Context) (kaws.Auth, error) {
+	return func(ctx context.Context) (kaws.Auth, error) {
+		if creds != nil {
+			return authenticateAwsIam(ctx, creds)
+		}
+		s, err := session.NewSession()
+		if err != nil {
+			return kaws.Auth{}, err
+		}
+		return authenticateAwsIam(ctx, s.Config.Credentials)
+	}
+}
+
+func authenticateAwsIam(ctx context.Context, creds *credentials.Credentials) (a kaws.Auth, err error) {
+	var v credentials.Value
+	v, err = creds.GetWithContext(ctx)
+	if err != nil {
+		return kaws.Auth{}, err
+	}
+
+	a = kaws.Auth{
+		AccessKey:    v.AccessKeyID,
+		SecretKey:    v.SecretAccessKey,
+		SessionToken: v.SessionToken,
+		UserAgent:    "crossplane-provider-kafka",
+	}
+
+	return a, nil
+}
+
+// Add options to TLS config for client certificate (if configured)
+func configureClientCertificate(ctx context.Context, kc Config, kube client.Reader, tc *tls.Config) error {
+	sr := kc.TLS.ClientCertificateSecretRef
+	if sr == nil {
+		return nil
+	}
+
+	if sr.Name == "" || sr.Namespace == "" {
+		return errors.New(errMissingClientCertSecretRefKeys)
+	}
+
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: sr.Namespace, Name: sr.Name}, secret); err != nil {
+		return errors.Wrap(err, errCannotReadClientCertSecret)
+	}
+
+	kf := valueOrDefault(sr.KeyField, defaultClientCertificateKeyField)
+	cf := valueOrDefault(sr.CertField, defaultClientCertificateCertField)
+	kp, err := tls.X509KeyPair(secret.Data[cf], secret.Data[kf])
+	if err != nil {
+		return errors.Wrapf(err, "Invalid key pair, using fields %q/%q from secret %q in namespace %q",
+			cf, kf, sr.Name, sr.Namespace)
+	}
+
+	tc.Certificates = append(tc.Certificates, kp)
+	return nil
+}
+
+// Helper method to return default if value string is empty.
+func valueOrDefault(value, defaultValue string) string {
+	if value != "" {
+		return value
+	}
+	return defaultValue
+}
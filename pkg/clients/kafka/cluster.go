@@ -0,0 +1,176 @@
+package kafka
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// Metadata modes of a Kafka cluster.
+const (
+	MetadataModeKRaft     = "KRaft"
+	MetadataModeZooKeeper = "ZooKeeper"
+)
+
+const (
+	// metadataTopic is the topic KRaft controllers replicate cluster
+	// metadata through.
+	metadataTopic = "__cluster_metadata"
+
+	errDescribeBrokers = "cannot describe brokers"
+	errAPIVersions     = "cannot get API versions of brokers"
+	errDescribeQuorum  = "cannot describe metadata quorum"
+)
+
+// ClusterInfo describes a Kafka cluster.
+type ClusterInfo struct {
+	ID           string
+	ControllerID int32
+	Brokers      []int32
+	// BrokerDetails are the brokers of the cluster, sorted by ID.
+	BrokerDetails []Broker
+	// Racks are the distinct racks of the brokers, sorted.
+	Racks []string
+	// Version is the Kafka version the brokers most likely run, guessed
+	// from the API versions they support.
+	Version string
+	// MetadataMode is how the cluster stores its metadata: KRaft or
+	// ZooKeeper.
+	MetadataMode string
+	// Quorum is the KRaft metadata quorum. It is nil for ZooKeeper clusters,
+	// and if the quorum may not be described.
+	Quorum *Quorum
+	// Capabilities are the features the cluster supports.
+	Capabilities Capabilities
+}
+
+// A Broker of a Kafka cluster.
+type Broker struct {
+	ID   int32
+	Host string
+	Port int32
+	// Rack is the broker.rack of the broker, if set.
+	Rack string
+	// Controller is true if the broker is the active controller.
+	Controller bool
+}
+
+// Quorum describes the KRaft metadata quorum of a Kafka cluster.
+type Quorum struct {
+	LeaderID      int32
+	LeaderEpoch   int32
+	HighWatermark int64
+	Voters        []int32
+	Observers     []int32
+}
+
+// DescribeCluster describes the Kafka cluster. Clusters whose brokers do not
+// support describing the metadata quorum, which brokers in KRaft mode do since
+// Kafka 3.3, are assumed to store their metadata in ZooKeeper.
+func (c *Client) DescribeCluster(ctx context.Context) (*ClusterInfo, error) {
+	m, err := c.BrokerMetadata(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errDescribeBrokers)
+	}
+	ci := &ClusterInfo{ID: m.Cluster, ControllerID: m.Controller, Brokers: m.Brokers.NodeIDs(), MetadataMode: MetadataModeZooKeeper}
+	sort.Slice(ci.Brokers, func(i, j int) bool { return ci.Brokers[i] < ci.Brokers[j] })
+	ci.BrokerDetails, ci.Racks = inventory(m)
+
+	vs, err := c.ApiVersions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errAPIVersions)
+	}
+	for _, v := range vs.Sorted() {
+		if v.Err != nil {
+			continue
+		}
+		if ci.Version == "" {
+			ci.Version = v.VersionGuess()
+		}
+		if _, ok := v.KeyMaxVersion(kmsg.DescribeQuorum.Int16()); ok {
+			ci.MetadataMode = MetadataModeKRaft
+		}
+	}
+	ci.Capabilities = apiCapabilities(vs)
+	if err := c.probeCapabilities(ctx, &ci.Capabilities); err != nil {
+		return nil, err
+	}
+	if ci.MetadataMode != MetadataModeKRaft {
+		return ci, nil
+	}
+
+	q, err := c.describeQuorum(ctx)
+	// Describing the quorum requires the Describe operation on the cluster,
+	// without which the rest of the description is still useful.
+	if errors.Is(err, kerr.ClusterAuthorizationFailed) {
+		return ci, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errDescribeQuorum)
+	}
+	ci.Quorum = q
+	return ci, nil
+}
+
+// inventory returns the brokers of the supplied metadata sorted by ID, and
+// their distinct racks.
+func inventory(m kadm.Metadata) ([]Broker, []string) {
+	brokers := make([]Broker, 0, len(m.Brokers))
+	racks := []string{}
+	seen := map[string]bool{}
+	for _, b := range m.Brokers {
+		br := Broker{ID: b.NodeID, Host: b.Host, Port: b.Port, Controller: b.NodeID == m.Controller}
+		if b.Rack != nil && *b.Rack != "" {
+			br.Rack = *b.Rack
+			if !seen[br.Rack] {
+				seen[br.Rack] = true
+				racks = append(racks, br.Rack)
+			}
+		}
+		brokers = append(brokers, br)
+	}
+	sort.Slice(brokers, func(i, j int) bool { return brokers[i].ID < brokers[j].ID })
+	sort.Strings(racks)
+	return brokers, racks
+}
+
+func (c *Client) describeQuorum(ctx context.Context) (*Quorum, error) {
+	req := kmsg.NewPtrDescribeQuorumRequest()
+	t := kmsg.NewDescribeQuorumRequestTopic()
+	t.Topic = metadataTopic
+	t.Partitions = []kmsg.DescribeQuorumRequestTopicPartition{kmsg.NewDescribeQuorumRequestTopicPartition()}
+	req.Topics = append(req.Topics, t)
+
+	r, err := c.Request(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return quorum(r.(*kmsg.DescribeQuorumResponse))
+}
+
+// quorum returns the metadata quorum described by the supplied response.
+func quorum(resp *kmsg.DescribeQuorumResponse) (*Quorum, error) {
+	if err := kerr.ErrorForCode(resp.ErrorCode); err != nil {
+		return nil, err
+	}
+	for _, t := range resp.Topics {
+		for _, p := range t.Partitions {
+			if err := kerr.ErrorForCode(p.ErrorCode); err != nil {
+				return nil, err
+			}
+			q := &Quorum{LeaderID: p.LeaderID, LeaderEpoch: p.LeaderEpoch, HighWatermark: p.HighWatermark}
+			for _, v := range p.CurrentVoters {
+				q.Voters = append(q.Voters, v.ReplicaID)
+			}
+			for _, o := range p.Observers {
+				q.Observers = append(q.Observers, o.ReplicaID)
+			}
+			return q, nil
+		}
+	}
+	return nil, kerr.UnknownTopicOrPartition
+}
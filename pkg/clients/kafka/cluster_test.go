@@ -0,0 +1,125 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+func TestDescribeClusterZooKeeper(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.NumBrokers(3), kfake.ClusterID("sample-cluster"))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	creds, _ := json.Marshal(Config{Brokers: c.ListenAddrs()})
+	cl, err := NewAdminClient(ctx, creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	// The brokers cannot describe a metadata quorum, as brokers of clusters
+	// storing their metadata in ZooKeeper.
+	got, err := cl.DescribeCluster(ctx)
+	if err != nil {
+		t.Fatalf("DescribeCluster(...): %v", err)
+	}
+	// The guessed version, the controller and the addresses of the brokers
+	// depend on the fake cluster.
+	if len(got.BrokerDetails) != 3 {
+		t.Errorf("DescribeCluster(...): want 3 broker details, got %d", len(got.BrokerDetails))
+	}
+	got.Version, got.ControllerID, got.BrokerDetails = "", 0, nil
+	if diff := cmp.Diff(&ClusterInfo{
+		ID:           "sample-cluster",
+		Brokers:      []int32{0, 1, 2},
+		Racks:        []string{},
+		MetadataMode: MetadataModeZooKeeper,
+		// The fake brokers support neither ACLs nor delegation tokens.
+		Capabilities: Capabilities{TopicIDs: true, IncrementalAlterConfigs: true},
+	}, got); diff != "" {
+		t.Errorf("DescribeCluster(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestQuorum(t *testing.T) {
+	voter := func(id int32) kmsg.DescribeQuorumResponseTopicPartitionReplicaState {
+		s := kmsg.NewDescribeQuorumResponseTopicPartitionReplicaState()
+		s.ReplicaID = id
+		return s
+	}
+	p := kmsg.NewDescribeQuorumResponseTopicPartition()
+	p.LeaderID, p.LeaderEpoch, p.HighWatermark = 3000, 7, 1234
+	p.CurrentVoters = append(p.CurrentVoters, voter(3000), voter(3001), voter(3002))
+	p.Observers = append(p.Observers, voter(0), voter(1))
+	topic := kmsg.NewDescribeQuorumResponseTopic()
+	topic.Topic = metadataTopic
+	topic.Partitions = append(topic.Partitions, p)
+
+	cases := map[string]struct {
+		resp    *kmsg.DescribeQuorumResponse
+		want    *Quorum
+		wantErr error
+	}{
+		"Described": {
+			resp: &kmsg.DescribeQuorumResponse{Topics: []kmsg.DescribeQuorumResponseTopic{topic}},
+			want: &Quorum{LeaderID: 3000, LeaderEpoch: 7, HighWatermark: 1234, Voters: []int32{3000, 3001, 3002}, Observers: []int32{0, 1}},
+		},
+		"Unauthorized": {
+			resp:    &kmsg.DescribeQuorumResponse{ErrorCode: kerr.ClusterAuthorizationFailed.Code},
+			wantErr: kerr.ClusterAuthorizationFailed,
+		},
+		"NoPartition": {
+			resp:    &kmsg.DescribeQuorumResponse{},
+			wantErr: kerr.UnknownTopicOrPartition,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := quorum(tc.resp)
+			if err != tc.wantErr {
+				t.Errorf("quorum(...): got error %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("quorum(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInventory(t *testing.T) {
+	rack := func(r string) *string { return &r }
+	m := kadm.Metadata{
+		Controller: 1,
+		Brokers: kadm.BrokerDetails{
+			{NodeID: 2, Host: "kafka-2", Port: 9092, Rack: rack("eu-west-1b")},
+			{NodeID: 0, Host: "kafka-0", Port: 9092, Rack: rack("eu-west-1a")},
+			{NodeID: 1, Host: "kafka-1", Port: 9093, Rack: rack("eu-west-1a")},
+			{NodeID: 3, Host: "kafka-3", Port: 9092},
+		},
+	}
+
+	brokers, racks := inventory(m)
+	wantBrokers := []Broker{
+		{ID: 0, Host: "kafka-0", Port: 9092, Rack: "eu-west-1a"},
+		{ID: 1, Host: "kafka-1", Port: 9093, Rack: "eu-west-1a", Controller: true},
+		{ID: 2, Host: "kafka-2", Port: 9092, Rack: "eu-west-1b"},
+		{ID: 3, Host: "kafka-3", Port: 9092},
+	}
+	if diff := cmp.Diff(wantBrokers, brokers); diff != "" {
+		t.Errorf("inventory(...): -want brokers, +got brokers:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"eu-west-1a", "eu-west-1b"}, racks); diff != "" {
+		t.Errorf("inventory(...): -want racks, +got racks:\n%s", diff)
+	}
+}
@@ -0,0 +1,161 @@
+package kafka
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/pkg/errors"
+)
+
+// Config is a Kafka client configuration
+type Config struct {
+	Brokers        []string        `json:"brokers"`
+	SASL           *SASL           `json:"sasl,omitempty"`
+	TLS            *TLS            `json:"tls,omitempty"`
+	SchemaRegistry *SchemaRegistry `json:"schemaRegistry,omitempty"`
+	MSK            *MSK            `json:"msk,omitempty"`
+	MDS            *MDS            `json:"mds,omitempty"`
+}
+
+// SASL is an sasl option
+type SASL struct {
+	// Mechanism is negotiated with the brokers when empty, preferring
+	// SCRAM-SHA-512 over SCRAM-SHA-256 over PLAIN.
+	Mechanism string `json:"mechanism"`
+	// Mechanisms are tried in order instead of Mechanism, and the first the
+	// brokers accept the credentials with is used, e.g. while migrating
+	// between mechanisms.
+	Mechanisms []string `json:"mechanisms,omitempty"`
+	Username   string   `json:"username"`
+	Password   string   `json:"password"`
+	// Authzid is the authorization ID to act as, for brokers that allow
+	// the authenticated user to impersonate others. Only PLAIN and SCRAM
+	// mechanisms support it.
+	Authzid string `json:"authzid,omitempty"`
+	// TokenAuth authenticates with a delegation token, whose ID is the
+	// username and whose HMAC is the password. Only SCRAM mechanisms
+	// support it.
+	TokenAuth bool `json:"tokenAuth,omitempty"`
+	// AWSCredentials are used by the AWS-MSK-IAM mechanism instead of the
+	// default AWS credential chain. They are taken from MSK, if set.
+	AWSCredentials *credentials.Credentials `json:"-"`
+}
+
+// TLS is an option for enabling encryption in transit
+type TLS struct {
+	ClientCertificateSecretRef *ClientCertificateSecretRef `json:"clientCertificateSecretRef,omitempty"`
+	// KeystoreSecretRef references a JKS or PKCS12 keystore holding the
+	// client certificate and key, as an alternative to a PEM key pair.
+	KeystoreSecretRef *StoreSecretRef `json:"keystoreSecretRef,omitempty"`
+	// TruststoreSecretRef references a JKS or PKCS12 truststore holding the
+	// CA certificates used to verify the brokers.
+	TruststoreSecretRef *StoreSecretRef `json:"truststoreSecretRef,omitempty"`
+	// CACertificate is a PEM encoded CA certificate used to verify the
+	// brokers, e.g. those of a hosted Kafka that only offers SASL over TLS.
+	CACertificate string `json:"caCertificate,omitempty"`
+	// CACertificateSecretRef references a Secret holding a PEM encoded CA
+	// certificate used to verify the brokers.
+	CACertificateSecretRef *CACertificateSecretRef `json:"caCertificateSecretRef,omitempty"`
+	InsecureSkipVerify     bool                    `json:"insecureSkipVerify"`
+	// MinVersion is the oldest TLS version to connect to the brokers with,
+	// one of 1.0, 1.1, 1.2 or 1.3. Defaults to 1.2.
+	MinVersion string `json:"minVersion,omitempty"`
+	// CipherSuites are the names of the cipher suites allowed up to TLS 1.2,
+	// e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, to restrict them to those
+	// approved by a security baseline. The cipher suites of TLS 1.3 cannot
+	// be restricted. Defaults to all secure cipher suites.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// ClientCertificateSecretRef is a TLS option for enable mTLS
+type ClientCertificateSecretRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	KeyField  string `json:"keyField,omitempty"`
+	CertField string `json:"certField,omitempty"`
+}
+
+// CACertificateSecretRef is a TLS option referencing a PEM encoded CA
+// certificate in a Secret
+type CACertificateSecretRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Field of the Secret holding the certificate. Defaults to ca.crt, like
+	// managed by cert-manager.
+	Field string `json:"field,omitempty"`
+}
+
+// StoreSecretRef is a TLS option referencing a JKS or PKCS12 keystore or
+// truststore in a Secret
+type StoreSecretRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Field of the Secret holding the store. Defaults to keystore.p12 or
+	// truststore.p12, like managed by cert-manager.
+	Field string `json:"field,omitempty"`
+	// Type of the store, either JKS or PKCS12. Detected from the store
+	// when empty.
+	Type string `json:"type,omitempty"`
+	// Password of the store.
+	Password string `json:"password,omitempty"`
+}
+
+// SchemaRegistry is an optional Schema Registry used to verify the schemas
+// of topics
+type SchemaRegistry struct {
+	URL      string `json:"url"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// MDS is an optional Confluent Metadata Service, through which the
+// RoleBindings of clusters using Confluent RBAC are managed
+type MDS struct {
+	URL      string `json:"url"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// ParseConfig parses a Kafka client configuration from JSON or YAML
+// credentials, and validates it against the CredentialsSchema.
+func ParseConfig(data []byte) (*Config, error) {
+	j, err := credentialsJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	kc := &Config{}
+	if err := json.Unmarshal(j, kc); err != nil {
+		return nil, unmarshalError(err)
+	}
+	if errs := ValidateConfig(kc); len(errs) > 0 {
+		return nil, errors.Wrap(errs.ToAggregate(), errInvalidCredentials)
+	}
+	return kc, nil
+}
+
+// Timeouts bound how long individual admin operations may take, so that a
+// hung broker fails a reconcile early rather than holding on to it until the
+// reconcile itself times out.
+type Timeouts struct {
+	// Metadata bounds operations that only read from the cluster.
+	Metadata time.Duration
+	// Mutation bounds operations that create, alter or delete resources.
+	Mutation time.Duration
+	// Create bounds creating a topic once it was validated, which can take
+	// longer than other mutations for topics with many partitions.
+	Create time.Duration
+	// ClusterMetadata bounds reading the metadata of all topics, whose
+	// response is large and slow on clusters with tens of thousands of
+	// partitions. Everything else only reads metadata of the topics it is
+	// about.
+	ClusterMetadata time.Duration
+}
+
+// DefaultTimeouts are the default Timeouts.
+var DefaultTimeouts = Timeouts{
+	Metadata:        10 * time.Second,
+	Mutation:        30 * time.Second,
+	Create:          30 * time.Second,
+	ClusterMetadata: 1 * time.Minute,
+}
@@ -0,0 +1,188 @@
+package kafka
+
+import (
+	_ "embed" // Embeds the credentials schema.
+	"encoding/json"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	errInvalidCredentials = "invalid credentials"
+
+	msgBroker  = "must be host:port, e.g. kafka-0:9092"
+	msgHTTPURL = "must be an http or https URL"
+)
+
+// CredentialsSchema is the JSON schema of the credentials, e.g. for editors
+// and CI to validate credentials Secrets with. ParseConfig validates the
+// credentials against it.
+//
+//go:embed credentials.schema.json
+var CredentialsSchema []byte
+
+// saslMechanisms are the SASL mechanisms the credentials can name.
+var saslMechanisms = []string{"PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512", "AWS-MSK-IAM"}
+
+// storeTypes are the types of keystores and truststores.
+var storeTypes = []string{"JKS", "PKCS12"}
+
+// credentialsJSON returns the supplied credentials as JSON. Credentials may be
+// JSON, which is returned unchanged, or YAML.
+func credentialsJSON(data []byte) ([]byte, error) {
+	if json.Valid(data) {
+		return data, nil
+	}
+	j, err := yaml.YAMLToJSON(data)
+	return j, errors.Wrap(err, errCannotParse)
+}
+
+// unmarshalError returns the supplied error of unmarshalling credentials,
+// naming the offending field where it can.
+func unmarshalError(err error) error {
+	te := &json.UnmarshalTypeError{}
+	if errors.As(err, &te) && te.Field != "" {
+		err = field.ErrorList{field.Invalid(field.NewPath(te.Field), te.Value, "must be "+jsonType(te.Type.Kind().String()))}.ToAggregate()
+	}
+	return errors.Wrap(err, errCannotParse)
+}
+
+func jsonType(kind string) string {
+	switch kind {
+	case "slice":
+		return "a list"
+	case "struct", "map", "ptr":
+		return "an object"
+	case "bool":
+		return "a boolean"
+	case "string":
+		return "a string"
+	}
+	return "a number"
+}
+
+// ValidateConfig returns the errors of the supplied credentials, each naming
+// the offending field, e.g. brokers[1].
+func ValidateConfig(kc *Config) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(kc.Brokers) == 0 && (kc.MSK == nil || kc.MSK.ClusterARN == "") {
+		errs = append(errs, field.Required(field.NewPath("brokers"), "brokers are required unless msk.clusterARN is set"))
+	}
+	for i, b := range kc.Brokers {
+		if !validBroker(b) {
+			errs = append(errs, field.Invalid(field.NewPath("brokers").Index(i), b, msgBroker))
+		}
+	}
+	errs = append(errs, validateSASL(field.NewPath("sasl"), kc.SASL)...)
+	errs = append(errs, validateTLS(field.NewPath("tls"), kc.TLS)...)
+	if sr := kc.SchemaRegistry; sr != nil && !validHTTPURL(sr.URL) {
+		errs = append(errs, field.Invalid(field.NewPath("schemaRegistry", "url"), sr.URL, msgHTTPURL))
+	}
+	if m := kc.MDS; m != nil && !validHTTPURL(m.URL) {
+		errs = append(errs, field.Invalid(field.NewPath("mds", "url"), m.URL, msgHTTPURL))
+	}
+	if kc.MSK != nil && kc.MSK.ClusterARN == "" {
+		errs = append(errs, field.Required(field.NewPath("msk", "clusterARN"), ""))
+	}
+	return errs
+}
+
+// validHTTPURL returns true if the supplied URL is an http or https URL.
+func validHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// validBroker returns true if the supplied broker is a host, optionally
+// followed by a port. Brokers without a port use 9092.
+func validBroker(b string) bool {
+	if b == "" || strings.ContainsAny(b, "/ \t\n") {
+		return false
+	}
+	if !strings.Contains(b, ":") {
+		return true
+	}
+	host, port, err := net.SplitHostPort(b)
+	if err != nil || host == "" {
+		return false
+	}
+	p, err := strconv.Atoi(port)
+	return err == nil && p > 0 && p <= 65535
+}
+
+func validateSASL(p *field.Path, s *SASL) field.ErrorList {
+	errs := field.ErrorList{}
+	if s == nil {
+		return errs
+	}
+	if s.Mechanism != "" && !oneOf(s.Mechanism, saslMechanisms) {
+		errs = append(errs, field.NotSupported(p.Child("mechanism"), s.Mechanism, saslMechanisms))
+	}
+	if s.Mechanism != "" && len(s.Mechanisms) > 0 {
+		errs = append(errs, field.Forbidden(p.Child("mechanisms"), errMechanismAndMechanisms))
+	}
+	for i, m := range s.Mechanisms {
+		if !oneOf(m, saslMechanisms) {
+			errs = append(errs, field.NotSupported(p.Child("mechanisms").Index(i), m, saslMechanisms))
+		}
+	}
+	return errs
+}
+
+func validateTLS(p *field.Path, t *TLS) field.ErrorList {
+	errs := field.ErrorList{}
+	if t == nil {
+		return errs
+	}
+	if r := t.ClientCertificateSecretRef; r != nil {
+		errs = append(errs, validateSecretRef(p.Child("clientCertificateSecretRef"), r.Name, r.Namespace)...)
+	}
+	if r := t.CACertificateSecretRef; r != nil {
+		errs = append(errs, validateSecretRef(p.Child("caCertificateSecretRef"), r.Name, r.Namespace)...)
+	}
+	errs = append(errs, validateStoreRef(p.Child("keystoreSecretRef"), t.KeystoreSecretRef)...)
+	errs = append(errs, validateStoreRef(p.Child("truststoreSecretRef"), t.TruststoreSecretRef)...)
+	if _, ok := tlsVersions[t.MinVersion]; t.MinVersion != "" && !ok {
+		errs = append(errs, field.NotSupported(p.Child("minVersion"), t.MinVersion, []string{"1.0", "1.1", "1.2", "1.3"}))
+	}
+	return errs
+}
+
+func validateStoreRef(p *field.Path, r *StoreSecretRef) field.ErrorList {
+	if r == nil {
+		return nil
+	}
+	errs := validateSecretRef(p, r.Name, r.Namespace)
+	if r.Type != "" && !oneOf(r.Type, storeTypes) {
+		errs = append(errs, field.NotSupported(p.Child("type"), r.Type, storeTypes))
+	}
+	return errs
+}
+
+func validateSecretRef(p *field.Path, name, namespace string) field.ErrorList {
+	errs := field.ErrorList{}
+	if name == "" {
+		errs = append(errs, field.Required(p.Child("name"), ""))
+	}
+	if namespace == "" {
+		errs = append(errs, field.Required(p.Child("namespace"), ""))
+	}
+	return errs
+}
+
+// oneOf returns true if the supplied value is one of the supplied values,
+// ignoring case.
+func oneOf(v string, values []string) bool {
+	for _, o := range values {
+		if strings.EqualFold(v, o) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package kafka
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseConfig(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		data    string
+		want    *Config
+		wantErr string
+	}{
+		"JSON": {
+			reason: "Credentials should be parsed from JSON.",
+			data:   `{"brokers":["kafka-0:9092"],"sasl":{"mechanism":"SCRAM-SHA-512","username":"u","password":"p"}}`,
+			want:   &Config{Brokers: []string{"kafka-0:9092"}, SASL: &SASL{Mechanism: "SCRAM-SHA-512", Username: "u", Password: "p"}},
+		},
+		"YAML": {
+			reason: "Credentials should be parsed from YAML.",
+			data:   "brokers:\n- kafka-0:9092\n- kafka-1\ntls:\n  minVersion: \"1.3\"\n",
+			want:   &Config{Brokers: []string{"kafka-0:9092", "kafka-1"}, TLS: &TLS{MinVersion: "1.3"}},
+		},
+		"MSK": {
+			reason: "Brokers should not be required when they are looked up from MSK.",
+			data:   `{"msk":{"clusterARN":"arn:aws:kafka:eu-west-1:123456789012:cluster/orders/abc"}}`,
+			want:   &Config{MSK: &MSK{ClusterARN: "arn:aws:kafka:eu-west-1:123456789012:cluster/orders/abc"}},
+		},
+		"Malformed": {
+			reason:  "Credentials that are neither JSON nor YAML should not be parsed.",
+			data:    "brokers: [kafka-0:9092",
+			wantErr: "cannot parse credentials: yaml: line 1: did not find expected ',' or ']'",
+		},
+		"WrongType": {
+			reason:  "A field of the wrong type should be named.",
+			data:    `{"brokers":["kafka-0:9092"],"sasl":{"username":42}}`,
+			wantErr: "cannot parse credentials: sasl.username: Invalid value: \"number\": must be a string",
+		},
+		"InvalidBroker": {
+			reason:  "An invalid broker should be named by its index.",
+			data:    `{"brokers":["kafka-0:9092","SASL_SSL://kafka-1:9092","kafka-2:port"]}`,
+			wantErr: `invalid credentials: [brokers[1]: Invalid value: "SASL_SSL://kafka-1:9092": must be host:port, e.g. kafka-0:9092, brokers[2]: Invalid value: "kafka-2:port": must be host:port, e.g. kafka-0:9092]`,
+		},
+		"NoBrokers": {
+			reason:  "Brokers should be required.",
+			data:    `{"sasl":{"mechanism":"PLAIN"}}`,
+			wantErr: "invalid credentials: brokers: Required value: brokers are required unless msk.clusterARN is set",
+		},
+		"Invalid": {
+			reason:  "Every invalid field should be named.",
+			data:    `{"brokers":["kafka:9092"],"sasl":{"mechanism":"GSSAPI"},"tls":{"keystoreSecretRef":{"name":"keystore","type":"PEM"}},"schemaRegistry":{"url":"registry:8081"}}`,
+			wantErr: `invalid credentials: [sasl.mechanism: Unsupported value: "GSSAPI": supported values: "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512", "AWS-MSK-IAM", tls.keystoreSecretRef.namespace: Required value, tls.keystoreSecretRef.type: Unsupported value: "PEM": supported values: "JKS", "PKCS12", schemaRegistry.url: Invalid value: "registry:8081": must be an http or https URL]`,
+		},
+		"InvalidMDS": {
+			reason:  "An MDS URL that is not an http or https URL should be rejected.",
+			data:    `{"brokers":["kafka:9092"],"mds":{"url":"mds:8090"}}`,
+			wantErr: `invalid credentials: mds.url: Invalid value: "mds:8090": must be an http or https URL`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseConfig([]byte(tc.data))
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("\n%s\nParseConfig(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nParseConfig(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// TestCredentialsSchema ensures the published schema describes every field of
+// the credentials.
+func TestCredentialsSchema(t *testing.T) {
+	schema := map[string]any{}
+	if err := json.Unmarshal(CredentialsSchema, &schema); err != nil {
+		t.Fatalf("json.Unmarshal(CredentialsSchema): %v", err)
+	}
+	defs, _ := schema["$defs"].(map[string]any)
+
+	var missing []string
+	var walk func(path string, s map[string]any, typ reflect.Type)
+	walk = func(path string, s map[string]any, typ reflect.Type) {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return
+		}
+		props, _ := s["properties"].(map[string]any)
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			name := strings.Split(f.Tag.Get("json"), ",")[0]
+			if name == "-" {
+				continue
+			}
+			p, ok := props[name]
+			if !ok {
+				missing = append(missing, path+name)
+				continue
+			}
+			ps, _ := p.(map[string]any)
+			if ref, ok := ps["$ref"].(string); ok {
+				ps, _ = defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+			}
+			walk(path+name+".", ps, f.Type)
+		}
+	}
+	walk("", schema, reflect.TypeOf(Config{}))
+
+	sort.Strings(missing)
+	if len(missing) > 0 {
+		t.Errorf("CredentialsSchema does not describe %v", missing)
+	}
+}
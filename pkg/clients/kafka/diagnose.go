@@ -0,0 +1,197 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// Stages at which using a seed broker can fail.
+const (
+	BrokerStageDNS     = "DNS"
+	BrokerStageDial    = "Dial"
+	BrokerStageTLS     = "TLS"
+	BrokerStageAuth    = "Auth"
+	BrokerStageRequest = "Request"
+)
+
+// diagnoseTimeout bounds how long probing the seed brokers may take. Probes
+// do not share the deadline of the request that failed, which has typically
+// been used up by the time it failed.
+const diagnoseTimeout = 5 * time.Second
+
+// diagnosisTTL is how long the seed brokers found unusable are reported
+// without probing them again, so that the resources using a client that fail
+// together probe them once.
+const diagnosisTTL = 30 * time.Second
+
+// A diagnosis is the seed brokers a client found unusable, and when.
+type diagnosis struct {
+	at      time.Time
+	brokers []BrokerError
+}
+
+// A BrokerError is why a seed broker could not be used.
+type BrokerError struct {
+	Broker string
+	Stage  string
+	Err    error
+}
+
+// A BrokersUnreachableError is returned when a request failed and probing
+// the seed brokers one by one found some of them unusable.
+type BrokersUnreachableError struct {
+	// Err is the error of the request that failed.
+	Err     error
+	Brokers []BrokerError
+}
+
+func (e *BrokersUnreachableError) Error() string {
+	b := make([]string, 0, len(e.Brokers))
+	for _, be := range e.Brokers {
+		b = append(b, fmt.Sprintf("%s: %s: %s", be.Broker, be.Stage, be.Err))
+	}
+	return fmt.Sprintf("%s; unusable seed brokers: %s", e.Err, strings.Join(b, "; "))
+}
+
+// Unwrap returns the error of the request that failed.
+func (e *BrokersUnreachableError) Unwrap() error {
+	return e.Err
+}
+
+// Diagnose returns a *BrokersUnreachableError detailing why each seed broker
+// could not be used, if the supplied error of a failed request suggests the
+// brokers could not be reached. The supplied error is returned unchanged if
+// a broker returned it, or if every seed broker can be used. Seed brokers are
+// probed at most once per diagnosisTTL.
+func (c *Client) Diagnose(ctx context.Context, err error) error {
+	if err == nil || c == nil || len(c.seeds) == 0 {
+		return err
+	}
+	if ke := (*kerr.Error)(nil); errors.As(err, &ke) {
+		return err
+	}
+
+	// Brokers are probed past the deadline of a request that timed out, but
+	// not once the request was cancelled, e.g. as its resource was deleted.
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return err
+	}
+	v, _, _ := c.diagnosing.Do("", func() (any, error) {
+		c.mu.Lock()
+		d := c.diagnosis
+		c.mu.Unlock()
+		if d != nil && time.Since(d.at) < diagnosisTTL {
+			return d.brokers, nil
+		}
+		brokers := c.probeSeeds(ctx)
+		c.mu.Lock()
+		c.diagnosis = &diagnosis{at: time.Now(), brokers: brokers}
+		c.mu.Unlock()
+		return brokers, nil
+	})
+	brokers, _ := v.([]BrokerError)
+	if len(brokers) == 0 {
+		return err
+	}
+	return &BrokersUnreachableError{Err: err, Brokers: brokers}
+}
+
+// probeSeeds probes each seed broker on its own, with the dialer, TLS and SASL
+// options of the client. It returns why using the seed brokers that could not
+// be used failed.
+func (c *Client) probeSeeds(ctx context.Context) []BrokerError {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), diagnoseTimeout)
+	defer cancel()
+
+	results := make([]*BrokerError, len(c.seeds))
+	wg := sync.WaitGroup{}
+	for i, s := range c.seeds {
+		wg.Add(1)
+		go func(i int, s string) {
+			defer wg.Done()
+			results[i] = probe(ctx, s, c.opts)
+		}(i, s)
+	}
+	wg.Wait()
+
+	var brokers []BrokerError
+	for _, r := range results {
+		if r != nil {
+			brokers = append(brokers, *r)
+		}
+	}
+	return brokers
+}
+
+// probe connects to the supplied seed broker alone and issues a metadata
+// request, which requires authenticating. It returns why that failed, if it
+// did.
+func probe(ctx context.Context, seed string, opts []kgo.Opt) *BrokerError {
+	opts = append(append([]kgo.Opt{}, opts...), kgo.SeedBrokers(seed), kgo.RequestRetries(0))
+	cl, err := kgo.NewClient(opts...)
+	if err != nil {
+		return &BrokerError{Broker: seed, Stage: BrokerStageRequest, Err: err}
+	}
+	defer cl.Close()
+
+	req := kmsg.NewPtrMetadataRequest()
+	req.Topics = []kmsg.MetadataRequestTopic{}
+	if _, err := req.RequestWith(ctx, cl); err != nil {
+		return &BrokerError{Broker: seed, Stage: stage(err), Err: err}
+	}
+	return nil
+}
+
+// stage returns the stage at which the supplied error occurred.
+func stage(err error) string {
+	var (
+		dnsErr      *net.DNSError
+		opErr       *net.OpError
+		unknownCA   x509.UnknownAuthorityError
+		invalidCert x509.CertificateInvalidError
+		hostname    x509.HostnameError
+		verifyErr   *tls.CertificateVerificationError
+		recordErr   tls.RecordHeaderError
+	)
+	switch {
+	case errors.As(err, &dnsErr):
+		return BrokerStageDNS
+	case errors.As(err, &unknownCA), errors.As(err, &invalidCert), errors.As(err, &hostname),
+		errors.As(err, &verifyErr), errors.As(err, &recordErr):
+		return BrokerStageTLS
+	case errors.Is(err, kerr.SaslAuthenticationFailed), errors.Is(err, kerr.IllegalSaslState),
+		errors.Is(err, kerr.UnsupportedSaslMechanism):
+		return BrokerStageAuth
+	case errors.As(err, &opErr) && opErr.Op == "dial", errors.Is(err, context.DeadlineExceeded):
+		return BrokerStageDial
+	}
+	return BrokerStageRequest
+}
+
+// UnreachableBrokers returns the seed brokers a *BrokersUnreachableError found
+// unusable, for recording in the status of a managed resource. It returns nil
+// for any other error.
+func UnreachableBrokers(err error) []v1alpha1.BrokerError {
+	e := &BrokersUnreachableError{}
+	if !errors.As(err, &e) {
+		return nil
+	}
+	out := make([]v1alpha1.BrokerError, 0, len(e.Brokers))
+	for _, b := range e.Brokers {
+		out = append(out, v1alpha1.BrokerError{Broker: b.Broker, Stage: b.Stage, Message: b.Err.Error()})
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package kafka
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kfake"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+func TestDiagnose(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.NumBrokers(1))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	// A listener closed straight away leaves an address nothing listens on.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen(...): %v", err)
+	}
+	closed := l.Addr().String()
+	_ = l.Close()
+
+	errBoom := errors.New("boom")
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := map[string]struct {
+		reason string
+		ctx    context.Context
+		seeds  []string
+		err    error
+		want   []v1alpha1.BrokerError
+	}{
+		"BrokerError": {
+			reason: "Errors returned by a broker show that brokers can be reached, so seed brokers should not be probed.",
+			seeds:  []string{closed},
+			err:    errors.Wrap(kerr.UnknownTopicOrPartition, "cannot describe topic"),
+		},
+		"Cancelled": {
+			reason: "Seed brokers should not be probed once the request was cancelled.",
+			ctx:    cancelled,
+			seeds:  []string{closed},
+			err:    context.Canceled,
+		},
+		"AllReachable": {
+			reason: "No seed broker should be reported if every seed broker can be used.",
+			seeds:  c.ListenAddrs(),
+			err:    errBoom,
+		},
+		"SomeUnreachable": {
+			reason: "Only the seed brokers that cannot be used should be reported, with the stage at which using them failed.",
+			seeds:  append([]string{closed, "broker.invalid:9092"}, c.ListenAddrs()...),
+			err:    errBoom,
+			want: []v1alpha1.BrokerError{
+				{Broker: closed, Stage: BrokerStageDial},
+				{Broker: "broker.invalid:9092", Stage: BrokerStageDNS},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			cl := &Client{seeds: tc.seeds}
+			err := cl.Diagnose(ctx, tc.err)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("\n%s\nDiagnose(...): got error %v, want it to wrap %v", tc.reason, err, tc.err)
+			}
+			got := UnreachableBrokers(err)
+			for i := range got {
+				got[i].Message = ""
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDiagnose(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDiagnoseCached(t *testing.T) {
+	// A listener closed straight away leaves an address nothing listens on.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen(...): %v", err)
+	}
+	closed := l.Addr().String()
+	_ = l.Close()
+
+	cl := &Client{seeds: []string{closed}}
+	cl.diagnosis = &diagnosis{at: time.Now(), brokers: []BrokerError{{Broker: "cached:9092", Stage: BrokerStageDial, Err: errors.New("boom")}}}
+	got := UnreachableBrokers(cl.Diagnose(context.Background(), errors.New("boom")))
+	if len(got) != 1 || got[0].Broker != "cached:9092" {
+		t.Errorf("Diagnose(...) within the TTL: want the cached diagnosis, got %v", got)
+	}
+
+	cl.diagnosis.at = time.Now().Add(-2 * diagnosisTTL)
+	got = UnreachableBrokers(cl.Diagnose(context.Background(), errors.New("boom")))
+	if len(got) != 1 || got[0].Broker != closed {
+		t.Errorf("Diagnose(...) after the TTL: want the seed brokers probed again, got %v", got)
+	}
+}
@@ -0,0 +1,16 @@
+// Package kafka connects to Kafka clusters the way provider-kafka does. It is
+// public so that other Crossplane components, such as composition functions
+// or operators, can reuse the provider's connection logic against the same
+// credentials schema.
+//
+// Credentials are the JSON documented by Config, as stored in the Secret a
+// ProviderConfig refers to. ParseConfig parses them, and NewAdminClient
+// connects to the brokers they list. Secrets referenced by the credentials,
+// such as keystores or CA certificates, are read through a
+// controller-runtime client.Reader. A ClientCache shares one client among all
+// callers using the same credentials, created by the Builder a ProviderConfig
+// selects, e.g. one adapting the credentials to a hosted Kafka service.
+//
+// The topic, acl and group packages manage the corresponding Kafka resources
+// through a Client.
+package kafka
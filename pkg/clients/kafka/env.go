@@ -0,0 +1,155 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Environment variables credentials are read from when a ProviderConfig's
+// credentials source is Environment and no variable is named. They follow the
+// conventions of kcl and most Kafka tooling; the KAFKA_ variables take
+// precedence over their KCL_ equivalents.
+const (
+	EnvBrokers               = "KAFKA_BROKERS"
+	EnvSASLMechanism         = "KAFKA_SASL_MECHANISM"
+	EnvSASLUsername          = "KAFKA_SASL_USERNAME"
+	EnvSASLPassword          = "KAFKA_SASL_PASSWORD"
+	EnvSASLAuthzid           = "KAFKA_SASL_AUTHZID"
+	EnvSASLTokenAuth         = "KAFKA_SASL_TOKEN_AUTH"
+	EnvTLSEnabled            = "KAFKA_TLS_ENABLED"
+	EnvTLSCACert             = "KAFKA_TLS_CA_CERT"
+	EnvTLSCACertPath         = "KAFKA_TLS_CA_CERT_PATH"
+	EnvTLSInsecureSkipVerify = "KAFKA_TLS_INSECURE_SKIP_VERIFY"
+	EnvSchemaRegistryURL     = "KAFKA_SCHEMA_REGISTRY_URL"
+	EnvSchemaRegistryUser    = "KAFKA_SCHEMA_REGISTRY_USERNAME"
+	EnvSchemaRegistryPass    = "KAFKA_SCHEMA_REGISTRY_PASSWORD"
+	EnvMDSURL                = "KAFKA_MDS_URL"
+	EnvMDSUser               = "KAFKA_MDS_USERNAME"
+	EnvMDSPass               = "KAFKA_MDS_PASSWORD"
+
+	EnvKCLSeedBrokers   = "KCL_SEED_BROKERS"
+	EnvKCLSASLMethod    = "KCL_SASL_METHOD"
+	EnvKCLSASLUser      = "KCL_SASL_USER"
+	EnvKCLSASLPass      = "KCL_SASL_PASS"
+	EnvKCLTLSCACertPath = "KCL_TLS_CA_CERT_PATH"
+
+	errNoEnvBrokers    = "neither " + EnvBrokers + " nor " + EnvKCLSeedBrokers + " is set"
+	errParseEnvBool    = "cannot parse %s"
+	errReadEnvCACert   = "cannot read CA certificate file"
+	errMarshalEnvCreds = "cannot marshal credentials from environment"
+)
+
+// ExtractCredentials returns the credentials of a ProviderConfig. Unlike
+// resource.CommonCredentialExtractor, it builds them from the KAFKA_ and KCL_
+// environment variables when the source is Environment but no variable
+// holding them is named.
+func ExtractCredentials(ctx context.Context, source xpv1.CredentialsSource, kube client.Client, s xpv1.CommonCredentialSelectors) ([]byte, error) {
+	if source == xpv1.CredentialsSourceEnvironment && (s.Env == nil || s.Env.Name == "") {
+		return ConfigFromEnvironment(os.Getenv)
+	}
+	return resource.CommonCredentialExtractor(ctx, source, kube, s)
+}
+
+// ConfigFromEnvironment returns credentials built from the environment
+// variables looked up by the supplied function. Client certificates cannot be
+// supplied this way.
+func ConfigFromEnvironment(getenv func(string) string) ([]byte, error) { // nolint: gocyclo
+	first := func(keys ...string) string {
+		for _, k := range keys {
+			if v := getenv(k); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	flag := func(key string) (bool, error) {
+		v := getenv(key)
+		if v == "" {
+			return false, nil
+		}
+		b, err := strconv.ParseBool(v)
+		return b, errors.Wrapf(err, errParseEnvBool, key)
+	}
+
+	kc := Config{}
+	for _, b := range strings.Split(first(EnvBrokers, EnvKCLSeedBrokers), ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			kc.Brokers = append(kc.Brokers, b)
+		}
+	}
+	if len(kc.Brokers) == 0 {
+		return nil, errors.New(errNoEnvBrokers)
+	}
+
+	mechanism := first(EnvSASLMechanism, EnvKCLSASLMethod)
+	username := first(EnvSASLUsername, EnvKCLSASLUser)
+	token, err := flag(EnvSASLTokenAuth)
+	if err != nil {
+		return nil, err
+	}
+	if mechanism != "" || username != "" {
+		kc.SASL = &SASL{
+			// kcl spells mechanisms like aws_msk_iam.
+			Mechanism: strings.ReplaceAll(mechanism, "_", "-"),
+			Username:  username,
+			Password:  first(EnvSASLPassword, EnvKCLSASLPass),
+			Authzid:   getenv(EnvSASLAuthzid),
+			TokenAuth: token,
+		}
+		// A comma separated list of mechanisms is tried in order.
+		if strings.Contains(kc.SASL.Mechanism, ",") {
+			for _, m := range strings.Split(kc.SASL.Mechanism, ",") {
+				if m = strings.TrimSpace(m); m != "" {
+					kc.SASL.Mechanisms = append(kc.SASL.Mechanisms, m)
+				}
+			}
+			kc.SASL.Mechanism = ""
+		}
+	}
+
+	enabled, err := flag(EnvTLSEnabled)
+	if err != nil {
+		return nil, err
+	}
+	insecure, err := flag(EnvTLSInsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+	ca := getenv(EnvTLSCACert)
+	if p := first(EnvTLSCACertPath, EnvKCLTLSCACertPath); ca == "" && p != "" {
+		b, err := os.ReadFile(p) // nolint: gosec
+		if err != nil {
+			return nil, errors.Wrap(err, errReadEnvCACert)
+		}
+		ca = string(b)
+	}
+	if enabled || insecure || ca != "" {
+		kc.TLS = &TLS{CACertificate: ca, InsecureSkipVerify: insecure}
+	}
+
+	if u := getenv(EnvSchemaRegistryURL); u != "" {
+		kc.SchemaRegistry = &SchemaRegistry{
+			URL:      u,
+			Username: getenv(EnvSchemaRegistryUser),
+			Password: getenv(EnvSchemaRegistryPass),
+		}
+	}
+	if u := getenv(EnvMDSURL); u != "" {
+		kc.MDS = &MDS{
+			URL:      u,
+			Username: getenv(EnvMDSUser),
+			Password: getenv(EnvMDSPass),
+		}
+	}
+
+	data, err := json.Marshal(kc)
+	return data, errors.Wrap(err, errMarshalEnvCreds)
+}
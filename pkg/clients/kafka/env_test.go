@@ -0,0 +1,110 @@
+package kafka
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConfigFromEnvironment(t *testing.T) {
+	caPath := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(caPath, []byte("ca"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		env     map[string]string
+		want    *Config
+		wantErr bool
+	}{
+		"Kafka": {
+			env: map[string]string{
+				EnvBrokers:           "kafka-0:9092, kafka-1:9092",
+				EnvSASLMechanism:     "SCRAM-SHA-512",
+				EnvSASLUsername:      "admin",
+				EnvSASLPassword:      "secret",
+				EnvTLSEnabled:        "true",
+				EnvSchemaRegistryURL: "http://registry:8081",
+			},
+			want: &Config{
+				Brokers:        []string{"kafka-0:9092", "kafka-1:9092"},
+				SASL:           &SASL{Mechanism: "SCRAM-SHA-512", Username: "admin", Password: "secret"},
+				TLS:            &TLS{},
+				SchemaRegistry: &SchemaRegistry{URL: "http://registry:8081"},
+			},
+		},
+		"KCL": {
+			env: map[string]string{
+				EnvKCLSeedBrokers:   "kafka-0:9092",
+				EnvKCLSASLMethod:    "aws_msk_iam",
+				EnvKCLTLSCACertPath: caPath,
+			},
+			want: &Config{
+				Brokers: []string{"kafka-0:9092"},
+				SASL:    &SASL{Mechanism: "aws-msk-iam"},
+				TLS:     &TLS{CACertificate: "ca"},
+			},
+		},
+		"DelegationToken": {
+			env: map[string]string{
+				EnvBrokers:       "kafka:9092",
+				EnvSASLMechanism: "SCRAM-SHA-256",
+				EnvSASLUsername:  "token-id",
+				EnvSASLPassword:  "hmac",
+				EnvSASLAuthzid:   "orders-service",
+				EnvSASLTokenAuth: "true",
+			},
+			want: &Config{
+				Brokers: []string{"kafka:9092"},
+				SASL:    &SASL{Mechanism: "SCRAM-SHA-256", Username: "token-id", Password: "hmac", Authzid: "orders-service", TokenAuth: true},
+			},
+		},
+		"MechanismList": {
+			env: map[string]string{
+				EnvBrokers:       "kafka:9092",
+				EnvSASLMechanism: "SCRAM-SHA-512, PLAIN",
+				EnvSASLUsername:  "admin",
+			},
+			want: &Config{
+				Brokers: []string{"kafka:9092"},
+				SASL:    &SASL{Mechanisms: []string{"SCRAM-SHA-512", "PLAIN"}, Username: "admin"},
+			},
+		},
+		"KafkaTakesPrecedence": {
+			env: map[string]string{
+				EnvBrokers:        "kafka:9092",
+				EnvKCLSeedBrokers: "kcl:9092",
+			},
+			want: &Config{Brokers: []string{"kafka:9092"}},
+		},
+		"NoBrokers": {
+			env:     map[string]string{EnvSASLUsername: "admin"},
+			wantErr: true,
+		},
+		"InvalidFlag": {
+			env:     map[string]string{EnvBrokers: "kafka:9092", EnvTLSEnabled: "maybe"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			data, err := ConfigFromEnvironment(func(k string) string { return tc.env[k] })
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ConfigFromEnvironment(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := ParseConfig(data)
+			if err != nil {
+				t.Fatalf("ParseConfig(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ConfigFromEnvironment(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package group observes and deletes Kafka consumer groups.
+package group
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/group/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const (
+	// stateDead is reported for groups the brokers do not know.
+	stateDead = "Dead"
+
+	errCannotDescribeGroup = "cannot describe consumer group"
+	errNoDescribeResponse  = "no describe response for consumer group"
+	errCannotDeleteGroup   = "cannot delete consumer group"
+	errNoDeleteResponse    = "no delete response for consumer group"
+	errActiveMembers       = "consumer group %q has %d active members; deleting it would reset their positions, set force to delete it anyway"
+)
+
+// Group is a holistic representation of a Kafka consumer group.
+type Group struct {
+	Name  string
+	State string
+	// Members are the IDs of the active members of the group.
+	Members []string
+}
+
+// Get returns the consumer group with the supplied name, or nil if it does
+// not exist.
+func Get(ctx context.Context, client *kafka.Client, name string) (*Group, error) {
+	dg, err := client.DescribeGroups(ctx, name)
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotDescribeGroup)
+	}
+	d, ok := dg[name]
+	if !ok {
+		return nil, errors.New(errNoDescribeResponse)
+	}
+	if errors.Is(d.Err, kerr.GroupIDNotFound) {
+		return nil, nil
+	}
+	if d.Err != nil {
+		return nil, errors.Wrap(d.Err, errCannotDescribeGroup)
+	}
+	return fromDescribed(d), nil
+}
+
+func fromDescribed(d kadm.DescribedGroup) *Group {
+	if d.State == stateDead {
+		return nil
+	}
+	g := &Group{Name: d.Group, State: d.State}
+	for _, m := range d.Members {
+		g.Members = append(g.Members, m.MemberID)
+	}
+	return g
+}
+
+// CheckDelete returns an error unless the supplied group may be deleted, that
+// is unless it has no active members or deletion is forced.
+func CheckDelete(g *Group, params v1alpha1.ConsumerGroupParameters) error {
+	if g == nil || params.Force || len(g.Members) == 0 {
+		return nil
+	}
+	return errors.Errorf(errActiveMembers, g.Name, len(g.Members))
+}
+
+// Delete deletes the consumer group with the supplied name.
+func Delete(ctx context.Context, client *kafka.Client, name string) error {
+	resp, err := client.DeleteGroups(ctx, name)
+	if err != nil {
+		return errors.Wrap(err, errCannotDeleteGroup)
+	}
+	d, ok := resp[name]
+	if !ok {
+		return errors.New(errNoDeleteResponse)
+	}
+	if errors.Is(d.Err, kerr.GroupIDNotFound) {
+		return nil
+	}
+	return errors.Wrap(d.Err, errCannotDeleteGroup)
+}
+
+// Observe returns the observable fields of the supplied group.
+func Observe(g *Group) v1alpha1.ConsumerGroupObservation {
+	return v1alpha1.ConsumerGroupObservation{State: g.State, Members: len(g.Members)}
+}
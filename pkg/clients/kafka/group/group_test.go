@@ -0,0 +1,68 @@
+package group
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kadm"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/group/v1alpha1"
+)
+
+func TestFromDescribed(t *testing.T) {
+	cases := map[string]struct {
+		described kadm.DescribedGroup
+		want      *Group
+	}{
+		"Stable": {
+			described: kadm.DescribedGroup{Group: "billing", State: "Stable", Members: []kadm.DescribedGroupMember{{MemberID: "m-1"}}},
+			want:      &Group{Name: "billing", State: "Stable", Members: []string{"m-1"}},
+		},
+		"Empty": {
+			described: kadm.DescribedGroup{Group: "billing", State: "Empty"},
+			want:      &Group{Name: "billing", State: "Empty"},
+		},
+		"Dead": {
+			described: kadm.DescribedGroup{Group: "billing", State: "Dead"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, fromDescribed(tc.described)); diff != "" {
+				t.Errorf("fromDescribed(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckDelete(t *testing.T) {
+	active := &Group{Name: "billing", State: "Stable", Members: []string{"m-1", "m-2"}}
+
+	cases := map[string]struct {
+		group   *Group
+		params  v1alpha1.ConsumerGroupParameters
+		wantErr bool
+	}{
+		"NotFound": {},
+		"Empty": {
+			group: &Group{Name: "billing", State: "Empty"},
+		},
+		"ActiveMembers": {
+			group:   active,
+			wantErr: true,
+		},
+		"ActiveMembersForced": {
+			group:  active,
+			params: v1alpha1.ConsumerGroupParameters{Force: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := CheckDelete(tc.group, tc.params); (err != nil) != tc.wantErr {
+				t.Errorf("CheckDelete(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kerr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/group/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const (
+	// DefaultHistory is the number of snapshots kept in a ConfigMap when
+	// none is configured.
+	DefaultHistory = 24
+
+	// snapshotKeyFormat names the ConfigMap key of a snapshot after the time
+	// it was taken, so that keys sort chronologically.
+	snapshotKeyFormat = "20060102T150405Z"
+
+	errCannotFetchOffsets = "cannot fetch committed offsets of consumer group"
+	errFetchPartition     = "cannot fetch committed offset of partition %d of topic %q"
+	errGetSnapshotCM      = "cannot get snapshot ConfigMap %s/%s"
+	errWriteSnapshotCM    = "cannot write snapshot ConfigMap %s/%s"
+)
+
+// FetchOffsets returns the offsets committed by the consumer group with the
+// supplied name, sorted by topic and partition. Partitions the group never
+// committed an offset for are omitted, as are all of them if the group does
+// not exist.
+func FetchOffsets(ctx context.Context, client *kafka.Client, name string) ([]v1alpha1.PartitionOffset, error) {
+	resp, err := client.FetchOffsets(ctx, name)
+	if errors.Is(err, kerr.GroupIDNotFound) {
+		return []v1alpha1.PartitionOffset{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotFetchOffsets)
+	}
+	out := []v1alpha1.PartitionOffset{}
+	for _, o := range resp.Sorted() {
+		if o.Err != nil {
+			return nil, errors.Wrapf(o.Err, errFetchPartition, o.Partition, o.Topic)
+		}
+		if o.At < 0 {
+			continue
+		}
+		out = append(out, v1alpha1.PartitionOffset{Topic: o.Topic, Partition: o.Partition, Offset: o.At})
+	}
+	return out, nil
+}
+
+// SnapshotKey returns the ConfigMap key of a snapshot taken at the supplied
+// time.
+func SnapshotKey(t time.Time) string {
+	return t.UTC().Format(snapshotKeyFormat) + ".csv"
+}
+
+// OffsetsCSV returns the supplied offsets in the CSV format read by
+// kafka-consumer-groups --reset-offsets --from-file.
+func OffsetsCSV(offsets []v1alpha1.PartitionOffset) string {
+	b := &strings.Builder{}
+	for _, o := range offsets {
+		fmt.Fprintf(b, "%s,%d,%d\n", o.Topic, o.Partition, o.Offset)
+	}
+	return b.String()
+}
+
+// prune removes all but the most recent history snapshots from the supplied
+// ConfigMap data. Keys that do not name a snapshot are left alone.
+func prune(data map[string]string, history int) {
+	keys := []string{}
+	for k := range data {
+		if _, err := time.Parse(snapshotKeyFormat+".csv", k); err == nil {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) <= history {
+		return
+	}
+	sort.Strings(keys)
+	for _, k := range keys[:len(keys)-history] {
+		delete(data, k)
+	}
+}
+
+// WriteSnapshot writes the supplied offsets to the referenced ConfigMap under
+// the supplied key, creating the ConfigMap if it does not exist, and removes
+// the snapshots beyond its history.
+func WriteSnapshot(ctx context.Context, kube client.Client, ref *v1alpha1.SnapshotConfigMap, key string, offsets []v1alpha1.PartitionOffset) error {
+	history := ref.History
+	if history <= 0 {
+		history = DefaultHistory
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm)
+	if kerrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ref.Namespace, Name: ref.Name},
+			Data:       map[string]string{key: OffsetsCSV(offsets)},
+		}
+		return errors.Wrapf(kube.Create(ctx, cm), errWriteSnapshotCM, ref.Namespace, ref.Name)
+	}
+	if err != nil {
+		return errors.Wrapf(err, errGetSnapshotCM, ref.Namespace, ref.Name)
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[key] = OffsetsCSV(offsets)
+	prune(cm.Data, history)
+	return errors.Wrapf(kube.Update(ctx, cm), errWriteSnapshotCM, ref.Namespace, ref.Name)
+}
@@ -0,0 +1,154 @@
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/group/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+func TestFetchOffsets(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.SeedTopics(2, "orders", "payments"))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(ctx, creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	// Brokers only accept commits of members of the group, so a consumer
+	// joins it and commits once it fetched a record.
+	producer, err := kgo.NewClient(kgo.SeedBrokers(c.ListenAddrs()...), kgo.DefaultProduceTopic("orders"))
+	if err != nil {
+		t.Fatalf("kgo.NewClient(...): %v", err)
+	}
+	defer producer.Close()
+	if err := producer.ProduceSync(ctx, &kgo.Record{Value: []byte("order")}).FirstErr(); err != nil {
+		t.Fatalf("ProduceSync(...): %v", err)
+	}
+	consumer, err := kgo.NewClient(kgo.SeedBrokers(c.ListenAddrs()...), kgo.ConsumerGroup("billing"), kgo.ConsumeTopics("orders"), kgo.DisableAutoCommit())
+	if err != nil {
+		t.Fatalf("kgo.NewClient(...): %v", err)
+	}
+	defer consumer.Close()
+	pollCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := consumer.PollFetches(pollCtx).Err(); err != nil {
+		t.Fatalf("PollFetches(...): %v", err)
+	}
+	committed := map[string]map[int32]kgo.EpochOffset{
+		"orders":   {0: {Epoch: -1, Offset: 3}, 1: {Epoch: -1, Offset: 42}},
+		"payments": {0: {Epoch: -1, Offset: 7}},
+	}
+	var commitErr error
+	consumer.CommitOffsetsSync(ctx, committed, func(_ *kgo.Client, _ *kmsg.OffsetCommitRequest, _ *kmsg.OffsetCommitResponse, err error) {
+		commitErr = err
+	})
+	if commitErr != nil {
+		t.Fatalf("CommitOffsetsSync(...): %v", commitErr)
+	}
+
+	got, err := FetchOffsets(ctx, cl, "billing")
+	if err != nil {
+		t.Fatalf("FetchOffsets(...): %v", err)
+	}
+	want := []v1alpha1.PartitionOffset{
+		{Topic: "orders", Partition: 0, Offset: 3},
+		{Topic: "orders", Partition: 1, Offset: 42},
+		{Topic: "payments", Partition: 0, Offset: 7},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FetchOffsets(...): -want, +got:\n%s", diff)
+	}
+
+	got, err = FetchOffsets(ctx, cl, "unknown")
+	if err != nil {
+		t.Fatalf("FetchOffsets(...): %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("FetchOffsets(...): got %v, want no offsets for an unknown group", got)
+	}
+}
+
+func TestWriteSnapshot(t *testing.T) {
+	offsets := []v1alpha1.PartitionOffset{{Topic: "orders", Partition: 0, Offset: 3}, {Topic: "orders", Partition: 1, Offset: 42}}
+	key := SnapshotKey(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	cases := map[string]struct {
+		existing map[string]string
+		getErr   error
+		history  int
+		want     map[string]string
+		wantErr  bool
+	}{
+		"CreatesConfigMap": {
+			getErr: kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "billing-offsets"),
+			want:   map[string]string{"20240301T120000Z.csv": "orders,0,3\norders,1,42\n"},
+		},
+		"PrunesHistory": {
+			existing: map[string]string{
+				"20240301T100000Z.csv": "orders,0,1\n",
+				"20240301T110000Z.csv": "orders,0,2\n",
+				"README":               "offsets of billing",
+			},
+			history: 2,
+			want: map[string]string{
+				"20240301T110000Z.csv": "orders,0,2\n",
+				"20240301T120000Z.csv": "orders,0,3\norders,1,42\n",
+				"README":               "offsets of billing",
+			},
+		},
+		"GetError": {
+			getErr:  errors.New("boom"),
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got map[string]string
+			save := func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+				got = obj.(*corev1.ConfigMap).Data
+				return nil
+			}
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(tc.getErr, func(obj client.Object) error {
+					obj.(*corev1.ConfigMap).Data = tc.existing
+					return nil
+				}),
+				MockCreate: save,
+				MockUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					return save(ctx, obj)
+				},
+			}
+			ref := &v1alpha1.SnapshotConfigMap{Name: "billing-offsets", Namespace: "kafka", History: tc.history}
+			err := WriteSnapshot(context.Background(), kube, ref, key, offsets)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("WriteSnapshot(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("WriteSnapshot(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errNoClientCertificate = "client certificate identity %q is selected, but the credentials have no tls.clientCertificateSecretRef"
+	errSelectIdentity      = "cannot select client certificate identity"
+)
+
+// SelectClientCertificate returns the supplied credentials with the client
+// certificate of the supplied identity, i.e. the PEM key pair held under the
+// fields <identity>.crt and <identity>.key of the Secret their
+// tls.clientCertificateSecretRef references. This allows one Secret to hold
+// several identities, e.g. admin and readonly, of different privileges. The
+// credentials are returned unchanged if no identity is supplied.
+func SelectClientCertificate(data []byte, identity string) ([]byte, error) {
+	if identity == "" {
+		return data, nil
+	}
+
+	// Only the fields of the client certificate are replaced, so that fields
+	// unknown to Config are passed on unchanged.
+	creds := map[string]json.RawMessage{}
+	if len(data) > 0 {
+		j, err := credentialsJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(j, &creds); err != nil {
+			return nil, errors.Wrap(err, errCannotParse)
+		}
+	}
+	tls := map[string]json.RawMessage{}
+	if err := unmarshalObject(creds["tls"], &tls); err != nil {
+		return nil, err
+	}
+	ref := map[string]json.RawMessage{}
+	if err := unmarshalObject(tls["clientCertificateSecretRef"], &ref); err != nil {
+		return nil, err
+	}
+	if len(ref) == 0 {
+		return nil, errors.Errorf(errNoClientCertificate, identity)
+	}
+
+	var err error
+	if ref["certField"], err = json.Marshal(identity + ".crt"); err != nil {
+		return nil, errors.Wrap(err, errSelectIdentity)
+	}
+	if ref["keyField"], err = json.Marshal(identity + ".key"); err != nil {
+		return nil, errors.Wrap(err, errSelectIdentity)
+	}
+	if tls["clientCertificateSecretRef"], err = json.Marshal(ref); err != nil {
+		return nil, errors.Wrap(err, errSelectIdentity)
+	}
+	if creds["tls"], err = json.Marshal(tls); err != nil {
+		return nil, errors.Wrap(err, errSelectIdentity)
+	}
+	out, err := json.Marshal(creds)
+	return out, errors.Wrap(err, errSelectIdentity)
+}
+
+// unmarshalObject unmarshals the supplied JSON object, if any, into out.
+func unmarshalObject(data json.RawMessage, out *map[string]json.RawMessage) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	return errors.Wrap(json.Unmarshal(data, out), errCannotParse)
+}
@@ -0,0 +1,48 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSelectClientCertificate(t *testing.T) {
+	cases := map[string]struct {
+		data     string
+		identity string
+		want     string
+		wantErr  bool
+	}{
+		"NoIdentity": {
+			data: `{"brokers":["kafka:9093"]}`,
+			want: `{"brokers":["kafka:9093"]}`,
+		},
+		"SelectsIdentity": {
+			data:     `{"brokers":["kafka:9093"],"tls":{"caCertificate":"ca","clientCertificateSecretRef":{"name":"certs","namespace":"crossplane-system","certField":"tls.crt"}}}`,
+			identity: "readonly",
+			want:     `{"brokers":["kafka:9093"],"tls":{"caCertificate":"ca","clientCertificateSecretRef":{"certField":"readonly.crt","keyField":"readonly.key","name":"certs","namespace":"crossplane-system"}}}`,
+		},
+		"YAML": {
+			data:     "brokers: [kafka:9093]\ntls:\n  clientCertificateSecretRef:\n    name: certs\n    namespace: crossplane-system\n",
+			identity: "admin",
+			want:     `{"brokers":["kafka:9093"],"tls":{"clientCertificateSecretRef":{"certField":"admin.crt","keyField":"admin.key","name":"certs","namespace":"crossplane-system"}}}`,
+		},
+		"NoClientCertificate": {
+			data:     `{"brokers":["kafka:9093"],"tls":{"caCertificate":"ca"}}`,
+			identity: "admin",
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := SelectClientCertificate([]byte(tc.data), tc.identity)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("SelectClientCertificate(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("SelectClientCertificate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"strings"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+const (
+	// default Secret field names for key and trust stores, like managed by cert-manager
+	defaultKeystoreField   = "keystore.p12"
+	defaultTruststoreField = "truststore.p12"
+
+	storeTypeJKS    = "jks"
+	storeTypePKCS12 = "pkcs12"
+
+	errMissingStoreSecretRefKeys = "missing store secret ref name or namespace"
+	errCannotReadStoreSecret     = "cannot read store secret"
+	errMissingStoreField         = "secret %q in namespace %q has no field %q"
+	errUnknownStoreType          = "unknown store type %q, only JKS and PKCS12 are supported"
+	errCannotDecodeKeystore      = "cannot decode keystore"
+	errCannotDecodeTruststore    = "cannot decode truststore"
+	errNoPrivateKeyEntry         = "keystore has no private key entry"
+	errNoTrustedCertificates     = "truststore has no trusted certificates"
+)
+
+// jksMagic starts every JKS store.
+var jksMagic = []byte{0xFE, 0xED, 0xFE, 0xED}
+
+// Add options to TLS config for key and trust stores (if configured)
+func configureStores(ctx context.Context, kc Config, kube client.Reader, tc *tls.Config) error {
+	if sr := kc.TLS.KeystoreSecretRef; sr != nil {
+		data, typ, err := readStore(ctx, kube, sr, defaultKeystoreField)
+		if err != nil {
+			return err
+		}
+		c, err := decodeKeystore(data, typ, sr.Password)
+		if err != nil {
+			return errors.Wrap(err, errCannotDecodeKeystore)
+		}
+		tc.Certificates = append(tc.Certificates, c)
+	}
+
+	if sr := kc.TLS.TruststoreSecretRef; sr != nil {
+		data, typ, err := readStore(ctx, kube, sr, defaultTruststoreField)
+		if err != nil {
+			return err
+		}
+		pool, err := decodeTruststore(data, typ, sr.Password)
+		if err != nil {
+			return errors.Wrap(err, errCannotDecodeTruststore)
+		}
+		tc.RootCAs = pool
+	}
+	return nil
+}
+
+// readStore returns the store referenced by the supplied ref, and its type.
+func readStore(ctx context.Context, kube client.Reader, sr *StoreSecretRef, defaultField string) ([]byte, string, error) {
+	if sr.Name == "" || sr.Namespace == "" {
+		return nil, "", errors.New(errMissingStoreSecretRefKeys)
+	}
+
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: sr.Namespace, Name: sr.Name}, secret); err != nil {
+		return nil, "", errors.Wrap(err, errCannotReadStoreSecret)
+	}
+
+	f := valueOrDefault(sr.Field, defaultField)
+	data, ok := secret.Data[f]
+	if !ok {
+		return nil, "", errors.Errorf(errMissingStoreField, sr.Name, sr.Namespace, f)
+	}
+	return data, storeType(sr.Type, data), nil
+}
+
+// storeType returns the supplied store type, or detects it from the store.
+func storeType(typ string, data []byte) string {
+	if typ != "" {
+		return strings.ToLower(typ)
+	}
+	if bytes.HasPrefix(data, jksMagic) {
+		return storeTypeJKS
+	}
+	return storeTypePKCS12
+}
+
+// decodeKeystore returns the client certificate and key held by a keystore.
+// JKS keystores must use the store password for their private key entry.
+func decodeKeystore(data []byte, typ, password string) (tls.Certificate, error) {
+	switch typ {
+	case storeTypePKCS12:
+		key, cert, chain, err := pkcs12.DecodeChain(data, password)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+		c := tls.Certificate{PrivateKey: key, Leaf: cert, Certificate: [][]byte{cert.Raw}}
+		for _, ca := range chain {
+			c.Certificate = append(c.Certificate, ca.Raw)
+		}
+		return c, nil
+	case storeTypeJKS:
+		ks, err := loadJKS(data, password)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+		for _, alias := range ks.Aliases() {
+			if !ks.IsPrivateKeyEntry(alias) {
+				continue
+			}
+			e, err := ks.GetPrivateKeyEntry(alias, []byte(password))
+			if err != nil {
+				return tls.Certificate{}, err
+			}
+			key, err := x509.ParsePKCS8PrivateKey(e.PrivateKey)
+			if err != nil {
+				return tls.Certificate{}, err
+			}
+			c := tls.Certificate{PrivateKey: key}
+			for _, cert := range e.CertificateChain {
+				c.Certificate = append(c.Certificate, cert.Content)
+			}
+			return c, nil
+		}
+		return tls.Certificate{}, errors.New(errNoPrivateKeyEntry)
+	default:
+		return tls.Certificate{}, errors.Errorf(errUnknownStoreType, typ)
+	}
+}
+
+// decodeTruststore returns a pool of the CA certificates held by a
+// truststore.
+func decodeTruststore(data []byte, typ, password string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	switch typ {
+	case storeTypePKCS12:
+		certs, err := pkcs12.DecodeTrustStore(data, password)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range certs {
+			pool.AddCert(c)
+		}
+	case storeTypeJKS:
+		ks, err := loadJKS(data, password)
+		if err != nil {
+			return nil, err
+		}
+		for _, alias := range ks.Aliases() {
+			if !ks.IsTrustedCertificateEntry(alias) {
+				continue
+			}
+			e, err := ks.GetTrustedCertificateEntry(alias)
+			if err != nil {
+				return nil, err
+			}
+			c, err := x509.ParseCertificate(e.Certificate.Content)
+			if err != nil {
+				return nil, err
+			}
+			pool.AddCert(c)
+		}
+	default:
+		return nil, errors.Errorf(errUnknownStoreType, typ)
+	}
+	if len(pool.Subjects()) == 0 { //nolint:staticcheck // Only used to check whether the pool is empty.
+		return nil, errors.New(errNoTrustedCertificates)
+	}
+	return pool, nil
+}
+
+func loadJKS(data []byte, password string) (keystore.KeyStore, error) {
+	ks := keystore.New()
+	return ks, ks.Load(bytes.NewReader(data), []byte(password))
+}
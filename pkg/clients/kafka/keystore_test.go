@@ -0,0 +1,144 @@
+package kafka
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+const storePassword = "changeit"
+
+func selfSigned(t *testing.T) (*ecdsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kafka-client"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, cert
+}
+
+func jksStore(t *testing.T, key *ecdsa.PrivateKey, cert *x509.Certificate) (ks []byte, ts []byte) {
+	t.Helper()
+	pk, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := keystore.Certificate{Type: "X509", Content: cert.Raw}
+
+	k := keystore.New()
+	if err := k.SetPrivateKeyEntry("client", keystore.PrivateKeyEntry{CreationTime: time.Now(), PrivateKey: pk, CertificateChain: []keystore.Certificate{c}}, []byte(storePassword)); err != nil {
+		t.Fatal(err)
+	}
+	kb := &bytes.Buffer{}
+	if err := k.Store(kb, []byte(storePassword)); err != nil {
+		t.Fatal(err)
+	}
+
+	tr := keystore.New()
+	if err := tr.SetTrustedCertificateEntry("ca", keystore.TrustedCertificateEntry{CreationTime: time.Now(), Certificate: c}); err != nil {
+		t.Fatal(err)
+	}
+	tb := &bytes.Buffer{}
+	if err := tr.Store(tb, []byte(storePassword)); err != nil {
+		t.Fatal(err)
+	}
+	return kb.Bytes(), tb.Bytes()
+}
+
+func TestStores(t *testing.T) {
+	key, cert := selfSigned(t)
+
+	p12Keystore, err := pkcs12.Modern2023.Encode(key, cert, nil, storePassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p12Truststore, err := pkcs12.Modern2023.EncodeTrustStore([]*x509.Certificate{cert}, storePassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jksKeystore, jksTruststore := jksStore(t, key, cert)
+
+	cases := map[string]struct {
+		keystore   []byte
+		truststore []byte
+		typ        string
+		password   string
+		wantErr    bool
+	}{
+		"PKCS12": {
+			keystore:   p12Keystore,
+			truststore: p12Truststore,
+			password:   storePassword,
+		},
+		"JKS": {
+			keystore:   jksKeystore,
+			truststore: jksTruststore,
+			password:   storePassword,
+		},
+		"ExplicitType": {
+			keystore:   jksKeystore,
+			truststore: jksTruststore,
+			typ:        "JKS",
+			password:   storePassword,
+		},
+		"WrongPassword": {
+			keystore:   p12Keystore,
+			truststore: p12Truststore,
+			password:   "wrong",
+			wantErr:    true,
+		},
+		"UnknownType": {
+			keystore:   p12Keystore,
+			truststore: p12Truststore,
+			typ:        "BKS",
+			password:   storePassword,
+			wantErr:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := decodeKeystore(tc.keystore, storeType(tc.typ, tc.keystore), tc.password)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("decodeKeystore(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err == nil && !bytes.Equal(c.Certificate[0], cert.Raw) {
+				t.Errorf("decodeKeystore(...): got another certificate than was stored")
+			}
+
+			pool, err := decodeTruststore(tc.truststore, storeType(tc.typ, tc.truststore), tc.password)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("decodeTruststore(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if _, err := cert.Verify(x509.VerifyOptions{Roots: pool}); err != nil {
+				t.Errorf("decodeTruststore(...): stored CA is not trusted: %s", err)
+			}
+		})
+	}
+}
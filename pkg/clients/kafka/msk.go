@@ -0,0 +1,107 @@
+package kafka
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	msk "github.com/aws/aws-sdk-go/service/kafka"
+	"github.com/aws/aws-sdk-go/service/kafka/kafkaiface"
+	"github.com/pkg/errors"
+)
+
+const (
+	errParseMSKClusterARN  = "cannot parse MSK cluster ARN"
+	errNewMSKSession       = "cannot create AWS session to look up MSK brokers"
+	errGetBootstrapBrokers = "cannot get bootstrap brokers of MSK cluster"
+	errNoBootstrapBrokers  = "MSK cluster %s has no %s bootstrap brokers"
+)
+
+// MSK identifies an Amazon MSK cluster whose bootstrap brokers are looked up
+// through the MSK API whenever a client connects, replacing any brokers of
+// the credentials. Brokers of an MSK cluster change e.g. when it is scaled,
+// which then never requires updating the credentials.
+type MSK struct {
+	// ClusterARN is the ARN of the cluster. The region is taken from it.
+	ClusterARN string `json:"clusterARN"`
+	// Public connects through the public bootstrap brokers of the cluster.
+	Public bool `json:"public,omitempty"`
+	// AccessKeyID, SecretAccessKey and SessionToken are the AWS credentials
+	// to look up the brokers with, and to authenticate with the AWS-MSK-IAM
+	// SASL mechanism. The default AWS credential chain, e.g. IRSA, is used
+	// if they are empty.
+	AccessKeyID     string `json:"accessKeyID,omitempty"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+	SessionToken    string `json:"sessionToken,omitempty"`
+}
+
+// credentials returns the static AWS credentials of the MSK cluster, or nil
+// to use the default credential chain.
+func (m *MSK) credentials() *credentials.Credentials {
+	if m.AccessKeyID == "" {
+		return nil
+	}
+	return credentials.NewStaticCredentials(m.AccessKeyID, m.SecretAccessKey, m.SessionToken)
+}
+
+// resolveMSKBrokers replaces the brokers of the supplied credentials by the
+// bootstrap brokers of their MSK cluster, if any.
+func resolveMSKBrokers(ctx context.Context, kc *Config) error {
+	if kc.MSK == nil {
+		return nil
+	}
+	a, err := arn.Parse(kc.MSK.ClusterARN)
+	if err != nil {
+		return errors.Wrap(err, errParseMSKClusterARN)
+	}
+	s, err := session.NewSession(&aws.Config{Region: aws.String(a.Region), Credentials: kc.MSK.credentials()})
+	if err != nil {
+		return errors.Wrap(err, errNewMSKSession)
+	}
+	brokers, err := bootstrapBrokers(ctx, msk.New(s), kc)
+	if err != nil {
+		return err
+	}
+	kc.Brokers = brokers
+	if kc.SASL != nil {
+		kc.SASL.AWSCredentials = kc.MSK.credentials()
+	}
+	return nil
+}
+
+// bootstrapBrokers returns the bootstrap brokers of the MSK cluster of the
+// supplied credentials that match how they connect: through SASL/IAM,
+// SASL/SCRAM, TLS or plaintext, publicly or not.
+func bootstrapBrokers(ctx context.Context, api kafkaiface.KafkaAPI, kc *Config) ([]string, error) {
+	out, err := api.GetBootstrapBrokersWithContext(ctx, &msk.GetBootstrapBrokersInput{ClusterArn: aws.String(kc.MSK.ClusterARN)})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetBootstrapBrokers)
+	}
+
+	kind, private, public := "plaintext", out.BootstrapBrokerString, (*string)(nil)
+	mechanism := ""
+	if kc.SASL != nil {
+		mechanism = strings.ToLower(kc.SASL.Mechanism)
+	}
+	switch {
+	case mechanism == "aws-msk-iam":
+		kind, private, public = "SASL/IAM", out.BootstrapBrokerStringSaslIam, out.BootstrapBrokerStringPublicSaslIam
+	case strings.HasPrefix(mechanism, "scram-"):
+		kind, private, public = "SASL/SCRAM", out.BootstrapBrokerStringSaslScram, out.BootstrapBrokerStringPublicSaslScram
+	case kc.TLS != nil:
+		kind, private, public = "TLS", out.BootstrapBrokerStringTls, out.BootstrapBrokerStringPublicTls
+	}
+	s := private
+	if kc.MSK.Public {
+		kind, s = "public "+kind, public
+	}
+
+	brokers := strings.Split(aws.StringValue(s), ",")
+	if brokers[0] == "" {
+		return nil, errors.Errorf(errNoBootstrapBrokers, kc.MSK.ClusterARN, kind)
+	}
+	return brokers, nil
+}
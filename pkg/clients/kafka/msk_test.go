@@ -0,0 +1,75 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	msk "github.com/aws/aws-sdk-go/service/kafka"
+	"github.com/aws/aws-sdk-go/service/kafka/kafkaiface"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeMSK struct {
+	kafkaiface.KafkaAPI
+	out *msk.GetBootstrapBrokersOutput
+}
+
+func (f *fakeMSK) GetBootstrapBrokersWithContext(_ aws.Context, _ *msk.GetBootstrapBrokersInput, _ ...request.Option) (*msk.GetBootstrapBrokersOutput, error) {
+	return f.out, nil
+}
+
+func TestBootstrapBrokers(t *testing.T) {
+	out := &msk.GetBootstrapBrokersOutput{
+		BootstrapBrokerString:              aws.String("b-1:9092,b-2:9092"),
+		BootstrapBrokerStringTls:           aws.String("b-1:9094,b-2:9094"),
+		BootstrapBrokerStringSaslScram:     aws.String("b-1:9096,b-2:9096"),
+		BootstrapBrokerStringSaslIam:       aws.String("b-1:9098,b-2:9098"),
+		BootstrapBrokerStringPublicSaslIam: aws.String("b-1-public:9198"),
+	}
+	arn := "arn:aws:kafka:eu-west-1:123456789012:cluster/orders/abc"
+
+	cases := map[string]struct {
+		kc      *Config
+		want    []string
+		wantErr bool
+	}{
+		"Plaintext": {
+			kc:   &Config{MSK: &MSK{ClusterARN: arn}},
+			want: []string{"b-1:9092", "b-2:9092"},
+		},
+		"TLS": {
+			kc:   &Config{MSK: &MSK{ClusterARN: arn}, TLS: &TLS{}},
+			want: []string{"b-1:9094", "b-2:9094"},
+		},
+		"SCRAM": {
+			kc:   &Config{MSK: &MSK{ClusterARN: arn}, TLS: &TLS{}, SASL: &SASL{Mechanism: "SCRAM-SHA-512"}},
+			want: []string{"b-1:9096", "b-2:9096"},
+		},
+		"IAM": {
+			kc:   &Config{MSK: &MSK{ClusterARN: arn}, TLS: &TLS{}, SASL: &SASL{Mechanism: "AWS-MSK-IAM"}},
+			want: []string{"b-1:9098", "b-2:9098"},
+		},
+		"PublicIAM": {
+			kc:   &Config{MSK: &MSK{ClusterARN: arn, Public: true}, TLS: &TLS{}, SASL: &SASL{Mechanism: "aws-msk-iam"}},
+			want: []string{"b-1-public:9198"},
+		},
+		"NoPublicTLS": {
+			kc:      &Config{MSK: &MSK{ClusterARN: arn, Public: true}, TLS: &TLS{}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := bootstrapBrokers(context.Background(), &fakeMSK{out: out}, tc.kc)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("bootstrapBrokers(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("bootstrapBrokers(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
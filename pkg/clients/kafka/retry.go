@@ -0,0 +1,47 @@
+package kafka
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+const (
+	errCannotRefreshMetadata = "cannot refresh metadata after NOT_CONTROLLER"
+)
+
+// RefreshMetadata forces the client to re-learn the cluster's brokers and its
+// current controller. No topics are requested, so this is cheap even on
+// large clusters.
+func RefreshMetadata(ctx context.Context, r kmsg.Requestor) error {
+	req := kmsg.NewPtrMetadataRequest()
+	req.Topics = []kmsg.MetadataRequestTopic{}
+	_, err := req.RequestWith(ctx, r)
+	return err
+}
+
+// RetryOnNotController calls fn, and calls it once more if it failed with
+// NOT_CONTROLLER. This happens during controller failover, when a mutation
+// lands on a broker that is no longer the controller. Metadata is refreshed
+// before retrying so that the retry is sent to the new controller, rather
+// than failing the whole reconcile and waiting for its backoff.
+func RetryOnNotController(ctx context.Context, r kmsg.Requestor, fn func() error) error {
+	err := fn()
+	if !errors.Is(err, kerr.NotController) {
+		return err
+	}
+	if rerr := RefreshMetadata(ctx, r); rerr != nil {
+		return errors.Wrap(rerr, errCannotRefreshMetadata)
+	}
+	return fn()
+}
+
+// Interrupted returns true if the supplied error of a request means it was
+// cancelled or timed out before the brokers answered. Such a request may or
+// may not have been applied, which only observing the brokers again tells.
+func Interrupted(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded)
+}
@@ -0,0 +1,106 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+type fakeRequestor struct {
+	requests []kmsg.Request
+	err      error
+}
+
+func (f *fakeRequestor) Request(_ context.Context, req kmsg.Request) (kmsg.Response, error) {
+	f.requests = append(f.requests, req)
+	return req.ResponseKind(), f.err
+}
+
+func TestRetryOnNotController(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err       error
+		calls     int
+		refreshes int
+	}
+
+	cases := map[string]struct {
+		reason     string
+		errs       []error
+		refreshErr error
+		want       want
+	}{
+		"Success": {
+			reason: "A successful call should not be retried.",
+			errs:   []error{nil},
+			want:   want{calls: 1},
+		},
+		"OtherError": {
+			reason: "Errors other than NOT_CONTROLLER should not be retried.",
+			errs:   []error{errBoom},
+			want:   want{err: errBoom, calls: 1},
+		},
+		"NotControllerThenSuccess": {
+			reason: "NOT_CONTROLLER should refresh metadata and retry once.",
+			errs:   []error{errors.Wrap(kerr.NotController, "cannot create topic"), nil},
+			want:   want{calls: 2, refreshes: 1},
+		},
+		"NotControllerTwice": {
+			reason: "Only one retry should be attempted.",
+			errs:   []error{kerr.NotController, kerr.NotController},
+			want:   want{err: kerr.NotController, calls: 2, refreshes: 1},
+		},
+		"RefreshError": {
+			reason:     "A failed metadata refresh should be returned without retrying.",
+			errs:       []error{kerr.NotController},
+			refreshErr: errBoom,
+			want:       want{err: errors.Wrap(errBoom, errCannotRefreshMetadata), calls: 1, refreshes: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &fakeRequestor{err: tc.refreshErr}
+			calls := 0
+			err := RetryOnNotController(context.Background(), r, func() error {
+				err := tc.errs[calls]
+				calls++
+				return err
+			})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRetryOnNotController(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nRetryOnNotController(...): -want calls, +got calls:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.refreshes, len(r.requests)); diff != "" {
+				t.Errorf("\n%s\nRetryOnNotController(...): -want refreshes, +got refreshes:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestInterrupted(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"Cancelled":       {err: errors.Wrap(context.Canceled, "cannot create topic"), want: true},
+		"DeadlineExpired": {err: errors.Wrap(context.DeadlineExceeded, "cannot create topic"), want: true},
+		"BrokerError":     {err: errors.Wrap(kerr.TopicAlreadyExists, "cannot create topic")},
+		"NoError":         {},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := Interrupted(tc.err); got != tc.want {
+				t.Errorf("Interrupted(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package kafka
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+const (
+	errCannotProbeSASL         = "cannot probe brokers for supported SASL mechanisms"
+	errNoSupportedMechanism    = "none of the SASL mechanisms supported by the brokers %v can be negotiated automatically"
+	errCannotCreateProbeClient = "cannot create client to probe SASL mechanisms"
+	errNoMechanismAccepted     = "the brokers accepted the credentials with none of the SASL mechanisms: %s"
+)
+
+// negotiableMechanisms are the SASL mechanisms that may be picked when
+// credentials don't specify one, strongest first.
+var negotiableMechanisms = []string{"SCRAM-SHA-512", "SCRAM-SHA-256", "PLAIN"}
+
+// negotiateSASLMechanism asks the brokers which SASL mechanisms they have
+// enabled using a SaslHandshake request, and returns the strongest of them
+// that can be used with a username and password. The mechanism is kept by the
+// client it was negotiated for, so brokers are only probed again when a
+// ClientCache creates a new client, e.g. once it evicted or replaced the
+// previous one. A change of the mechanisms the brokers enable is picked up
+// then.
+func negotiateSASLMechanism(ctx context.Context, brokers []string, opts []kgo.Opt) (string, error) {
+	cl, err := kgo.NewClient(opts...)
+	if err != nil {
+		return "", errors.Wrap(err, errCannotCreateProbeClient)
+	}
+	defer cl.Close()
+
+	// An empty mechanism is never supported, so brokers answer with the list
+	// of mechanisms they have enabled.
+	req := kmsg.NewPtrSASLHandshakeRequest()
+	resp, err := req.RequestWith(ctx, cl)
+	if err != nil {
+		return "", errors.Wrap(err, errCannotProbeSASL)
+	}
+
+	m, ok := strongestMechanism(resp.SupportedMechanisms)
+	if !ok {
+		return "", errors.Errorf(errNoSupportedMechanism, resp.SupportedMechanisms)
+	}
+	loggerFrom(ctx).Info("Negotiated SASL mechanism", "brokers", strings.Join(brokers, ","), "mechanism", m, "supported", resp.SupportedMechanisms)
+	return m, nil
+}
+
+// strongestMechanism returns the strongest negotiable mechanism among the
+// supplied ones.
+func strongestMechanism(supported []string) (string, bool) {
+	for _, m := range negotiableMechanisms {
+		for _, s := range supported {
+			if strings.EqualFold(m, s) {
+				return m, true
+			}
+		}
+	}
+	return "", false
+}
+
+// tryMechanisms authenticates with each of the SASL mechanisms of the supplied
+// credentials in turn, and returns the first the brokers accept them with.
+// Mechanisms are only tried again when a client is created anew, which the
+// ClientCache does periodically, so that a migration between mechanisms is
+// eventually picked up.
+func tryMechanisms(ctx context.Context, opts []kgo.Opt, s *SASL) (string, error) {
+	log := loggerFrom(ctx)
+	failed := make([]string, 0, len(s.Mechanisms))
+	for _, name := range s.Mechanisms {
+		err := tryMechanism(ctx, opts, name, s)
+		if err == nil {
+			log.Info("Authenticated with SASL mechanism", "mechanism", name, "failed", failed)
+			return name, nil
+		}
+		// Brokers may close the connection rather than answer a failed
+		// authentication, so any error moves on to the next mechanism.
+		log.Info("Cannot authenticate with SASL mechanism", "mechanism", name, "error", err)
+		failed = append(failed, name+": "+err.Error())
+	}
+	return "", errors.Errorf(errNoMechanismAccepted, strings.Join(failed, "; "))
+}
+
+// tryMechanism returns an error unless the brokers accept the supplied
+// credentials with the named SASL mechanism.
+func tryMechanism(ctx context.Context, opts []kgo.Opt, name string, s *SASL) error {
+	m, mopts, err := saslMechanism(name, s)
+	if err != nil {
+		return err
+	}
+	opts = append(append(append([]kgo.Opt{}, opts...), mopts...), kgo.SASL(m), kgo.RequestRetries(0))
+	cl, err := kgo.NewClient(opts...)
+	if err != nil {
+		return errors.Wrap(err, errCannotCreateProbeClient)
+	}
+	defer cl.Close()
+
+	// A metadata request for no topics is cheap, and is only answered once
+	// the connection is authenticated.
+	req := kmsg.NewPtrMetadataRequest()
+	req.Topics = []kmsg.MetadataRequestTopic{}
+	_, err = req.RequestWith(ctx, cl)
+	return err
+}
@@ -0,0 +1,227 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// recordingLogger records the messages logged at info level, each followed by
+// its key value pairs.
+type recordingLogger struct {
+	logged *[][]any
+}
+
+func (l recordingLogger) Info(msg string, keysAndValues ...any) {
+	*l.logged = append(*l.logged, append([]any{msg}, keysAndValues...))
+}
+
+func (l recordingLogger) Debug(_ string, _ ...any) {}
+
+func (l recordingLogger) WithValues(_ ...any) logging.Logger { return l }
+
+func TestStrongestMechanism(t *testing.T) {
+	type want struct {
+		mechanism string
+		ok        bool
+	}
+
+	cases := map[string]struct {
+		supported []string
+		want      want
+	}{
+		"PrefersSCRAM512": {
+			supported: []string{"PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512"},
+			want:      want{mechanism: "SCRAM-SHA-512", ok: true},
+		},
+		"PrefersSCRAM256OverPlain": {
+			supported: []string{"PLAIN", "SCRAM-SHA-256"},
+			want:      want{mechanism: "SCRAM-SHA-256", ok: true},
+		},
+		"CaseInsensitive": {
+			supported: []string{"plain"},
+			want:      want{mechanism: "PLAIN", ok: true},
+		},
+		"NoneNegotiable": {
+			supported: []string{"GSSAPI", "OAUTHBEARER"},
+			want:      want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, ok := strongestMechanism(tc.supported)
+			if diff := cmp.Diff(tc.want, want{mechanism: m, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("strongestMechanism(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSASLMechanism(t *testing.T) {
+	type want struct {
+		name    string
+		initial string
+		err     bool
+	}
+
+	cases := map[string]struct {
+		mechanism string
+		sasl      *SASL
+		want      want
+	}{
+		"PlainAuthzid": {
+			mechanism: "PLAIN",
+			sasl:      &SASL{Username: "admin", Password: "secret", Authzid: "orders"},
+			want:      want{name: "PLAIN", initial: "orders\x00admin\x00secret"},
+		},
+		"SCRAMToken": {
+			mechanism: "SCRAM-SHA-512",
+			sasl:      &SASL{Username: "token-id", Password: "hmac", Authzid: "orders", TokenAuth: true},
+			want:      want{name: "SCRAM-SHA-512", initial: "n,a=orders,n=token-id,r="},
+		},
+		"PlainToken": {
+			mechanism: "PLAIN",
+			sasl:      &SASL{Username: "admin", Password: "secret", TokenAuth: true},
+			want:      want{err: true},
+		},
+		"IAMAuthzid": {
+			mechanism: "AWS-MSK-IAM",
+			sasl:      &SASL{Authzid: "orders"},
+			want:      want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, _, err := saslMechanism(tc.mechanism, tc.sasl)
+			got := want{err: err != nil}
+			if err == nil {
+				_, initial, err := m.Authenticate(context.Background(), "kafka:9092")
+				if err != nil {
+					t.Fatalf("Authenticate(...): %v", err)
+				}
+				got.name = m.Name()
+				got.initial = string(initial)
+				// SCRAM appends a random nonce.
+				if i := strings.Index(got.initial, ",r="); i >= 0 {
+					got.initial = got.initial[:i+3]
+				}
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("saslMechanism(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNegotiateSASLMechanism(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.EnableSASL(), kfake.Superuser("PLAIN", "admin", "secret"))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	// The brokers enable SCRAM-SHA-512 after the first client was created.
+	enabled := [][]string{{"PLAIN"}, {"PLAIN", "SCRAM-SHA-512"}}
+	c.ControlKey(int16(kmsg.SASLHandshake), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+		c.KeepControl()
+		resp := kreq.ResponseKind().(*kmsg.SASLHandshakeResponse)
+		resp.ErrorCode = kerr.UnsupportedSaslMechanism.Code
+		resp.SupportedMechanisms = enabled[0]
+		if len(enabled) > 1 {
+			enabled = enabled[1:]
+		}
+		return resp, nil, true
+	})
+
+	var logged [][]any
+	ctx := withLogger(context.Background(), recordingLogger{logged: &logged})
+	opts := []kgo.Opt{kgo.SeedBrokers(c.ListenAddrs()...)}
+	got := make([]string, 0, 2)
+	for i := 0; i < 2; i++ {
+		m, err := negotiateSASLMechanism(ctx, c.ListenAddrs(), opts)
+		if err != nil {
+			t.Fatalf("negotiateSASLMechanism(...): %v", err)
+		}
+		got = append(got, m)
+	}
+	if diff := cmp.Diff([]string{"PLAIN", "SCRAM-SHA-512"}, got); diff != "" {
+		t.Errorf("negotiateSASLMechanism(...) for each new client: -want, +got:\n%s", diff)
+	}
+	for i, l := range logged {
+		if diff := cmp.Diff([]any{"Negotiated SASL mechanism", "mechanism", got[i]}, []any{l[0], l[3], l[4]}); diff != "" {
+			t.Errorf("negotiateSASLMechanism(...) logged: -want, +got:\n%s", diff)
+		}
+	}
+	if len(logged) != len(got) {
+		t.Errorf("negotiateSASLMechanism(...) logged %d messages, want %d", len(logged), len(got))
+	}
+}
+
+func TestNewAdminClientMechanisms(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.EnableSASL(), kfake.Superuser("PLAIN", "admin", "secret"))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	cases := map[string]struct {
+		mechanisms []string
+		wantErr    bool
+		// wantLogged are the messages logged, each with its mechanism.
+		wantLogged [][]any
+	}{
+		"FallsBackToPlain": {
+			mechanisms: []string{"SCRAM-SHA-512", "PLAIN"},
+			wantLogged: [][]any{
+				{"Cannot authenticate with SASL mechanism", "SCRAM-SHA-512"},
+				{"Authenticated with SASL mechanism", "PLAIN"},
+			},
+		},
+		"NoneAccepted": {
+			mechanisms: []string{"SCRAM-SHA-512", "SCRAM-SHA-256"},
+			wantErr:    true,
+			wantLogged: [][]any{
+				{"Cannot authenticate with SASL mechanism", "SCRAM-SHA-512"},
+				{"Cannot authenticate with SASL mechanism", "SCRAM-SHA-256"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			creds, _ := json.Marshal(Config{
+				Brokers: c.ListenAddrs(),
+				SASL:    &SASL{Mechanisms: tc.mechanisms, Username: "admin", Password: "secret"},
+			})
+			var logged [][]any
+			cl, err := NewAdminClient(withLogger(context.Background(), recordingLogger{logged: &logged}), creds, nil)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewAdminClient(...): error = %v, wantErr %v", err, tc.wantErr)
+			}
+			got := make([][]any, 0, len(logged))
+			for _, l := range logged {
+				got = append(got, []any{l[0], l[2]})
+			}
+			if diff := cmp.Diff(tc.wantLogged, got); diff != "" {
+				t.Errorf("NewAdminClient(...) logged: -want, +got:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			defer cl.Close()
+			if _, err := cl.ListTopics(context.Background()); err != nil {
+				t.Errorf("ListTopics(...): %v", err)
+			}
+		})
+	}
+}
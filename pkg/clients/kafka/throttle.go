@@ -0,0 +1,112 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// A ThrottleObserver is notified of every response with which a broker
+// throttled a client, e.g. because the client exceeded its request quota.
+type ThrottleObserver func(broker int32, d time.Duration)
+
+// throttle records until when the brokers throttle a client. It is notified
+// of throttled responses through the kgo.HookBrokerThrottle hook.
+type throttle struct {
+	observe ThrottleObserver
+
+	mu    sync.Mutex
+	until time.Time
+}
+
+// OnBrokerThrottle implements kgo.HookBrokerThrottle.
+func (t *throttle) OnBrokerThrottle(meta kgo.BrokerMetadata, d time.Duration, _ bool) {
+	if d <= 0 {
+		return
+	}
+	if t.observe != nil {
+		t.observe(meta.NodeID, d)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if u := time.Now().Add(d); u.After(t.until) {
+		t.until = u
+	}
+}
+
+func (t *throttle) throttledUntil() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.until
+}
+
+type throttlingKey struct{}
+
+// Throttling records the clients a reconcile got from a ClientCache, to learn
+// until when the brokers throttle them.
+type Throttling struct {
+	mu        sync.Mutex
+	throttles []*throttle
+}
+
+// WithThrottling returns a context recording the clients a ClientCache returns
+// for it in the returned Throttling.
+func WithThrottling(ctx context.Context) (context.Context, *Throttling) {
+	t := &Throttling{}
+	return context.WithValue(ctx, throttlingKey{}, t), t
+}
+
+// Until returns until when the brokers throttle any of the recorded clients.
+// It is in the past if none is throttled.
+func (t *Throttling) Until() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	until := time.Time{}
+	for _, th := range t.throttles {
+		if u := th.throttledUntil(); u.After(until) {
+			until = u
+		}
+	}
+	return until
+}
+
+func (t *Throttling) record(th *throttle) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.throttles = append(t.throttles, th)
+}
+
+func recordThrottle(ctx context.Context, th *throttle) {
+	if t, ok := ctx.Value(throttlingKey{}).(*Throttling); ok {
+		t.record(th)
+	}
+}
+
+// NewThrottlingReconciler returns a reconciler that requeues a reconcile of
+// the supplied reconciler that was throttled by the brokers no sooner than
+// the throttle ends, rather than retrying immediately and being throttled
+// harder.
+func NewThrottlingReconciler(r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		ctx, t := WithThrottling(ctx)
+		res, err := r.Reconcile(ctx, req)
+		return throttledResult(res, err, time.Until(t.Until())), err
+	})
+}
+
+// throttledResult returns the supplied result of a reconcile, requeued after
+// the supplied remaining throttle if it would otherwise be requeued sooner.
+func throttledResult(res reconcile.Result, err error, remaining time.Duration) reconcile.Result {
+	if remaining <= 0 || err != nil {
+		// Errors are requeued with the backoff of the rate limiter, which
+		// ignores the result.
+		return res
+	}
+	if res.Requeue || (res.RequeueAfter > 0 && res.RequeueAfter < remaining) {
+		return reconcile.Result{RequeueAfter: remaining}
+	}
+	return res
+}
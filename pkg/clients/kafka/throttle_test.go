@@ -0,0 +1,92 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestThrottling(t *testing.T) {
+	c := newTestCache(t)
+	observed := time.Duration(0)
+	c.WithThrottleObserver(func(_ int32, d time.Duration) { observed += d })
+
+	ctx, th := WithThrottling(context.Background())
+	if _, err := c.Get(ctx, "", []byte("a"), nil); err != nil {
+		t.Fatalf("Get(a): %s", err)
+	}
+	if !th.Until().IsZero() {
+		t.Errorf("Until(): want zero before any throttle, got %s", th.Until())
+	}
+
+	for _, cc := range c.clients {
+		cc.throttle.OnBrokerThrottle(kgo.BrokerMetadata{NodeID: 1}, time.Minute, true)
+	}
+	if remaining := time.Until(th.Until()); remaining < 50*time.Second {
+		t.Errorf("Until(): want about a minute from now, got %s", remaining)
+	}
+	if observed != time.Minute {
+		t.Errorf("OnBrokerThrottle(...): want %s observed, got %s", time.Minute, observed)
+	}
+
+	_, other := WithThrottling(context.Background())
+	if !other.Until().IsZero() {
+		t.Errorf("Until(): want zero for a reconcile that got no client, got %s", other.Until())
+	}
+}
+
+func TestThrottledResult(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason    string
+		res       reconcile.Result
+		err       error
+		remaining time.Duration
+		want      reconcile.Result
+	}{
+		"NotThrottled": {
+			reason: "A reconcile that was not throttled should be requeued as it asked.",
+			res:    reconcile.Result{Requeue: true},
+			want:   reconcile.Result{Requeue: true},
+		},
+		"Requeue": {
+			reason:    "A throttled reconcile should not be requeued before the throttle ends.",
+			res:       reconcile.Result{Requeue: true},
+			remaining: time.Minute,
+			want:      reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"RequeueSooner": {
+			reason:    "A throttled reconcile should not be requeued before the throttle ends.",
+			res:       reconcile.Result{RequeueAfter: time.Second},
+			remaining: time.Minute,
+			want:      reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"RequeueLater": {
+			reason:    "A throttled reconcile that is requeued after the throttle ends should be left alone.",
+			res:       reconcile.Result{RequeueAfter: time.Hour},
+			remaining: time.Minute,
+			want:      reconcile.Result{RequeueAfter: time.Hour},
+		},
+		"Error": {
+			reason:    "The result of a reconcile that returned an error should be left alone, as it is ignored.",
+			err:       errBoom,
+			remaining: time.Minute,
+			want:      reconcile.Result{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := throttledResult(tc.res, tc.err, tc.remaining)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nthrottledResult(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
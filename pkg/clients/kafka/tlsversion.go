@@ -0,0 +1,99 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errUnknownTLSVersion    = "unknown TLS minVersion %q, use one of 1.0, 1.1, 1.2 or 1.3"
+	errUnknownCipherSuite   = "unknown TLS cipher suite %q, use one of %s"
+	errInsecureCipherSuite  = "TLS cipher suite %q is insecure"
+	errTLS13CipherSuite     = "TLS cipher suite %q is a TLS 1.3 cipher suite, which cannot be restricted"
+	errCipherSuitesMinTLS13 = "TLS cipherSuites only apply up to TLS 1.2, but minVersion is 1.3"
+)
+
+// tlsVersions are the TLS versions a minVersion can name.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// configureTLSVersions restricts the TLS versions and cipher suites of the
+// supplied TLS config to those the credentials allow, if any.
+func configureTLSVersions(kc Config, tc *tls.Config) error {
+	if v := kc.TLS.MinVersion; v != "" {
+		version, ok := tlsVersions[v]
+		if !ok {
+			return errors.Errorf(errUnknownTLSVersion, v)
+		}
+		tc.MinVersion = version
+	}
+	if len(kc.TLS.CipherSuites) == 0 {
+		return nil
+	}
+	if tc.MinVersion == tls.VersionTLS13 {
+		return errors.New(errCipherSuitesMinTLS13)
+	}
+	ids, err := cipherSuites(kc.TLS.CipherSuites)
+	if err != nil {
+		return err
+	}
+	tc.CipherSuites = ids
+	return nil
+}
+
+// cipherSuites returns the IDs of the cipher suites of the supplied names.
+// Only secure cipher suites up to TLS 1.2 can be named.
+func cipherSuites(names []string) ([]uint16, error) {
+	secure := map[string]*tls.CipherSuite{}
+	for _, cs := range tls.CipherSuites() {
+		secure[cs.Name] = cs
+	}
+	insecure := map[string]bool{}
+	for _, cs := range tls.InsecureCipherSuites() {
+		insecure[cs.Name] = true
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, n := range names {
+		cs, ok := secure[n]
+		switch {
+		case insecure[n]:
+			return nil, errors.Errorf(errInsecureCipherSuite, n)
+		case !ok:
+			return nil, errors.Errorf(errUnknownCipherSuite, n, strings.Join(cipherSuiteNames(secure), ", "))
+		case onlyTLS13(cs):
+			return nil, errors.Errorf(errTLS13CipherSuite, n)
+		}
+		ids = append(ids, cs.ID)
+	}
+	return ids, nil
+}
+
+// cipherSuiteNames returns the sorted names of the supplied cipher suites that
+// can be restricted.
+func cipherSuiteNames(suites map[string]*tls.CipherSuite) []string {
+	names := make([]string, 0, len(suites))
+	for n, cs := range suites {
+		if !onlyTLS13(cs) {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func onlyTLS13(cs *tls.CipherSuite) bool {
+	for _, v := range cs.SupportedVersions {
+		if v != tls.VersionTLS13 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConfigureTLSVersions(t *testing.T) {
+	cases := map[string]struct {
+		tls     TLS
+		want    *tls.Config
+		wantErr bool
+	}{
+		"Defaults": {
+			want: &tls.Config{},
+		},
+		"Baseline": {
+			tls: TLS{MinVersion: "1.2", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"}},
+			want: &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+			},
+		},
+		"TLS13Only": {
+			tls:  TLS{MinVersion: "1.3"},
+			want: &tls.Config{MinVersion: tls.VersionTLS13},
+		},
+		"UnknownVersion": {
+			tls:     TLS{MinVersion: "TLSv1.2"},
+			wantErr: true,
+		},
+		"UnknownCipherSuite": {
+			tls:     TLS{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA"}},
+			wantErr: true,
+		},
+		"InsecureCipherSuite": {
+			tls:     TLS{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}},
+			wantErr: true,
+		},
+		"TLS13CipherSuite": {
+			tls:     TLS{CipherSuites: []string{"TLS_AES_128_GCM_SHA256"}},
+			wantErr: true,
+		},
+		"CipherSuitesWithTLS13": {
+			tls:     TLS{MinVersion: "1.3", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &tls.Config{}
+			err := configureTLSVersions(Config{TLS: &tc.tls}, got)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("configureTLSVersions(...): error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.MinVersion, got.MinVersion); diff != "" {
+				t.Errorf("configureTLSVersions(...): -want min version, +got min version:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.CipherSuites, got.CipherSuites); diff != "" {
+				t.Errorf("configureTLSVersions(...): -want cipher suites, +got cipher suites:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package topic
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kfake"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+func TestArchive(t *testing.T) {
+	c, err := kfake.NewCluster()
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(ctx, creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	// Topics seeded without configs cannot be altered by kfake.
+	if _, err := cl.CreateTopic(ctx, 1, 1, map[string]*string{"retention.ms": kadm.StringPtr("60000")}, "orders"); err != nil {
+		t.Fatalf("CreateTopic(...): %v", err)
+	}
+
+	if err := Archive(ctx, cl, "orders"); err != nil {
+		t.Fatalf("Archive(...): %v", err)
+	}
+	tc, err := cl.DescribeTopicConfigs(ctx, "orders")
+	if err != nil {
+		t.Fatalf("DescribeTopicConfigs(...): %v", err)
+	}
+	rc, err := tc.On("orders", nil)
+	if err != nil {
+		t.Fatalf("On(...): %v", err)
+	}
+	got := map[string]string{}
+	for _, c := range rc.Configs {
+		if c.Key == "retention.ms" || c.Key == "retention.bytes" {
+			got[c.Key] = c.MaybeValue()
+		}
+	}
+	if diff := cmp.Diff(map[string]string{"retention.ms": "-1", "retention.bytes": "-1"}, got); diff != "" {
+		t.Errorf("Archive(...): -want retention, +got:\n%s", diff)
+	}
+
+	if err := Archive(ctx, cl, "payments"); err == nil {
+		t.Errorf("Archive(...) of a missing topic: want error, got nil")
+	}
+}
@@ -0,0 +1,43 @@
+package topic
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kerr"
+)
+
+// The ACL operations on a topic the provider needs to observe it.
+const (
+	OperationDescribe        = "Describe"
+	OperationDescribeConfigs = "DescribeConfigs"
+)
+
+const errUnauthorized = "the principal of the provider is not allowed the %s operation on topic %q"
+
+// An AuthorizationError reports that the brokers refused an operation on a
+// topic with TOPIC_AUTHORIZATION_FAILED, as no ACL allows the principal of
+// the provider the operation on the topic.
+type AuthorizationError struct {
+	Topic     string
+	Operation string
+}
+
+func (e *AuthorizationError) Error() string {
+	return fmt.Sprintf(errUnauthorized, e.Operation, e.Topic)
+}
+
+// Unwrap returns the TOPIC_AUTHORIZATION_FAILED error.
+func (e *AuthorizationError) Unwrap() error {
+	return kerr.TopicAuthorizationFailed
+}
+
+// unauthorized returns an AuthorizationError for the supplied operation on the
+// supplied topic if the supplied error is TOPIC_AUTHORIZATION_FAILED, and nil
+// otherwise.
+func unauthorized(err error, name, operation string) error {
+	if !errors.Is(err, kerr.TopicAuthorizationFailed) {
+		return nil
+	}
+	return &AuthorizationError{Topic: name, Operation: operation}
+}
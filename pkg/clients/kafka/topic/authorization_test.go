@@ -0,0 +1,97 @@
+package topic
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+func TestGetUnauthorized(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.SeedTopics(1, "orders", "payments"))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	// The provider may not describe secrets, nor the configs of payments.
+	c.ControlKey(int16(kmsg.Metadata), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+		c.KeepControl()
+		req := kreq.(*kmsg.MetadataRequest)
+		if len(req.Topics) != 1 || req.Topics[0].Topic == nil || *req.Topics[0].Topic != "secrets" {
+			return nil, nil, false
+		}
+		resp := req.ResponseKind().(*kmsg.MetadataResponse)
+		rt := kmsg.NewMetadataResponseTopic()
+		rt.Topic = kmsg.StringPtr("secrets")
+		rt.ErrorCode = kerr.TopicAuthorizationFailed.Code
+		resp.Topics = append(resp.Topics, rt)
+		return resp, nil, true
+	})
+	c.ControlKey(int16(kmsg.DescribeConfigs), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+		c.KeepControl()
+		req := kreq.(*kmsg.DescribeConfigsRequest)
+		if len(req.Resources) != 1 || req.Resources[0].ResourceName != "payments" {
+			return nil, nil, false
+		}
+		resp := req.ResponseKind().(*kmsg.DescribeConfigsResponse)
+		rr := kmsg.NewDescribeConfigsResponseResource()
+		rr.ResourceType = kmsg.ConfigResourceTypeTopic
+		rr.ResourceName = "payments"
+		rr.ErrorCode = kerr.TopicAuthorizationFailed.Code
+		resp.Resources = append(resp.Resources, rr)
+		return resp, nil, true
+	})
+
+	ctx := context.Background()
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(ctx, creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	cases := map[string]struct {
+		reason string
+		name   string
+		want   *AuthorizationError
+	}{
+		"Describe": {
+			reason: "A topic the provider may not describe should be reported as such, rather than as missing.",
+			name:   "secrets",
+			want:   &AuthorizationError{Topic: "secrets", Operation: OperationDescribe},
+		},
+		"DescribeConfigs": {
+			reason: "A topic whose configs the provider may not describe should be reported as such.",
+			name:   "payments",
+			want:   &AuthorizationError{Topic: "payments", Operation: OperationDescribeConfigs},
+		},
+		"Authorized": {
+			reason: "A topic the provider may describe should be got.",
+			name:   "orders",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Get(ctx, cl, tc.name)
+			var got *AuthorizationError
+			if !errors.As(err, &got) && err != nil {
+				t.Fatalf("\n%s\nGet(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGet(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if got != nil && !errors.Is(err, kerr.TopicAuthorizationFailed) {
+				t.Errorf("\n%s\nGet(...): want error wrapping TOPIC_AUTHORIZATION_FAILED, got %v", tc.reason, err)
+			}
+		})
+	}
+}
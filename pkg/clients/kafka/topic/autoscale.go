@@ -0,0 +1,127 @@
+package topic
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kadm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const (
+	// DefaultAutoScaleWindow is how long throughput is measured over when
+	// a PartitionsAutoScale configures no window.
+	DefaultAutoScaleWindow = 5 * time.Minute
+
+	// maxScaleEvents is the number of scale events kept in the status of a
+	// Topic.
+	maxScaleEvents = 10
+)
+
+// A Throughput sample of a topic.
+type Throughput struct {
+	// EndOffsets is the sum of the end offsets of the partitions of the
+	// topic, i.e. the number of records ever produced to it.
+	EndOffsets int64
+	// RecordBytes is the average size of the records retained in the
+	// topic, or zero if it retains none.
+	RecordBytes float64
+}
+
+// SampleThroughput returns a throughput sample of the topic with the supplied
+// name and replication factor. Offsets only count records, so their size is
+// estimated from the size on disk of the topic, which requires describing
+// the log dirs of the brokers.
+func SampleThroughput(ctx context.Context, client *kafka.Client, name string, replicationFactor int16) (*Throughput, error) {
+	ends, err := client.ListEndOffsets(ctx, name)
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotListOffsets)
+	}
+	if err := ends.Error(); err != nil {
+		return nil, errors.Wrap(err, errCannotListOffsets)
+	}
+	sz, err := GetSize(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Throughput{}
+	ends.Each(func(o kadm.ListedOffset) { t.EndOffsets += o.Offset })
+	if sz.Records > 0 && replicationFactor > 0 {
+		t.RecordBytes = float64(sz.Bytes) / float64(replicationFactor) / float64(sz.Records)
+	}
+	return t, nil
+}
+
+// ScaledPartitions returns the number of partitions a topic with the supplied
+// number of partitions, to which records are produced at the supplied rate in
+// bytes per second, should be grown to. It is never less than the supplied
+// number, nor more than the maximum unless the topic already has more.
+func ScaledPartitions(as *v1alpha1.PartitionsAutoScale, partitions int, bytesInPerSecond float64) int {
+	if as.TargetBytesInPerPartition <= 0 || partitions >= as.MaxPartitions {
+		return partitions
+	}
+	want := int(math.Ceil(bytesInPerSecond / float64(as.TargetBytesInPerPartition)))
+	if want > as.MaxPartitions {
+		want = as.MaxPartitions
+	}
+	if want < partitions {
+		return partitions
+	}
+	return want
+}
+
+// AutoScale measures the throughput of a topic with the supplied number of
+// partitions over the window of the supplied PartitionsAutoScale, using the
+// supplied sample taken now, and records it in the supplied observation. It
+// returns the number of partitions to grow the topic to once a window is
+// complete, and records it along with a scale event if it exceeds the
+// supplied number. The window restarts if the topic lost records, e.g. as it
+// was recreated.
+func AutoScale(as *v1alpha1.PartitionsAutoScale, o *v1alpha1.PartitionsAutoScaleObservation, partitions int, sample *Throughput, now time.Time) int {
+	window := DefaultAutoScaleWindow
+	if as.Window != nil {
+		window = as.Window.Duration
+	}
+
+	start := o.WindowStartTime
+	if start == nil || sample.EndOffsets < o.WindowStartOffset {
+		restartWindow(o, sample, now)
+		return partitions
+	}
+	elapsed := now.Sub(start.Time)
+	if elapsed < window {
+		return partitions
+	}
+
+	bytesIn := float64(sample.EndOffsets-o.WindowStartOffset) / elapsed.Seconds() * sample.RecordBytes
+	o.BytesInPerSecond = int64(bytesIn)
+	restartWindow(o, sample, now)
+
+	to := ScaledPartitions(as, partitions, bytesIn)
+	if to <= partitions {
+		return partitions
+	}
+	o.Partitions = to
+	o.ScaleEvents = append(o.ScaleEvents, v1alpha1.PartitionsScaleEvent{
+		Time:             metav1.NewTime(now),
+		From:             partitions,
+		To:               to,
+		BytesInPerSecond: o.BytesInPerSecond,
+	})
+	if len(o.ScaleEvents) > maxScaleEvents {
+		o.ScaleEvents = o.ScaleEvents[len(o.ScaleEvents)-maxScaleEvents:]
+	}
+	return to
+}
+
+func restartWindow(o *v1alpha1.PartitionsAutoScaleObservation, sample *Throughput, now time.Time) {
+	t := metav1.NewTime(now)
+	o.WindowStartTime = &t
+	o.WindowStartOffset = sample.EndOffsets
+}
@@ -0,0 +1,117 @@
+package topic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+func TestScaledPartitions(t *testing.T) {
+	as := &v1alpha1.PartitionsAutoScale{MaxPartitions: 12, TargetBytesInPerPartition: 1000}
+
+	cases := map[string]struct {
+		partitions int
+		bytesIn    float64
+		want       int
+	}{
+		"BelowTarget": {partitions: 3, bytesIn: 2500, want: 3},
+		"AboveTarget": {partitions: 3, bytesIn: 4500, want: 5},
+		"Bounded":     {partitions: 3, bytesIn: 50000, want: 12},
+		"AtMaximum":   {partitions: 12, bytesIn: 50000, want: 12},
+		"BeyondMax":   {partitions: 16, bytesIn: 50000, want: 16},
+		"Idle":        {partitions: 3, want: 3},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ScaledPartitions(as, tc.partitions, tc.bytesIn); got != tc.want {
+				t.Errorf("ScaledPartitions(...): want %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAutoScale(t *testing.T) {
+	as := &v1alpha1.PartitionsAutoScale{MaxPartitions: 12, TargetBytesInPerPartition: 1000, Window: &metav1.Duration{Duration: time.Minute}}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	started := func() *v1alpha1.PartitionsAutoScaleObservation {
+		t := metav1.NewTime(start)
+		return &v1alpha1.PartitionsAutoScaleObservation{WindowStartTime: &t, WindowStartOffset: 1000}
+	}
+	at := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(start.Add(d))
+		return &t
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      *v1alpha1.PartitionsAutoScaleObservation
+		sample *Throughput
+		now    time.Time
+		want   int
+		wantO  *v1alpha1.PartitionsAutoScaleObservation
+	}{
+		"FirstSample": {
+			reason: "The first sample should start a window.",
+			o:      &v1alpha1.PartitionsAutoScaleObservation{},
+			sample: &Throughput{EndOffsets: 1000, RecordBytes: 100},
+			now:    start,
+			want:   3,
+			wantO:  started(),
+		},
+		"WindowIncomplete": {
+			reason: "Partitions should not be added before a window is complete.",
+			o:      started(),
+			sample: &Throughput{EndOffsets: 100000, RecordBytes: 100},
+			now:    start.Add(30 * time.Second),
+			want:   3,
+			wantO:  started(),
+		},
+		"BelowTarget": {
+			reason: "Partitions should not be added while records are produced to each at less than the target rate.",
+			o:      started(),
+			sample: &Throughput{EndOffsets: 2200, RecordBytes: 100},
+			now:    start.Add(time.Minute),
+			want:   3,
+			wantO:  &v1alpha1.PartitionsAutoScaleObservation{BytesInPerSecond: 2000, WindowStartTime: at(time.Minute), WindowStartOffset: 2200},
+		},
+		"AboveTarget": {
+			reason: "Partitions should be added to bring the rate per partition back to the target, and the scale event recorded.",
+			o:      started(),
+			sample: &Throughput{EndOffsets: 4000, RecordBytes: 100},
+			now:    start.Add(time.Minute),
+			want:   5,
+			wantO: &v1alpha1.PartitionsAutoScaleObservation{
+				Partitions:        5,
+				BytesInPerSecond:  5000,
+				WindowStartTime:   at(time.Minute),
+				WindowStartOffset: 4000,
+				ScaleEvents:       []v1alpha1.PartitionsScaleEvent{{Time: metav1.NewTime(start.Add(time.Minute)), From: 3, To: 5, BytesInPerSecond: 5000}},
+			},
+		},
+		"Recreated": {
+			reason: "A window should restart if the topic lost records.",
+			o:      started(),
+			sample: &Throughput{EndOffsets: 10, RecordBytes: 100},
+			now:    start.Add(time.Minute),
+			want:   3,
+			wantO:  &v1alpha1.PartitionsAutoScaleObservation{WindowStartTime: at(time.Minute), WindowStartOffset: 10},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := AutoScale(as, tc.o, 3, tc.sample, tc.now)
+			if got != tc.want {
+				t.Errorf("\n%s\nAutoScale(...): want %d partitions, got %d", tc.reason, tc.want, got)
+			}
+			if diff := cmp.Diff(tc.wantO, tc.o); diff != "" {
+				t.Errorf("\n%s\nAutoScale(...): -want observation, +got observation:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package topic
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"golang.org/x/time/rate"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+// batchWindow is how long topics are gathered before the first batch of a
+// queue is deleted, so that topics deleted at once share a request.
+const batchWindow = time.Second
+
+// A BatchDeleter deletes topics in batches, each with a single DeleteTopics
+// request, and at most at a fixed rate of topics per second. It keeps
+// deleting many topics at once, e.g. when a namespace is torn down, from
+// spiking the load of the controller broker or exceeding request quotas.
+type BatchDeleter struct {
+	limiter *rate.Limiter
+	size    int
+	timeout time.Duration
+
+	mu sync.Mutex
+	// queues are keyed by the Key of the clients deleting their topics, so
+	// that a queue outlives the replacement of its client.
+	queues map[string]*deleteQueue
+}
+
+// deleteQueue holds the topics of a cluster waiting to be deleted, and the
+// errors deleting those that failed. It is removed once it holds neither.
+type deleteQueue struct {
+	// client is the latest client its topics were queued with.
+	client *kafka.Client
+	names  []string
+	failed map[string]error
+}
+
+// NewBatchDeleter returns a BatchDeleter deleting up to the supplied number of
+// topics per request, and at most the supplied number of topics per second.
+// Each request may take up to the supplied timeout.
+func NewBatchDeleter(perSecond float64, size int, timeout time.Duration) *BatchDeleter {
+	if size < 1 {
+		size = 1
+	}
+	return &BatchDeleter{
+		limiter: rate.NewLimiter(rate.Limit(perSecond), size),
+		size:    size,
+		timeout: timeout,
+		queues:  map[string]*deleteQueue{},
+	}
+}
+
+// Rate returns the number of topics deleted per second at most.
+func (d *BatchDeleter) Rate() float64 {
+	return float64(d.limiter.Limit())
+}
+
+// Delete queues the named topic for deletion, unless it already is. It returns
+// the number of topics queued before it, or the error the brokers returned
+// deleting it since it was last called. The topic is queued again on the next
+// call after an error. It never waits for the topic to be deleted, so callers
+// find out it was by observing that it no longer exists.
+func (d *BatchDeleter) Delete(cl *kafka.Client, name string) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := cl.Key()
+	q, ok := d.queues[key]
+	if !ok {
+		q = &deleteQueue{failed: map[string]error{}}
+		d.queues[key] = q
+	}
+	q.client = cl
+	if err, ok := q.failed[name]; ok {
+		delete(q.failed, name)
+		d.release(key, q)
+		return 0, err
+	}
+	for i, n := range q.names {
+		if n == name {
+			return i, nil
+		}
+	}
+	q.names = append(q.names, name)
+	if len(q.names) == 1 {
+		go d.drain(key, q)
+	}
+	return len(q.names) - 1, nil
+}
+
+// release removes the supplied queue of the supplied key if it holds neither
+// topics nor errors. It must be called with the lock held.
+func (d *BatchDeleter) release(key string, q *deleteQueue) {
+	if len(q.names) == 0 && len(q.failed) == 0 && d.queues[key] == q {
+		delete(d.queues, key)
+	}
+}
+
+// drain deletes the queued topics batch by batch until none are left.
+func (d *BatchDeleter) drain(key string, q *deleteQueue) {
+	time.Sleep(batchWindow)
+	for {
+		d.mu.Lock()
+		n := len(q.names)
+		if n == 0 {
+			d.release(key, q)
+			d.mu.Unlock()
+			return
+		}
+		if n > d.size {
+			n = d.size
+		}
+		batch := append([]string{}, q.names[:n]...)
+		cl := q.client
+		d.mu.Unlock()
+
+		_ = d.limiter.WaitN(context.Background(), n)
+		failed := d.deleteBatch(cl, batch)
+
+		d.mu.Lock()
+		q.names = q.names[n:]
+		for name, err := range failed {
+			q.failed[name] = err
+		}
+		d.mu.Unlock()
+	}
+}
+
+// deleteBatch deletes the supplied topics with a single request, and returns
+// the errors deleting those that could not be deleted. Topics that no longer
+// exist count as deleted.
+func (d *BatchDeleter) deleteBatch(cl *kafka.Client, names []string) map[string]error {
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+
+	failed := map[string]error{}
+	resp, err := cl.DeleteTopics(ctx, names...)
+	if err != nil {
+		for _, n := range names {
+			failed[n] = errors.Wrap(err, errCannotDeleteTopic)
+		}
+		return failed
+	}
+	for _, n := range names {
+		t, ok := resp[n]
+		switch {
+		case !ok:
+			failed[n] = errors.New(errNoDeleteResponseForTopic)
+		case t.Err != nil && !errors.Is(t.Err, kerr.UnknownTopicOrPartition):
+			failed[n] = errors.Wrap(t.Err, errCannotDeleteTopic)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,112 @@
+package topic
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+func TestBatchDeleter(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.SeedTopics(1, "orders", "payments", "audit"))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	// Requests are recorded, and deleting audit is refused.
+	var mu sync.Mutex
+	var requests [][]string
+	recorded := func() [][]string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([][]string{}, requests...)
+	}
+	c.ControlKey(int16(kmsg.DeleteTopics), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+		req := kreq.(*kmsg.DeleteTopicsRequest)
+		var names []string
+		for _, t := range req.Topics {
+			names = append(names, *t.Topic)
+		}
+		mu.Lock()
+		requests = append(requests, names)
+		mu.Unlock()
+		for _, n := range names {
+			if n == "audit" {
+				resp := req.ResponseKind().(*kmsg.DeleteTopicsResponse)
+				for _, n := range names {
+					rt := kmsg.NewDeleteTopicsResponseTopic()
+					rt.Topic = kmsg.StringPtr(n)
+					if n == "audit" {
+						rt.ErrorCode = kerr.TopicAuthorizationFailed.Code
+					}
+					resp.Topics = append(resp.Topics, rt)
+				}
+				return resp, nil, true
+			}
+		}
+		c.KeepControl()
+		return nil, nil, false
+	})
+
+	ctx := context.Background()
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(ctx, creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	d := NewBatchDeleter(100, 2, 5*time.Second)
+	var ahead []int
+	for _, n := range []string{"orders", "payments", "audit", "orders"} {
+		a, err := d.Delete(cl, n)
+		if err != nil {
+			t.Fatalf("Delete(%q): %v", n, err)
+		}
+		ahead = append(ahead, a)
+	}
+	if diff := cmp.Diff([]int{0, 1, 2, 0}, ahead); diff != "" {
+		t.Errorf("Delete(...): -want topics ahead, +got:\n%s", diff)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		td, err := cl.ListTopics(ctx)
+		if err != nil {
+			t.Fatalf("ListTopics(...): %v", err)
+		}
+		if !td.Has("orders") && !td.Has("payments") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("topics were not deleted: %v", td.Names())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// Wait for the batch holding audit to be done with.
+	for len(recorded()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if diff := cmp.Diff([][]string{{"orders", "payments"}, {"audit"}}, recorded()); diff != "" {
+		t.Errorf("DeleteTopics requests: -want, +got:\n%s", diff)
+	}
+	if _, err := d.Delete(cl, "audit"); err == nil {
+		t.Error("Delete(\"audit\"): want the error deleting it")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.queues) != 0 {
+		t.Errorf("BatchDeleter queues: want the drained queue removed, got %d queues", len(d.queues))
+	}
+}
@@ -0,0 +1,93 @@
+package topic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// redacted replaces the values of sensitive config keys.
+	redacted = "(redacted)"
+	// unset stands for the value of a config key that is not set on the
+	// topic, i.e. that takes the default of the brokers.
+	unset = "(default)"
+)
+
+// sensitiveKeyParts are parts of config keys whose values may be secret.
+var sensitiveKeyParts = []string{"password", "secret", "token", "credential", "jaas", "ssl.key"}
+
+// A ConfigChange changes the value of a config key of a topic. A nil value is
+// not set on the topic.
+type ConfigChange struct {
+	Key string
+	Old *string
+	New *string
+}
+
+// String returns the change as key: old → new, with the values of sensitive
+// keys redacted.
+func (c ConfigChange) String() string {
+	if Sensitive(c.Key) {
+		return fmt.Sprintf("%s: %s", c.Key, redacted)
+	}
+	return fmt.Sprintf("%s: %s → %s", c.Key, displayValue(c.Old), displayValue(c.New))
+}
+
+// ConfigChanges returns the changes that make the config of the existing topic
+// that of the desired one, sorted by key. Keys the desired topic does not set
+// are left alone, and keys it sets to the default of the brokers have their
+// topic-level override removed rather than set.
+func ConfigChanges(desired, existing *Topic) []ConfigChange {
+	keys := make([]string, 0, len(desired.Config))
+	for k, v := range desired.Config {
+		if !configMatches(k, v, existing) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := make([]ConfigChange, 0, len(keys))
+	for _, k := range keys {
+		changes = append(changes, ConfigChange{Key: k, Old: existing.Config[k], New: desiredConfig(k, desired.Config[k], existing)})
+	}
+	return changes
+}
+
+// Sensitive returns whether the value of the supplied config key may be
+// secret, judging by its name.
+func Sensitive(key string) bool {
+	k := strings.ToLower(key)
+	for _, p := range sensitiveKeyParts {
+		if strings.Contains(k, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// appliedChanges returns the supplied changes that the supplied error reports
+// as applied and not rolled back.
+func appliedChanges(changes []ConfigChange, e *ConfigUpdateError) []ConfigChange {
+	kept := map[string]bool{}
+	for _, k := range e.Applied {
+		kept[k] = true
+	}
+	for _, k := range e.RolledBack {
+		kept[k] = false
+	}
+	out := []ConfigChange{}
+	for _, c := range changes {
+		if kept[c.Key] {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func displayValue(v *string) string {
+	if v == nil {
+		return unset
+	}
+	return *v
+}
@@ -0,0 +1,49 @@
+package topic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConfigChanges(t *testing.T) {
+	day, week, snappy, jaas := "86400000", "604800000", "snappy", "org.apache.kafka.common.security.plain.PlainLoginModule required;"
+
+	desired := &Topic{Config: map[string]*string{
+		"retention.ms":                &day,
+		"compression.type":            &snappy,
+		"cleanup.policy":              nil,
+		"sasl.jaas.config":            &jaas,
+		"unchanged.retention.minutes": &week,
+	}}
+	existing := &Topic{Config: map[string]*string{
+		"retention.ms":                &week,
+		"cleanup.policy":              &snappy,
+		"unchanged.retention.minutes": &week,
+		"segment.ms":                  &week,
+	}}
+
+	got := []string{}
+	for _, c := range ConfigChanges(desired, existing) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"cleanup.policy: snappy → (default)",
+		"compression.type: (default) → snappy",
+		"retention.ms: 604800000 → 86400000",
+		"sasl.jaas.config: (redacted)",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConfigChanges(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestAppliedChanges(t *testing.T) {
+	changes := []ConfigChange{{Key: "a"}, {Key: "b"}, {Key: "c"}, {Key: "d"}}
+	e := &ConfigUpdateError{Applied: []string{"a", "c", "d"}, RolledBack: []string{"c"}}
+
+	want := []ConfigChange{{Key: "a"}, {Key: "d"}}
+	if diff := cmp.Diff(want, appliedChanges(changes, e)); diff != "" {
+		t.Errorf("appliedChanges(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,88 @@
+package topic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+const errGetConfigMap = "cannot get config ConfigMap %s/%s"
+
+// ReferencedConfig returns the config key/value pairs of the supplied
+// ConfigMaps, later ConfigMaps taking precedence over earlier ones. It returns
+// nil if no ConfigMap is supplied.
+func ReferencedConfig(ctx context.Context, kube client.Reader, refs []v1alpha1.ConfigMapReference) (map[string]*string, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	config := map[string]*string{}
+	for _, ref := range refs {
+		cm := &corev1.ConfigMap{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+			return nil, errors.Wrapf(err, errGetConfigMap, ref.Namespace, ref.Name)
+		}
+		for k, v := range cm.Data {
+			v := v
+			config[k] = &v
+		}
+	}
+	return config, nil
+}
+
+// MergeConfig returns the supplied inline config merged over the supplied
+// referenced config. The inline config is returned as is if nothing is
+// referenced.
+func MergeConfig(referenced, inline map[string]*string) map[string]*string {
+	if len(referenced) == 0 {
+		return inline
+	}
+	config := make(map[string]*string, len(referenced)+len(inline))
+	for k, v := range referenced {
+		config[k] = v
+	}
+	for k, v := range inline {
+		config[k] = v
+	}
+	return config
+}
+
+// ResolveConfig returns the config of the supplied parameters merged over the
+// config of the ConfigMaps they reference.
+func ResolveConfig(ctx context.Context, kube client.Reader, params *v1alpha1.TopicParameters) (map[string]*string, error) {
+	referenced, err := ReferencedConfig(ctx, kube, params.ConfigFrom)
+	if err != nil {
+		return nil, err
+	}
+	return MergeConfig(referenced, params.Config), nil
+}
+
+// WithConfig returns a copy of the supplied parameters with the supplied
+// config, e.g. one returned by ResolveConfig.
+func WithConfig(params *v1alpha1.TopicParameters, config map[string]*string) *v1alpha1.TopicParameters {
+	p := params.DeepCopy()
+	p.Config = config
+	return p
+}
+
+// WithoutConfigKeys returns a copy of the supplied observed topic without the
+// keys of the supplied config, e.g. so that keys set by ConfigMaps are not
+// late initialized into the parameters of a Topic and keep following their
+// ConfigMaps.
+func WithoutConfigKeys(observed *Topic, config map[string]*string) *Topic {
+	if len(config) == 0 {
+		return observed
+	}
+	t := *observed
+	t.Config = make(map[string]*string, len(observed.Config))
+	for k, v := range observed.Config {
+		if _, ok := config[k]; !ok {
+			t.Config[k] = v
+		}
+	}
+	return &t
+}
@@ -0,0 +1,59 @@
+package topic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+func TestResolveConfig(t *testing.T) {
+	str := func(s string) *string { return &s }
+	profiles := map[string]map[string]string{
+		"compacted":   {"cleanup.policy": "compact", "min.compaction.lag.ms": "60000"},
+		"low-latency": {"min.compaction.lag.ms": "0", "segment.ms": "600000"},
+	}
+	kube := &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		obj.(*corev1.ConfigMap).Data = profiles[key.Name]
+		return nil
+	}}
+
+	params := &v1alpha1.TopicParameters{
+		Config: map[string]*string{"segment.ms": str("3600000")},
+		ConfigFrom: []v1alpha1.ConfigMapReference{
+			{Namespace: "kafka", Name: "compacted"},
+			{Namespace: "kafka", Name: "low-latency"},
+		},
+	}
+	got, err := ResolveConfig(context.Background(), kube, params)
+	if err != nil {
+		t.Fatalf("ResolveConfig(...): %s", err)
+	}
+	want := map[string]*string{
+		"cleanup.policy":        str("compact"),
+		"min.compaction.lag.ms": str("0"),
+		"segment.ms":            str("3600000"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ResolveConfig(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestWithoutConfigKeys(t *testing.T) {
+	str := func(s string) *string { return &s }
+	observed := &Topic{Name: "orders", Config: map[string]*string{"cleanup.policy": str("compact"), "retention.ms": str("1000")}}
+
+	got := WithoutConfigKeys(observed, map[string]*string{"cleanup.policy": str("compact")})
+	want := &Topic{Name: "orders", Config: map[string]*string{"retention.ms": str("1000")}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("WithoutConfigKeys(...): -want, +got:\n%s", diff)
+	}
+	if len(observed.Config) != 2 {
+		t.Errorf("WithoutConfigKeys(...): the observed topic should not be modified")
+	}
+}
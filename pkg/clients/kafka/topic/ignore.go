@@ -0,0 +1,42 @@
+package topic
+
+import (
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+// IgnoreConfigKeys returns a copy of the supplied config without the keys the
+// supplied parameters ignore. It returns the config itself if no key is
+// ignored.
+func IgnoreConfigKeys(params *v1alpha1.TopicParameters, config map[string]*string) map[string]*string {
+	if len(params.IgnoreConfigKeys) == 0 || config == nil {
+		return config
+	}
+	ignored := ignoredKeys(params)
+	out := make(map[string]*string, len(config))
+	for k, v := range config {
+		if !ignored[k] {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// WithoutIgnoredConfigKeys returns a copy of the supplied observed topic
+// without the config keys the supplied parameters ignore, so that they are
+// neither late initialized nor checked for drift.
+func WithoutIgnoredConfigKeys(params *v1alpha1.TopicParameters, observed *Topic) *Topic {
+	if len(params.IgnoreConfigKeys) == 0 {
+		return observed
+	}
+	t := *observed
+	t.Config = IgnoreConfigKeys(params, observed.Config)
+	return &t
+}
+
+func ignoredKeys(params *v1alpha1.TopicParameters) map[string]bool {
+	ignored := make(map[string]bool, len(params.IgnoreConfigKeys))
+	for _, k := range params.IgnoreConfigKeys {
+		ignored[k] = true
+	}
+	return ignored
+}
@@ -0,0 +1,37 @@
+package topic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+func TestIgnoreConfigKeys(t *testing.T) {
+	str := func(s string) *string { return &s }
+	params := &v1alpha1.TopicParameters{
+		Partitions:        3,
+		ReplicationFactor: 1,
+		Config:            map[string]*string{"retention.ms": str("1000"), "remote.storage.enable": str("true")},
+		IgnoreConfigKeys:  []string{"remote.storage.enable", "leader.replication.throttled.replicas"},
+	}
+	observed := &Topic{Name: "orders", Partitions: 3, ReplicationFactor: 1, Config: map[string]*string{
+		"retention.ms":                          str("1000"),
+		"remote.storage.enable":                 str("false"),
+		"leader.replication.throttled.replicas": str("0:1"),
+	}}
+
+	got := IsUpToDate(WithConfig(params, IgnoreConfigKeys(params, params.Config)), WithoutIgnoredConfigKeys(params, observed))
+	if !got {
+		t.Errorf("IsUpToDate(...): a topic differing only in ignored keys should be up to date")
+	}
+	if len(observed.Config) != 3 || len(params.Config) != 2 {
+		t.Errorf("IgnoreConfigKeys(...): the supplied config should not be modified")
+	}
+
+	want := map[string]*string{"retention.ms": str("1000")}
+	if diff := cmp.Diff(want, WithoutIgnoredConfigKeys(params, observed).Config); diff != "" {
+		t.Errorf("WithoutIgnoredConfigKeys(...): -want, +got:\n%s", diff)
+	}
+}
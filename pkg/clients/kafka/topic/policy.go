@@ -0,0 +1,60 @@
+package topic
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+// CheckPolicy returns every way in which the topic with the supplied name and
+// parameters violates the supplied policy. It returns nothing if the topic
+// complies with the policy.
+func CheckPolicy(policy *apisv1alpha1.TopicPolicySpec, name string, params *v1alpha1.TopicParameters) []string {
+	var violations []string
+
+	if policy.NamePattern != "" {
+		re, err := regexp.Compile(policy.NamePattern)
+		switch {
+		case err != nil:
+			violations = append(violations, fmt.Sprintf("invalid name pattern %q: %s", policy.NamePattern, err))
+		case !re.MatchString(name):
+			violations = append(violations, fmt.Sprintf("name %q does not match %q", name, policy.NamePattern))
+		}
+	}
+
+	partitions, _ := shape(params)
+	if policy.MinPartitions != nil && partitions < *policy.MinPartitions {
+		violations = append(violations, fmt.Sprintf("%d partitions are fewer than the minimum of %d", partitions, *policy.MinPartitions))
+	}
+	if policy.MaxPartitions != nil && partitions > *policy.MaxPartitions {
+		violations = append(violations, fmt.Sprintf("%d partitions are more than the maximum of %d", partitions, *policy.MaxPartitions))
+	}
+
+	for _, rc := range policy.RequiredConfigs {
+		v, ok := params.Config[rc.Key]
+		if !ok || v == nil {
+			violations = append(violations, fmt.Sprintf("config %s is required", rc.Key))
+			continue
+		}
+		if rc.Value != nil && *v != *rc.Value {
+			violations = append(violations, fmt.Sprintf("config %s must be %q", rc.Key, *rc.Value))
+		}
+		if rc.Minimum != nil {
+			n, err := strconv.ParseInt(*v, 10, 64)
+			if err != nil || n < *rc.Minimum {
+				violations = append(violations, fmt.Sprintf("config %s must be at least %d", rc.Key, *rc.Minimum))
+			}
+		}
+	}
+
+	for _, k := range policy.ForbiddenConfigs {
+		if _, ok := params.Config[k]; ok {
+			violations = append(violations, fmt.Sprintf("config %s is forbidden", k))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,86 @@
+package topic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kafka/apis/v1alpha1"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	three, ten := 3, 10
+	twoStr := "2"
+	minISR := int64(2)
+	compact := "compact"
+	one := "1"
+	bad := "all"
+
+	policy := &apisv1alpha1.TopicPolicySpec{
+		MinPartitions: &three,
+		MaxPartitions: &ten,
+		RequiredConfigs: []apisv1alpha1.RequiredTopicConfig{
+			{Key: "min.insync.replicas", Minimum: &minISR},
+		},
+		ForbiddenConfigs: []string{"unclean.leader.election.enable"},
+		NamePattern:      `^team-[a-z]+\.`,
+	}
+
+	cases := map[string]struct {
+		policy *apisv1alpha1.TopicPolicySpec
+		name   string
+		params *v1alpha1.TopicParameters
+		want   []string
+	}{
+		"Compliant": {
+			policy: policy,
+			name:   "team-payments.orders",
+			params: &v1alpha1.TopicParameters{Partitions: 6, Config: map[string]*string{"min.insync.replicas": &twoStr}},
+		},
+		"EveryViolation": {
+			policy: policy,
+			name:   "orders",
+			params: &v1alpha1.TopicParameters{Partitions: 12, Config: map[string]*string{"unclean.leader.election.enable": &one}},
+			want: []string{
+				`name "orders" does not match "^team-[a-z]+\\."`,
+				"12 partitions are more than the maximum of 10",
+				"config min.insync.replicas is required",
+				"config unclean.leader.election.enable is forbidden",
+			},
+		},
+		"TooFewPartitionsAssigned": {
+			policy: &apisv1alpha1.TopicPolicySpec{MinPartitions: &three},
+			name:   "orders",
+			params: &v1alpha1.TopicParameters{ReplicaAssignment: []v1alpha1.ReplicaAssignment{{Partition: 0, Brokers: []int{1}}, {Partition: 1, Brokers: []int{2}}}},
+			want:   []string{"2 partitions are fewer than the minimum of 3"},
+		},
+		"BelowMinimum": {
+			policy: &apisv1alpha1.TopicPolicySpec{RequiredConfigs: []apisv1alpha1.RequiredTopicConfig{{Key: "min.insync.replicas", Minimum: &minISR}}},
+			name:   "orders",
+			params: &v1alpha1.TopicParameters{Config: map[string]*string{"min.insync.replicas": &one}},
+			want:   []string{"config min.insync.replicas must be at least 2"},
+		},
+		"NotNumeric": {
+			policy: &apisv1alpha1.TopicPolicySpec{RequiredConfigs: []apisv1alpha1.RequiredTopicConfig{{Key: "min.insync.replicas", Minimum: &minISR}}},
+			name:   "orders",
+			params: &v1alpha1.TopicParameters{Config: map[string]*string{"min.insync.replicas": &bad}},
+			want:   []string{"config min.insync.replicas must be at least 2"},
+		},
+		"WrongValue": {
+			policy: &apisv1alpha1.TopicPolicySpec{RequiredConfigs: []apisv1alpha1.RequiredTopicConfig{{Key: "cleanup.policy", Value: &compact}}},
+			name:   "orders",
+			params: &v1alpha1.TopicParameters{Config: map[string]*string{"cleanup.policy": &one}},
+			want:   []string{`config cleanup.policy must be "compact"`},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CheckPolicy(tc.policy, tc.name, tc.params)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("CheckPolicy(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package topic
+
+import (
+	"strconv"
+)
+
+// configMinInSyncReplicas is the config key of the number of replicas that
+// must acknowledge a write requiring acknowledgement by all in-sync replicas.
+const configMinInSyncReplicas = "min.insync.replicas"
+
+// MinInSyncReplicas returns the min.insync.replicas of the supplied config,
+// and whether the config sets a valid one.
+func MinInSyncReplicas(config map[string]*string) (int, bool) {
+	v := config[configMinInSyncReplicas]
+	if v == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(*v)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
+
+// UnderReplicated returns the partitions of the supplied topic that have
+// fewer in-sync replicas than the supplied minimum, sorted.
+func UnderReplicated(observed *Topic, minInSync int) []int32 {
+	out := []int32{}
+	for p, r := range observed.ReadyReplicas {
+		if int(r) < minInSync {
+			out = append(out, int32(p))
+		}
+	}
+	return out
+}
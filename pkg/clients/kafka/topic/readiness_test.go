@@ -0,0 +1,42 @@
+package topic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMinInSyncReplicas(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	cases := map[string]struct {
+		config map[string]*string
+		want   int
+		wantOk bool
+	}{
+		"Set":     {config: map[string]*string{"min.insync.replicas": str("2")}, want: 2, wantOk: true},
+		"Unset":   {config: map[string]*string{"retention.ms": str("1000")}},
+		"Default": {config: map[string]*string{"min.insync.replicas": nil}},
+		"Invalid": {config: map[string]*string{"min.insync.replicas": str("two")}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := MinInSyncReplicas(tc.config)
+			if got != tc.want || ok != tc.wantOk {
+				t.Errorf("MinInSyncReplicas(...): want %d, %t, got %d, %t", tc.want, tc.wantOk, got, ok)
+			}
+		})
+	}
+}
+
+func TestUnderReplicated(t *testing.T) {
+	observed := &Topic{Name: "orders", Partitions: 4, ReadyReplicas: []int32{3, 1, 2, 0}}
+
+	if diff := cmp.Diff([]int32{1, 3}, UnderReplicated(observed, 2)); diff != "" {
+		t.Errorf("UnderReplicated(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]int32{}, UnderReplicated(observed, 0)); diff != "" {
+		t.Errorf("UnderReplicated(...): -want, +got:\n%s", diff)
+	}
+}
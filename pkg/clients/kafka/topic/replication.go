@@ -0,0 +1,185 @@
+package topic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kadm"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const (
+	errCannotReassignPartitions = "cannot reassign partitions"
+	errCannotReassignPartition  = "cannot reassign partition %d"
+
+	infeasibleTooFewBrokers = "the cluster has %d brokers, fewer than the replication factor %d"
+	infeasibleMixedRacks    = "only %d of the %d brokers have a rack, so replicas cannot be placed by rack"
+)
+
+// A ReplicationPlan increases the replication factor of a topic by copying
+// each of its partitions to more brokers.
+type ReplicationPlan struct {
+	// ReplicationFactor the topic is increased to.
+	ReplicationFactor int16
+	// Brokers is the number of brokers of the cluster.
+	Brokers int
+	// Racks is the number of racks of the brokers, if they have racks.
+	Racks int
+	// Infeasible is why the replication factor cannot be increased, if it
+	// cannot.
+	Infeasible string
+	// Assignment are the brokers to place the replicas of each partition
+	// on, keyed by partition. The current replicas of a partition are kept.
+	Assignment map[int32][]int32
+	// Records is the approximate number of records copied to new replicas.
+	Records int64
+	// Bytes is the approximate size of the data copied to new replicas.
+	Bytes int64
+}
+
+// PlanReplicationFactor plans increasing the replication factor of the topic
+// of the supplied name to the supplied one. The data copied to the new
+// replicas is estimated from the offsets and size on disk of the topic, which
+// are only read if the plan is feasible.
+func PlanReplicationFactor(ctx context.Context, client *kafka.Client, name string, replicationFactor int16) (*ReplicationPlan, error) {
+	md, err := client.Metadata(ctx, name)
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotListTopics)
+	}
+	td, ok := md.Topics[name]
+	if !ok {
+		return nil, errors.New(ErrTopicDoesNotExist)
+	}
+	if td.Err != nil {
+		return nil, errors.Wrap(td.Err, ErrTopicDoesNotExist)
+	}
+	partitions := make(map[int32][]int32, len(td.Partitions))
+	for _, p := range td.Partitions {
+		partitions[p.Partition] = p.Replicas
+	}
+
+	plan := planReplicas(partitions, md.Brokers, replicationFactor)
+	if plan.Infeasible != "" {
+		return plan, nil
+	}
+	current := int64(len(partitions[0]))
+	if current == 0 {
+		return plan, nil
+	}
+	sz, err := GetSize(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+	added := int64(replicationFactor) - current
+	plan.Records = sz.Records * added
+	plan.Bytes = sz.Bytes / current * added
+	return plan, nil
+}
+
+// planReplicas plans adding replicas to the supplied partitions, keyed by
+// partition, until each has the supplied number, on the supplied brokers.
+// New replicas are placed on the racks holding the fewest replicas of their
+// partition, then on the brokers holding the fewest replicas of the topic.
+func planReplicas(partitions map[int32][]int32, brokers kadm.BrokerDetails, replicationFactor int16) *ReplicationPlan {
+	plan := &ReplicationPlan{ReplicationFactor: replicationFactor, Brokers: len(brokers)}
+
+	racks := make(map[int32]string, len(brokers))
+	distinct := map[string]bool{}
+	for _, b := range brokers {
+		if b.Rack != nil {
+			racks[b.NodeID] = *b.Rack
+			distinct[*b.Rack] = true
+		}
+	}
+	plan.Racks = len(distinct)
+
+	switch {
+	case len(brokers) < int(replicationFactor):
+		plan.Infeasible = fmt.Sprintf(infeasibleTooFewBrokers, len(brokers), replicationFactor)
+		return plan
+	case len(racks) > 0 && len(racks) < len(brokers):
+		// Kafka refuses to place replicas by rack unless every broker
+		// has one.
+		plan.Infeasible = fmt.Sprintf(infeasibleMixedRacks, len(racks), len(brokers))
+		return plan
+	}
+
+	load := make(map[int32]int, len(brokers))
+	ids := make([]int32, 0, len(partitions))
+	for p, replicas := range partitions {
+		ids = append(ids, p)
+		for _, b := range replicas {
+			load[b]++
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	plan.Assignment = make(map[int32][]int32, len(partitions))
+	for _, p := range ids {
+		replicas := append([]int32{}, partitions[p]...)
+		for len(replicas) < int(replicationFactor) {
+			b := nextReplica(replicas, brokers, racks, load)
+			replicas = append(replicas, b)
+			load[b]++
+		}
+		plan.Assignment[p] = replicas
+	}
+	return plan
+}
+
+// nextReplica returns the broker to place the next replica of a partition
+// with the supplied replicas on: the one on the rack holding the fewest of
+// them, then holding the fewest replicas, then of the lowest ID.
+func nextReplica(replicas []int32, brokers kadm.BrokerDetails, racks map[int32]string, load map[int32]int) int32 {
+	placed := make(map[int32]bool, len(replicas))
+	onRack := map[string]int{}
+	for _, b := range replicas {
+		placed[b] = true
+		onRack[racks[b]]++
+	}
+	best := int32(-1)
+	for _, b := range brokers {
+		if placed[b.NodeID] {
+			continue
+		}
+		if best == -1 || preferred(b.NodeID, best, racks, onRack, load) {
+			best = b.NodeID
+		}
+	}
+	return best
+}
+
+// preferred returns whether broker a is preferred over broker b for the next
+// replica of a partition.
+func preferred(a, b int32, racks map[int32]string, onRack map[string]int, load map[int32]int) bool {
+	if ra, rb := onRack[racks[a]], onRack[racks[b]]; ra != rb {
+		return ra < rb
+	}
+	if load[a] != load[b] {
+		return load[a] < load[b]
+	}
+	return a < b
+}
+
+// IncreaseReplicationFactor reassigns the partitions of the topic of the
+// supplied name as the supplied plan assigns them. The brokers copy the data
+// to the new replicas in the background.
+func IncreaseReplicationFactor(ctx context.Context, client *kafka.Client, name string, plan *ReplicationPlan) error {
+	req := kadm.AlterPartitionAssignmentsReq{}
+	for p, brokers := range plan.Assignment {
+		req.Assign(name, p, brokers)
+	}
+	rs, err := client.AlterPartitionAssignments(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, errCannotReassignPartitions)
+	}
+	for _, r := range rs.Sorted() {
+		if r.Err != nil {
+			return errors.Wrapf(r.Err, errCannotReassignPartition, r.Partition)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package topic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kadm"
+)
+
+func TestPlanReplicas(t *testing.T) {
+	rack := func(r string) *string { return &r }
+	brokers := func(racks ...*string) kadm.BrokerDetails {
+		bs := make(kadm.BrokerDetails, len(racks))
+		for i, r := range racks {
+			bs[i] = kadm.BrokerDetail{NodeID: int32(i + 1), Rack: r}
+		}
+		return bs
+	}
+
+	cases := map[string]struct {
+		reason     string
+		partitions map[int32][]int32
+		brokers    kadm.BrokerDetails
+		rf         int16
+		want       *ReplicationPlan
+	}{
+		"TooFewBrokers": {
+			reason:     "A replication factor above the number of brokers should be infeasible.",
+			partitions: map[int32][]int32{0: {1}},
+			brokers:    brokers(nil, nil),
+			rf:         3,
+			want:       &ReplicationPlan{ReplicationFactor: 3, Brokers: 2, Infeasible: "the cluster has 2 brokers, fewer than the replication factor 3"},
+		},
+		"MixedRacks": {
+			reason:     "Replicas should not be placed when only some brokers have a rack.",
+			partitions: map[int32][]int32{0: {1}},
+			brokers:    brokers(rack("a"), rack("b"), nil),
+			rf:         2,
+			want:       &ReplicationPlan{ReplicationFactor: 2, Brokers: 3, Racks: 2, Infeasible: "only 2 of the 3 brokers have a rack, so replicas cannot be placed by rack"},
+		},
+		"LeastLoaded": {
+			reason:     "New replicas should be placed on the brokers holding the fewest replicas, keeping the current ones.",
+			partitions: map[int32][]int32{0: {1}, 1: {2}, 2: {1}},
+			brokers:    brokers(nil, nil, nil),
+			rf:         2,
+			want: &ReplicationPlan{ReplicationFactor: 2, Brokers: 3, Assignment: map[int32][]int32{
+				0: {1, 3},
+				1: {2, 3},
+				2: {1, 2},
+			}},
+		},
+		"SpreadOverRacks": {
+			reason:     "New replicas should be placed on the racks holding the fewest replicas of their partition.",
+			partitions: map[int32][]int32{0: {1}, 1: {3}},
+			brokers:    brokers(rack("a"), rack("a"), rack("b"), rack("b")),
+			rf:         2,
+			want: &ReplicationPlan{ReplicationFactor: 2, Brokers: 4, Racks: 2, Assignment: map[int32][]int32{
+				0: {1, 4},
+				1: {3, 2},
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := planReplicas(tc.partitions, tc.brokers, tc.rf)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nplanReplicas(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package topic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+// TestLargeClusterMetadata checks that on a cluster with 50k partitions only
+// learning the config keys reads the metadata of all topics, and only once.
+func TestLargeClusterMetadata(t *testing.T) {
+	topics := make([]string, 500)
+	for i := range topics {
+		topics[i] = fmt.Sprintf("topic-%03d", i)
+	}
+	c, err := kfake.NewCluster(kfake.SeedTopics(100, topics...))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	// Metadata requests without topics are for all of them.
+	var mu sync.Mutex
+	all := 0
+	c.ControlKey(int16(kmsg.Metadata), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+		c.KeepControl()
+		if req := kreq.(*kmsg.MetadataRequest); req.Topics == nil {
+			mu.Lock()
+			all++
+			mu.Unlock()
+		}
+		return nil, nil, false
+	})
+	allRequests := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return all
+	}
+
+	ctx := context.Background()
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(ctx, creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	tp, err := Get(ctx, cl, "topic-042")
+	if err != nil {
+		t.Fatalf("Get(...): %v", err)
+	}
+	if tp.Partitions != 100 {
+		t.Errorf("Get(...): want 100 partitions, got %d", tp.Partitions)
+	}
+	if _, err := GetSize(ctx, cl, "topic-042"); err != nil {
+		t.Fatalf("GetSize(...): %v", err)
+	}
+	if n := allRequests(); n != 0 {
+		t.Errorf("Get(...), GetSize(...): want no metadata requests for all topics, got %d", n)
+	}
+
+	for i := 0; i < 2; i++ {
+		keys, err := ConfigKeys(ctx, cl)
+		if err != nil {
+			t.Fatalf("ConfigKeys(...): %v", err)
+		}
+		if len(keys) == 0 {
+			t.Errorf("ConfigKeys(...): want config keys, got none")
+		}
+	}
+	if n := allRequests(); n != 1 {
+		t.Errorf("ConfigKeys(...) twice: want one metadata request for all topics, got %d", n)
+	}
+}
@@ -0,0 +1,71 @@
+package topic
+
+import (
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// A ConfigSource is where the value of a config key of a topic comes from,
+// i.e. from a topic-level override or from a default of the brokers.
+type ConfigSource struct {
+	// Overridden is whether the value is set on the topic itself.
+	Overridden bool
+
+	// Default is the value the key takes without a topic-level override,
+	// from the config of the brokers or the defaults of Kafka. It is nil if
+	// the brokers did not report it.
+	Default *string
+}
+
+// configSource returns the source of the supplied described config key. Its
+// default is its own value unless it is set on the topic, in which case it is
+// that of its first synonym that is not, as synonyms are listed in order of
+// precedence.
+func configSource(c kadm.Config) ConfigSource {
+	if c.Source != kmsg.ConfigSourceDynamicTopicConfig {
+		return ConfigSource{Default: c.Value}
+	}
+	s := ConfigSource{Overridden: true}
+	for _, syn := range c.Synonyms {
+		if syn.Source != kmsg.ConfigSourceDynamicTopicConfig {
+			s.Default = syn.Value
+			break
+		}
+	}
+	return s
+}
+
+// configMatches returns whether the supplied desired value of the supplied
+// config key matches that of the existing topic. A nil value asks for the
+// default of the brokers, which a key without a topic-level override takes
+// whatever its value.
+func configMatches(key string, desired *string, existing *Topic) bool {
+	if stringValue(desired) == stringValue(existing.Config[key]) {
+		return true
+	}
+	s, ok := existing.ConfigSources[key]
+	return desired == nil && ok && !s.Overridden
+}
+
+// desiredConfig returns the value to set the supplied config key of the
+// existing topic to, so that it takes the supplied desired value. A desired
+// value that is the default of the brokers is not written as a topic-level
+// override, rather the override is removed so that the key takes the default.
+func desiredConfig(key string, desired *string, existing *Topic) *string {
+	s, ok := existing.ConfigSources[key]
+	if ok && s.Overridden && s.Default != nil && stringValue(desired) == *s.Default {
+		return nil
+	}
+	return desired
+}
+
+// previousConfig returns the value to restore the supplied config key of the
+// existing topic to. A key that was not set on the topic itself has the
+// override removed, so that it keeps following the default of the brokers
+// rather than having the default pinned as an override.
+func previousConfig(key string, existing *Topic) *string {
+	if s, ok := existing.ConfigSources[key]; ok && !s.Overridden {
+		return nil
+	}
+	return existing.Config[key]
+}
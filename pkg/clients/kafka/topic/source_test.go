@@ -0,0 +1,85 @@
+package topic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+)
+
+func TestConfigSource(t *testing.T) {
+	day, week := "86400000", "604800000"
+
+	cases := map[string]struct {
+		reason string
+		c      kadm.Config
+		want   ConfigSource
+	}{
+		"BrokerDefault": {
+			reason: "A key the topic does not override should default to its own value.",
+			c:      kadm.Config{Key: "retention.ms", Value: &week, Source: kmsg.ConfigSourceStaticBrokerConfig},
+			want:   ConfigSource{Default: &week},
+		},
+		"Overridden": {
+			reason: "A key the topic overrides should default to its first synonym that is not a topic-level override.",
+			c: kadm.Config{Key: "retention.ms", Value: &day, Source: kmsg.ConfigSourceDynamicTopicConfig, Synonyms: []kadm.ConfigSynonym{
+				{Key: "retention.ms", Value: &day, Source: kmsg.ConfigSourceDynamicTopicConfig},
+				{Key: "log.retention.ms", Value: &week, Source: kmsg.ConfigSourceDynamicDefaultBrokerConfig},
+				{Key: "log.retention.hours", Value: &day, Source: kmsg.ConfigSourceDefaultConfig},
+			}},
+			want: ConfigSource{Overridden: true, Default: &week},
+		},
+		"NoSynonyms": {
+			reason: "A key the topic overrides should have no known default without synonyms.",
+			c:      kadm.Config{Key: "retention.ms", Value: &day, Source: kmsg.ConfigSourceDynamicTopicConfig},
+			want:   ConfigSource{Overridden: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, configSource(tc.c)); diff != "" {
+				t.Errorf("\n%s\nconfigSource(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConfigChangesOfDefaults(t *testing.T) {
+	day, week, del := "86400000", "604800000", "delete"
+
+	desired := &Topic{Config: map[string]*string{
+		"retention.ms":   &week,
+		"segment.ms":     &week,
+		"cleanup.policy": nil,
+	}}
+	existing := &Topic{
+		Config: map[string]*string{
+			"retention.ms":   &day,
+			"segment.ms":     &week,
+			"cleanup.policy": &del,
+		},
+		ConfigSources: map[string]ConfigSource{
+			"retention.ms":   {Overridden: true, Default: &week},
+			"segment.ms":     {Default: &week},
+			"cleanup.policy": {Default: &del},
+		},
+	}
+
+	// The override of retention.ms is removed rather than set to the
+	// default, and cleanup.policy already takes the default.
+	want := []ConfigChange{{Key: "retention.ms", Old: &day}}
+	if diff := cmp.Diff(want, ConfigChanges(desired, existing)); diff != "" {
+		t.Errorf("ConfigChanges(...): -want, +got:\n%s", diff)
+	}
+	if IsUpToDate(&v1alpha1.TopicParameters{Config: desired.Config}, existing) {
+		t.Errorf("IsUpToDate(...): got true, want false")
+	}
+	existing.Config["retention.ms"] = &week
+	existing.ConfigSources["retention.ms"] = ConfigSource{Default: &week}
+	if !IsUpToDate(&v1alpha1.TopicParameters{Config: desired.Config}, existing) {
+		t.Errorf("IsUpToDate(...): got false, want true once the override is removed")
+	}
+}
@@ -0,0 +1,765 @@
+// Package topic creates, observes, updates and deletes Kafka topics.
+package topic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+// Topic is a holistic representation of a Kafka Topic with all configurable
+// fields
+type Topic struct {
+	Name              string
+	ReplicationFactor int16
+	Partitions        int32
+	ID                string
+	Config            map[string]*string
+	// ConfigSources are where the values of the Config come from, keyed by
+	// config key. They are only known for described topics.
+	ConfigSources map[string]ConfigSource
+	// ReadyReplicas is the number of in-sync replicas of each partition,
+	// indexed by partition.
+	ReadyReplicas []int32
+	// ReplicaAssignment are the brokers to place the replicas of each
+	// partition on when creating the topic, keyed by partition.
+	ReplicaAssignment map[int32][]int32
+	// RollbackConfig restores the previous values of the config keys an
+	// update applied when other keys of the same update failed.
+	RollbackConfig bool
+	// Internal is whether the brokers mark the topic internal.
+	Internal bool
+}
+
+// reservedNames are the names of the topics Kafka, its KRaft controllers and
+// the Confluent Schema Registry use internally.
+var reservedNames = map[string]bool{
+	"__consumer_offsets":  true,
+	"__transaction_state": true,
+	"__cluster_metadata":  true,
+	"_schemas":            true,
+}
+
+// reservedPrefix is the prefix of the names of the topics Confluent Platform
+// uses internally.
+const reservedPrefix = "_confluent-"
+
+// Reserved returns true if a topic of the supplied name is reserved for
+// internal use by Kafka or well known tooling. Other topics the brokers mark
+// internal are only known once described. Kafka considers "." and "_"
+// colliding in topic names, so either is treated as the other.
+func Reserved(name string) bool {
+	n := strings.ReplaceAll(name, ".", "_")
+	return reservedNames[n] || strings.HasPrefix(n, reservedPrefix)
+}
+
+// ConfigKeys returns the keys of all configs of the topic.
+func (t *Topic) ConfigKeys() []string {
+	keys := make([]string, 0, len(t.Config))
+	for k := range t.Config {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+const (
+	errCannotListTopics           = "cannot list topics"
+	errNoCreateResponse           = "no create response for topic"
+	errCannotDescribeTopic        = "cannot describe topics"
+	errCannotFindTopicInDescribe  = "cannot find topic in describe result"
+	errErrorInTopicDescribeResult = "error in topic describe result"
+	errNoCreateResponseForTopic   = "no create response for topic"
+	errCannotCreateTopic          = "cannot create topic"
+	errInvalidTopic               = "topic failed validation"
+	errNoDeleteResponseForTopic   = "no delete response for topic"
+	errCannotDeleteTopic          = "cannot delete topic"
+	errCannotArchiveTopic         = "cannot archive topic"
+	errCannotGetTopic             = "cannot get topic"
+	errCannotUpdateTopicConfigs   = "cannot update topic configs"
+	errCannotListOffsets          = "cannot list topic offsets"
+	errCannotDescribeLogDirs      = "cannot describe log directories"
+	errCannotListGroups           = "cannot list consumer groups"
+	errCannotDescribeGroups       = "cannot describe consumer groups"
+	errAssignmentNotContiguous    = "replicaAssignment must assign partitions 0 to %d exactly once"
+	errAssignmentUneven           = "replicaAssignment must place the same number of replicas for every partition"
+
+	// ErrTopicDoesNotExist indicates that the topic of a given name doesn't exist in the external Kafka cluster
+	ErrTopicDoesNotExist = "topic does not exist"
+)
+
+// Get gets the topic from Kafka side and returns a Topic object.
+func Get(ctx context.Context, client *kafka.Client, name string) (*Topic, error) {
+	ts, err := GetMetadata(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+
+	tc, err := client.DescribeTopicConfigs(ctx, name)
+	if ae := unauthorized(err, name, OperationDescribeConfigs); ae != nil {
+		return nil, ae
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotDescribeTopic)
+	}
+
+	rc, err := tc.On(name, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, errCannotFindTopicInDescribe)
+	}
+	if err := unauthorized(rc.Err, name, OperationDescribeConfigs); err != nil {
+		return nil, err
+	}
+	if rc.Err != nil {
+		return nil, errors.Wrapf(rc.Err, errErrorInTopicDescribeResult)
+	}
+	ts.Config = make(map[string]*string, len(rc.Configs))
+	ts.ConfigSources = make(map[string]ConfigSource, len(rc.Configs))
+	for _, value := range rc.Configs {
+		ts.Config[value.Key] = value.Value
+		ts.ConfigSources[value.Key] = configSource(value)
+	}
+	return ts, nil
+}
+
+// GetMetadata gets a topic from Kafka without its config, which is cheaper
+// than describing its config too. Internal topics are got like any other, as
+// only the named topic is requested.
+func GetMetadata(ctx context.Context, client *kafka.Client, name string) (*Topic, error) {
+	td, err := client.ListTopicsWithInternal(ctx, name)
+	// Brokers refuse to tell whether a topic exists to principals that may
+	// not describe it, so that is not mistaken for a missing topic.
+	if ae := unauthorized(err, name, OperationDescribe); ae != nil {
+		return nil, ae
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotListTopics)
+	}
+	if ae := unauthorized(td[name].Err, name, OperationDescribe); ae != nil {
+		return nil, ae
+	}
+	if td[name].Err != nil {
+		return nil, errors.Wrap(td[name].Err, ErrTopicDoesNotExist)
+	}
+
+	t, ok := td[name]
+	if !ok {
+		return nil, errors.New(errNoCreateResponse)
+	}
+
+	ts := Topic{}
+	ts.Name = name
+	ts.Partitions = int32(len(t.Partitions))
+	if len(t.Partitions) > 0 {
+		ts.ReplicationFactor = int16(len(t.Partitions[0].Replicas))
+	}
+	ts.ID = t.ID.String()
+	ts.Internal = t.IsInternal
+	for _, p := range t.Partitions.Sorted() {
+		ts.ReadyReplicas = append(ts.ReadyReplicas, int32(len(p.ISR)))
+	}
+	return &ts, nil
+}
+
+// A PolicyViolationError is returned when a create topic policy of the
+// brokers rejected a topic.
+type PolicyViolationError struct {
+	// Reason is the reason given by the policy, if any.
+	Reason string
+}
+
+func (e *PolicyViolationError) Error() string {
+	if e.Reason == "" {
+		return kerr.PolicyViolation.Error()
+	}
+	return kerr.PolicyViolation.Error() + ": " + e.Reason
+}
+
+// Unwrap returns kerr.PolicyViolation.
+func (e *PolicyViolationError) Unwrap() error {
+	return kerr.PolicyViolation
+}
+
+// MarkedForDeletion returns true if the brokers are still deleting the topic
+// of the supplied name, which is missing from their metadata. ZooKeeper based
+// brokers remove such a topic from their metadata before they finish deleting
+// it, yet it cannot be created again until then: asked to validate creating
+// it, they report that it already exists.
+func MarkedForDeletion(ctx context.Context, client *kafka.Client, name string) bool {
+	err := create(ctx, client, newCreateTopicsRequest(ctx, &Topic{Name: name, Partitions: -1, ReplicationFactor: -1}, true))
+	return errors.Is(err, kerr.TopicAlreadyExists)
+}
+
+// Create creates the topic from Kafka side
+func Create(ctx context.Context, client *kafka.Client, topic *Topic) error {
+	err := create(ctx, client, newCreateTopicsRequest(ctx, topic, false))
+	return errors.Wrap(err, errCannotCreateTopic)
+}
+
+// Validate asks the controller whether the topic could be created, without
+// creating it. Invalid configs, or more partitions or replicas than the
+// brokers allow, are rejected without side effects.
+func Validate(ctx context.Context, client *kafka.Client, topic *Topic) error {
+	err := create(ctx, client, newCreateTopicsRequest(ctx, topic, true))
+	return errors.Wrap(err, errInvalidTopic)
+}
+
+func create(ctx context.Context, client *kafka.Client, req *kmsg.CreateTopicsRequest) error {
+	resp, err := req.RequestWith(ctx, client)
+	if err != nil {
+		return err
+	}
+	if len(resp.Topics) == 0 {
+		return errors.New(errNoCreateResponseForTopic)
+	}
+	t := resp.Topics[0]
+	if err := kerr.ErrorForCode(t.ErrorCode); err != nil {
+		if errors.Is(err, kerr.PolicyViolation) {
+			pv := &PolicyViolationError{}
+			if t.ErrorMessage != nil {
+				pv.Reason = *t.ErrorMessage
+			}
+			return pv
+		}
+		if t.ErrorMessage != nil {
+			return errors.Wrap(err, *t.ErrorMessage)
+		}
+		return err
+	}
+	return nil
+}
+
+// newCreateTopicsRequest returns a request creating the supplied topic. The
+// brokers are given until the context's deadline to create it.
+func newCreateTopicsRequest(ctx context.Context, topic *Topic, validateOnly bool) *kmsg.CreateTopicsRequest {
+	t := kmsg.NewCreateTopicsRequestTopic()
+	t.Topic = topic.Name
+	t.NumPartitions = topic.Partitions
+	t.ReplicationFactor = topic.ReplicationFactor
+	if len(topic.ReplicaAssignment) > 0 {
+		// Partitions and replication factor must be unset when assigning
+		// replicas explicitly.
+		t.NumPartitions = -1
+		t.ReplicationFactor = -1
+	}
+	for _, p := range sortedPartitions(topic.ReplicaAssignment) {
+		a := kmsg.NewCreateTopicsRequestTopicReplicaAssignment()
+		a.Partition = p
+		a.Replicas = topic.ReplicaAssignment[p]
+		t.ReplicaAssignment = append(t.ReplicaAssignment, a)
+	}
+	keys := topic.ConfigKeys()
+	sort.Strings(keys)
+	for _, k := range keys {
+		c := kmsg.NewCreateTopicsRequestTopicConfig()
+		c.Name = k
+		c.Value = topic.Config[k]
+		t.Configs = append(t.Configs, c)
+	}
+
+	req := kmsg.NewPtrCreateTopicsRequest()
+	req.Topics = append(req.Topics, t)
+	req.ValidateOnly = validateOnly
+	if d, ok := ctx.Deadline(); ok {
+		req.TimeoutMillis = int32(time.Until(d).Milliseconds())
+	}
+	return req
+}
+
+// ValidateReplicaAssignment returns an error if the supplied replica
+// assignment does not assign every partition exactly once, or does not place
+// the same number of replicas for every partition.
+func ValidateReplicaAssignment(assignment []v1alpha1.ReplicaAssignment) error {
+	seen := make(map[int]bool, len(assignment))
+	for _, a := range assignment {
+		if a.Partition < 0 || a.Partition >= len(assignment) || seen[a.Partition] {
+			return errors.Errorf(errAssignmentNotContiguous, len(assignment)-1)
+		}
+		seen[a.Partition] = true
+		if len(a.Brokers) != len(assignment[0].Brokers) {
+			return errors.New(errAssignmentUneven)
+		}
+	}
+	return nil
+}
+
+func sortedPartitions(assignment map[int32][]int32) []int32 {
+	ps := make([]int32, 0, len(assignment))
+	for p := range assignment {
+		ps = append(ps, p)
+	}
+	sort.Slice(ps, func(i, j int) bool { return ps[i] < ps[j] })
+	return ps
+}
+
+// Delete deletes the topic from Kafka side
+func Delete(ctx context.Context, client *kafka.Client, name string) error {
+
+	td, err := client.DeleteTopics(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	t, ok := td[name]
+	if !ok {
+		return errors.New(errNoDeleteResponseForTopic)
+	}
+	if t.Err != nil {
+		return errors.Wrap(t.Err, errCannotDeleteTopic)
+	}
+
+	return nil
+}
+
+// Archive disables the time and size based retention of a topic, so that its
+// records are kept for good once it is no longer managed.
+func Archive(ctx context.Context, client *kafka.Client, name string) error {
+	cs := []kadm.AlterConfig{
+		{Op: kadm.SetConfig, Name: "retention.ms", Value: kadm.StringPtr("-1")},
+		{Op: kadm.SetConfig, Name: "retention.bytes", Value: kadm.StringPtr("-1")},
+	}
+	r, err := client.AlterTopicConfigs(ctx, cs, name)
+	if err != nil {
+		return errors.Wrap(err, errCannotArchiveTopic)
+	}
+	for _, c := range r {
+		if c.Err != nil {
+			return errors.Wrap(c.Err, errCannotArchiveTopic)
+		}
+	}
+	return nil
+}
+
+// Size describes how much data a topic holds.
+type Size struct {
+	// Records is the approximate number of records retained in the topic.
+	Records int64
+	// Bytes is the size on disk of the log segments of all replicas of the
+	// topic.
+	Bytes int64
+}
+
+// GetSize returns how much data a topic holds. It lists the start and end
+// offsets of every partition, and describes the log directories of every
+// broker, so it is considerably more expensive than getting the topic.
+func GetSize(ctx context.Context, client *kafka.Client, name string) (*Size, error) {
+	records, partitions, err := countRecords(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+	// Brokers only describe the partitions they are asked for.
+	s := kadm.TopicsSet{}
+	s.Add(name, partitions...)
+	dirs, err := client.DescribeAllLogDirs(ctx, s)
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotDescribeLogDirs)
+	}
+	sz := &Size{Records: records}
+	for _, broker := range dirs {
+		for _, d := range broker {
+			if d.Err != nil {
+				return nil, errors.Wrap(d.Err, errCannotDescribeLogDirs)
+			}
+			for _, p := range d.Topics[name] {
+				sz.Bytes += p.Size
+			}
+		}
+	}
+	return sz, nil
+}
+
+// countRecords returns the approximate number of records retained in a topic,
+// the sum of the differences of the end and start offsets of its partitions,
+// along with its partitions.
+func countRecords(ctx context.Context, client *kafka.Client, name string) (int64, []int32, error) {
+	start, err := client.ListStartOffsets(ctx, name)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, errCannotListOffsets)
+	}
+	end, err := client.ListEndOffsets(ctx, name)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, errCannotListOffsets)
+	}
+
+	var records int64
+	partitions := make([]int32, 0, len(end[name]))
+	for p, eo := range end[name] {
+		if eo.Err != nil {
+			return 0, nil, errors.Wrap(eo.Err, errCannotListOffsets)
+		}
+		partitions = append(partitions, p)
+		so := start[name][p]
+		if n := eo.Offset - so.Offset; so.Err == nil && n > 0 {
+			records += n
+		}
+	}
+	return records, partitions, nil
+}
+
+// Usage describes the data held by a topic and the consumer groups that are
+// currently consuming it.
+type Usage struct {
+	// Records is the approximate number of records retained in the topic.
+	Records int64
+	// ConsumerGroups are the groups with members assigned to the topic.
+	ConsumerGroups []string
+}
+
+// InUse returns true if the topic holds records or is being consumed.
+func (u *Usage) InUse() bool {
+	return u.Records > 0 || len(u.ConsumerGroups) > 0
+}
+
+// An InUseError is returned when a topic is kept because it is in use.
+type InUseError struct {
+	// Name of the topic.
+	Name string
+	// Usage of the topic.
+	Usage Usage
+}
+
+func (e *InUseError) Error() string {
+	return fmt.Sprintf("topic %q holds %d records with active consumer groups %v", e.Name, e.Usage.Records, e.Usage.ConsumerGroups)
+}
+
+// GetUsage returns the data held by a topic and the consumer groups that
+// have members assigned to any of its partitions.
+func GetUsage(ctx context.Context, client *kafka.Client, name string) (*Usage, error) {
+	records, _, err := countRecords(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+	u := &Usage{Records: records}
+
+	lg, err := client.ListGroups(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotListGroups)
+	}
+	if len(lg) == 0 {
+		return u, nil
+	}
+	dg, err := client.DescribeGroups(ctx, lg.Groups()...)
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotDescribeGroups)
+	}
+	for _, g := range dg.Sorted() {
+		if _, ok := g.AssignedPartitions()[name]; ok {
+			u.ConsumerGroups = append(u.ConsumerGroups, g.Group)
+		}
+	}
+	return u, nil
+}
+
+// Update determines if a Topic Partition or a Topic Admin Config update needs to be called and routes properly
+func Update(ctx context.Context, client *kafka.Client, desired *Topic) error {
+	_, err := ApplyUpdate(ctx, client, desired)
+	return err
+}
+
+// ApplyUpdate updates the topic like Update, and returns the config changes
+// it applied. Config changes that were rolled back are not returned. Changes
+// are returned along with a ConfigUpdateError if some keys failed.
+func ApplyUpdate(ctx context.Context, client *kafka.Client, desired *Topic) ([]ConfigChange, error) {
+	// First Get existing Topic
+	existing, err := Get(ctx, client, desired.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotGetTopic)
+	}
+	if existing == nil {
+		return nil, errors.New(ErrTopicDoesNotExist)
+	}
+
+	if desired.Partitions != existing.Partitions {
+		return nil, UpdatePartitions(ctx, client, desired)
+	}
+
+	if desired.ReplicationFactor != existing.ReplicationFactor {
+		return nil, UpdateReplicationFactor()
+	}
+
+	if desired.Config != nil {
+		return applyConfigs(ctx, client, desired, existing)
+	}
+
+	return nil, nil
+}
+
+// UpdatePartitions updates a topic Partition count in Kafka
+func UpdatePartitions(ctx context.Context, client *kafka.Client, desired *Topic) error {
+	// First Get existing Topic
+	existing, err := Get(ctx, client, desired.Name)
+	if err != nil {
+		return errors.Wrap(err, errCannotGetTopic)
+	}
+	if existing == nil {
+		return errors.New(ErrTopicDoesNotExist)
+	}
+
+	if desired.Partitions != existing.Partitions {
+		resp, err := client.UpdatePartitions(ctx, int(desired.Partitions), desired.Name)
+		if err != nil {
+			return errors.Wrap(err, "cannot update topic partitions")
+		}
+		r, err := resp.On(desired.Name, nil)
+		if err != nil {
+			return errors.Wrap(err, "cannot find topic in update partitions result")
+		}
+		if r.Err != nil {
+			return errors.Wrap(r.Err, "error in update partitions result")
+		}
+	}
+
+	return nil
+}
+
+// UpdateReplicationFactor is not supported in Kafka. A user is given an error message
+func UpdateReplicationFactor() error {
+
+	return errors.New("updating replication factor is not supported")
+}
+
+// A ConfigUpdateError reports which config keys of an update were applied
+// and which failed, and which applied keys were rolled back.
+type ConfigUpdateError struct {
+	Applied []string
+	Failed  map[string]error
+	// RolledBack are the applied keys restored to their previous values.
+	RolledBack []string
+	// RollbackFailed are the applied keys that could not be restored.
+	RollbackFailed map[string]error
+}
+
+func (e *ConfigUpdateError) Error() string {
+	failed := make([]string, 0, len(e.Failed))
+	for _, k := range sortedKeys(e.Failed) {
+		failed = append(failed, fmt.Sprintf("%s (%s)", k, e.Failed[k]))
+	}
+	msg := fmt.Sprintf("%s: failed %s; applied %v", errCannotUpdateTopicConfigs, strings.Join(failed, ", "), e.Applied)
+	if len(e.RolledBack) > 0 || len(e.RollbackFailed) > 0 {
+		msg += fmt.Sprintf("; rolled back %v", e.RolledBack)
+	}
+	if len(e.RollbackFailed) > 0 {
+		rf := make([]string, 0, len(e.RollbackFailed))
+		for _, k := range sortedKeys(e.RollbackFailed) {
+			rf = append(rf, fmt.Sprintf("%s (%s)", k, e.RollbackFailed[k]))
+		}
+		msg += "; could not roll back " + strings.Join(rf, ", ")
+	}
+	return msg
+}
+
+func sortedKeys(m map[string]error) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// UpdateConfigs updates an optional topic Admin Configuration in Kafka. Every
+// changed key is altered on its own, so that a key the brokers reject does not
+// keep the others from being applied. If any key fails, a ConfigUpdateError
+// is returned, after rolling back the applied keys if the desired topic asks
+// for it.
+func UpdateConfigs(ctx context.Context, client *kafka.Client, desired *Topic) error {
+	// First Get existing Topic
+	existing, err := Get(ctx, client, desired.Name)
+	if err != nil {
+		return errors.Wrap(err, errCannotGetTopic)
+	}
+	if existing == nil {
+		return errors.New("topic does not exist")
+	}
+
+	_, err = applyConfigs(ctx, client, desired, existing)
+	return err
+}
+
+// applyConfigs alters the config keys of the supplied existing topic that
+// differ from the desired one, and returns the changes that were applied and
+// not rolled back.
+func applyConfigs(ctx context.Context, client *kafka.Client, desired, existing *Topic) ([]ConfigChange, error) {
+	changes := ConfigChanges(desired, existing)
+
+	e := &ConfigUpdateError{Failed: map[string]error{}}
+	for _, c := range changes {
+		if err := alterConfig(ctx, client, desired.Name, c.Key, c.New); err != nil {
+			e.Failed[c.Key] = err
+			continue
+		}
+		e.Applied = append(e.Applied, c.Key)
+	}
+	if len(e.Failed) == 0 {
+		return changes, nil
+	}
+	if !desired.RollbackConfig {
+		return appliedChanges(changes, e), e
+	}
+
+	for _, k := range e.Applied {
+		if err := alterConfig(ctx, client, desired.Name, k, previousConfig(k, existing)); err != nil {
+			if e.RollbackFailed == nil {
+				e.RollbackFailed = map[string]error{}
+			}
+			e.RollbackFailed[k] = err
+			continue
+		}
+		e.RolledBack = append(e.RolledBack, k)
+	}
+	return appliedChanges(changes, e), e
+}
+
+// alterConfig sets the supplied config key of a topic, or restores its
+// default if the value is nil.
+func alterConfig(ctx context.Context, client *kafka.Client, topic, key string, value *string) error {
+	c := kadm.AlterConfig{Op: kadm.SetConfig, Name: key, Value: value}
+	if value == nil {
+		c.Op = kadm.DeleteConfig
+	}
+	r, err := client.AlterTopicConfigs(ctx, []kadm.AlterConfig{c}, topic)
+	if err != nil {
+		return err
+	}
+	return r[0].Err
+}
+
+// Generate is used to convert Crossplane TopicParameters to Kafka's Topic.
+func Generate(name string, params *v1alpha1.TopicParameters) *Topic {
+	tpc := &Topic{
+		Name:              name,
+		ReplicationFactor: int16(params.ReplicationFactor),
+		Partitions:        int32(params.Partitions),
+		RollbackConfig:    params.RollbackConfigOnFailure != nil && *params.RollbackConfigOnFailure,
+	}
+
+	if len(params.ReplicaAssignment) > 0 {
+		tpc.ReplicaAssignment = make(map[int32][]int32, len(params.ReplicaAssignment))
+		for _, a := range params.ReplicaAssignment {
+			brokers := make([]int32, len(a.Brokers))
+			for i, b := range a.Brokers {
+				brokers[i] = int32(b)
+			}
+			tpc.ReplicaAssignment[int32(a.Partition)] = brokers
+		}
+		p, rf := shape(params)
+		tpc.Partitions, tpc.ReplicationFactor = int32(p), int16(rf)
+	}
+
+	if len(params.Config) > 0 {
+		tpc.Config = make(map[string]*string, len(params.Config))
+		for k, v := range params.Config {
+			tpc.Config[k] = v
+		}
+	}
+
+	return tpc
+}
+
+// shape returns the number of partitions and the replication factor of the
+// supplied parameters, which are implied by a replica assignment.
+func shape(params *v1alpha1.TopicParameters) (partitions, replicationFactor int) {
+	if len(params.ReplicaAssignment) == 0 {
+		return params.Partitions, params.ReplicationFactor
+	}
+	return len(params.ReplicaAssignment), len(params.ReplicaAssignment[0].Brokers)
+}
+
+// LateInitializeSpec fills empty spec fields with the data retrieved from Kafka.
+func LateInitializeSpec(params *v1alpha1.TopicParameters, observed *Topic) bool {
+	lateInitialized := false
+	if params.Config == nil {
+		params.Config = make(map[string]*string, len(observed.Config))
+	}
+
+	for k, v := range observed.Config {
+		if _, ok := params.Config[k]; !ok {
+			lateInitialized = true
+			params.Config[k] = v
+		}
+	}
+	return lateInitialized
+}
+
+// Observe returns the observable fields of the supplied Kafka Topic.
+func Observe(observed *Topic) v1alpha1.TopicObservation {
+	o := v1alpha1.TopicObservation{
+		TopicName:         observed.Name,
+		ID:                observed.ID,
+		TopicID:           observed.ID,
+		PartitionCount:    int(observed.Partitions),
+		ReplicationFactor: int(observed.ReplicationFactor),
+	}
+	for _, r := range observed.ReadyReplicas {
+		o.ReadyReplicasPerPartition = append(o.ReadyReplicasPerPartition, int(r))
+	}
+	return o
+}
+
+// IsUpToDate returns true if the supplied Kubernetes resource differs from the
+// supplied Kafka Topic.
+func IsUpToDate(in *v1alpha1.TopicParameters, observed *Topic) bool {
+	partitions, replicationFactor := shape(in)
+	if partitions != int(observed.Partitions) {
+		return false
+	}
+	if replicationFactor != int(observed.ReplicationFactor) {
+		return false
+	}
+	if len(in.Config) != len(observed.Config) {
+		return false
+	}
+	for k := range observed.Config {
+		if iv, ok := in.Config[k]; !ok || !configMatches(k, iv, observed) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsCompacted returns true if the supplied topic configs enable log
+// compaction.
+func IsCompacted(config map[string]*string) bool {
+	for _, p := range strings.Split(stringValue(config["cleanup.policy"]), ",") {
+		if strings.TrimSpace(p) == "compact" {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfigHash returns a hash of the supplied topic configs, which is the same
+// for equal configs.
+func ConfigHash(config map[string]*string) string {
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		// Separate keys and values by NUL bytes, which configs do not
+		// contain, and distinguish nil from empty values.
+		fmt.Fprintf(h, "%s\x00%t\x00%s\x00", k, config[k] != nil, stringValue(config[k]))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func stringValue(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
@@ -0,0 +1,844 @@
+package topic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+var kafkaPassword = os.Getenv("KAFKA_PASSWORD")
+
+var dataTesting = []byte(
+	fmt.Sprintf(`{
+			"brokers": [ "kafka-dev-0.kafka-dev-headless:9092"],
+			"sasl": {
+				"mechanism": "PLAIN",
+				"username": "user",
+				"password": "%s"
+			}
+		}`, kafkaPassword),
+)
+
+func TestCreate(t *testing.T) {
+
+	newAc, _ := kafka.NewAdminClient(context.Background(), dataTesting, nil)
+
+	type args struct {
+		ctx    context.Context
+		client *kafka.Client
+		topic  *Topic
+	}
+	{
+		cases := map[string]struct {
+			name    string
+			args    args
+			wantErr bool
+		}{
+			"CreateTopicOne": {
+				name: "CreateTopicOne",
+				args: args{
+					ctx:    context.Background(),
+					client: newAc,
+					topic: &Topic{
+						Name:              "testTopic-1",
+						ReplicationFactor: 1,
+						Partitions:        1,
+						Config:            nil,
+					},
+				},
+				wantErr: false,
+			},
+
+			"CreateTopicTwo": {
+				name: "CreateTopicTwo",
+				args: args{
+					ctx:    context.Background(),
+					client: newAc,
+					topic: &Topic{
+						Name:              "testTopic-2",
+						ReplicationFactor: 1,
+						Partitions:        1,
+						Config:            nil,
+					},
+				},
+				wantErr: false,
+			},
+
+			"CreateTopicThree": {
+				name: "CreateTopicThree",
+				args: args{
+					ctx:    context.Background(),
+					client: newAc,
+					topic: &Topic{
+						Name:              "testTopic-3",
+						ReplicationFactor: 1,
+						Partitions:        1,
+						Config:            nil,
+					},
+				},
+				wantErr: false,
+			},
+		}
+
+		// TODO: Add test cases.
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				if err := Create(tt.args.ctx, tt.args.client, tt.args.topic); (err != nil) != tt.wantErr {
+					t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+
+	newAc, _ := kafka.NewAdminClient(context.Background(), dataTesting, nil)
+
+	type args struct {
+		ctx    context.Context
+		client *kafka.Client
+		name   string
+	}
+	cases := map[string]struct {
+		name    string
+		args    args
+		want    *Topic
+		wantErr bool
+	}{
+		"GetTopicWorked": {
+			name: "GetTopicWorked",
+			args: args{
+				ctx:    context.Background(),
+				client: newAc,
+				name:   "testTopic-1",
+			},
+			want: &Topic{
+				Name:              "testTopic-1",
+				ReplicationFactor: 1,
+				Partitions:        1,
+				Config:            nil,
+			},
+			wantErr: false,
+		},
+		"GetTopicDoesNotExist": {
+			name: "GetTopicDoesNotExist",
+			args: args{
+				ctx:    context.Background(),
+				client: newAc,
+				name:   "testTopic-00",
+			},
+			want: &Topic{
+				Name:              "testTopic-1",
+				ReplicationFactor: 1,
+				Partitions:        1,
+				Config:            nil,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Get(tt.args.ctx, tt.args.client, tt.args.name)
+			fmt.Println(err)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	type args struct {
+		name   string
+		params *v1alpha1.TopicParameters
+	}
+
+	type want struct {
+		topic *Topic
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ValidComparison": {
+			args: args{
+				name: "validComparison",
+				params: &v1alpha1.TopicParameters{
+					ReplicationFactor: 1,
+					Partitions:        1,
+					Config:            nil,
+				},
+			},
+			want: want{
+				&Topic{
+					Name:              "validComparison",
+					ReplicationFactor: 1,
+					Partitions:        1,
+					Config:            nil,
+				},
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			topic := Generate(tt.args.name, tt.args.params)
+			fmt.Println(topic)
+			if diff := cmp.Diff(tt.want.topic, topic); diff != "" {
+				t.Errorf("Generate() =  -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	cases := map[string]struct {
+		observed *Topic
+		want     v1alpha1.TopicObservation
+	}{
+		"AllReplicasReady": {
+			observed: &Topic{
+				Name:              "orders",
+				ID:                "AAAAAAAAAAAAAAAAAAAAAQ",
+				ReplicationFactor: 3,
+				Partitions:        2,
+				ReadyReplicas:     []int32{3, 3},
+			},
+			want: v1alpha1.TopicObservation{
+				TopicName:                 "orders",
+				ID:                        "AAAAAAAAAAAAAAAAAAAAAQ",
+				TopicID:                   "AAAAAAAAAAAAAAAAAAAAAQ",
+				PartitionCount:            2,
+				ReplicationFactor:         3,
+				ReadyReplicasPerPartition: []int{3, 3},
+			},
+		},
+		"UnderReplicatedPartition": {
+			observed: &Topic{
+				Name:              "orders",
+				ID:                "AAAAAAAAAAAAAAAAAAAAAQ",
+				ReplicationFactor: 3,
+				Partitions:        2,
+				ReadyReplicas:     []int32{3, 1},
+			},
+			want: v1alpha1.TopicObservation{
+				TopicName:                 "orders",
+				ID:                        "AAAAAAAAAAAAAAAAAAAAAQ",
+				TopicID:                   "AAAAAAAAAAAAAAAAAAAAAQ",
+				PartitionCount:            2,
+				ReplicationFactor:         3,
+				ReadyReplicasPerPartition: []int{3, 1},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, Observe(tc.observed)); diff != "" {
+				t.Errorf("Observe() = -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReserved(t *testing.T) {
+	cases := map[string]bool{
+		"__consumer_offsets":         true,
+		"__transaction_state":        true,
+		"__cluster_metadata":         true,
+		"_schemas":                   true,
+		"_confluent-metrics":         true,
+		"_.consumer_offsets":         true,
+		"..consumer_offsets":         true,
+		"_consumer_offsets":          false,
+		"__orders":                   false,
+		"__amazon_msk_canary":        false,
+		"connect-offsets":            false,
+		"orders__v1":                 false,
+		"_confluent_metrics_archive": false,
+	}
+	for name, want := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := Reserved(name); got != want {
+				t.Errorf("Reserved(%q) = %v, want %v", name, got, want)
+			}
+		})
+	}
+}
+
+func TestGenerateReplicaAssignment(t *testing.T) {
+	params := &v1alpha1.TopicParameters{
+		ReplicaAssignment: []v1alpha1.ReplicaAssignment{
+			{Partition: 1, Brokers: []int{2, 3}},
+			{Partition: 0, Brokers: []int{1, 2}},
+		},
+	}
+	want := &Topic{
+		Name:              "placed",
+		ReplicationFactor: 2,
+		Partitions:        2,
+		ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 3}},
+	}
+
+	got := Generate("placed", params)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Generate() =  -want, +got:\n%s", diff)
+	}
+	if !IsUpToDate(params, &Topic{Name: "placed", ReplicationFactor: 2, Partitions: 2}) {
+		t.Errorf("IsUpToDate() = false, want true for a topic matching its replica assignment")
+	}
+}
+
+func TestValidateReplicaAssignment(t *testing.T) {
+	cases := map[string]struct {
+		assignment []v1alpha1.ReplicaAssignment
+		wantErr    bool
+	}{
+		"Valid": {
+			assignment: []v1alpha1.ReplicaAssignment{{Partition: 0, Brokers: []int{1, 2}}, {Partition: 1, Brokers: []int{2, 3}}},
+		},
+		"MissingPartition": {
+			assignment: []v1alpha1.ReplicaAssignment{{Partition: 0, Brokers: []int{1}}, {Partition: 2, Brokers: []int{2}}},
+			wantErr:    true,
+		},
+		"DuplicatePartition": {
+			assignment: []v1alpha1.ReplicaAssignment{{Partition: 0, Brokers: []int{1}}, {Partition: 0, Brokers: []int{2}}},
+			wantErr:    true,
+		},
+		"UnevenReplicas": {
+			assignment: []v1alpha1.ReplicaAssignment{{Partition: 0, Brokers: []int{1, 2}}, {Partition: 1, Brokers: []int{2}}},
+			wantErr:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := ValidateReplicaAssignment(tc.assignment); (err != nil) != tc.wantErr {
+				t.Errorf("ValidateReplicaAssignment() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewCreateTopicsRequest(t *testing.T) {
+	retention := "1000"
+
+	cases := map[string]struct {
+		topic        *Topic
+		validateOnly bool
+		want         kmsg.CreateTopicsRequestTopic
+	}{
+		"PartitionsAndReplicationFactor": {
+			topic: &Topic{Name: "orders", Partitions: 3, ReplicationFactor: 2, Config: map[string]*string{"retention.ms": &retention}},
+			want: kmsg.CreateTopicsRequestTopic{
+				Topic:             "orders",
+				NumPartitions:     3,
+				ReplicationFactor: 2,
+				Configs:           []kmsg.CreateTopicsRequestTopicConfig{{Name: "retention.ms", Value: &retention}},
+			},
+		},
+		"ReplicaAssignment": {
+			topic: &Topic{Name: "placed", Partitions: 2, ReplicationFactor: 1, ReplicaAssignment: map[int32][]int32{1: {2}, 0: {1}}},
+			want: kmsg.CreateTopicsRequestTopic{
+				Topic:             "placed",
+				NumPartitions:     -1,
+				ReplicationFactor: -1,
+				ReplicaAssignment: []kmsg.CreateTopicsRequestTopicReplicaAssignment{{Partition: 0, Replicas: []int32{1}}, {Partition: 1, Replicas: []int32{2}}},
+			},
+			validateOnly: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := newCreateTopicsRequest(context.Background(), tc.topic, tc.validateOnly)
+			if req.ValidateOnly != tc.validateOnly {
+				t.Errorf("newCreateTopicsRequest().ValidateOnly = %t, want %t", req.ValidateOnly, tc.validateOnly)
+			}
+			if diff := cmp.Diff([]kmsg.CreateTopicsRequestTopic{tc.want}, req.Topics, cmpopts.EquateEmpty(), cmpopts.IgnoreTypes(kmsg.Tags{})); diff != "" {
+				t.Errorf("newCreateTopicsRequest() = -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	type args struct {
+		in       *v1alpha1.TopicParameters
+		observed *Topic
+	}
+
+	cases := map[string]struct {
+		name string
+		args args
+		want bool
+	}{
+		"IsUpToDate": {
+			name: "upToDate",
+			args: args{
+				in: &v1alpha1.TopicParameters{
+					ReplicationFactor: 1,
+					Partitions:        1,
+					Config:            nil,
+				},
+				observed: &Topic{
+					Name:              "upToDate",
+					ReplicationFactor: 1,
+					Partitions:        1,
+					Config:            nil,
+				},
+			},
+			want: true,
+		},
+		"DiffReplicationFactor": {
+			name: "repFactorDiff",
+			args: args{
+				in: &v1alpha1.TopicParameters{
+					ReplicationFactor: 2,
+					Partitions:        1,
+					Config:            nil,
+				},
+				observed: &Topic{
+					Name:              "k25",
+					ReplicationFactor: 1,
+					Partitions:        1,
+					Config:            nil,
+				},
+			},
+			want: false,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			isUpToDate := IsUpToDate(tt.args.in, tt.args.observed)
+			fmt.Println(isUpToDate)
+			if diff := cmp.Diff(tt.want, isUpToDate); diff != "" {
+				t.Errorf("IsUpToDate() = -want +got")
+			}
+
+		})
+	}
+}
+
+func TestCreateDuplicateTopic(t *testing.T) {
+
+	newAc, _ := kafka.NewAdminClient(context.Background(), dataTesting, nil)
+
+	fmt.Printf("------Checking duplicate topic creation logic------")
+
+	type args struct {
+		ctx    context.Context
+		client *kafka.Client
+		topic  *Topic
+	}
+	{
+		cases := map[string]struct {
+			name    string
+			args    args
+			wantErr bool
+		}{
+			"CreateTopicOneExists": {
+				name: "CreateTopicOneExists",
+				args: args{
+					ctx:    context.Background(),
+					client: newAc,
+					topic: &Topic{
+						Name:              "testTopic-1",
+						ReplicationFactor: 1,
+						Partitions:        1,
+						Config:            nil,
+					},
+				},
+				wantErr: true,
+			},
+
+			"CreateTopicTwoExists": {
+				name: "CreateTopicTwoExists",
+				args: args{
+					ctx:    context.Background(),
+					client: newAc,
+					topic: &Topic{
+						Name:              "testTopic-2",
+						ReplicationFactor: 1,
+						Partitions:        1,
+						Config:            nil,
+					},
+				},
+				wantErr: true,
+			},
+
+			"CreateTopicThreeExists": {
+				name: "CreateTopicThreeExists",
+				args: args{
+					ctx:    context.Background(),
+					client: newAc,
+					topic: &Topic{
+						Name:              "testTopic-3",
+						ReplicationFactor: 1,
+						Partitions:        1,
+						Config:            nil,
+					},
+				},
+				wantErr: true,
+			},
+			"CreateTopicDoesNotExist": {
+				name: "CreateTopicDoesNotExist",
+				args: args{
+					ctx:    context.Background(),
+					client: newAc,
+					topic: &Topic{
+						Name:              "createTopic-doesNot-exist",
+						ReplicationFactor: 1,
+						Partitions:        1,
+						Config:            nil,
+					},
+				},
+				wantErr: false,
+			},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				if err := Create(tt.args.ctx, tt.args.client, tt.args.topic); (err != nil) != tt.wantErr {
+					t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestDelete(t *testing.T) {
+
+	newAc, _ := kafka.NewAdminClient(context.Background(), dataTesting, nil)
+
+	type args struct {
+		ctx    context.Context
+		client *kafka.Client
+		name   string
+	}
+	cases := map[string]struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		"DeleteTopicOne": {
+			name: "DeleteTopicOne",
+			args: args{
+				ctx:    context.Background(),
+				client: newAc,
+				name:   "testTopic-1",
+			},
+			wantErr: false,
+		},
+		"DeleteTopicTwo": {
+			name: "DeleteTopicTwo",
+			args: args{
+				ctx:    context.Background(),
+				client: newAc,
+				name:   "testTopic-2",
+			},
+			wantErr: false,
+		},
+		"DeleteTopicThree": {
+			name: "DeleteTopicThree",
+			args: args{
+				ctx:    context.Background(),
+				client: newAc,
+				name:   "testTopic-3",
+			},
+			wantErr: false,
+		},
+		"DeleteTopicThreeDoesNotExist": {
+			name: "DeleteTopicThreeDoesNotExist",
+			args: args{
+				ctx:    context.Background(),
+				client: newAc,
+				name:   "create3",
+			},
+			wantErr: true,
+		},
+		"DeleteTopicThatDoesNotExist": {
+			name: "DeleteTopicThatDoesNotExist",
+			args: args{
+				ctx:    context.Background(),
+				client: newAc,
+				name:   "createTopic-doesNot-exist",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Delete(tt.args.ctx, tt.args.client, tt.args.name); (err != nil) != tt.wantErr {
+				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigHash(t *testing.T) {
+	empty := ""
+	compact := "compact"
+	delete := "delete"
+
+	cases := map[string]struct {
+		a, b  map[string]*string
+		equal bool
+	}{
+		"SameConfig": {
+			a:     map[string]*string{"cleanup.policy": &compact, "retention.ms": &empty},
+			b:     map[string]*string{"retention.ms": &empty, "cleanup.policy": &compact},
+			equal: true,
+		},
+		"DifferentValue": {
+			a: map[string]*string{"cleanup.policy": &compact},
+			b: map[string]*string{"cleanup.policy": &delete},
+		},
+		"NilIsNotEmpty": {
+			a: map[string]*string{"retention.ms": nil},
+			b: map[string]*string{"retention.ms": &empty},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ConfigHash(tc.a) == ConfigHash(tc.b); got != tc.equal {
+				t.Errorf("ConfigHash(a) == ConfigHash(b) = %t, want %t", got, tc.equal)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyViolation(t *testing.T) {
+	c, err := kfake.NewCluster()
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+	reason := "topic names must start with the team name"
+	c.ControlKey(int16(kmsg.CreateTopics), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+		req := kreq.(*kmsg.CreateTopicsRequest)
+		resp := req.ResponseKind().(*kmsg.CreateTopicsResponse)
+		rt := kmsg.NewCreateTopicsResponseTopic()
+		rt.Topic = req.Topics[0].Topic
+		rt.ErrorCode = kerr.PolicyViolation.Code
+		rt.ErrorMessage = &reason
+		resp.Topics = append(resp.Topics, rt)
+		return resp, nil, true
+	})
+
+	ctx := context.Background()
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(ctx, creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	err = Validate(ctx, cl, &Topic{Name: "orders", Partitions: 1, ReplicationFactor: 1})
+	pv := &PolicyViolationError{}
+	if !errors.As(err, &pv) {
+		t.Fatalf("Validate(...): want PolicyViolationError, got %v", err)
+	}
+	if diff := cmp.Diff(reason, pv.Reason); diff != "" {
+		t.Errorf("Validate(...): -want reason, +got reason:\n%s", diff)
+	}
+	if !errors.Is(err, kerr.PolicyViolation) {
+		t.Errorf("Validate(...): want error to be POLICY_VIOLATION, got %v", err)
+	}
+}
+
+func TestMarkedForDeletion(t *testing.T) {
+	c, err := kfake.NewCluster()
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+	deleting := true
+	c.ControlKey(int16(kmsg.CreateTopics), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+		c.KeepControl()
+		req := kreq.(*kmsg.CreateTopicsRequest)
+		resp := req.ResponseKind().(*kmsg.CreateTopicsResponse)
+		rt := kmsg.NewCreateTopicsResponseTopic()
+		rt.Topic = req.Topics[0].Topic
+		if deleting {
+			rt.ErrorCode = kerr.TopicAlreadyExists.Code
+			msg := "Topic '" + rt.Topic + "' is marked for deletion."
+			rt.ErrorMessage = &msg
+		}
+		resp.Topics = append(resp.Topics, rt)
+		return resp, nil, true
+	})
+
+	ctx := context.Background()
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(ctx, creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	if !MarkedForDeletion(ctx, cl, "orders") {
+		t.Error("MarkedForDeletion(...): want true while the brokers delete the topic")
+	}
+	deleting = false
+	if MarkedForDeletion(ctx, cl, "orders") {
+		t.Error("MarkedForDeletion(...): want false once the brokers deleted the topic")
+	}
+}
+
+func TestGetSize(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.SeedTopics(2, "orders"))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	producer, err := kgo.NewClient(kgo.SeedBrokers(c.ListenAddrs()...), kgo.DefaultProduceTopic("orders"))
+	if err != nil {
+		t.Fatalf("kgo.NewClient(...): %v", err)
+	}
+	defer producer.Close()
+	for i := 0; i < 3; i++ {
+		if err := producer.ProduceSync(ctx, &kgo.Record{Value: []byte("order")}).FirstErr(); err != nil {
+			t.Fatalf("ProduceSync(...): %v", err)
+		}
+	}
+
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(ctx, creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	sz, err := GetSize(ctx, cl, "orders")
+	if err != nil {
+		t.Fatalf("GetSize(...): %v", err)
+	}
+	if sz.Records != 3 {
+		t.Errorf("GetSize(...): Records = %d, want 3", sz.Records)
+	}
+	if sz.Bytes <= 0 {
+		t.Errorf("GetSize(...): Bytes = %d, want more than 0", sz.Bytes)
+	}
+}
+
+func TestUpdateConfigsPartialFailure(t *testing.T) {
+	retention := "1000"
+	cases := map[string]struct {
+		retention      *string
+		rollback       bool
+		wantApplied    []string
+		wantRetention  string
+		wantOverridden bool
+	}{
+		"NoRollback": {
+			retention:      &retention,
+			wantApplied:    []string{"retention.ms"},
+			wantRetention:  "2000",
+			wantOverridden: true,
+		},
+		"Rollback": {
+			retention:      &retention,
+			rollback:       true,
+			wantApplied:    []string{"retention.ms"},
+			wantRetention:  "1000",
+			wantOverridden: true,
+		},
+		"RollbackDefault": {
+			rollback:      true,
+			wantApplied:   []string{"retention.ms"},
+			wantRetention: "604800000",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := kfake.NewCluster()
+			if err != nil {
+				t.Fatalf("kfake.NewCluster(): %v", err)
+			}
+			defer c.Close()
+			// The brokers reject any change to cleanup.policy.
+			c.ControlKey(int16(kmsg.IncrementalAlterConfigs), func(kreq kmsg.Request) (kmsg.Response, error, bool) {
+				req := kreq.(*kmsg.IncrementalAlterConfigsRequest)
+				if req.Resources[0].Configs[0].Name != "cleanup.policy" {
+					return nil, nil, false
+				}
+				c.KeepControl()
+				resp := req.ResponseKind().(*kmsg.IncrementalAlterConfigsResponse)
+				rr := kmsg.NewIncrementalAlterConfigsResponseResource()
+				rr.ResourceType = req.Resources[0].ResourceType
+				rr.ResourceName = req.Resources[0].ResourceName
+				rr.ErrorCode = kerr.PolicyViolation.Code
+				resp.Resources = append(resp.Resources, rr)
+				return resp, nil, true
+			})
+
+			ctx := context.Background()
+			creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+			cl, err := kafka.NewAdminClient(ctx, creds, nil)
+			if err != nil {
+				t.Fatalf("NewAdminClient(...): %v", err)
+			}
+			defer cl.Close()
+
+			config := map[string]*string{}
+			if tc.retention != nil {
+				config["retention.ms"] = tc.retention
+			}
+			if _, err := cl.CreateTopic(ctx, 1, 1, config, "orders"); err != nil {
+				t.Fatalf("CreateTopic(...): %v", err)
+			}
+
+			newRetention, compact := "2000", "compact"
+			desired := &Topic{
+				Name:           "orders",
+				Config:         map[string]*string{"retention.ms": &newRetention, "cleanup.policy": &compact},
+				RollbackConfig: tc.rollback,
+			}
+			err = UpdateConfigs(ctx, cl, desired)
+			ue := &ConfigUpdateError{}
+			if !errors.As(err, &ue) {
+				t.Fatalf("UpdateConfigs(...): want ConfigUpdateError, got %v", err)
+			}
+			if diff := cmp.Diff(tc.wantApplied, ue.Applied); diff != "" {
+				t.Errorf("UpdateConfigs(...): -want applied, +got applied:\n%s", diff)
+			}
+			if diff := cmp.Diff([]string{"cleanup.policy"}, sortedKeys(ue.Failed)); diff != "" {
+				t.Errorf("UpdateConfigs(...): -want failed, +got failed:\n%s", diff)
+			}
+
+			got, err := Get(ctx, cl, "orders")
+			if err != nil {
+				t.Fatalf("Get(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.wantRetention, stringValue(got.Config["retention.ms"])); diff != "" {
+				t.Errorf("UpdateConfigs(...): -want retention.ms, +got retention.ms:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantOverridden, got.ConfigSources["retention.ms"].Overridden); diff != "" {
+				t.Errorf("UpdateConfigs(...): -want retention.ms overridden, +got retention.ms overridden:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package topic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kadm"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const (
+	errListTruncationOffsets = "cannot list offsets to delete records before"
+	errUnknownPartition      = "topic %q has no partition %d"
+	errDeleteRecords         = "cannot delete records"
+	errDeleteRecordsFailed   = "cannot delete records of partition %d: %s"
+)
+
+// Truncate deletes the records of the supplied topic as the supplied
+// parameters describe, and returns the partitions whose records were deleted
+// along with their new low watermarks, sorted by partition. Records are never
+// deleted beyond the end of a partition.
+func Truncate(ctx context.Context, client *kafka.Client, params v1alpha1.RecordsTruncationParameters) ([]v1alpha1.PartitionTruncation, error) {
+	ends, err := client.ListEndOffsets(ctx, params.Topic)
+	if err != nil {
+		return nil, errors.Wrap(err, errListTruncationOffsets)
+	}
+	if err := ends.Error(); err != nil {
+		return nil, errors.Wrap(err, errListTruncationOffsets)
+	}
+
+	var before kadm.ListedOffsets
+	if params.BeforeTime != nil {
+		if before, err = client.ListOffsetsAfterMilli(ctx, params.BeforeTime.UnixMilli(), params.Topic); err != nil {
+			return nil, errors.Wrap(err, errListTruncationOffsets)
+		}
+		if err := before.Error(); err != nil {
+			return nil, errors.Wrap(err, errListTruncationOffsets)
+		}
+	}
+
+	offsets, err := truncationOffsets(params, ends, before)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.DeleteRecords(ctx, offsets)
+	if err != nil {
+		return nil, errors.Wrap(err, errDeleteRecords)
+	}
+
+	out := []v1alpha1.PartitionTruncation{}
+	failed := []string{}
+	for _, ps := range resp {
+		for _, r := range ps {
+			if r.Err != nil {
+				failed = append(failed, fmt.Sprintf(errDeleteRecordsFailed, r.Partition, r.Err))
+				continue
+			}
+			out = append(out, v1alpha1.PartitionTruncation{Partition: r.Partition, LowWatermark: r.LowWatermark})
+		}
+	}
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return nil, errors.New(strings.Join(failed, "; "))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Partition < out[j].Partition })
+	return out, nil
+}
+
+// truncationOffsets returns the offsets to delete the records of each
+// partition before, given the end offsets of the partitions and, if deleting
+// records before a time, the offsets of the first records produced after it.
+func truncationOffsets(params v1alpha1.RecordsTruncationParameters, ends, before kadm.ListedOffsets) (kadm.Offsets, error) {
+	partitions := params.Partitions
+	if len(partitions) == 0 {
+		ends.Each(func(o kadm.ListedOffset) { partitions = append(partitions, o.Partition) })
+	}
+
+	out := kadm.Offsets{}
+	for _, p := range partitions {
+		end, ok := ends.Lookup(params.Topic, p)
+		if !ok {
+			return nil, errors.Errorf(errUnknownPartition, params.Topic, p)
+		}
+		at := end.Offset
+		switch {
+		case params.BeforeOffset != nil && *params.BeforeOffset < at:
+			at = *params.BeforeOffset
+		case params.BeforeTime != nil:
+			if b, ok := before.Lookup(params.Topic, p); ok && b.Offset >= 0 && b.Offset < at {
+				at = b.Offset
+			}
+		}
+		out.AddOffset(params.Topic, p, at, -1)
+	}
+	return out, nil
+}
@@ -0,0 +1,74 @@
+package topic
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/crossplane-contrib/provider-kafka/apis/topic/v1alpha1"
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+func TestTruncate(t *testing.T) {
+	c, err := kfake.NewCluster(kfake.SeedTopics(2, "orders"))
+	if err != nil {
+		t.Fatalf("kfake.NewCluster(): %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	creds, _ := json.Marshal(kafka.Config{Brokers: c.ListenAddrs()})
+	cl, err := kafka.NewAdminClient(ctx, creds, nil)
+	if err != nil {
+		t.Fatalf("NewAdminClient(...): %v", err)
+	}
+	defer cl.Close()
+
+	// Each partition holds five records.
+	producer, err := kgo.NewClient(kgo.SeedBrokers(c.ListenAddrs()...), kgo.DefaultProduceTopic("orders"), kgo.RecordPartitioner(kgo.ManualPartitioner()))
+	if err != nil {
+		t.Fatalf("kgo.NewClient(...): %v", err)
+	}
+	defer producer.Close()
+	for p := int32(0); p < 2; p++ {
+		for i := 0; i < 5; i++ {
+			if err := producer.ProduceSync(ctx, &kgo.Record{Partition: p, Value: []byte("order")}).FirstErr(); err != nil {
+				t.Fatalf("ProduceSync(...): %v", err)
+			}
+		}
+	}
+
+	offset := func(o int64) *int64 { return &o }
+	steps := []struct {
+		params  v1alpha1.RecordsTruncationParameters
+		want    []v1alpha1.PartitionTruncation
+		wantErr bool
+	}{
+		{
+			params: v1alpha1.RecordsTruncationParameters{Topic: "orders", Partitions: []int32{1}, BeforeOffset: offset(2)},
+			want:   []v1alpha1.PartitionTruncation{{Partition: 1, LowWatermark: 2}},
+		},
+		{
+			// Records are not deleted beyond the end of a partition.
+			params: v1alpha1.RecordsTruncationParameters{Topic: "orders", BeforeOffset: offset(100)},
+			want:   []v1alpha1.PartitionTruncation{{Partition: 0, LowWatermark: 5}, {Partition: 1, LowWatermark: 5}},
+		},
+		{
+			params:  v1alpha1.RecordsTruncationParameters{Topic: "orders", Partitions: []int32{7}, All: true},
+			wantErr: true,
+		},
+	}
+	for i, s := range steps {
+		got, err := Truncate(ctx, cl, s.params)
+		if (err != nil) != s.wantErr {
+			t.Fatalf("step %d: Truncate(...): error = %v, wantErr %v", i, err, s.wantErr)
+		}
+		if diff := cmp.Diff(s.want, got); diff != "" {
+			t.Errorf("step %d: Truncate(...): -want, +got:\n%s", i, diff)
+		}
+	}
+}
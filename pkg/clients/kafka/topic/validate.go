@@ -0,0 +1,144 @@
+package topic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-kafka/pkg/clients/kafka"
+)
+
+const (
+	errUnknownConfigKeys = "unknown topic config keys"
+
+	// maxSuggestionDistance is the largest edit distance for which a known
+	// config key is suggested as a replacement for an unknown one.
+	maxSuggestionDistance = 3
+	// maxSuggestions is the number of replacements suggested per unknown key.
+	maxSuggestions = 3
+)
+
+// ConfigKeys returns the topic config keys supported by the cluster. Brokers
+// report every supported key when describing a topic, so the configs of any
+// existing topic are used. Internal topics are skipped, as they are often
+// the only topics a principal may not describe. No keys are returned if no
+// other topic exists yet. Listing the topics reads the metadata of all of
+// them, so the keys are stored with the client and only listed once per
+// client.
+func ConfigKeys(ctx context.Context, client *kafka.Client) ([]string, error) {
+	if keys := client.TopicConfigKeys(); keys != nil {
+		return keys, nil
+	}
+	td, err := client.ListTopics(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotListTopics)
+	}
+	names := td.Names()
+	if len(names) == 0 {
+		return nil, nil
+	}
+	sort.Strings(names)
+
+	tc, err := client.DescribeTopicConfigs(ctx, names[0])
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotDescribeTopic)
+	}
+	rc, err := tc.On(names[0], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errCannotFindTopicInDescribe)
+	}
+	if rc.Err != nil {
+		return nil, errors.Wrap(rc.Err, errErrorInTopicDescribeResult)
+	}
+
+	keys := make([]string, 0, len(rc.Configs))
+	for _, c := range rc.Configs {
+		keys = append(keys, c.Key)
+	}
+	client.SetTopicConfigKeys(keys)
+	return keys, nil
+}
+
+// ValidateConfigKeys returns an error listing every desired config key that
+// is not among the known keys, along with the known keys closest to it. Kafka
+// would otherwise reject or ignore them, which typically hides typos such as
+// "rentention.ms". No validation happens if no keys are known.
+func ValidateConfigKeys(desired map[string]*string, known []string) error {
+	if len(known) == 0 {
+		return nil
+	}
+	k := make(map[string]bool, len(known))
+	for _, key := range known {
+		k[key] = true
+	}
+
+	unknown := make([]string, 0)
+	for key := range desired {
+		if !k[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+
+	msgs := make([]string, len(unknown))
+	for i, key := range unknown {
+		msgs[i] = fmt.Sprintf("%q", key)
+		if s := suggest(key, known); len(s) > 0 {
+			msgs[i] += fmt.Sprintf(" (did you mean %s?)", strings.Join(s, ", "))
+		}
+	}
+	return errors.Errorf("%s: %s", errUnknownConfigKeys, strings.Join(msgs, "; "))
+}
+
+// suggest returns the known keys nearest to the supplied one.
+func suggest(key string, known []string) []string {
+	type candidate struct {
+		key      string
+		distance int
+	}
+	c := make([]candidate, 0)
+	for _, k := range known {
+		if d := levenshtein(key, k); d <= maxSuggestionDistance {
+			c = append(c, candidate{key: k, distance: d})
+		}
+	}
+	sort.Slice(c, func(i, j int) bool {
+		if c[i].distance != c[j].distance {
+			return c[i].distance < c[j].distance
+		}
+		return c[i].key < c[j].key
+	})
+
+	s := make([]string, 0, maxSuggestions)
+	for i := 0; i < len(c) && i < maxSuggestions; i++ {
+		s = append(s, fmt.Sprintf("%q", c[i].key))
+	}
+	return s
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
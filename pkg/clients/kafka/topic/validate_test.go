@@ -0,0 +1,47 @@
+package topic
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func TestValidateConfigKeys(t *testing.T) {
+	known := []string{"retention.ms", "retention.bytes", "cleanup.policy", "min.insync.replicas"}
+	v := "1"
+
+	cases := map[string]struct {
+		desired map[string]*string
+		known   []string
+		want    error
+	}{
+		"AllKnown": {
+			desired: map[string]*string{"retention.ms": &v, "cleanup.policy": &v},
+			known:   known,
+		},
+		"NothingKnown": {
+			desired: map[string]*string{"rentention.ms": &v},
+		},
+		"Typo": {
+			desired: map[string]*string{"rentention.ms": &v},
+			known:   known,
+			want:    errors.New(errUnknownConfigKeys + `: "rentention.ms" (did you mean "retention.ms"?)`),
+		},
+		"NoSuggestion": {
+			desired: map[string]*string{"segment.jitter": &v},
+			known:   known,
+			want:    errors.New(errUnknownConfigKeys + `: "segment.jitter"`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateConfigKeys(tc.desired, tc.known)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateConfigKeys(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
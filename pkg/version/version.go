@@ -0,0 +1,72 @@
+// Package version reports the build of the provider.
+package version
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// Version of the provider, set at build time.
+var Version = "unknown"
+
+// GitCommit the provider was built from, set at build time. It defaults to the
+// revision the Go toolchain stamps into binaries built from a git checkout.
+var GitCommit = ""
+
+// Info describes the build of the provider.
+type Info struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	// GitTreeModified is true if the provider was built from a checkout
+	// with uncommitted changes.
+	GitTreeModified bool   `json:"gitTreeModified,omitempty"`
+	GoVersion       string `json:"goVersion"`
+	// APIVersions are the API group versions served by the provider, e.g.
+	// topic.kafka.crossplane.io/v1beta1.
+	APIVersions []string `json:"apiVersions"`
+}
+
+// Get returns the build of the provider, serving the supplied API versions.
+func Get(apiVersions []string) Info {
+	i := Info{Version: Version, GitCommit: GitCommit, GoVersion: runtime.Version(), APIVersions: apiVersions}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return i
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if i.GitCommit == "" {
+				i.GitCommit = s.Value
+			}
+		case "vcs.modified":
+			i.GitTreeModified = s.Value == "true"
+		}
+	}
+	return i
+}
+
+// APIVersions returns the group versions of the supplied scheme whose group
+// ends with the supplied suffix, sorted.
+func APIVersions(s *kruntime.Scheme, groupSuffix string) []string {
+	out := []string{}
+	for _, gv := range s.PrioritizedVersionsAllGroups() {
+		if strings.HasSuffix(gv.Group, groupSuffix) {
+			out = append(out, gv.String())
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ServeHTTP writes the build as JSON.
+func (i Info) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(i)
+}
@@ -0,0 +1,44 @@
+package version
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestAPIVersions(t *testing.T) {
+	s := runtime.NewScheme()
+	for _, gv := range []schema.GroupVersion{
+		{Group: "topic.kafka.crossplane.io", Version: "v1beta1"},
+		{Group: "topic.kafka.crossplane.io", Version: "v1alpha1"},
+		{Group: "kafka.crossplane.io", Version: "v1alpha1"},
+		{Group: "apps", Version: "v1"},
+	} {
+		s.AddKnownTypeWithName(gv.WithKind("Thing"), &runtime.Unknown{})
+	}
+
+	want := []string{"kafka.crossplane.io/v1alpha1", "topic.kafka.crossplane.io/v1alpha1", "topic.kafka.crossplane.io/v1beta1"}
+	if diff := cmp.Diff(want, APIVersions(s, "kafka.crossplane.io")); diff != "" {
+		t.Errorf("APIVersions(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	i := Info{Version: "v1.0.0", GitCommit: "abc", GoVersion: "go1.21", APIVersions: []string{"kafka.crossplane.io/v1alpha1"}}
+
+	w := httptest.NewRecorder()
+	i.ServeHTTP(w, httptest.NewRequest("GET", "/version", nil))
+
+	got := Info{}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("ServeHTTP(...): cannot unmarshal response: %s", err)
+	}
+	if diff := cmp.Diff(i, got, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("ServeHTTP(...): -want, +got:\n%s", diff)
+	}
+}